@@ -0,0 +1,108 @@
+package cartapi
+
+import (
+	"context"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	pbcart "github.com/mikebway/poc-gcp-ecomm/pb/cart"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	// benchmarkLatency is the delay added to every Firestore RPC made during our benchmarks to simulate the network
+	// round trip to the real Firestore service; the emulator is so close at hand that it would otherwise hide the
+	// cost of making requests one after another rather than concurrently.
+	benchmarkLatency = 2 * time.Millisecond
+)
+
+// rpcCounter is a gRPC client interceptor that counts, and delays, the Firestore RPCs made through it.
+type rpcCounter struct {
+	count int64
+}
+
+// unary intercepts unary RPCs such as Commit and BeginTransaction.
+func (c *rpcCounter) unary(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	atomic.AddInt64(&c.count, 1)
+	time.Sleep(benchmarkLatency)
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+// stream intercepts streaming RPCs such as BatchGetDocuments and RunQuery.
+func (c *rpcCounter) stream(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	atomic.AddInt64(&c.count, 1)
+	time.Sleep(benchmarkLatency)
+	return streamer(ctx, desc, cc, method, opts...)
+}
+
+// emulatorOwnerCreds presents the credentials that the Firestore emulator expects of an administrator, mirroring
+// what the Firestore client does itself when it connects to the emulator.
+type emulatorOwnerCreds struct{}
+
+// GetRequestMetadata returns the authorization header that the emulator accepts from administrators.
+func (emulatorOwnerCreds) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer owner"}, nil
+}
+
+// RequireTransportSecurity returns false; the emulator does not use TLS.
+func (emulatorOwnerCreds) RequireTransportSecurity() bool {
+	return false
+}
+
+// BenchmarkAddItemToShoppingCart measures adding an item to a cart, including reading back the updated cart for
+// the response, reporting the number of Firestore RPCs made per operation alongside the time taken.
+func BenchmarkAddItemToShoppingCart(b *testing.B) {
+
+	// Make sure that we are talking to the emulator, not the live project
+	ctx := context.Background()
+	_ = os.Setenv(EnvFirestoreEmulator, FirestoreEmulatorHost)
+	ProjectId = "demo-poc-gcp-ecomm"
+
+	// Build a cart service whose Firestore client counts every RPC that it makes
+	service, err := NewCartService()
+	if err != nil {
+		b.Fatalf("failed to obtain cart service: %v", err)
+	}
+	counter := &rpcCounter{}
+	conn, err := grpc.Dial(FirestoreEmulatorHost,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(emulatorOwnerCreds{}),
+		grpc.WithUnaryInterceptor(counter.unary),
+		grpc.WithStreamInterceptor(counter.stream))
+	if err != nil {
+		b.Fatalf("failed to dial the Firestore emulator: %v", err)
+	}
+	service.FsClient, err = firestore.NewClient(ctx, ProjectId, option.WithGRPCConn(conn))
+	if err != nil {
+		b.Fatalf("failed to obtain counting Firestore client: %v", err)
+	}
+	service.itemsGetterProxy = &ItemCollGetterProxy{FsClient: service.FsClient}
+
+	// Make sure that the catalog has the product that we are going to buy, then give ourselves a cart to fill
+	err = storeMockProducts(ctx, service)
+	if err != nil {
+		b.Fatalf("failed to store the mock products in the catalog: %v", err)
+	}
+	createResp, err := service.CreateShoppingCart(ctx, &pbcart.CreateShoppingCartRequest{Shopper: buildMockShopper()})
+	if err != nil {
+		b.Fatalf("failed to create cart: %v", err)
+	}
+	cartId := createResp.Cart.Id
+
+	// Keep adding the same item to the cart, which merges it with the first, so that the cart does not grow
+	atomic.StoreInt64(&counter.count, 0)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err = service.AddItemToShoppingCart(ctx, &pbcart.AddItemToShoppingCartRequest{CartId: cartId, Item: buildMockCartItem(cartItemProductCode1)})
+		if err != nil {
+			b.Fatalf("failed to add item to cart: %v", err)
+		}
+	}
+	b.StopTimer()
+	b.ReportMetric(float64(atomic.LoadInt64(&counter.count))/float64(b.N), "rpcs/op")
+}
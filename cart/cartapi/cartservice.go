@@ -4,15 +4,21 @@ package cartapi
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"cloud.google.com/go/firestore"
 	"github.com/google/uuid"
 	"github.com/mikebway/poc-gcp-ecomm/cart/schema"
+	"github.com/mikebway/poc-gcp-ecomm/cart/shipping"
+	"github.com/mikebway/poc-gcp-ecomm/cart/tax"
+	"github.com/mikebway/poc-gcp-ecomm/inventory"
+	"github.com/mikebway/poc-gcp-ecomm/payments"
 	pbcart "github.com/mikebway/poc-gcp-ecomm/pb/cart"
 	"github.com/mikebway/poc-gcp-ecomm/types"
 	"go.uber.org/zap"
 	"google.golang.org/api/iterator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -42,6 +48,10 @@ type CartService struct {
 	// FsClient is the GCP Firestore client - it is thread safe and can be reused concurrently
 	FsClient *firestore.Client
 
+	// Orders is used to read previous orders when a shopper wants to buy the same things again. It is left for
+	// whoever starts the service to set since the order service depends on this package, not the other way around.
+	Orders OrderReader
+
 	// drProxy is used to allow unit tests to intercept firestore.DocumentRef function calls
 	// and insert errors etc. into the responses.
 	drProxy DocumentRefProxy
@@ -54,6 +64,25 @@ type CartService struct {
 	// substitute an alternative implementation this interface in order to be able to insert errors etc.
 	// into the responses of the ItemsCollectionProxy that the ItemCollectionGetterProxy returns.
 	itemsGetterProxy ItemCollectionGetterProxy
+
+	// queryProxy is used to allow unit tests to intercept firestore.Query function calls
+	// and insert errors etc. into the responses of the document iterator that the query returns.
+	queryProxy QueryExecutionProxy
+
+	// taxCalculator is used to estimate the tax due on open carts. Unit tests may substitute a calculator with a
+	// tax table of their own.
+	taxCalculator tax.TaxCalculator
+
+	// shippingProvider is used to quote the delivery options available for open carts. Unit tests may substitute
+	// a provider with shipping rules of their own.
+	shippingProvider shipping.ShippingRateProvider
+
+	// inventory holds stock for the items in open carts and takes it out of stock when carts are checked out
+	inventory *inventory.Inventory
+
+	// payments authorizes the shopper's payment when a cart is checked out. Unit tests may substitute a payment
+	// provider of their own.
+	payments *payments.Payments
 }
 
 // NewCartService is a factory method returning an instance of our shopping cart service.
@@ -62,8 +91,9 @@ func NewCartService() (*CartService, error) {
 	// Build our service instance here with our default, direct passthrough, interception proxies
 	// for firestore.DocumentRef and firestore.DocumentSnapshot function calls
 	svc := &CartService{
-		drProxy: &DocRefProxy{},
-		dsProxy: &DocSnapProxy{},
+		drProxy:    &DocRefProxy{},
+		dsProxy:    &DocSnapProxy{},
+		queryProxy: &QueryExecProxy{},
 	}
 
 	// Obtain a firestore client and stuff that in the service instance
@@ -88,11 +118,33 @@ func NewCartService() (*CartService, error) {
 		FsClient: svc.FsClient,
 	}
 
+	// Stock is reserved and committed in the same Firestore database as the carts
+	svc.inventory = inventory.NewInventory(svc.FsClient)
+
+	// As are payments, although, for now, the money only moves through a pretend payment provider
+	svc.payments = payments.NewPayments(svc.FsClient, payments.NewFakeProvider())
+
+	// Load the tax table that we use to estimate the tax due on carts
+	svc.taxCalculator, err = tax.NewDefaultCalculator()
+	if err != nil {
+		return nil, fmt.Errorf("could not load tax calculator: %w", err)
+	}
+
+	// And the shipping rules that we use to quote delivery options
+	svc.shippingProvider, err = shipping.NewDefaultProvider()
+	if err != nil {
+		return nil, fmt.Errorf("could not load shipping rules: %w", err)
+	}
+
 	// All done - return the populated service instance
 	return svc, nil
 }
 
 // CreateShoppingCart returns a new shopping cart structure with a unique ID, creation time, and status assigned.
+//
+// If the request carries a request ID, the cart ID is derived from the shopper and request IDs rather than being
+// random, so a retried request finds that its cart already exists and is answered with the cart as it was first
+// created.
 func (cs *CartService) CreateShoppingCart(ctx context.Context, req *pbcart.CreateShoppingCartRequest) (*pbcart.CreateShoppingCartResponse, error) {
 
 	// Obtain a shortcut handle on our globally configured logger
@@ -101,9 +153,19 @@ func (cs *CartService) CreateShoppingCart(ctx context.Context, req *pbcart.Creat
 	// TODO: Parameter validation
 	// TODO: Access control
 
+	// Pick the ID of the new cart
+	cartId := uuid.NewString()
+	if req.RequestId != "" {
+		err := validateRequestId(req.RequestId)
+		if err != nil {
+			return nil, err
+		}
+		cartId = createdCartId(req)
+	}
+
 	// Create the storable cart structure with a new unique ID
 	storableCart := &schema.ShoppingCart{
-		Id:           uuid.NewString(),
+		Id:           cartId,
 		CreationTime: time.Now(),
 		Status:       schema.CsOpen,
 		Shopper:      types.PersonFromPB(req.Shopper),
@@ -112,17 +174,40 @@ func (cs *CartService) CreateShoppingCart(ctx context.Context, req *pbcart.Creat
 
 	// Store the empty new cart in the firestore
 	ref := cs.FsClient.Doc(storableCart.StoreRefPath())
-	_, err := cs.drProxy.Create(ref, ctx, storableCart)
-	if err != nil {
+	wr, err := cs.drProxy.Create(ref, ctx, storableCart)
+	if err != nil && req.RequestId != "" && status.Code(err) == codes.AlreadyExists {
+
+		// This is a retry, answer it as we did the original
+		processed, err := cs.getProcessedRequest(ctx, cartId, req)
+		if err != nil {
+			l.Error(err.Error(), zap.String("cartId", cartId))
+			return nil, err
+		}
+		pbCart, err := cs.cartForResponse(ctx, req, cartId, processed)
+		if err != nil {
+			return nil, err
+		}
+		return &pbcart.CreateShoppingCartResponse{Cart: pbCart}, nil
+
+	} else if err != nil {
 		err = fmt.Errorf("failed creating new cart in Firestore: %w", err)
 		l.Error(err.Error(), zap.String("cartId", storableCart.Id))
 		return nil, err
 	}
 
+	// Derive the etag of the new cart from the time at which it was written to the store
+	storableCart.Etag = schema.EtagFromUpdateTime(wr.UpdateTime)
+
+	// Remember what we said in case we have to say it again
+	pbCart := storableCart.AsPBShoppingCart()
+	if req.RequestId != "" {
+		cs.recordResponseCart(ctx, req, pbCart)
+	}
+
 	// All good, log our joy and return the protocol buffer transliteration of our shiny new cart
 	l.Info("new cart stored successfully", zap.String("cartId", storableCart.Id), zap.String("path", ref.Path))
 	return &pbcart.CreateShoppingCartResponse{
-		Cart: storableCart.AsPBShoppingCart(),
+		Cart: pbCart,
 	}, nil
 }
 
@@ -150,6 +235,19 @@ func (cs *CartService) GetShoppingCartByID(ctx context.Context, req *pbcart.GetS
 // buffer form. It is used by all the public set and get methods that include a copy of the cart in their
 // response.
 func (cs *CartService) getShoppingCart(ctx context.Context, cartId string) (*pbcart.ShoppingCart, error) {
+	storedCart, err := cs.loadShoppingCart(ctx, cartId)
+	if err != nil {
+		return nil, err
+	}
+	return storedCart.AsPBShoppingCart(), nil
+}
+
+// loadShoppingCart retrieves a shopping cart, complete with its delivery address and items, and works out its
+// discounts, delivery cost, and tax.
+//
+// The cart document and its delivery address document are fetched together in a single Firestore request while
+// the cart items are fetched concurrently, so the whole cart costs one round trip rather than three.
+func (cs *CartService) loadShoppingCart(ctx context.Context, cartId string) (*schema.ShoppingCart, error) {
 
 	// Obtain a shortcut handle on our globally configured logger
 	l := zap.L()
@@ -158,47 +256,158 @@ func (cs *CartService) getShoppingCart(ctx context.Context, cartId string) (*pbc
 	// Form a cart structure to receive the data from the store
 	storedCart := &schema.ShoppingCart{Id: cartId}
 
-	// TODO: Duh! Firestore supports getting the whole document tree in a single request.
-	//       The implementation below is inefficient and should be replaced with a single Get operation.
+	// Set the items loading while we fetch the cart and its delivery address
+	waitForItems := cs.loadCartItemsConcurrently(ctx, []*schema.ShoppingCart{storedCart})
 
-	// Ask the firestore client for the specified cart
-	ref := cs.FsClient.Doc(storedCart.StoreRefPath())
-	snap, err := cs.drProxy.Get(ref, ctx)
+	// Ask the firestore client for the specified cart and its delivery address (if there is one)
+	refs := []*firestore.DocumentRef{
+		cs.FsClient.Doc(storedCart.StoreRefPath()),
+		cs.FsClient.Doc(storedCart.DeliveryAddressPath()),
+	}
+	snaps, err := cs.drProxy.GetAll(cs.FsClient, ctx, refs)
+
+	// Collect the cart items, whatever happened with the cart, so that we do not leave anything running behind us
+	items, itemsErr := waitForItems()
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve cart snapshot with ID %s: %w", cartId, err)
 	}
+	if !snaps[0].Exists() {
+		return nil, status.Errorf(codes.NotFound, "cart not found: cart ID=%s", cartId)
+	}
 
 	// Unmarshall the snapshot into our internal structure form
-	err = cs.dsProxy.DataTo(snap, storedCart)
+	err = cs.dsProxy.DataTo(snaps[0], storedCart)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal cart snapshot with ID %s: %w", cartId, err)
 	}
+	storedCart.Etag = schema.EtagFromUpdateTime(snaps[0].UpdateTime)
 
-	// Get the delivery address if one has been set
-	storedCart.DeliveryAddress, err = cs.getDeliveryAddress(ctx, storedCart)
+	// Unpack the delivery address if one has been set
+	storedCart.DeliveryAddress, err = cs.deliveryAddressFromSnapshot(storedCart, snaps[1], nil)
 	if err != nil {
 		return nil, err
 	}
 
-	// TODO: There should be a way to request all descendants in a single Firestore request
-	//       rather than geting the items separately
-	// Get the cart items
-	storedCart.CartItems, err = cs.getCartItems(ctx, storedCart)
+	// Finally, add the items
+	if itemsErr != nil {
+		return nil, itemsErr
+	}
+	storedCart.CartItems = items[0]
+
+	// Work out what the cart's promotion codes have earned, what delivery costs, and the tax due, now that we
+	// know what is in it
+	err = cs.applyDiscounts(ctx, []*schema.ShoppingCart{storedCart})
 	if err != nil {
 		return nil, err
 	}
+	cs.requoteDeliveryOptions([]*schema.ShoppingCart{storedCart})
+	cs.estimateTax([]*schema.ShoppingCart{storedCart})
 
-	// All good, log our joy and return the protocol buffer transliteration of our retrieved cart
-	return storedCart.AsPBShoppingCart(), nil
+	// All good
+	return storedCart, nil
 }
 
-// getDeliveryAddress returns the delivery address for the given cart in its package internal structure form
-// or nil if no address was found or an error occurred.
-func (cs *CartService) getDeliveryAddress(ctx context.Context, cart *schema.ShoppingCart) (*types.PostalAddress, error) {
+// loadCartDescendants fills in the delivery addresses and items of the given carts, the top level fields of which
+// must already have been loaded. The delivery addresses of all the carts are fetched in a single Firestore request
+// while the items of each cart are fetched concurrently.
+func (cs *CartService) loadCartDescendants(ctx context.Context, carts []*schema.ShoppingCart) error {
+
+	// Nothing to do for nothing
+	if len(carts) == 0 {
+		return nil
+	}
+
+	// Set the items loading while we fetch the delivery addresses
+	waitForItems := cs.loadCartItemsConcurrently(ctx, carts)
+
+	// Ask for all the delivery addresses at once
+	refs := make([]*firestore.DocumentRef, len(carts))
+	for i, cart := range carts {
+		refs[i] = cs.FsClient.Doc(cart.DeliveryAddressPath())
+	}
+	snaps, err := cs.drProxy.GetAll(cs.FsClient, ctx, refs)
+
+	// Collect the cart items, whatever happened with the addresses, so that we do not leave anything running behind us
+	items, itemsErr := waitForItems()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve delivery addresses for %d carts: %w", len(carts), err)
+	}
+	if itemsErr != nil {
+		return itemsErr
+	}
+
+	// Fill in the blanks
+	for i, cart := range carts {
+		cart.DeliveryAddress, err = cs.deliveryAddressFromSnapshot(cart, snaps[i], nil)
+		if err != nil {
+			return err
+		}
+		cart.CartItems = items[i]
+	}
+
+	// Finally, work out what the carts' promotion codes have earned, what delivery costs, and the tax due
+	err = cs.applyDiscounts(ctx, carts)
+	if err != nil {
+		return err
+	}
+	cs.requoteDeliveryOptions(carts)
+	cs.estimateTax(carts)
+	return nil
+}
+
+// loadCartItemsConcurrently starts loading the items of each of the given carts in parallel and returns a function
+// that waits for the loading to complete then returns the items of each cart, in the same order as the carts, or
+// the first error encountered. The returned function must always be called, even if the caller has lost interest.
+//
+// Only the IDs of the carts are read, and that before this function returns, so the caller is free to populate the
+// carts' other fields in the meantime.
+func (cs *CartService) loadCartItemsConcurrently(ctx context.Context, carts []*schema.ShoppingCart) func() ([][]*schema.ShoppingCartItem, error) {
+
+	// Each goroutine has its own slot in which to leave its results
+	items := make([][]*schema.ShoppingCartItem, len(carts))
+	errs := make([]error, len(carts))
+	var wg sync.WaitGroup
+	for i, cart := range carts {
+		wg.Add(1)
+		go func(i int, cart *schema.ShoppingCart) {
+			defer wg.Done()
+			items[i], errs[i] = cs.getCartItems(ctx, cart)
+		}(i, &schema.ShoppingCart{Id: cart.Id})
+	}
 
-	// Ask the firestore client for the delivery address (if there is one)
+	// Give the caller a way to collect the results
+	return func() ([][]*schema.ShoppingCartItem, error) {
+		wg.Wait()
+		for _, err := range errs {
+			if err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	}
+}
+
+// getTransactionalDeliveryAddress returns the delivery address for the given cart, read within the given Firestore
+// transaction, in its package internal structure form or nil if no address was found or an error occurred.
+func (cs *CartService) getTransactionalDeliveryAddress(tx *firestore.Transaction, cart *schema.ShoppingCart) (*types.PostalAddress, error) {
+
+	// Ask the firestore transaction for the delivery address (if there is one)
 	ref := cs.FsClient.Doc(cart.DeliveryAddressPath())
-	snap, err := cs.drProxy.Get(ref, ctx)
+	snap, err := cs.drProxy.TransactionalGet(ref, tx)
+	return cs.deliveryAddressFromSnapshot(cart, snap, err)
+}
+
+// deliveryAddressFromSnapshot unmarshals the delivery address for the given cart from the given snapshot, or
+// interprets the error that was returned in place of the snapshot. A nil address is returned if the snapshot is
+// of a delivery address document that does not exist.
+func (cs *CartService) deliveryAddressFromSnapshot(cart *schema.ShoppingCart, snap *firestore.DocumentSnapshot, err error) (*types.PostalAddress, error) {
+
+	// A snapshot of a document that does not exist means that no address has been set
+	if err == nil && !snap.Exists() {
+		return nil, nil
+	}
+
+	// If we got a snapshot, convert it to our internal structure form
 	if err == nil {
 
 		// Unmarshall the snapshot into our internal structure form
@@ -227,12 +436,26 @@ func (cs *CartService) getDeliveryAddress(ctx context.Context, cart *schema.Shop
 // The returned slice may be empty if the cart does not currently contain any selected items.
 func (cs *CartService) getCartItems(ctx context.Context, cart *schema.ShoppingCart) ([]*schema.ShoppingCartItem, error) {
 
-	// Build our result set here
-	var items []*schema.ShoppingCartItem
-
 	// Obtain an iterator that can walk the cart's item collection's documents. Under the hood, a "GetAll"
 	// operation retrieves all the item documents in a single round trip
 	docs := cs.itemsGetterProxy.Items(cart.ItemCollectionPath()).GetAll(ctx)
+	return collectCartItems(docs, cart)
+}
+
+// getTransactionalCartItems returns the collection of cart items for the given cart, read within the given
+// Firestore transaction, in their package internal structure form. The returned slice may be empty if the cart
+// does not currently contain any selected items.
+func (cs *CartService) getTransactionalCartItems(tx *firestore.Transaction, cart *schema.ShoppingCart) ([]*schema.ShoppingCartItem, error) {
+	docs := cs.itemsGetterProxy.Items(cart.ItemCollectionPath()).TransactionalGetAll(tx)
+	return collectCartItems(docs, cart)
+}
+
+// collectCartItems walks the given cart item document iterator, gathering all the items in their package internal
+// structure form. The iterator is stopped before returning, regardless of whether an error was encountered or not.
+func collectCartItems(docs DocumentIteratorProxy, cart *schema.ShoppingCart) ([]*schema.ShoppingCartItem, error) {
+
+	// Build our result set here
+	var items []*schema.ShoppingCartItem
 
 	// Close the iterator when we are done with it regardless of whether we are successful or not
 	defer docs.Stop()
@@ -261,22 +484,28 @@ func (cs *CartService) getCartItems(ctx context.Context, cart *schema.ShoppingCa
 	return items, nil
 }
 
-// SetDeliveryAddress adds (or replaces) the delivery address to be used for physical cart items.
+// SetDeliveryAddress adds (or replaces) the delivery address to be used for physical cart items. Since the delivery
+// options available depend on where the items are going, any delivery option already chosen is cleared.
 func (cs *CartService) SetDeliveryAddress(ctx context.Context, req *pbcart.SetDeliveryAddressRequest) (*pbcart.SetDeliveryAddressResponse, error) {
 
 	// Obtain a shortcut handle on our globally configured logger
 	l := zap.L()
 	l.Info("setting delivery address", zap.String("cartId", req.CartId))
 
-	// Form a skeleton cart representation that we can query for the delivery address path
-	cart := &schema.ShoppingCart{Id: req.CartId}
-
-	// Store the delivery address as a child of the cart in the firestore
+	// Store the delivery address as a child of the cart in the firestore, but only if the cart is still open
 	deliveryAddress := types.PostalAddressFromPB(req.DeliveryAddress)
-	ref := cs.FsClient.Doc(cart.DeliveryAddressPath())
-	_, err := cs.drProxy.Set(ref, ctx, deliveryAddress)
+	processed, err := cs.updateOpenCart(ctx, req, "set delivery address of", func(tx *firestore.Transaction, cart *schema.ShoppingCart) ([]firestore.Update, error) {
+		ref := cs.FsClient.Doc(cart.DeliveryAddressPath())
+		err := cs.drProxy.TransactionalSet(ref, tx, deliveryAddress)
+		if err != nil {
+			return nil, fmt.Errorf("failed setting delivery address to firestore for cart: %w", err)
+		}
+		if cart.DeliveryOption == nil {
+			return nil, nil
+		}
+		return []firestore.Update{{Path: "deliveryOption", Value: firestore.Delete}}, nil
+	})
 	if err != nil {
-		err = fmt.Errorf("failed setting delivery address to firestore for cart: %w", err)
 		l.Error(err.Error(), zap.String("cartId", req.CartId))
 		return nil, err
 	}
@@ -285,7 +514,7 @@ func (cs *CartService) SetDeliveryAddress(ctx context.Context, req *pbcart.SetDe
 	l.Info("delivery address set successfully", zap.String("cartId", req.CartId))
 
 	// Have our internal sibling do all the remaining work
-	pbCart, err := cs.getShoppingCart(ctx, req.CartId)
+	pbCart, err := cs.cartForResponse(ctx, req, req.CartId, processed)
 	if err != nil {
 		return nil, err
 	}
@@ -294,11 +523,20 @@ func (cs *CartService) SetDeliveryAddress(ctx context.Context, req *pbcart.SetDe
 	}, nil
 }
 
-// AddItemToShoppingCart adds an item to a cart.
+// AddItemToShoppingCart adds an item to a cart. If the cart already contains an item with the same product code and
+// the same attributes, the quantity of the new item is added to that of the existing item rather than a second item
+// being added. Attribute names must be given and must be unique within the item, otherwise a codes.InvalidArgument
+// status error is returned.
+//
+// The product must be in the product catalog, otherwise a codes.InvalidArgument status error is returned. Any unit
+// price given in the request is ignored; the item is priced from the catalog, as is an existing item that the new
+// one is merged into.
+//
+// Stock is reserved for all of the cart's items for the product, for as long as the inventory allows. If there is
+// not enough stock, a codes.ResourceExhausted status error is returned and the cart is left unchanged.
 //
-// TODO: Confirm that the item type is not already in the cart. Combine multiples / increasing quantity??
 // TODO: Mock handling of requirements / dependencies / rejecting invalid combinations
-// TODO: Access control? - Cannot change if cart already closed. Cannot change if user/shopper does not match.
+// TODO: Access control? - Cannot change if user/shopper does not match.
 func (cs *CartService) AddItemToShoppingCart(ctx context.Context, req *pbcart.AddItemToShoppingCartRequest) (*pbcart.AddItemToShoppingCartResponse, error) {
 
 	// Obtain a shortcut handle on our globally configured logger
@@ -312,28 +550,215 @@ func (cs *CartService) AddItemToShoppingCart(ctx context.Context, req *pbcart.Ad
 	req.Item.Id = itemId
 	req.Item.CartId = req.CartId
 	item := schema.ShoppingCartItemFromPB(req.Item)
+	err := validateItemAttributes(item)
+	if err != nil {
+		return nil, err
+	}
 
-	// Store the item as a child of the cart
-	ref := cs.FsClient.Doc(item.StoreRefPath())
-	_, err := cs.drProxy.Set(ref, ctx, item)
+	// Store the item as a child of the cart, or merge it with an existing item, but only if the cart is still open
+	var mergedItemId string
+	processed, err := cs.updateOpenCart(ctx, req, "add item to", func(tx *firestore.Transaction, cart *schema.ShoppingCart) ([]firestore.Update, error) {
+		var err error
+		mergedItemId, err = cs.addTransactionalItem(tx, cart, item)
+		return nil, err
+	})
 	if err != nil {
-		err = fmt.Errorf("failed setting cart item to firestore for cart: %w", err)
 		l.Error(err.Error(), zap.String("cartId", item.CartId), zap.String("itemId", item.Id))
 		return nil, err
 	}
 
 	// All good, log our joy before returning the protocol buffer transliteration of our retrieved cart
-	l.Info("cart item added successfully", zap.String("cartId", item.CartId), zap.String("itemId", item.Id))
+	if processed != nil {
+		l.Info("cart item add was a retry", zap.String("cartId", item.CartId), zap.String("requestId", req.RequestId))
+	} else if mergedItemId != "" {
+		l.Info("cart item merged successfully", zap.String("cartId", item.CartId), zap.String("itemId", mergedItemId))
+	} else {
+		l.Info("cart item added successfully", zap.String("cartId", item.CartId), zap.String("itemId", item.Id))
+	}
 
 	// Have our internal sibling do all the remaining work to return the complete cart as it now stands
-	pbCart, err := cs.getShoppingCart(ctx, req.CartId)
+	pbCart, err := cs.cartForResponse(ctx, req, req.CartId, processed)
 	if err != nil {
 		return nil, err
 	}
 	return &pbcart.AddItemToShoppingCartResponse{Cart: pbCart}, nil
 }
 
-// RemoveItemFromShoppingCart removes an item from the cart.
+// addTransactionalItem adds the given item to the given open cart within the given Firestore transaction, pricing
+// it from the catalog and reserving stock for it, or merges it with an existing item for the same product and
+// attributes. The ID of the existing item is returned if the new one was merged with it, otherwise an empty string.
+//
+// All of the reads are made before any of the writes, so this must be called before the transaction makes any
+// writes of its own.
+func (cs *CartService) addTransactionalItem(tx *firestore.Transaction, cart *schema.ShoppingCart, item *schema.ShoppingCartItem) (string, error) {
+
+	// The product must be in the catalog, and it is the catalog that sets the price, not the shopper, and that says
+	// whether the product has to be delivered and how it is taxed
+	product, err := cs.getTransactionalProduct(tx, item.ProductCode)
+	if err != nil {
+		return "", err
+	}
+	item.UnitPrice = product.UnitPrice
+	item.Digital = product.Digital
+	item.TaxCategory = product.TaxCategory
+
+	// Look for an existing item with the same product code and attributes
+	existingItems, err := cs.getTransactionalCartItems(tx, cart)
+	if err != nil {
+		return "", err
+	}
+	merged := findMergeableItem(existingItems, item)
+
+	// Hold enough stock for everything that the cart will have of the product, across all of its items
+	quantity := item.Quantity
+	if merged != nil {
+		quantity += merged.Quantity
+	}
+	reserved := productQuantity(existingItems, item.ProductCode) + int64(item.Quantity)
+	err = cs.reserveStock(tx, cart.Id, map[string]int64{item.ProductCode: reserved})
+	if err != nil {
+		return "", err
+	}
+
+	// If we found an existing item, increase its quantity rather than adding a second item for the same product,
+	// bringing its price up to date with the catalog while we are at it
+	if merged != nil {
+		ref := cs.FsClient.Doc(merged.StoreRefPath())
+		err = cs.drProxy.TransactionalUpdate(ref, tx, []firestore.Update{
+			{Path: "quantity", Value: quantity},
+			{Path: "unitPrice", Value: item.UnitPrice},
+			{Path: "digital", Value: item.Digital},
+			{Path: "taxCategory", Value: item.TaxCategory},
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed merging cart item into existing item %s in firestore for cart: %w", merged.Id, err)
+		}
+		return merged.Id, nil
+	}
+
+	// This is a new product for the cart, store it as a new item
+	ref := cs.FsClient.Doc(item.StoreRefPath())
+	err = cs.drProxy.TransactionalSet(ref, tx, item)
+	if err != nil {
+		return "", fmt.Errorf("failed setting cart item to firestore for cart: %w", err)
+	}
+	return "", nil
+}
+
+// validateItemAttributes returns a codes.InvalidArgument status error if any of the given item's attributes has no
+// name or has the same name as another.
+func validateItemAttributes(item *schema.ShoppingCartItem) error {
+	names := make(map[string]bool, len(item.Attributes))
+	for _, attribute := range item.Attributes {
+		if attribute.Name == "" {
+			return status.Errorf(codes.InvalidArgument, "cart item attribute name must be specified: product code=%s", item.ProductCode)
+		}
+		if names[attribute.Name] {
+			return status.Errorf(codes.InvalidArgument, "cart item attribute names must be unique: product code=%s, attribute=%s", item.ProductCode, attribute.Name)
+		}
+		names[attribute.Name] = true
+	}
+	return nil
+}
+
+// UpdateCartItem updates the fields of an existing cart item that are named in the request update mask. At
+// present, only the quantity may be updated.
+//
+// The stock reserved for the item is adjusted to match its new quantity. If there is not enough stock, a
+// codes.ResourceExhausted status error is returned and the item is left unchanged.
+func (cs *CartService) UpdateCartItem(ctx context.Context, req *pbcart.UpdateCartItemRequest) (*pbcart.UpdateCartItemResponse, error) {
+
+	// Obtain a shortcut handle on our globally configured logger then log what we are about to do
+	l := zap.L()
+	pbItem := req.GetItem()
+	l.Info("updating cart item", zap.String("cartId", req.CartId), zap.String("itemId", pbItem.GetId()))
+
+	// We have to know which item is to be updated
+	if pbItem.GetId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "cart item ID must be specified: cart ID=%s", req.CartId)
+	}
+
+	// Translate the update mask paths into Firestore field updates, assuming a quantity update if no mask was given
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		paths = []string{"quantity"}
+	}
+	var updates []firestore.Update
+	quantityUpdated := false
+	for _, path := range paths {
+		switch path {
+		case "quantity":
+			if pbItem.Quantity <= 0 {
+				return nil, status.Errorf(codes.InvalidArgument, "cart item quantity must be greater than zero: cart ID=%s, item ID=%s, quantity=%d", req.CartId, pbItem.Id, pbItem.Quantity)
+			}
+			updates = append(updates, firestore.Update{Path: "quantity", Value: pbItem.Quantity})
+			quantityUpdated = true
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "cart item field cannot be updated: cart ID=%s, item ID=%s, field=%s", req.CartId, pbItem.Id, path)
+		}
+	}
+
+	// Use a partial item structure to form the key of the target item to be updated
+	target := schema.ShoppingCartItem{
+		Id:     pbItem.Id,
+		CartId: req.CartId,
+	}
+
+	// Apply the updates to the item, provided that it exists and the cart is still open
+	processed, err := cs.updateOpenCart(ctx, req, "update item in", func(tx *firestore.Transaction, cart *schema.ShoppingCart) ([]firestore.Update, error) {
+
+		// Confirm that the item exists
+		ref := cs.FsClient.Doc(target.StoreRefPath())
+		snap, err := cs.drProxy.TransactionalGet(ref, tx)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return nil, status.Errorf(codes.NotFound, "cart item not found: cart ID=%s, item ID=%s", target.CartId, target.Id)
+			}
+			return nil, fmt.Errorf("failed to retrieve cart item snapshot with ID %s: %w", target.Id, err)
+		}
+
+		// Hold the stock for the new quantity, if it is changing, along with that of any other items for the product
+		if quantityUpdated {
+			stored := &schema.ShoppingCartItem{}
+			err = cs.dsProxy.DataTo(snap, stored)
+			if err != nil {
+				return nil, fmt.Errorf("failed to unmarshal cart item snapshot with ID %s: %w", target.Id, err)
+			}
+			items, err := cs.getTransactionalCartItems(tx, cart)
+			if err != nil {
+				return nil, err
+			}
+			reserved := productQuantity(items, stored.ProductCode) - int64(stored.Quantity) + int64(pbItem.Quantity)
+			err = cs.reserveStock(tx, cart.Id, map[string]int64{stored.ProductCode: reserved})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// Now update it
+		err = cs.drProxy.TransactionalUpdate(ref, tx, updates)
+		if err != nil {
+			return nil, fmt.Errorf("failed updating cart item in firestore: %w", err)
+		}
+		return nil, nil
+	})
+	if err != nil {
+		l.Error(err.Error(), zap.String("cartId", target.CartId), zap.String("itemId", target.Id))
+		return nil, err
+	}
+
+	// All good, log our joy before returning the protocol buffer transliteration of our retrieved cart
+	l.Info("cart item updated successfully", zap.String("cartId", target.CartId), zap.String("itemId", target.Id))
+
+	// Have our internal sibling do all the remaining work to return the complete cart as it now stands
+	pbCart, err := cs.cartForResponse(ctx, req, req.CartId, processed)
+	if err != nil {
+		return nil, err
+	}
+	return &pbcart.UpdateCartItemResponse{Cart: pbCart}, nil
+}
+
+// RemoveItemFromShoppingCart removes an item from the cart, releasing the stock reserved for it.
 func (cs *CartService) RemoveItemFromShoppingCart(ctx context.Context, req *pbcart.RemoveItemFromShoppingCartRequest) (*pbcart.RemoveItemFromShoppingCartResponse, error) {
 
 	// Obtain a shortcut handle on our globally configured logger then log what we are about to do
@@ -346,17 +771,46 @@ func (cs *CartService) RemoveItemFromShoppingCart(ctx context.Context, req *pbca
 		CartId: req.CartId,
 	}
 
-	// Instruct Firestore to remove the item with extreme prejudice
-	ref := cs.FsClient.Doc(target.StoreRefPath())
-	_, err := cs.drProxy.Delete(ref, ctx)
+	// Instruct Firestore to remove the item with extreme prejudice, but only if the cart is still open
+	processed, err := cs.updateOpenCart(ctx, req, "remove item from", func(tx *firestore.Transaction, cart *schema.ShoppingCart) ([]firestore.Update, error) {
+
+		// Find out what the item is for, so that we can release its stock, keeping only enough for any other items
+		// for the same product; an item that is already gone has no stock to release
+		ref := cs.FsClient.Doc(target.StoreRefPath())
+		snap, err := cs.drProxy.TransactionalGet(ref, tx)
+		if err == nil {
+			stored := &schema.ShoppingCartItem{}
+			err = cs.dsProxy.DataTo(snap, stored)
+			if err != nil {
+				return nil, fmt.Errorf("failed to unmarshal cart item snapshot with ID %s: %w", target.Id, err)
+			}
+			items, err := cs.getTransactionalCartItems(tx, cart)
+			if err != nil {
+				return nil, err
+			}
+			reserved := productQuantity(items, stored.ProductCode) - int64(stored.Quantity)
+			err = cs.reserveStock(tx, cart.Id, map[string]int64{stored.ProductCode: reserved})
+			if err != nil {
+				return nil, err
+			}
+		} else if status.Code(err) != codes.NotFound {
+			return nil, fmt.Errorf("failed to retrieve cart item snapshot with ID %s: %w", target.Id, err)
+		}
+
+		// Now delete it
+		err = cs.drProxy.TransactionalDelete(ref, tx)
+		if err != nil {
+			return nil, fmt.Errorf("failed deleting cart item from firestore: %w", err)
+		}
+		return nil, nil
+	})
 	if err != nil {
-		err = fmt.Errorf("failed deleting cart item from firestore: %w", err)
 		l.Error(err.Error(), zap.String("cartId", target.CartId), zap.String("itemId", target.Id))
 		return nil, err
 	}
 
 	// Have our internal sibling do all the remaining work to return the complete cart as it now stands
-	pbCart, err := cs.getShoppingCart(ctx, req.CartId)
+	pbCart, err := cs.cartForResponse(ctx, req, req.CartId, processed)
 	if err != nil {
 		return nil, err
 	}
@@ -365,8 +819,17 @@ func (cs *CartService) RemoveItemFromShoppingCart(ctx context.Context, req *pbca
 
 // CheckoutShoppingCart submits the order / checkout the shopping cart
 //
-// TODO: consider more informative structured error reporting in the response rather than as an HTTP / protocol error?
-// TODO: Should not be able to check out cart with nothing in it and no delivery address (although delivery address might more normally be collected during checkout)
+// The cart is validated before it is checked out. If it has no shopper, no items, items with invalid quantities or
+// prices, or physical items but no delivery address, a codes.FailedPrecondition status error is returned with a
+// google.rpc.BadRequest detail listing every problem that was found.
+//
+// The stock reserved for the items is committed, i.e. taken out of the inventory for good, as the cart is checked out.
+// Should there no longer be enough stock, perhaps because the cart's reservations lapsed and somebody else bought the
+// last units, a codes.ResourceExhausted status error is returned and the cart is left open.
+//
+// The shopper's payment for the cart total, including the estimated tax, is authorized as the cart is checked out
+// and recorded against the ID of the order that the cart will become. If the payment is declined, a
+// codes.FailedPrecondition status error is returned and the cart is left open.
 func (cs *CartService) CheckoutShoppingCart(ctx context.Context, req *pbcart.CheckoutShoppingCartRequest) (*pbcart.CheckoutShoppingCartResponse, error) {
 
 	// Obtain a shortcut handle on our globally configured logger then log what we are about to do
@@ -374,7 +837,7 @@ func (cs *CartService) CheckoutShoppingCart(ctx context.Context, req *pbcart.Che
 	l.Info("checking out cart", zap.String("cartId", req.CartId))
 
 	// Check out and abandon are almost the same data operation except for the status value and log messaging
-	pbCart, err := cs.closeCart(ctx, req.CartId, schema.CsCheckedOut)
+	pbCart, err := cs.closeCart(ctx, req, schema.CsCheckedOut)
 	if err != nil {
 		return nil, err
 	}
@@ -385,7 +848,8 @@ func (cs *CartService) CheckoutShoppingCart(ctx context.Context, req *pbcart.Che
 }
 
 // AbandonShoppingCart explicitly abandons a shopping cart in response to a user request (as against the
-// system cancelling a cart that has gone unused for some period of time).
+// system cancelling a cart that has gone unused for some period of time). The stock reserved for the items in the
+// cart is released.
 func (cs *CartService) AbandonShoppingCart(ctx context.Context, req *pbcart.AbandonShoppingCartRequest) (*pbcart.AbandonShoppingCartResponse, error) {
 
 	// Obtain a shortcut handle on our globally configured logger then log what we are about to do
@@ -393,7 +857,7 @@ func (cs *CartService) AbandonShoppingCart(ctx context.Context, req *pbcart.Aban
 	l.Info("abandoning out cart", zap.String("cartId", req.CartId))
 
 	// Check out and abandon are almost the same data operation except for the status value and log messaging
-	pbCart, err := cs.closeCart(ctx, req.CartId, schema.CsAbandonedByUser)
+	pbCart, err := cs.closeCart(ctx, req, schema.CsAbandonedByUser)
 	if err != nil {
 		return nil, err
 	}
@@ -403,24 +867,234 @@ func (cs *CartService) AbandonShoppingCart(ctx context.Context, req *pbcart.Aban
 	return &pbcart.AbandonShoppingCartResponse{Cart: pbCart}, nil
 }
 
-// closeCart updates the status of an open cart to one of the closed status options.
-func (cs *CartService) closeCart(ctx context.Context, cartId string, closedState schema.CartStatus) (*pbcart.ShoppingCart, error) {
+// closeCart updates the status of the open cart identified in the given request to one of the closed status
+// options. If the request etag is not empty, the cart must not have been modified since the etag was issued.
+func (cs *CartService) closeCart(ctx context.Context, req mutatingRequest, closedState schema.CartStatus) (*pbcart.ShoppingCart, error) {
+
+	// Change the status of the cart within the same transaction that confirms it is open, keeping hold of every
+	// payment that we authorize so that those that do not end up recorded can be given up. Each attempt at the
+	// transaction totals the cart afresh, and a cart that changed in between is authorized again for its new total.
+	cartId := req.GetCartId()
+	var authorizations []*payments.Payment
+	processed, err := cs.updateOpenCart(ctx, req, "change status of", func(tx *firestore.Transaction, cart *schema.ShoppingCart) ([]firestore.Update, error) {
+
+		// Good to go, unless the cart is being checked out and is not complete and consistent
+		updates := []firestore.Update{
+			{Path: "status", Value: closedState},
+			{Path: "closedTime", Value: time.Now()},
+		}
+		if closedState == schema.CsCheckedOut {
+			err := cs.checkCartCanBeCheckedOut(tx, cart)
+			if err != nil {
+				return nil, err
+			}
+
+			// Freeze the discounts that the cart has earned so that they are carried through into the order
+			if len(cart.PromotionCodes) > 0 {
+				promotions, err := cs.getTransactionalPromotions(tx, cart)
+				if err != nil {
+					return nil, err
+				}
+				cart.Discounts = cart.CalculateDiscounts(promotions, time.Now())
+				updates = append(updates, firestore.Update{Path: "discounts", Value: cart.Discounts})
+			}
+
+			// Likewise the cost of delivery, as quoted for the cart as it now stands
+			if cart.DeliveryOption != nil {
+				updates = append(updates, firestore.Update{Path: "deliveryOption", Value: cart.DeliveryOption})
+			}
+
+			// Everything has been read, so now take the items out of stock
+			err = cs.commitStock(tx, cart.Id, cart.CartItems)
+			if err != nil {
+				return nil, err
+			}
+
+			// And have the shopper pay for them
+			authorized, err := cs.authorizePayment(ctx, tx, cart)
+			if err != nil {
+				return nil, err
+			}
+			authorizations = append(authorizations, authorized)
+
+			// Freeze the tax that the payment covers, if there is any, so that the order records the same figure
+			if cart.EstimatedTax != nil {
+				updates = append(updates, firestore.Update{Path: "estimatedTax", Value: cart.EstimatedTax})
+			}
+			return updates, nil
+		}
 
-	// Ask the firestore client for the specified cart
+		// The cart is being abandoned, let go of the stock that it was holding
+		items, err := cs.getTransactionalCartItems(tx, cart)
+		if err != nil {
+			return nil, err
+		}
+		err = cs.releaseStock(tx, cart.Id, items)
+		if err != nil {
+			return nil, err
+		}
+		return updates, nil
+	})
+	if err != nil {
+		zap.L().Error(err.Error(), zap.String("cartId", cartId))
+		cs.voidPayments(ctx, authorizations)
+		return nil, err
+	}
+
+	// The authorization made by the attempt that went through has been recorded; any made by earlier attempts, for
+	// totals that have since changed, have not
+	if len(authorizations) > 1 {
+		cs.voidPayments(ctx, authorizations[:len(authorizations)-1])
+	}
+
+	// All good, return the full updated cart or an error we get trying to retrieve it
+	return cs.cartForResponse(ctx, req, cartId, processed)
+}
+
+// checkCartCanBeCheckedOut loads the delivery address and items of the given cart within the given transaction then
+// validates that the cart is fit to be checked out. If it is not, a codes.FailedPrecondition status error is returned
+// with a google.rpc.BadRequest detail listing all the problems that were found.
+func (cs *CartService) checkCartCanBeCheckedOut(tx *firestore.Transaction, cart *schema.ShoppingCart) error {
+
+	// Fill in the parts of the cart that the validation needs to see
+	var err error
+	cart.DeliveryAddress, err = cs.getTransactionalDeliveryAddress(tx, cart)
+	if err != nil {
+		return err
+	}
+	cart.CartItems, err = cs.getTransactionalCartItems(tx, cart)
+	if err != nil {
+		return err
+	}
+
+	// Report every problem we find, not just the first
+	violations := validateCheckout(cart)
+	if cart.DeliveryOption != nil {
+		option, err := cart.QuoteDeliveryOption(cs.shippingProvider, cart.DeliveryOption.Code)
+		if err != nil || option == nil {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       "delivery_option",
+				Description: fmt.Sprintf("the chosen delivery option is no longer available for the cart: delivery option=%s", cart.DeliveryOption.Code),
+			})
+		}
+		cart.DeliveryOption = option
+	}
+	if len(violations) > 0 {
+		return checkoutViolationsError(cart.Id, violations)
+	}
+	return nil
+}
+
+// updateOpenCart loads the cart with the given ID inside a Firestore transaction and, provided that the cart is
+// still open, invokes the given update function to make changes to the cart's descendants within that same
+// transaction. The update function may also return field updates to be applied to the cart document itself.
+// Because the status check and the writes share a transaction, a concurrent checkout or abandonment of the cart
+// will cause the transaction to be retried rather than allowing a change to slip into a closed cart.
+//
+// The cart document is always touched, with a precondition that its update time has not changed since it was
+// read, so that its update time, and hence its etag, moves on with every change to the cart or its descendants.
+//
+// If the given etag is not empty and does not match that of the stored cart, a codes.Aborted status error is
+// returned. If the cart is not open, a codes.FailedPrecondition status error is returned, its message formed
+// using the given action description, e.g. "add item to". In either case, the update function is not called.
+//
+// If the request carries a request ID, a record of it is written in the same transaction as the changes. If such
+// a record already exists, the request is a retry: nothing is changed, the update function is not called, and the
+// record is returned so that the caller can respond as it did to the original request. The etag and open status
+// checks are skipped for retries since the original request will itself have moved the etag on or closed the cart.
+func (cs *CartService) updateOpenCart(ctx context.Context, req mutatingRequest, action string, update func(tx *firestore.Transaction, cart *schema.ShoppingCart) ([]firestore.Update, error)) (*schema.ProcessedRequest, error) {
+
+	// Make sure that any request ID that we have been given can be recorded
+	err := validateRequestId(req.GetRequestId())
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep track of errors returned by our own transaction function so that we can distinguish them from
+	// failures reported by Firestore when the transaction is committed
+	var txFuncErr error
+	var processed *schema.ProcessedRequest
+	err = cs.FsClient.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		processed, txFuncErr = cs.doUpdateOpenCart(tx, req, action, update)
+		return txFuncErr
+	})
+
+	// If Firestore rejected the commit because our update time precondition was not met then somebody else
+	// got in first; report that as an aborted request in the same way as a stale etag
+	if err != nil && err != txFuncErr && status.Code(err) == codes.FailedPrecondition {
+		return nil, status.Errorf(codes.Aborted, "cart was modified concurrently: cart ID=%s: %v", req.GetCartId(), err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return processed, nil
+}
+
+// doUpdateOpenCart is the transaction function body of updateOpenCart.
+func (cs *CartService) doUpdateOpenCart(tx *firestore.Transaction, req mutatingRequest, action string, update func(tx *firestore.Transaction, cart *schema.ShoppingCart) ([]firestore.Update, error)) (*schema.ProcessedRequest, error) {
+
+	// If this is a retry of a request that we have already applied then there is nothing more to do
+	cartId := req.GetCartId()
+	if req.GetRequestId() != "" {
+		processed, err := cs.getTransactionalProcessedRequest(tx, cartId, req)
+		if err != nil || processed != nil {
+			return processed, err
+		}
+	}
+
+	// Load the cart, confirming that it is open and that the caller is up to date with it
+	storedCart, snap, err := cs.getTransactionalOpenCart(tx, cartId, req.GetEtag(), action)
+	if err != nil {
+		return nil, err
+	}
+
+	// The cart is open, let the caller do its thing
+	updates, err := update(tx, storedCart)
+	if err != nil {
+		return nil, err
+	}
+
+	// Touch the cart document, applying any changes the caller asked for, provided nobody else has beaten us to it
+	updates = append(updates, firestore.Update{Path: "modifiedTime", Value: time.Now()})
+	err = cs.drProxy.TransactionalUpdate(snap.Ref, tx, updates, firestore.LastUpdateTime(snap.UpdateTime))
+	if err != nil {
+		return nil, fmt.Errorf("failed putting updated cart to datastore with ID %s: %w", cartId, err)
+	}
+
+	// Remember that we have done this, if we have been asked to
+	if req.GetRequestId() != "" {
+		return nil, cs.recordTransactionalProcessedRequest(tx, cartId, req)
+	}
+	return nil, nil
+}
+
+// getTransactionalOpenCart reads the cart with the given ID within the given Firestore transaction, returning both
+// the cart and the snapshot that it was read from. If the given etag is not empty and does not match that of the
+// stored cart, a codes.Aborted status error is returned. If the cart is not open, a codes.FailedPrecondition status
+// error is returned, its message formed using the given action description, e.g. "add item to".
+func (cs *CartService) getTransactionalOpenCart(tx *firestore.Transaction, cartId string, etag string, action string) (*schema.ShoppingCart, *firestore.DocumentSnapshot, error) {
+
+	// Ask the firestore transaction for the specified cart
 	storedCart := &schema.ShoppingCart{Id: cartId}
 	ref := cs.FsClient.Doc(storedCart.StoreRefPath())
-	snap, err := cs.drProxy.Get(ref, ctx)
+	snap, err := cs.drProxy.TransactionalGet(ref, tx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve cart snapshot with ID %s: %w", cartId, err)
+		return nil, nil, fmt.Errorf("failed to retrieve cart snapshot with ID %s: %w", cartId, err)
 	}
 
 	// Unmarshall the snapshot into our internal structure form
 	err = cs.dsProxy.DataTo(snap, storedCart)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal cart snapshot with ID %s: %w", cartId, err)
+		return nil, nil, fmt.Errorf("failed to unmarshal cart snapshot with ID %s: %w", cartId, err)
+	}
+
+	// If the caller told us which version of the cart they were looking at, it had better be the current one
+	storedCart.Etag = schema.EtagFromUpdateTime(snap.UpdateTime)
+	if etag != "" && etag != storedCart.Etag {
+		return nil, nil, status.Errorf(codes.Aborted, "cart has been modified since etag was issued: cart ID=%s, etag=%s", cartId, etag)
 	}
 
-	// If the status is not currently open, we can't abandon it!
+	// If the status is not currently open, we can't change it!
 	if storedCart.Status != schema.CsOpen {
 
 		// Watch out in case the cart status is one that we don't know about
@@ -428,19 +1102,9 @@ func (cs *CartService) closeCart(ctx context.Context, cartId string, closedState
 		if state == "" {
 			state = "unrecognized"
 		}
-		return nil, fmt.Errorf("cannot change status of cart that is not open: cart ID=%s, status=%s", storedCart.Id, state)
-	}
-
-	// Change the status and write the cart back to teh store
-	storedCart.Status = closedState
-	storedCart.ClosedTime = time.Now()
-	_, err = cs.drProxy.Set(ref, ctx, storedCart)
-	if err != nil {
-		err = fmt.Errorf("failed putting updated cart status to datastore with ID %s: %w", cartId, err)
-		zap.L().Error(err.Error(), zap.String("cartId", storedCart.Id))
-		return nil, err
+		return nil, nil, status.Errorf(codes.FailedPrecondition, "cannot %s cart that is not open: cart ID=%s, status=%s", action, storedCart.Id, state)
 	}
 
-	// All good, return the full updated cart or an error we get trying to retrieve it
-	return cs.getShoppingCart(ctx, storedCart.Id)
+	// All good
+	return storedCart, snap, nil
 }
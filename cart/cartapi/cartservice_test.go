@@ -4,13 +4,20 @@ import (
 	"context"
 	"errors"
 	"os"
+	"regexp"
+	"sort"
 	"testing"
 
 	"github.com/google/uuid"
 	pbcart "github.com/mikebway/poc-gcp-ecomm/pb/cart"
 	pbtypes "github.com/mikebway/poc-gcp-ecomm/pb/types"
+	"github.com/mikebway/poc-gcp-ecomm/types"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	pbmoney "google.golang.org/genproto/googleapis/type/money"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -254,7 +261,8 @@ func TestDeliveryAddressSetFailure(t *testing.T) {
 }
 
 // TestDeliveryAddressGetFailure examines what happens if the Firestore Get fails getting the delivery address
-// associated with a cart
+// associated with a cart on its own, as happens when the cart is validated for checkout, and when fetched along with
+// the cart itself.
 func TestDeliveryAddressGetFailure(t *testing.T) {
 
 	// Do the basic foundation stuff that most of this package's tests require
@@ -273,10 +281,17 @@ func TestDeliveryAddressGetFailure(t *testing.T) {
 	// Now, modify the cart service to return an error on the second get from Firestore, i.e. the address
 	service.drProxy = &UTDocRefProxy{Err: mockError, AllowCount: 1}
 
-	// .. and try to get the cart
-	getResponse, err := service.GetShoppingCartByID(ctx, &pbcart.GetShoppingCartByIDRequest{CartId: cart.Id})
+	// .. and try to check out the cart
+	checkoutResponse, err := service.CheckoutShoppingCart(ctx, &pbcart.CheckoutShoppingCartRequest{CartId: cart.Id})
 	req.NotNil(err, "should have seen an error getting the address from Rupert's cart")
 	req.Contains(err.Error(), "failed to retrieve delivery address for cart with ID "+cart.Id, "should have seen the expected delivery address retrieval error but got: %v", err)
+	req.Nil(checkoutResponse, "should not have got a response checking out Rupert's cart")
+
+	// The cart and its address are fetched together when the cart is retrieved so a failure there is a cart failure
+	service.drProxy = &UTDocRefProxy{Err: mockError, AllowCount: 0}
+	getResponse, err := service.GetShoppingCartByID(ctx, &pbcart.GetShoppingCartByIDRequest{CartId: cart.Id})
+	req.NotNil(err, "should have seen an error getting Rupert's cart")
+	req.Contains(err.Error(), "failed to retrieve cart snapshot with ID "+cart.Id, "should have seen the expected cart retrieval error but got: %v", err)
 	req.Nil(getResponse, "should not have got a response getting Rupert's cart")
 
 }
@@ -288,8 +303,9 @@ func TestDeliveryAddressSetResponseFailure(t *testing.T) {
 	// Do the basic foundation stuff that most of this package's tests require
 	req, ctx, service, cart := commonTestSetup(t)
 
-	// Modify the cart service to return an error only after the address has been set into the cart successfully
-	service.drProxy = &UTDocRefProxy{Err: mockError, AllowCount: 1}
+	// Modify the cart service to return an error only after the cart has been loaded to confirm that it is open,
+	// the address has been set into the cart successfully, and the cart has been touched
+	service.drProxy = &UTDocRefProxy{Err: mockError, AllowCount: 3}
 
 	// Add a delivery address to Rupert Potter's cart
 	deliveryAddress := buildMockDeliveryAddress()
@@ -315,7 +331,8 @@ func TestDeliveryAddressCorrupt(t *testing.T) {
 	req.NotEmpty(cart.Id, "created cart should an ID")
 
 	// Modify the cart service to return an error only after the address has been set into the cart successfully
-	service.dsProxy = &UTDocSnapProxy{Err: mockError, AllowCount: 1}
+	// and the cart itself has been reloaded, i.e. when the delivery address snapshot is unmarshalled
+	service.dsProxy = &UTDocSnapProxy{Err: mockError, AllowCount: 2}
 
 	// Add a delivery address to Rupert Potter's cart
 	deliveryAddress := buildMockDeliveryAddress()
@@ -427,8 +444,9 @@ func TestAddItemResponseFailure(t *testing.T) {
 	// Do the basic foundation stuff that most of this package's tests require
 	req, ctx, service, cart := commonTestSetup(t)
 
-	// Modify the cart service to return an error only after the item has been set into the cart successfully
-	service.drProxy = &UTDocRefProxy{Err: mockError, AllowCount: 1}
+	// Modify the cart service to return an error only after the cart has been loaded to confirm that it is open,
+	// the item has been set into the cart successfully, and the cart has been touched
+	service.drProxy = &UTDocRefProxy{Err: mockError, AllowCount: 3}
 
 	// Define one cart items to add to the cart
 	item := buildMockCartItem(cartItemProductCode1)
@@ -440,6 +458,170 @@ func TestAddItemResponseFailure(t *testing.T) {
 	req.Nil(response, "should have not obtained a response adding an item to Rupert's cart")
 }
 
+// TestAddItemMerge confirms that adding a product that is already in the cart increases the quantity of the
+// existing item rather than adding a second item for the same product.
+func TestAddItemMerge(t *testing.T) {
+
+	// Add the first item to the cart
+	req, ctx, service, cart, responseItem1 := addFirstItemToCart(t)
+
+	// Add the same product again
+	response, err := service.AddItemToShoppingCart(ctx, &pbcart.AddItemToShoppingCartRequest{CartId: cart.Id, Item: buildMockCartItem(cartItemProductCode1)})
+	req.Nil(err, "should not have seen an error adding %s to Rupert's cart a second time: %v", cartItemProductCode1, err)
+	req.NotNil(response.GetCart(), "response should have contained a cart after adding %s a second time", cartItemProductCode1)
+
+	// There should still be only one item, with the same ID as before but twice the quantity
+	responseItems := response.GetCart().GetCartItems()
+	req.Equal(1, len(responseItems), "returned cart should have contained only one item")
+	req.Equal(responseItem1.Id, responseItems[0].Id, "merged item should have retained its original ID")
+	req.Equal(cartItemProductCode1, responseItems[0].ProductCode, "merged item had the wrong product code")
+	req.Equal(cartItemQuantity1*2, responseItems[0].Quantity, "merged item should have had the combined quantity")
+}
+
+// TestAddItemAttributes confirms that items customized in different ways are kept apart rather than being merged, and
+// that attributes without names, or with duplicate names, are refused.
+func TestAddItemAttributes(t *testing.T) {
+
+	// Add the first, plain, item to the cart
+	req, ctx, service, cart, responseItem1 := addFirstItemToCart(t)
+
+	// Add an engraved one, twice over
+	item := buildMockCartItem(cartItemProductCode1)
+	item.Attributes = []*pbtypes.Attribute{{Name: "engraving", Value: "Happy Birthday Rupert!"}, {Name: "font", Value: "copperplate"}}
+	_, err := service.AddItemToShoppingCart(ctx, &pbcart.AddItemToShoppingCartRequest{CartId: cart.Id, Item: item})
+	req.Nil(err, "should not have seen an error adding an engraved %s to Rupert's cart: %v", cartItemProductCode1, err)
+	item = buildMockCartItem(cartItemProductCode1)
+	item.Attributes = []*pbtypes.Attribute{{Name: "font", Value: "copperplate"}, {Name: "engraving", Value: "Happy Birthday Rupert!"}}
+	response, err := service.AddItemToShoppingCart(ctx, &pbcart.AddItemToShoppingCartRequest{CartId: cart.Id, Item: item})
+	req.Nil(err, "should not have seen an error adding a second engraved %s to Rupert's cart: %v", cartItemProductCode1, err)
+
+	// The engraved items should have been merged with each other but not with the plain one
+	responseItems := response.GetCart().GetCartItems()
+	req.Equal(2, len(responseItems), "returned cart should have contained a plain item and an engraved item")
+	for _, responseItem := range responseItems {
+		if responseItem.Id == responseItem1.Id {
+			req.Empty(responseItem.Attributes, "plain item should not have gained any attributes")
+			req.Equal(cartItemQuantity1, responseItem.Quantity, "plain item quantity should not have changed")
+		} else {
+			req.Equal(2, len(responseItem.Attributes), "engraved item should have kept its attributes")
+			req.Equal(cartItemQuantity1*2, responseItem.Quantity, "engraved items should have been merged")
+		}
+	}
+
+	// Attributes must have names, and different ones at that
+	for name, attributes := range map[string][]*pbtypes.Attribute{
+		"unnamed":   {{Value: "Rupert"}},
+		"duplicate": {{Name: "engraving", Value: "Rupert"}, {Name: "engraving", Value: "Hermione"}},
+	} {
+		item = buildMockCartItem(cartItemProductCode1)
+		item.Attributes = attributes
+		_, err = service.AddItemToShoppingCart(ctx, &pbcart.AddItemToShoppingCartRequest{CartId: cart.Id, Item: item})
+		req.Equal(codes.InvalidArgument, status.Code(err), "%s attribute should have been an invalid argument: %v", name, err)
+	}
+}
+
+// TestAddItemMergeFailure examines what happens if the existing cart items cannot be read when looking for an item
+// to merge a new addition into.
+func TestAddItemMergeFailure(t *testing.T) {
+
+	// Do the basic foundation stuff that most of this package's tests require
+	req, ctx, service, cart := commonTestSetup(t)
+
+	// Modify the cart service to return an error on reading cart items
+	service.itemsGetterProxy = &UTItemCollGetterProxy{FsClient: service.FsClient, Err: mockError, AllowCount: 0}
+
+	// Attempt to Add an item to the cart and confirm that it fails as expected
+	response, err := service.AddItemToShoppingCart(ctx, &pbcart.AddItemToShoppingCartRequest{CartId: cart.Id, Item: buildMockCartItem(cartItemProductCode1)})
+	req.NotNil(err, "should have seen an error adding an item to Rupert's cart")
+	req.Contains(err.Error(), "failed to retrieve cart item for cart with ID "+cart.Id, "should have seen the configured error reading the existing items")
+	req.Nil(response, "should have not obtained a response adding an item to Rupert's cart")
+}
+
+// TestUpdateCartItemSuccess confirms that the quantity of an existing cart item can be updated, both with and without
+// an explicit field mask, and that the item retains its ID.
+func TestUpdateCartItemSuccess(t *testing.T) {
+
+	// Add the first item to the cart
+	req, ctx, service, cart, responseItem1 := addFirstItemToCart(t)
+
+	// Update the quantity naming the field in the update mask
+	response, err := service.UpdateCartItem(ctx, &pbcart.UpdateCartItemRequest{
+		CartId:     cart.Id,
+		Item:       &pbcart.CartItem{Id: responseItem1.Id, Quantity: 7},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"quantity"}},
+	})
+	req.Nil(err, "should not have seen an error updating the item quantity: %v", err)
+	responseItems := response.GetCart().GetCartItems()
+	req.Equal(1, len(responseItems), "returned cart should have contained one item")
+	req.Equal(responseItem1.Id, responseItems[0].Id, "updated item should have retained its ID")
+	req.Equal(int32(7), responseItems[0].Quantity, "updated item should have had the new quantity")
+	req.Equal(cartItemPriceUnits1, responseItems[0].UnitPrice.Units, "updated item should have retained its price")
+
+	// Update the quantity again with no mask at all
+	response, err = service.UpdateCartItem(ctx, &pbcart.UpdateCartItemRequest{
+		CartId: cart.Id,
+		Item:   &pbcart.CartItem{Id: responseItem1.Id, Quantity: 3},
+	})
+	req.Nil(err, "should not have seen an error updating the item quantity without a mask: %v", err)
+	req.Equal(int32(3), response.GetCart().GetCartItems()[0].Quantity, "updated item should have had the newer quantity")
+}
+
+// TestUpdateCartItemInvalid examines the various ways in which an update cart item request can be invalid.
+func TestUpdateCartItemInvalid(t *testing.T) {
+
+	// Add the first item to the cart
+	req, ctx, service, cart, responseItem1 := addFirstItemToCart(t)
+
+	// No item ID
+	_, err := service.UpdateCartItem(ctx, &pbcart.UpdateCartItemRequest{CartId: cart.Id, Item: &pbcart.CartItem{Quantity: 2}})
+	req.Equal(codes.InvalidArgument, status.Code(err), "missing item ID should have been an invalid argument: %v", err)
+
+	// A field that cannot be updated
+	_, err = service.UpdateCartItem(ctx, &pbcart.UpdateCartItemRequest{
+		CartId:     cart.Id,
+		Item:       &pbcart.CartItem{Id: responseItem1.Id, ProductCode: cartItemProductCode2},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"product_code"}},
+	})
+	req.Equal(codes.InvalidArgument, status.Code(err), "updating the product code should have been an invalid argument: %v", err)
+	req.Contains(err.Error(), "cart item field cannot be updated", "should have seen the unsupported field error")
+
+	// A zero quantity
+	_, err = service.UpdateCartItem(ctx, &pbcart.UpdateCartItemRequest{CartId: cart.Id, Item: &pbcart.CartItem{Id: responseItem1.Id}})
+	req.Equal(codes.InvalidArgument, status.Code(err), "zero quantity should have been an invalid argument: %v", err)
+
+	// An item that does not exist
+	_, err = service.UpdateCartItem(ctx, &pbcart.UpdateCartItemRequest{CartId: cart.Id, Item: &pbcart.CartItem{Id: uuid.NewString(), Quantity: 2}})
+	req.Equal(codes.NotFound, status.Code(err), "unknown item should have been not found: %v", err)
+
+	// None of that should have changed the item
+	getResp, err := service.GetShoppingCartByID(ctx, &pbcart.GetShoppingCartByIDRequest{CartId: cart.Id})
+	req.Nil(err, "should not have seen an error getting Rupert's cart: %v", err)
+	req.Equal(cartItemQuantity1, getResp.Cart.CartItems[0].Quantity, "rejected updates should not have changed the item quantity")
+}
+
+// TestUpdateCartItemFailure examines what happens if Firestore is unable to update the cart item.
+func TestUpdateCartItemFailure(t *testing.T) {
+
+	// Add the first item to the cart
+	req, ctx, service, cart, responseItem1 := addFirstItemToCart(t)
+
+	// Modify the cart service to return an error after the cart and the item have been read
+	service.drProxy = &UTDocRefProxy{Err: mockError, AllowCount: 2}
+
+	// Attempt to update the item and confirm that it fails as expected
+	response, err := service.UpdateCartItem(ctx, &pbcart.UpdateCartItemRequest{CartId: cart.Id, Item: &pbcart.CartItem{Id: responseItem1.Id, Quantity: 2}})
+	req.NotNil(err, "should have seen an error updating an item in Rupert's cart")
+	req.Contains(err.Error(), "failed updating cart item in firestore", "should have seen the configured error updating an item in Rupert's cart")
+	req.Nil(response, "should have not obtained a response updating an item in Rupert's cart")
+
+	// Now have the read of the item itself fail
+	service.drProxy = &UTDocRefProxy{Err: mockError, AllowCount: 1}
+	response, err = service.UpdateCartItem(ctx, &pbcart.UpdateCartItemRequest{CartId: cart.Id, Item: &pbcart.CartItem{Id: responseItem1.Id, Quantity: 2}})
+	req.NotNil(err, "should have seen an error reading an item in Rupert's cart")
+	req.Contains(err.Error(), "failed to retrieve cart item snapshot with ID "+responseItem1.Id, "should have seen the configured error reading an item in Rupert's cart")
+	req.Nil(response, "should have not obtained a response updating an item in Rupert's cart")
+}
+
 // TestRemoveItemSuccess examines what happens when removing a cart time has no issues with the data store.
 func TestRemoveItemSuccess(t *testing.T) {
 
@@ -478,8 +660,9 @@ func TestRemoveItemResponseFailure(t *testing.T) {
 	// Add the first item to the cart
 	req, ctx, service, cart, responseItem1 := addFirstItemToCart(t)
 
-	// Modify the cart service to return an error after the item deletion has been performed successfully
-	service.drProxy = &UTDocRefProxy{Err: mockError, AllowCount: 1}
+	// Modify the cart service to return an error after the cart has been loaded to confirm that it is open,
+	// the item deletion has been performed successfully, and the cart has been touched
+	service.drProxy = &UTDocRefProxy{Err: mockError, AllowCount: 3}
 
 	// Now remove that item
 	removeResp, err := service.RemoveItemFromShoppingCart(ctx, &pbcart.RemoveItemFromShoppingCartRequest{CartId: cart.Id, ItemId: responseItem1.Id})
@@ -492,8 +675,8 @@ func TestRemoveItemResponseFailure(t *testing.T) {
 // to check the same cart out a second time (which should fail).
 func TestCheckoutSuccess(t *testing.T) {
 
-	// Register a cart with a single item in it
-	req, ctx, service, cart, _ := addFirstItemToCart(t)
+	// Register a cart with a single item and a delivery address in it
+	req, ctx, service, cart, _ := prepareCartForCheckout(t)
 
 	// Check the cart out and confirm all went well
 	response, err := service.CheckoutShoppingCart(ctx, &pbcart.CheckoutShoppingCartRequest{CartId: cart.Id})
@@ -511,6 +694,75 @@ func TestCheckoutSuccess(t *testing.T) {
 	req.Nil(response, "should have not obtained a response after failing to change cart status")
 }
 
+// TestCheckoutValidation confirms that a cart that is not fit to be checked out is refused with a FailedPrecondition
+// status carrying a BadRequest detail that lists all the problems with the cart, and that the cart remains open.
+func TestCheckoutValidation(t *testing.T) {
+
+	// Do the basic foundation stuff that most of this package's tests require
+	req, ctx, service, cart := commonTestSetup(t)
+
+	// An empty cart cannot be checked out
+	_, err := service.CheckoutShoppingCart(ctx, &pbcart.CheckoutShoppingCartRequest{CartId: cart.Id})
+	fields := checkoutViolationFields(req, err)
+	req.Equal([]string{"cart_items"}, fields, "empty cart should have had exactly one violation")
+
+	// Fill the cart with rubbish: a zero quantity, a missing price, and a mismatched currency. Items are priced from
+	// the catalog, so the last two need badly stocked products.
+	req.Nil(storeProduct(ctx, service, "unpriced_yoyo", nil), "failed to store the unpriced product")
+	req.Nil(storeProduct(ctx, service, "silver_yoyo", types.NewMoney("GBP", 5, 0)), "failed to store the sterling product")
+	badQuantity := buildMockCartItem(cartItemProductCode1)
+	badQuantity.Quantity = 0
+	noPrice := buildMockCartItem(cartItemProductCode2)
+	noPrice.ProductCode = "unpriced_yoyo"
+	badCurrency := buildMockCartItem(cartItemProductCode1)
+	badCurrency.ProductCode = "silver_yoyo"
+	for _, item := range []*pbcart.CartItem{badQuantity, noPrice, badCurrency} {
+		_, err = service.AddItemToShoppingCart(ctx, &pbcart.AddItemToShoppingCartRequest{CartId: cart.Id, Item: item})
+		req.Nil(err, "should not have seen an error adding %s to Rupert's cart: %v", item.ProductCode, err)
+	}
+
+	// Now we should hear about everything that is wrong, not just the first thing. The order of the items
+	// in the cart is not predictable, so we strip the item indexes out of the field names and sort them.
+	_, err = service.CheckoutShoppingCart(ctx, &pbcart.CheckoutShoppingCartRequest{CartId: cart.Id})
+	fields = checkoutViolationFields(req, err)
+	for i, field := range fields {
+		fields[i] = regexp.MustCompile(`\[\d+\]`).ReplaceAllString(field, "[]")
+	}
+	sort.Strings(fields)
+	req.Equal([]string{
+		"cart_items[].quantity",
+		"cart_items[].unit_price",
+		"cart_items[].unit_price.currency_code",
+		"delivery_address",
+	}, fields, "did not see the expected violations")
+
+	// The cart should still be open
+	getResp, err := service.GetShoppingCartByID(ctx, &pbcart.GetShoppingCartByIDRequest{CartId: cart.Id})
+	req.Nil(err, "should not have seen an error getting Rupert's cart: %v", err)
+	req.Equal(pbcart.ShoppingCartStatus_SCS_OPEN, getResp.Cart.Status, "cart that failed validation should still be open")
+}
+
+// checkoutViolationFields confirms that the given error is a FailedPrecondition status with a BadRequest detail
+// attached and returns the field names of the violations that the detail contains.
+func checkoutViolationFields(req *require.Assertions, err error) []string {
+
+	// Dig into the status to find the BadRequest
+	req.NotNil(err, "should have seen an error checking out an invalid cart")
+	st := status.Convert(err)
+	req.Equal(codes.FailedPrecondition, st.Code(), "invalid cart checkout should have been a failed precondition: %v", err)
+	req.Equal(1, len(st.Details()), "invalid cart checkout error should have had one detail")
+	badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+	req.True(ok, "invalid cart checkout error detail should have been a BadRequest")
+
+	// Pull out the field names
+	var fields []string
+	for _, violation := range badRequest.FieldViolations {
+		req.NotEmpty(violation.Description, "violation of %s should have had a description", violation.Field)
+		fields = append(fields, violation.Field)
+	}
+	return fields
+}
+
 // TestCheckoutCartMissing examines what happens if the caller tries to check out a cart that does not exist.
 func TestCheckoutCartMissing(t *testing.T) {
 
@@ -552,19 +804,146 @@ func TestCheckoutOriginalCartUnmarshalFailure(t *testing.T) {
 // TestCheckoutFailure looks at what happens if Firestore is unable to set the checked out status on the target cart.
 func TestCheckoutFailure(t *testing.T) {
 
-	// Register a cart with a single item in it
-	req, ctx, service, cart, _ := addFirstItemToCart(t)
+	// Register a cart with a single item and a delivery address in it
+	req, ctx, service, cart, _ := prepareCartForCheckout(t)
 
-	// Modify the cart service to return an error the second time we try to use a document reference proxy
-	service.drProxy = &UTDocRefProxy{Err: mockError, AllowCount: 1}
+	// Modify the cart service to return an error the third time we try to use a document reference proxy, i.e.
+	// after the cart and its delivery address have been read
+	service.drProxy = &UTDocRefProxy{Err: mockError, AllowCount: 2}
 
 	// Check the cart out and confirm all went well
 	response, err := service.CheckoutShoppingCart(ctx, &pbcart.CheckoutShoppingCartRequest{CartId: cart.Id})
 	req.NotNil(err, "should have seen an error checking out a corrupt cart")
-	req.Contains(err.Error(), "failed putting updated cart status to datastore with ID "+cart.Id, "should have seen an document set error")
+	req.Contains(err.Error(), "failed putting updated cart to datastore with ID "+cart.Id, "should have seen an document set error")
 	req.Nil(response, "should have not obtained a response after failing to set the checked out status")
 }
 
+// TestClosedCartRejectsChanges confirms that once a cart has been checked out, any attempt to add items, remove
+// items, or set the delivery address is rejected with a FailedPrecondition status and leaves the cart unchanged.
+func TestClosedCartRejectsChanges(t *testing.T) {
+
+	// Register a cart with a single item and a delivery address in it and check it out
+	req, ctx, service, cart, responseItem1 := prepareCartForCheckout(t)
+	_, err := service.CheckoutShoppingCart(ctx, &pbcart.CheckoutShoppingCartRequest{CartId: cart.Id})
+	req.Nil(err, "failed to check cart out: %v", err)
+
+	// Try to add a second item to the checked out cart
+	addResp, err := service.AddItemToShoppingCart(ctx, &pbcart.AddItemToShoppingCartRequest{CartId: cart.Id, Item: buildMockCartItem(cartItemProductCode2)})
+	req.NotNil(err, "should have seen an error adding an item to Rupert's checked out cart")
+	req.Equal(codes.FailedPrecondition, status.Code(err), "adding an item to a checked out cart should have been a failed precondition: %v", err)
+	req.Contains(err.Error(), "cannot add item to cart that is not open: cart ID="+cart.Id, "should have seen cannot add to a closed cart error")
+	req.Nil(addResp, "should have not obtained a response after failing to add an item to a checked out cart")
+
+	// Try to remove the original item from the checked out cart
+	removeResp, err := service.RemoveItemFromShoppingCart(ctx, &pbcart.RemoveItemFromShoppingCartRequest{CartId: cart.Id, ItemId: responseItem1.Id})
+	req.NotNil(err, "should have seen an error removing an item from Rupert's checked out cart")
+	req.Equal(codes.FailedPrecondition, status.Code(err), "removing an item from a checked out cart should have been a failed precondition: %v", err)
+	req.Contains(err.Error(), "cannot remove item from cart that is not open: cart ID="+cart.Id, "should have seen cannot remove from a closed cart error")
+	req.Nil(removeResp, "should have not obtained a response after failing to remove an item from a checked out cart")
+
+	// Try to set the delivery address of the checked out cart
+	setResp, err := service.SetDeliveryAddress(ctx, &pbcart.SetDeliveryAddressRequest{CartId: cart.Id, DeliveryAddress: buildMockDeliveryAddress()})
+	req.NotNil(err, "should have seen an error setting the address of Rupert's checked out cart")
+	req.Equal(codes.FailedPrecondition, status.Code(err), "setting the address of a checked out cart should have been a failed precondition: %v", err)
+	req.Contains(err.Error(), "cannot set delivery address of cart that is not open: cart ID="+cart.Id, "should have seen cannot set address of a closed cart error")
+	req.Nil(setResp, "should have not obtained a response after failing to set the address of a checked out cart")
+
+	// Confirm that the stored cart is exactly as it was when it was checked out
+	getResp, err := service.GetShoppingCartByID(ctx, &pbcart.GetShoppingCartByIDRequest{CartId: cart.Id})
+	req.Nil(err, "should not have seen an error getting Rupert's checked out cart: %v", err)
+	req.Equal(pbcart.ShoppingCartStatus_SCS_CHECKED_OUT, getResp.Cart.Status, "cart should still have the checked out status")
+	req.Equal(1, len(getResp.Cart.CartItems), "checked out cart should still contain exactly one item")
+	req.Equal(responseItem1.Id, getResp.Cart.CartItems[0].Id, "checked out cart should still contain its original item")
+	req.Equal(addrLocality, getResp.Cart.DeliveryAddress.Locality, "checked out cart should have retained its delivery address")
+}
+
+// TestEtagPreconditions confirms that every change to a cart yields a new etag and that requests made with a
+// stale etag are rejected with an Aborted status while those made with the current etag, or none, succeed.
+func TestEtagPreconditions(t *testing.T) {
+
+	// Do the basic foundation stuff that most of this package's tests require
+	req, ctx, service, cart := commonTestSetup(t)
+	req.NotEmpty(cart.Etag, "new cart should have an etag")
+
+	// Add an item using the etag of the freshly created cart
+	addResp, err := service.AddItemToShoppingCart(ctx, &pbcart.AddItemToShoppingCartRequest{CartId: cart.Id, Item: buildMockCartItem(cartItemProductCode1), Etag: cart.Etag})
+	req.Nil(err, "should not have seen an error adding an item with the current etag: %v", err)
+	req.NotEmpty(addResp.Cart.Etag, "cart returned after adding an item should have an etag")
+	req.NotEqual(cart.Etag, addResp.Cart.Etag, "adding an item should have changed the cart etag")
+	staleEtag := cart.Etag
+	currentEtag := addResp.Cart.Etag
+
+	// Getting the cart should report the same etag as the add response did
+	getResp, err := service.GetShoppingCartByID(ctx, &pbcart.GetShoppingCartByIDRequest{CartId: cart.Id})
+	req.Nil(err, "should not have seen an error getting Rupert's cart: %v", err)
+	req.Equal(currentEtag, getResp.Cart.Etag, "retrieved cart etag should match that returned by the add")
+
+	// Every mutating request should be refused if it uses the stale etag
+	_, err = service.AddItemToShoppingCart(ctx, &pbcart.AddItemToShoppingCartRequest{CartId: cart.Id, Item: buildMockCartItem(cartItemProductCode2), Etag: staleEtag})
+	req.Equal(codes.Aborted, status.Code(err), "adding an item with a stale etag should have been aborted: %v", err)
+	_, err = service.RemoveItemFromShoppingCart(ctx, &pbcart.RemoveItemFromShoppingCartRequest{CartId: cart.Id, ItemId: addResp.Cart.CartItems[0].Id, Etag: staleEtag})
+	req.Equal(codes.Aborted, status.Code(err), "removing an item with a stale etag should have been aborted: %v", err)
+	_, err = service.SetDeliveryAddress(ctx, &pbcart.SetDeliveryAddressRequest{CartId: cart.Id, DeliveryAddress: buildMockDeliveryAddress(), Etag: staleEtag})
+	req.Equal(codes.Aborted, status.Code(err), "setting the address with a stale etag should have been aborted: %v", err)
+	_, err = service.CheckoutShoppingCart(ctx, &pbcart.CheckoutShoppingCartRequest{CartId: cart.Id, Etag: staleEtag})
+	req.Equal(codes.Aborted, status.Code(err), "checking out with a stale etag should have been aborted: %v", err)
+	req.Contains(err.Error(), "cart has been modified since etag was issued: cart ID="+cart.Id, "should have seen the stale etag error message")
+	_, err = service.AbandonShoppingCart(ctx, &pbcart.AbandonShoppingCartRequest{CartId: cart.Id, Etag: staleEtag})
+	req.Equal(codes.Aborted, status.Code(err), "abandoning with a stale etag should have been aborted: %v", err)
+
+	// None of that should have changed the cart
+	getResp, err = service.GetShoppingCartByID(ctx, &pbcart.GetShoppingCartByIDRequest{CartId: cart.Id})
+	req.Nil(err, "should not have seen an error getting Rupert's cart: %v", err)
+	req.Equal(currentEtag, getResp.Cart.Etag, "rejected requests should not have changed the cart etag")
+	req.Equal(1, len(getResp.Cart.CartItems), "rejected requests should not have changed the cart items")
+	req.Nil(getResp.Cart.DeliveryAddress, "rejected requests should not have set a delivery address")
+
+	// Setting the address with no etag at all is fine, and moves the etag on
+	setResp, err := service.SetDeliveryAddress(ctx, &pbcart.SetDeliveryAddressRequest{CartId: cart.Id, DeliveryAddress: buildMockDeliveryAddress()})
+	req.Nil(err, "should not have seen an error setting the address without an etag: %v", err)
+	req.NotEqual(currentEtag, setResp.Cart.Etag, "setting the address should have changed the cart etag")
+
+	// Finally, check out with the current etag
+	checkoutResp, err := service.CheckoutShoppingCart(ctx, &pbcart.CheckoutShoppingCartRequest{CartId: cart.Id, Etag: setResp.Cart.Etag})
+	req.Nil(err, "should not have seen an error checking out with the current etag: %v", err)
+	req.Equal(pbcart.ShoppingCartStatus_SCS_CHECKED_OUT, checkoutResp.Cart.Status, "cart should have been checked out")
+	req.NotEqual(setResp.Cart.Etag, checkoutResp.Cart.Etag, "checking out should have changed the cart etag")
+}
+
+// TestAddItemCartMissing examines what happens if the caller tries to add an item to a cart that does not exist.
+func TestAddItemCartMissing(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Initialize our target cart service
+	service, err := NewCartService()
+	req.Nil(err, "failed to obtain cart service: %v", err)
+
+	// Form the ID of a cart that we know cannot exist and try to add an item to it
+	cartId := uuid.NewString()
+	ctx := context.Background()
+	response, err := service.AddItemToShoppingCart(ctx, &pbcart.AddItemToShoppingCartRequest{CartId: cartId, Item: buildMockCartItem(cartItemProductCode1)})
+	req.NotNil(err, "should have seen an error adding an item to a non-existent cart")
+	req.Contains(err.Error(), "failed to retrieve cart snapshot with ID "+cartId, "should have seen failed to load cart error")
+	req.Nil(response, "should have not obtained a response after failing to add an item to a non-existent cart")
+}
+
+// prepareCartForCheckout establishes a cart containing a single item and a delivery address, i.e. a cart that
+// is fit to be checked out.
+func prepareCartForCheckout(t *testing.T) (*require.Assertions, context.Context, *CartService, *pbcart.ShoppingCart, *pbcart.CartItem) {
+
+	// Start with a cart containing one item
+	req, ctx, service, cart, responseItem1 := addFirstItemToCart(t)
+
+	// Add the delivery address
+	setResp, err := service.SetDeliveryAddress(ctx, &pbcart.SetDeliveryAddressRequest{CartId: cart.Id, DeliveryAddress: buildMockDeliveryAddress()})
+	req.Nil(err, "should not have seen an error adding an address to Rupert's cart: %v", err)
+
+	// Pass everything back that the caller needs to perform additional testing
+	return req, ctx, service, setResp.Cart, responseItem1
+}
+
 // commonTestSetup helps us to be a little DRY (Don't Repeat Yourself) in this file, doing the steps that more
 // than half the unit test functions in here need to do before going on to anything else.
 func commonTestSetup(t *testing.T) (*require.Assertions, context.Context, *CartService, *pbcart.ShoppingCart) {
@@ -612,6 +991,10 @@ func storeMockCart(ctx context.Context, req *require.Assertions) (*CartService,
 	service, err := NewCartService()
 	req.Nil(err, "failed to obtain cart service: %v", err)
 
+	// Make sure that the catalog has the products that our tests put in their carts
+	err = storeMockProducts(ctx, service)
+	req.Nil(err, "failed to store the mock products in the catalog: %v", err)
+
 	// Establish a cart for our mock shopper
 	createResp, err := service.CreateShoppingCart(ctx, &pbcart.CreateShoppingCartRequest{Shopper: buildMockShopper()})
 	req.Nil(err, "should not have seen an error creating a new cart: %v", err)
@@ -0,0 +1,39 @@
+package cartapi
+
+import (
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	products "github.com/mikebway/poc-gcp-ecomm/catalog/schema"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// getTransactionalProduct returns the catalog entry for the product with the given code, read within the given
+// Firestore transaction. The catalog is the authority on which products can be bought and what they cost, so a
+// codes.InvalidArgument status error is returned if there is no such product; the shopper has asked for something
+// that we do not sell.
+func (cs *CartService) getTransactionalProduct(tx *firestore.Transaction, code string) (*products.Product, error) {
+
+	// Codes that could not be the ID of a product document cannot be in the catalog
+	if !products.IsValidProductCode(code) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid product code: %s", code)
+	}
+
+	// Ask the transaction for the product
+	product := &products.Product{Code: code}
+	snap, err := cs.drProxy.TransactionalGet(cs.FsClient.Doc(product.StoreRefPath()), tx)
+	if status.Code(err) == codes.NotFound {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown product: product code=%s", code)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve product with code %s: %w", code, err)
+	}
+
+	// Unmarshall the snapshot into our internal structure form
+	err = cs.dsProxy.DataTo(snap, product)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal product snapshot with code %s: %w", code, err)
+	}
+	return product, nil
+}
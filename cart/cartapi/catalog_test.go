@@ -0,0 +1,128 @@
+package cartapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cloud.google.com/go/firestore"
+	"github.com/google/uuid"
+	"github.com/mikebway/poc-gcp-ecomm/cart/schema"
+	products "github.com/mikebway/poc-gcp-ecomm/catalog/schema"
+	pbcart "github.com/mikebway/poc-gcp-ecomm/pb/cart"
+	"github.com/mikebway/poc-gcp-ecomm/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UTProductDocSnapProxy is a unit test implementation of the DocumentSnapshotProxy interface that fails to
+// unmarshal products, and only products.
+type UTProductDocSnapProxy struct {
+	DocSnapProxy
+}
+
+// DataTo returns an error if asked to unmarshal a product, otherwise it passes through to the real DataTo.
+func (p *UTProductDocSnapProxy) DataTo(snap *firestore.DocumentSnapshot, target interface{}) error {
+	if _, ok := target.(*products.Product); ok {
+		return mockError
+	}
+	return p.DocSnapProxy.DataTo(snap, target)
+}
+
+// storeProduct writes a product straight into the catalog collection of the Firestore emulator, bypassing the
+// validation that the catalog service would apply, so that tests can stock whatever they need.
+func storeProduct(ctx context.Context, service *CartService, code string, unitPrice *types.Money) error {
+	product := &products.Product{Code: code, Name: code, UnitPrice: unitPrice}
+	_, err := service.FsClient.Doc(product.StoreRefPath()).Set(ctx, product)
+	return err
+}
+
+// storeMockProducts makes sure that the catalog holds the two products that our mock cart items are for, at the
+// prices that the tests expect them to have.
+func storeMockProducts(ctx context.Context, service *CartService) error {
+	err := storeProduct(ctx, service, cartItemProductCode1, types.NewMoney(cartItemPriceCurrency, cartItemPriceUnits1, cartItemPriceNanos1))
+	if err != nil {
+		return err
+	}
+	return storeProduct(ctx, service, cartItemProductCode2, types.NewMoney(cartItemPriceCurrency, cartItemPriceUnits2, cartItemPriceNanos2))
+}
+
+// TestAddItemPricedFromCatalog confirms that cart items are priced from the catalog, whatever price the shopper's
+// client claims, and that merging an item brings its price up to date.
+func TestAddItemPricedFromCatalog(t *testing.T) {
+
+	// Do the basic foundation stuff that most of this package's tests require
+	req, ctx, service, cart := commonTestSetup(t)
+
+	// Stock a product of our very own, so that we can change its price without upsetting other tests
+	code := "ut_yoyo_" + uuid.NewString()
+	err := storeProduct(ctx, service, code, types.NewMoney(cartItemPriceCurrency, 5, 0))
+	req.Nil(err, "failed to store product %s: %v", code, err)
+
+	// Try to buy it for a cent
+	item := &pbcart.CartItem{ProductCode: code, Quantity: 2, UnitPrice: types.NewMoney(cartItemPriceCurrency, 0, 10_000_000).AsPBMoney()}
+	addResp, err := service.AddItemToShoppingCart(ctx, &pbcart.AddItemToShoppingCartRequest{CartId: cart.Id, Item: item})
+	req.Nil(err, "should not have seen an error adding %s to the cart: %v", code, err)
+	req.Equal("USD 5.00", types.MoneyFromPB(addResp.Cart.CartItems[0].UnitPrice).String(), "item should have been priced from the catalog")
+	req.Equal("USD 10.00", types.MoneyFromPB(addResp.Cart.Total).String(), "cart total should have been priced from the catalog")
+
+	// Put the price up and add some more; the whole item is now at the new price
+	err = storeProduct(ctx, service, code, types.NewMoney(cartItemPriceCurrency, 6, 0))
+	req.Nil(err, "failed to update product %s: %v", code, err)
+	item.UnitPrice = nil
+	addResp, err = service.AddItemToShoppingCart(ctx, &pbcart.AddItemToShoppingCartRequest{CartId: cart.Id, Item: item})
+	req.Nil(err, "should not have seen an error adding more %s to the cart: %v", code, err)
+	req.Equal(1, len(addResp.Cart.CartItems), "second addition should have been merged with the first")
+	req.Equal(int32(4), addResp.Cart.CartItems[0].Quantity, "merged quantity did not match")
+	req.Equal("USD 6.00", types.MoneyFromPB(addResp.Cart.CartItems[0].UnitPrice).String(), "merged item should have been repriced from the catalog")
+}
+
+// TestAddItemDigitalFromCatalog confirms that cart items record whether their products are digital, and their tax
+// category, as the catalog says when they are priced, so that checkout knows whether they have to be delivered and
+// how they are taxed.
+func TestAddItemDigitalFromCatalog(t *testing.T) {
+
+	// Do the basic foundation stuff that most of this package's tests require
+	req, ctx, service, cart := commonTestSetup(t)
+
+	// Stock a digital product of our very own
+	product := &products.Product{Code: "ut_ebook_" + uuid.NewString(), Name: "Yo-Yo Tricks", UnitPrice: types.NewMoney(cartItemPriceCurrency, 9, 0), Digital: true, TaxCategory: "books"}
+	_, err := service.FsClient.Doc(product.StoreRefPath()).Set(ctx, product)
+	req.Nil(err, "failed to store product %s: %v", product.Code, err)
+
+	// Add it to the cart then see what was stored
+	item := &pbcart.CartItem{ProductCode: product.Code, Quantity: 1}
+	addResp, err := service.AddItemToShoppingCart(ctx, &pbcart.AddItemToShoppingCartRequest{CartId: cart.Id, Item: item})
+	req.Nil(err, "should not have seen an error adding %s to the cart: %v", product.Code, err)
+	stored := &schema.ShoppingCartItem{CartId: cart.Id, Id: addResp.Cart.CartItems[0].Id}
+	snap, err := service.FsClient.Doc(stored.StoreRefPath()).Get(ctx)
+	req.Nil(err, "failed to retrieve stored cart item: %v", err)
+	req.Nil(snap.DataTo(stored), "failed to unmarshal stored cart item")
+	req.True(stored.Digital, "cart item should have been recorded as digital")
+	req.Equal("books", stored.TaxCategory, "cart item tax category did not match")
+}
+
+// TestAddItemNotInCatalog confirms that products that are not in the catalog cannot be added to a cart.
+func TestAddItemNotInCatalog(t *testing.T) {
+
+	// Do the basic foundation stuff that most of this package's tests require
+	req, ctx, service, cart := commonTestSetup(t)
+
+	// Products that we do not sell, and could not sell
+	for _, code := range []string{"no_such_yoyo", "gold/yoyo", ""} {
+		item := buildMockCartItem(cartItemProductCode1)
+		item.ProductCode = code
+		_, err := service.AddItemToShoppingCart(ctx, &pbcart.AddItemToShoppingCartRequest{CartId: cart.Id, Item: item})
+		req.Equal(codes.InvalidArgument, status.Code(err), "adding product %q should have been an invalid argument: %v", code, err)
+	}
+
+	// The cart should still be empty
+	getResp, err := service.GetShoppingCartByID(ctx, &pbcart.GetShoppingCartByIDRequest{CartId: cart.Id})
+	req.Nil(err, "should not have seen an error getting the cart: %v", err)
+	req.Empty(getResp.Cart.CartItems, "cart should not have had any items")
+
+	// A product that cannot be read is an error, but not the shopper's fault
+	service.dsProxy = &UTProductDocSnapProxy{}
+	_, err = service.AddItemToShoppingCart(ctx, &pbcart.AddItemToShoppingCartRequest{CartId: cart.Id, Item: buildMockCartItem(cartItemProductCode1)})
+	req.True(errors.Is(err, mockError), "should have seen our mock error reading the product: %v", err)
+}
@@ -0,0 +1,83 @@
+package cartapi
+
+import (
+	"fmt"
+
+	"github.com/mikebway/poc-gcp-ecomm/cart/schema"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// validateCheckout examines a fully populated shopping cart, i.e. one that includes its items and delivery address,
+// and returns a list of all the problems that would prevent it from being checked out. An empty list means that
+// the cart is good to go.
+//
+// The field names in the returned violations are the protocol buffer field paths of the pbcart.ShoppingCart that
+// the problem relates to, e.g. "cart_items[1].unit_price".
+func validateCheckout(cart *schema.ShoppingCart) []*errdetails.BadRequest_FieldViolation {
+
+	// Gather up our complaints here
+	var violations []*errdetails.BadRequest_FieldViolation
+	complain := func(field, description string) {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: field, Description: description})
+	}
+
+	// We need to know who we are selling to
+	if cart.Shopper == nil || cart.Shopper.Id == "" {
+		complain("shopper", "the cart has no shopper")
+	}
+
+	// There's no point checking out an empty cart
+	if len(cart.CartItems) == 0 {
+		complain("cart_items", "the cart contains no items")
+	}
+
+	// Look at each of the items in turn
+	currencyCode := ""
+	needsDelivery := false
+	for i, item := range cart.CartItems {
+
+		// We can't sell zero or fewer of anything
+		if item.Quantity <= 0 {
+			complain(fmt.Sprintf("cart_items[%d].quantity", i), fmt.Sprintf("item quantity must be greater than zero: product code=%s", item.ProductCode))
+		}
+
+		// Every item must have a price and all the prices must be in the same currency
+		if item.UnitPrice == nil || item.UnitPrice.CurrencyCode == "" {
+			complain(fmt.Sprintf("cart_items[%d].unit_price", i), fmt.Sprintf("item has no unit price: product code=%s", item.ProductCode))
+		} else if currencyCode == "" {
+			currencyCode = item.UnitPrice.CurrencyCode
+		} else if item.UnitPrice.CurrencyCode != currencyCode {
+			complain(fmt.Sprintf("cart_items[%d].unit_price.currency_code", i), fmt.Sprintf("item currency %s does not match cart currency %s: product code=%s", item.UnitPrice.CurrencyCode, currencyCode, item.ProductCode))
+		}
+
+		// Remember if we have anything that will need to be shipped, as the catalog said when the item was priced
+		if !item.Digital {
+			needsDelivery = true
+		}
+	}
+
+	// Physical products have to be delivered somewhere
+	if needsDelivery && cart.DeliveryAddress == nil {
+		complain("delivery_address", "the cart contains physical products but has no delivery address")
+	}
+
+	// Return whatever we found, hopefully nothing
+	return violations
+}
+
+// checkoutViolationsError returns a codes.FailedPrecondition status error with the given field violations attached
+// as google.rpc.BadRequest details.
+func checkoutViolationsError(cartId string, violations []*errdetails.BadRequest_FieldViolation) error {
+
+	// Build the basic status then try to attach the violation details to it
+	st := status.Newf(codes.FailedPrecondition, "cart cannot be checked out: cart ID=%s, problems=%d", cartId, len(violations))
+	detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if err != nil {
+
+		// This should never happen but, if it does, the undecorated status is better than nothing
+		return st.Err()
+	}
+	return detailed.Err()
+}
@@ -0,0 +1,96 @@
+package cartapi
+
+import (
+	"testing"
+
+	"github.com/mikebway/poc-gcp-ecomm/cart/schema"
+	"github.com/mikebway/poc-gcp-ecomm/types"
+	"github.com/stretchr/testify/require"
+)
+
+// TestValidateCheckoutClean confirms that a complete and consistent cart passes checkout validation.
+func TestValidateCheckoutClean(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// A cart with everything in order should yield no violations
+	cart := buildValidatableCart()
+	req.Empty(validateCheckout(cart), "a complete cart should not have had any violations")
+}
+
+// TestValidateCheckoutEverythingWrong confirms that checkout validation reports every problem with a cart, in the
+// order of the cart fields and items, rather than just the first that it finds.
+func TestValidateCheckoutEverythingWrong(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Break everything we can break in a cart that has items
+	cart := buildValidatableCart()
+	cart.Shopper = nil
+	cart.DeliveryAddress = nil
+	cart.CartItems[0].Quantity = -1
+	cart.CartItems[1].UnitPrice = nil
+	cart.CartItems = append(cart.CartItems, &schema.ShoppingCartItem{
+		ProductCode: "silver_yoyo",
+		Quantity:    1,
+		UnitPrice:   &types.Money{CurrencyCode: "GBP", Units: 5},
+	})
+
+	// See what we get
+	var fields []string
+	for _, violation := range validateCheckout(cart) {
+		fields = append(fields, violation.Field)
+	}
+	req.Equal([]string{
+		"shopper",
+		"cart_items[0].quantity",
+		"cart_items[1].unit_price",
+		"cart_items[2].unit_price.currency_code",
+		"delivery_address",
+	}, fields, "did not get the expected violations")
+
+	// An empty cart only has one thing wrong with it, no delivery address is required if there is nothing to deliver
+	cart = buildValidatableCart()
+	cart.CartItems = nil
+	cart.DeliveryAddress = nil
+	violations := validateCheckout(cart)
+	req.Equal(1, len(violations), "empty cart should have had exactly one violation")
+	req.Equal("cart_items", violations[0].Field, "empty cart violation should have been for the items")
+
+	// Nor is one needed for a cart that holds only digital products, but one physical product is enough to need it
+	cart = buildValidatableCart()
+	cart.DeliveryAddress = nil
+	for _, item := range cart.CartItems {
+		item.Digital = true
+	}
+	req.Empty(validateCheckout(cart), "a cart of digital products should not have needed a delivery address")
+	cart.CartItems[1].Digital = false
+	violations = validateCheckout(cart)
+	req.Equal(1, len(violations), "a cart with a physical product should have had exactly one violation")
+	req.Equal("delivery_address", violations[0].Field, "physical product violation should have been for the delivery address")
+}
+
+// buildValidatableCart returns a schema.ShoppingCart, complete with items and delivery address, that passes
+// checkout validation.
+func buildValidatableCart() *schema.ShoppingCart {
+	return &schema.ShoppingCart{
+		Id:              "3c3e3c64-6cf3-4e0f-9a8b-5a1d2b0c7f11",
+		Status:          schema.CsOpen,
+		Shopper:         &types.Person{Id: shopperId, FamilyName: shopperFamilyName, GivenName: shopperGivenName},
+		DeliveryAddress: &types.PostalAddress{RegionCode: addrRegionCode, PostalCode: addrPostalCode, Locality: addrLocality},
+		CartItems: []*schema.ShoppingCartItem{
+			{
+				ProductCode: cartItemProductCode1,
+				Quantity:    cartItemQuantity1,
+				UnitPrice:   &types.Money{CurrencyCode: cartItemPriceCurrency, Units: cartItemPriceUnits1, Nanos: cartItemPriceNanos1},
+			},
+			{
+				ProductCode: cartItemProductCode2,
+				Quantity:    cartItemQuantity2,
+				UnitPrice:   &types.Money{CurrencyCode: cartItemPriceCurrency, Units: cartItemPriceUnits2, Nanos: cartItemPriceNanos2},
+			},
+		},
+	}
+}
@@ -0,0 +1,144 @@
+package cartapi
+
+import (
+	"context"
+	"errors"
+
+	"cloud.google.com/go/firestore"
+	"github.com/mikebway/poc-gcp-ecomm/cart/schema"
+	"github.com/mikebway/poc-gcp-ecomm/cart/shipping"
+	pbcart "github.com/mikebway/poc-gcp-ecomm/pb/cart"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListDeliveryOptions returns the delivery options available for the cart identified in the
+// pbcart.ListDeliveryOptionsRequest, each priced for the cart's current contents and delivery address.
+//
+// The cart must be open and must have both items and a delivery address, otherwise a codes.FailedPrecondition status
+// error is returned. An empty list is returned if the cart cannot be delivered to its address at all.
+func (cs *CartService) ListDeliveryOptions(ctx context.Context, req *pbcart.ListDeliveryOptionsRequest) (*pbcart.ListDeliveryOptionsResponse, error) {
+
+	// Obtain a shortcut handle on our globally configured logger then log what we are about to do
+	l := zap.L()
+	l.Info("listing delivery options", zap.String("cartId", req.CartId))
+
+	// Load the cart, complete with its items and delivery address
+	cart, err := cs.loadShoppingCart(ctx, req.CartId)
+	if err != nil {
+		l.Error(err.Error(), zap.String("cartId", req.CartId))
+		return nil, err
+	}
+	if cart.Status != schema.CsOpen {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot list delivery options for cart that is not open: cart ID=%s", cart.Id)
+	}
+
+	// Ask for the quotes
+	options, err := cs.quoteDeliveryOptions(cart)
+	if err != nil {
+		l.Error(err.Error(), zap.String("cartId", req.CartId))
+		return nil, err
+	}
+
+	// All good, log our joy before returning the options in their protocol buffer form
+	l.Info("delivery options listed successfully", zap.String("cartId", req.CartId), zap.Int("optionCount", len(options)))
+	pbOptions := make([]*pbcart.DeliveryOption, len(options))
+	for i, option := range options {
+		pbOptions[i] = option.AsPBDeliveryOption()
+	}
+	return &pbcart.ListDeliveryOptionsResponse{DeliveryOptions: pbOptions}, nil
+}
+
+// SetDeliveryOption chooses how the physical items of an open cart are to be delivered. The delivery option is
+// quoted afresh for the cart, within the same transaction that stores it, so the cost recorded is what the shopper
+// will pay as the cart stands. A codes.NotFound status error is returned if the option is not available for the
+// cart.
+//
+// The cost of the chosen option is requoted every time that the cart is retrieved, so it follows the cart contents
+// as items come and go. It is fixed when the cart is checked out. Changing the delivery address clears the choice.
+func (cs *CartService) SetDeliveryOption(ctx context.Context, req *pbcart.SetDeliveryOptionRequest) (*pbcart.SetDeliveryOptionResponse, error) {
+
+	// Obtain a shortcut handle on our globally configured logger then log what we are about to do
+	l := zap.L()
+	l.Info("setting delivery option", zap.String("cartId", req.CartId), zap.String("deliveryOption", req.DeliveryOptionCode))
+
+	// We need to know what is being chosen
+	if req.DeliveryOptionCode == "" {
+		return nil, status.Error(codes.InvalidArgument, "delivery option code must be specified")
+	}
+
+	// Quote the option for the cart as it stands and store it within the same transaction
+	processed, err := cs.updateOpenCart(ctx, req, "set delivery option of", func(tx *firestore.Transaction, cart *schema.ShoppingCart) ([]firestore.Update, error) {
+		var err error
+		cart.DeliveryAddress, err = cs.getTransactionalDeliveryAddress(tx, cart)
+		if err != nil {
+			return nil, err
+		}
+		cart.CartItems, err = cs.getTransactionalCartItems(tx, cart)
+		if err != nil {
+			return nil, err
+		}
+		options, err := cs.quoteDeliveryOptions(cart)
+		if err != nil {
+			return nil, err
+		}
+		for _, option := range options {
+			if option.Code == req.DeliveryOptionCode {
+				return []firestore.Update{{Path: "deliveryOption", Value: option}}, nil
+			}
+		}
+		return nil, status.Errorf(codes.NotFound, "delivery option not available for cart: cart ID=%s, delivery option=%s", cart.Id, req.DeliveryOptionCode)
+	})
+	if err != nil {
+		l.Error(err.Error(), zap.String("cartId", req.CartId), zap.String("deliveryOption", req.DeliveryOptionCode))
+		return nil, err
+	}
+
+	// All good, log our joy before returning the protocol buffer transliteration of the updated cart
+	if processed == nil {
+		l.Info("delivery option set successfully", zap.String("cartId", req.CartId), zap.String("deliveryOption", req.DeliveryOptionCode))
+	}
+
+	// Have our internal sibling do all the remaining work to return the complete cart as it now stands
+	pbCart, err := cs.cartForResponse(ctx, req, req.CartId, processed)
+	if err != nil {
+		return nil, err
+	}
+	return &pbcart.SetDeliveryOptionResponse{Cart: pbCart}, nil
+}
+
+// quoteDeliveryOptions returns the delivery options available for the given cart, the items and delivery address of
+// which must already have been loaded. A codes.FailedPrecondition status error is returned if the cart has no items,
+// no delivery address, or cannot otherwise be quoted for.
+func (cs *CartService) quoteDeliveryOptions(cart *schema.ShoppingCart) ([]*schema.DeliveryOption, error) {
+	if len(cart.CartItems) == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot quote delivery options for cart with no items: cart ID=%s", cart.Id)
+	}
+	options, err := cart.QuoteDeliveryOptions(cs.shippingProvider)
+	if errors.Is(err, shipping.ErrNoDeliveryRegion) {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot quote delivery options for cart with no delivery address: cart ID=%s", cart.Id)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot quote delivery options for cart: cart ID=%s: %v", cart.Id, err)
+	}
+	return options, nil
+}
+
+// requoteDeliveryOptions brings the cost of the delivery option chosen for each of the given open carts up to date
+// with the cart contents, the items and delivery addresses of which must already have been loaded. Closed carts keep
+// the cost that was frozen into them when they were checked out.
+//
+// If the chosen option is no longer available, e.g. because the cart has outgrown it, the cart is left showing the
+// option as last quoted; the shopper will be asked to choose again if they try to check out.
+func (cs *CartService) requoteDeliveryOptions(carts []*schema.ShoppingCart) {
+	for _, cart := range carts {
+		if cart.Status != schema.CsOpen || cart.DeliveryOption == nil {
+			continue
+		}
+		option, err := cart.QuoteDeliveryOption(cs.shippingProvider, cart.DeliveryOption.Code)
+		if err == nil && option != nil {
+			cart.DeliveryOption = option
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package cartapi
+
+import (
+	"testing"
+
+	"github.com/mikebway/poc-gcp-ecomm/cart/shipping"
+	pbcart "github.com/mikebway/poc-gcp-ecomm/pb/cart"
+	"github.com/mikebway/poc-gcp-ecomm/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestDeliveryOptions lists and chooses delivery options, confirming that the chosen option is requoted as the cart
+// changes, cleared when the delivery address changes, and fixed when the cart is checked out.
+func TestDeliveryOptions(t *testing.T) {
+
+	// Start with a cart holding three gold yoyos, for a subtotal of USD 4955.82, but no delivery address
+	req, ctx, service, cart, _ := addFirstItemToCart(t)
+	_, err := service.ListDeliveryOptions(ctx, &pbcart.ListDeliveryOptionsRequest{CartId: cart.Id})
+	req.Equal(codes.FailedPrecondition, status.Code(err), "listing options for a cart without an address should have been a failed precondition: %v", err)
+	_, err = service.SetDeliveryOption(ctx, &pbcart.SetDeliveryOptionRequest{CartId: cart.Id, DeliveryOptionCode: "STANDARD"})
+	req.Equal(codes.FailedPrecondition, status.Code(err), "choosing an option for a cart without an address should have been a failed precondition: %v", err)
+
+	// Send it to the UK, where the default rules offer standard delivery and, for small parcels, express
+	_, err = service.SetDeliveryAddress(ctx, &pbcart.SetDeliveryAddressRequest{CartId: cart.Id, DeliveryAddress: buildMockDeliveryAddress()})
+	req.Nil(err, "should not have seen an error setting the delivery address: %v", err)
+	listResp, err := service.ListDeliveryOptions(ctx, &pbcart.ListDeliveryOptionsRequest{CartId: cart.Id})
+	req.Nil(err, "should not have seen an error listing delivery options: %v", err)
+	req.Equal(2, len(listResp.DeliveryOptions), "should have been offered two delivery options")
+	req.Equal("STANDARD", listResp.DeliveryOptions[0].Code, "first delivery option did not match")
+	req.Equal("USD 9.99", types.MoneyFromPB(listResp.DeliveryOptions[0].Cost).String(), "standard delivery cost did not match")
+	req.Equal("EXPRESS", listResp.DeliveryOptions[1].Code, "second delivery option did not match")
+	req.Equal("USD 35.99", types.MoneyFromPB(listResp.DeliveryOptions[1].Cost).String(), "express delivery cost did not match")
+
+	// Options that are not offered cannot be chosen
+	_, err = service.SetDeliveryOption(ctx, &pbcart.SetDeliveryOptionRequest{CartId: cart.Id})
+	req.Equal(codes.InvalidArgument, status.Code(err), "choosing no option should have been an invalid argument: %v", err)
+	_, err = service.SetDeliveryOption(ctx, &pbcart.SetDeliveryOptionRequest{CartId: cart.Id, DeliveryOptionCode: "OVERNIGHT"})
+	req.Equal(codes.NotFound, status.Code(err), "choosing an option that is not offered should have been not found: %v", err)
+
+	// Go express; the cost is added to the total along with the 20% UK tax on the items
+	setResp, err := service.SetDeliveryOption(ctx, &pbcart.SetDeliveryOptionRequest{CartId: cart.Id, DeliveryOptionCode: "EXPRESS"})
+	req.Nil(err, "should not have seen an error choosing express delivery: %v", err)
+	req.Equal("EXPRESS", setResp.Cart.DeliveryOption.GetCode(), "chosen delivery option did not match")
+	req.Equal("USD 5982.97", types.MoneyFromPB(setResp.Cart.Total).String(), "total with express delivery did not match")
+
+	// Add thirteen plastic yoyos and the parcel outgrows express delivery, which checkout then refuses
+	addResp, err := service.AddItemToShoppingCart(ctx, &pbcart.AddItemToShoppingCartRequest{CartId: cart.Id, Item: buildMockCartItem(cartItemProductCode2)})
+	req.Nil(err, "should not have seen an error adding plastic yoyos: %v", err)
+	req.Equal("EXPRESS", addResp.Cart.DeliveryOption.GetCode(), "cart should still have shown the express option")
+	_, err = service.CheckoutShoppingCart(ctx, &pbcart.CheckoutShoppingCartRequest{CartId: cart.Id})
+	req.Equal([]string{"delivery_option"}, checkoutViolationFields(req, err), "checkout should have complained about the delivery option")
+
+	// Changing the address clears the choice
+	addrResp, err := service.SetDeliveryAddress(ctx, &pbcart.SetDeliveryAddressRequest{CartId: cart.Id, DeliveryAddress: buildMockDeliveryAddress()})
+	req.Nil(err, "should not have seen an error changing the delivery address: %v", err)
+	req.Nil(addrResp.Cart.DeliveryOption, "changing the address should have cleared the delivery option")
+
+	// Standard delivery still takes the larger parcel, so check out with that
+	_, err = service.SetDeliveryOption(ctx, &pbcart.SetDeliveryOptionRequest{CartId: cart.Id, DeliveryOptionCode: "STANDARD"})
+	req.Nil(err, "should not have seen an error choosing standard delivery: %v", err)
+	checkoutResp, err := service.CheckoutShoppingCart(ctx, &pbcart.CheckoutShoppingCartRequest{CartId: cart.Id})
+	req.Nil(err, "should not have seen an error checking out: %v", err)
+	req.Equal("USD 9.99", types.MoneyFromPB(checkoutResp.Cart.DeliveryOption.GetCost()).String(), "checked out delivery cost did not match")
+
+	// Once checked out, the cost is fixed whatever happens to the shipping rules, and no more options are offered
+	service.shippingProvider = &shipping.RuleProvider{}
+	getResp, err := service.GetShoppingCartByID(ctx, &pbcart.GetShoppingCartByIDRequest{CartId: cart.Id})
+	req.Nil(err, "should not have seen an error retrieving the checked out cart: %v", err)
+	req.Equal("USD 9.99", types.MoneyFromPB(getResp.Cart.DeliveryOption.GetCost()).String(), "checked out delivery cost should not have changed")
+	_, err = service.ListDeliveryOptions(ctx, &pbcart.ListDeliveryOptionsRequest{CartId: cart.Id})
+	req.Equal(codes.FailedPrecondition, status.Code(err), "listing options for a closed cart should have been a failed precondition: %v", err)
+}
+
+// TestDeliveryOptionsRejected confirms that delivery options cannot be listed for empty or missing carts.
+func TestDeliveryOptionsRejected(t *testing.T) {
+
+	// Do the common setup that most of our tests require
+	req, ctx, service, cart := commonTestSetup(t)
+
+	// An empty cart has nothing to deliver
+	_, err := service.ListDeliveryOptions(ctx, &pbcart.ListDeliveryOptionsRequest{CartId: cart.Id})
+	req.Equal(codes.FailedPrecondition, status.Code(err), "listing options for an empty cart should have been a failed precondition: %v", err)
+	req.Contains(err.Error(), "no items", "did not see the expected empty cart error")
+
+	// A missing cart has nothing at all
+	_, err = service.ListDeliveryOptions(ctx, &pbcart.ListDeliveryOptionsRequest{CartId: "no-such-cart"})
+	req.Equal(codes.NotFound, status.Code(err), "listing options for a missing cart should have been not found: %v", err)
+}
@@ -0,0 +1,189 @@
+package cartapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/google/uuid"
+	"github.com/mikebway/poc-gcp-ecomm/cart/schema"
+	pbcart "github.com/mikebway/poc-gcp-ecomm/pb/cart"
+	"github.com/mikebway/poc-gcp-ecomm/types"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// RequestIdTTL is how long the record of a processed request is kept. A retry that arrives after this has
+	// passed is treated as a brand-new request.
+	RequestIdTTL = 24 * time.Hour
+
+	// maxRequestIdLength is the longest client supplied request ID that we will accept
+	maxRequestIdLength = 128
+)
+
+var (
+	// requestIdNamespace is the UUID namespace within which the IDs of carts created by CreateShoppingCart requests
+	// that carry a request ID are derived from the shopper ID and request ID. A retried create request thus
+	// arrives at the same cart ID as the original did.
+	requestIdNamespace = uuid.MustParse("8f6b1a7e-3c0d-4a5e-9b2f-6d1e7c4a0b93")
+)
+
+// idempotentRequest is satisfied by the protobuf messages of all the API requests that may carry a client supplied
+// request ID.
+type idempotentRequest interface {
+	proto.Message
+	GetRequestId() string
+}
+
+// mutatingRequest is satisfied by the protobuf messages of all the API requests that change an existing cart.
+type mutatingRequest interface {
+	idempotentRequest
+	GetCartId() string
+	GetEtag() string
+}
+
+// validateRequestId confirms that the given client supplied request ID, if there is one, can be used as the ID of a
+// Firestore document. A codes.InvalidArgument status error is returned if not.
+func validateRequestId(requestId string) error {
+	if requestId != "" && !types.IsValidDocumentId(requestId, maxRequestIdLength) {
+		return status.Errorf(codes.InvalidArgument, "invalid request ID: %s", requestId)
+	}
+	return nil
+}
+
+// createdCartId returns the ID to be given to a cart created by a CreateShoppingCart request that carries a request
+// ID. The same shopper and request ID always produce the same cart ID.
+func createdCartId(req *pbcart.CreateShoppingCartRequest) string {
+	return uuid.NewSHA1(requestIdNamespace, []byte(req.GetShopper().GetId()+"/"+req.RequestId)).String()
+}
+
+// newProcessedRequest returns a record of the given request having been applied to the cart with the given ID,
+// expiring RequestIdTTL from now.
+func newProcessedRequest(cartId string, req idempotentRequest) *schema.ProcessedRequest {
+	now := time.Now()
+	return &schema.ProcessedRequest{
+		RequestId:     req.GetRequestId(),
+		CartId:        cartId,
+		Method:        string(proto.MessageName(req)),
+		ProcessedTime: now,
+		ExpiryTime:    now.Add(RequestIdTTL),
+	}
+}
+
+// getProcessedRequest returns the record of the given request having been applied to the cart with the given ID.
+// Nil is returned if there is no such record or if the record has expired.
+func (cs *CartService) getProcessedRequest(ctx context.Context, cartId string, req idempotentRequest) (*schema.ProcessedRequest, error) {
+	processed := &schema.ProcessedRequest{CartId: cartId, RequestId: req.GetRequestId()}
+	snap, err := cs.drProxy.Get(cs.FsClient.Doc(processed.StoreRefPath()), ctx)
+	return cs.processedRequestFromSnapshot(processed, req, snap, err)
+}
+
+// getTransactionalProcessedRequest returns the record of the given request having been applied to the cart with the
+// given ID, read within the given Firestore transaction. Nil is returned if there is no such record or if the
+// record has expired.
+func (cs *CartService) getTransactionalProcessedRequest(tx *firestore.Transaction, cartId string, req idempotentRequest) (*schema.ProcessedRequest, error) {
+	processed := &schema.ProcessedRequest{CartId: cartId, RequestId: req.GetRequestId()}
+	snap, err := cs.drProxy.TransactionalGet(cs.FsClient.Doc(processed.StoreRefPath()), tx)
+	return cs.processedRequestFromSnapshot(processed, req, snap, err)
+}
+
+// processedRequestFromSnapshot unmarshals a processed request record from the given snapshot into the given
+// structure, or interprets the error that was returned in place of the snapshot. Nil is returned if the record does
+// not exist or has expired. If the record is of a different kind of request than the given one, the request ID has
+// been reused and a codes.InvalidArgument status error is returned.
+func (cs *CartService) processedRequestFromSnapshot(processed *schema.ProcessedRequest, req idempotentRequest, snap *firestore.DocumentSnapshot, err error) (*schema.ProcessedRequest, error) {
+
+	// Not having seen the request before is the normal case
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve processed request with ID %s for cart with ID %s: %w", processed.RequestId, processed.CartId, err)
+	}
+
+	// Unmarshall the snapshot into our internal structure form
+	err = cs.dsProxy.DataTo(snap, processed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal processed request with ID %s for cart with ID %s: %w", processed.RequestId, processed.CartId, err)
+	}
+
+	// Records that have outlived their usefulness might as well not exist
+	if processed.HasExpired(time.Now()) {
+		return nil, nil
+	}
+
+	// The same request ID had better not have been used for something else
+	method := string(proto.MessageName(req))
+	if processed.Method != method {
+		return nil, status.Errorf(codes.InvalidArgument, "request ID has already been used for a different request: request ID=%s, method=%s", processed.RequestId, processed.Method)
+	}
+	return processed, nil
+}
+
+// recordTransactionalProcessedRequest records, within the given Firestore transaction, that the given request has
+// been applied to the cart with the given ID.
+func (cs *CartService) recordTransactionalProcessedRequest(tx *firestore.Transaction, cartId string, req idempotentRequest) error {
+	processed := newProcessedRequest(cartId, req)
+	err := cs.drProxy.TransactionalSet(cs.FsClient.Doc(processed.StoreRefPath()), tx, processed)
+	if err != nil {
+		return fmt.Errorf("failed recording processed request with ID %s for cart: %w", processed.RequestId, err)
+	}
+	return nil
+}
+
+// cartForResponse returns the cart with the given ID to be included in the response to the given request.
+//
+// If the request is a retry of one that has already been processed, as indicated by the processed record, the cart
+// that was returned in response to the original request is returned again. Otherwise, the cart is read as it now
+// stands and, if the request carries a request ID, recorded for the benefit of any retries that follow. If the cart
+// returned to the original request was never recorded, the cart as it now stands is the best that we can do.
+func (cs *CartService) cartForResponse(ctx context.Context, req idempotentRequest, cartId string, processed *schema.ProcessedRequest) (*pbcart.ShoppingCart, error) {
+
+	// Obtain a shortcut handle on our globally configured logger
+	l := zap.L()
+
+	// A retry gets the same answer as the original, if we have it
+	if processed != nil {
+		l.Info("replaying response to processed request", zap.String("cartId", cartId), zap.String("requestId", processed.RequestId))
+		if len(processed.Cart) > 0 {
+			pbCart := &pbcart.ShoppingCart{}
+			err := proto.Unmarshal(processed.Cart, pbCart)
+			if err == nil {
+				return pbCart, nil
+			}
+			l.Warn("failed to unmarshal cart recorded for processed request", zap.String("cartId", cartId), zap.String("requestId", processed.RequestId), zap.Error(err))
+		}
+		return cs.getShoppingCart(ctx, cartId)
+	}
+
+	// Not a retry, read the cart as it now stands
+	pbCart, err := cs.getShoppingCart(ctx, cartId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Remember what we said in case we have to say it again
+	if req.GetRequestId() != "" {
+		cs.recordResponseCart(ctx, req, pbCart)
+	}
+	return pbCart, nil
+}
+
+// recordResponseCart records the cart returned in response to the given request against its request ID. The
+// request has already been applied by the time that we get here so a failure to record the cart is logged but
+// otherwise ignored; a retry will be answered with the cart as it stands at the time of the retry.
+func (cs *CartService) recordResponseCart(ctx context.Context, req idempotentRequest, pbCart *pbcart.ShoppingCart) {
+	processed := newProcessedRequest(pbCart.Id, req)
+	var err error
+	processed.Cart, err = proto.Marshal(pbCart)
+	if err == nil {
+		_, err = cs.drProxy.Set(cs.FsClient.Doc(processed.StoreRefPath()), ctx, processed)
+	}
+	if err != nil {
+		zap.L().Warn("failed to record response to processed request", zap.String("cartId", pbCart.Id), zap.String("requestId", processed.RequestId), zap.Error(err))
+	}
+}
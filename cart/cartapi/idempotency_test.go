@@ -0,0 +1,167 @@
+package cartapi
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/google/uuid"
+	"github.com/mikebway/poc-gcp-ecomm/cart/schema"
+	pbcart "github.com/mikebway/poc-gcp-ecomm/pb/cart"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestCreateIdempotent confirms that retrying a cart creation request returns the original cart rather than
+// creating a second one.
+func TestCreateIdempotent(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+	service, err := NewCartService()
+	req.Nil(err, "failed to obtain cart service: %v", err)
+	ctx := context.Background()
+
+	// Create a cart, then add an item to it
+	createReq := &pbcart.CreateShoppingCartRequest{Shopper: buildMockShopper(), RequestId: uuid.NewString()}
+	original, err := service.CreateShoppingCart(ctx, createReq)
+	req.Nil(err, "should not have seen an error creating the cart: %v", err)
+	_, err = service.AddItemToShoppingCart(ctx, &pbcart.AddItemToShoppingCartRequest{CartId: original.Cart.Id, Item: buildMockCartItem(cartItemProductCode1)})
+	req.Nil(err, "should not have seen an error adding an item: %v", err)
+
+	// A retry should get the very same response, without the item that was added since
+	retried, err := service.CreateShoppingCart(ctx, createReq)
+	req.Nil(err, "should not have seen an error retrying the creation: %v", err)
+	req.True(proto.Equal(original.Cart, retried.Cart), "retry should have returned the original cart")
+
+	// The same request ID from a different shopper is a different request
+	otherShopper := buildMockShopper()
+	otherShopper.Id = uuid.NewString()
+	other, err := service.CreateShoppingCart(ctx, &pbcart.CreateShoppingCartRequest{Shopper: otherShopper, RequestId: createReq.RequestId})
+	req.Nil(err, "should not have seen an error creating another shopper's cart: %v", err)
+	req.NotEqual(original.Cart.Id, other.Cart.Id, "different shoppers should have had different carts")
+
+	// If we failed to record the original response, the retry gets the cart as it now stands
+	service.drProxy = &UTDocRefProxy{Err: mockError, AllowCount: 1}
+	createReq.RequestId = uuid.NewString()
+	original, err = service.CreateShoppingCart(ctx, createReq)
+	req.Nil(err, "failing to record the response should not have failed the creation: %v", err)
+	service.drProxy = &DocRefProxy{}
+	retried, err = service.CreateShoppingCart(ctx, createReq)
+	req.Nil(err, "should not have seen an error retrying the creation: %v", err)
+	req.Equal(original.Cart.Id, retried.Cart.Id, "retry should have returned the original cart")
+}
+
+// TestAddItemIdempotent confirms that retrying an item addition does not add the item twice, even after the cart has
+// moved on.
+func TestAddItemIdempotent(t *testing.T) {
+
+	// Do the common setup that most of our tests require
+	req, ctx, service, cart := commonTestSetup(t)
+
+	// Add an item, then add it again as a retry
+	addReq := &pbcart.AddItemToShoppingCartRequest{CartId: cart.Id, Item: buildMockCartItem(cartItemProductCode1), RequestId: uuid.NewString()}
+	original, err := service.AddItemToShoppingCart(ctx, addReq)
+	req.Nil(err, "should not have seen an error adding the item: %v", err)
+	retried, err := service.AddItemToShoppingCart(ctx, addReq)
+	req.Nil(err, "should not have seen an error retrying the addition: %v", err)
+	req.True(proto.Equal(original.Cart, retried.Cart), "retry should have returned the original cart")
+	req.Equal(1, len(retried.Cart.CartItems), "retry should not have added a second item")
+	req.Equal(cartItemQuantity1, retried.Cart.CartItems[0].Quantity, "retry should not have merged the item with itself")
+
+	// A retry after the cart has been abandoned should still be answered in the same way
+	abandonReq := &pbcart.AbandonShoppingCartRequest{CartId: cart.Id, RequestId: uuid.NewString()}
+	abandoned, err := service.AbandonShoppingCart(ctx, abandonReq)
+	req.Nil(err, "should not have seen an error abandoning the cart: %v", err)
+	retried, err = service.AddItemToShoppingCart(ctx, addReq)
+	req.Nil(err, "should not have seen an error retrying the addition to an abandoned cart: %v", err)
+	req.True(proto.Equal(original.Cart, retried.Cart), "retry to an abandoned cart should have returned the original cart")
+
+	// As should a retry of the abandonment, even though the cart is no longer open
+	reabandoned, err := service.AbandonShoppingCart(ctx, abandonReq)
+	req.Nil(err, "should not have seen an error retrying the abandonment: %v", err)
+	req.True(proto.Equal(abandoned.Cart, reabandoned.Cart), "retried abandonment should have returned the original cart")
+}
+
+// TestRequestIdExpired confirms that a retry arriving after the request record has expired is applied afresh.
+func TestRequestIdExpired(t *testing.T) {
+
+	// Do the common setup that most of our tests require, then add an item
+	req, ctx, service, cart := commonTestSetup(t)
+	addReq := &pbcart.AddItemToShoppingCartRequest{CartId: cart.Id, Item: buildMockCartItem(cartItemProductCode1), RequestId: uuid.NewString()}
+	_, err := service.AddItemToShoppingCart(ctx, addReq)
+	req.Nil(err, "should not have seen an error adding the item: %v", err)
+
+	// Age the record of the request beyond its expiry
+	processed := &schema.ProcessedRequest{CartId: cart.Id, RequestId: addReq.RequestId}
+	ref := service.FsClient.Doc(processed.StoreRefPath())
+	_, err = ref.Update(ctx, []firestore.Update{{Path: "expiryTime", Value: time.Now().Add(-time.Minute)}})
+	req.Nil(err, "failed to age the request record: %v", err)
+
+	// The retry should be treated as a new request, merging the item with the first
+	retried, err := service.AddItemToShoppingCart(ctx, addReq)
+	req.Nil(err, "should not have seen an error retrying the addition: %v", err)
+	req.Equal(1, len(retried.Cart.CartItems), "retry should have merged with the first item")
+	req.Equal(2*cartItemQuantity1, retried.Cart.CartItems[0].Quantity, "retry should have doubled the quantity")
+}
+
+// TestRequestIdInvalid confirms that unusable request IDs, and request IDs used for more than one kind of request,
+// are rejected.
+func TestRequestIdInvalid(t *testing.T) {
+
+	// Do the common setup that most of our tests require
+	req, ctx, service, cart := commonTestSetup(t)
+
+	// Request IDs that could not be used as Firestore document IDs
+	for _, requestId := range []string{"a/b", "..", "__reserved__", strings.Repeat("x", maxRequestIdLength+1)} {
+		_, err := service.AbandonShoppingCart(ctx, &pbcart.AbandonShoppingCartRequest{CartId: cart.Id, RequestId: requestId})
+		req.NotNil(err, "should have seen an error for request ID %s", requestId)
+		req.Equal(codes.InvalidArgument, status.Code(err), "request ID %s should have been an invalid argument", requestId)
+		_, err = service.CreateShoppingCart(ctx, &pbcart.CreateShoppingCartRequest{Shopper: buildMockShopper(), RequestId: requestId})
+		req.NotNil(err, "should have seen an error creating a cart with request ID %s", requestId)
+		req.Equal(codes.InvalidArgument, status.Code(err), "request ID %s should have been an invalid argument for a creation", requestId)
+	}
+
+	// Using the same request ID for two different requests
+	requestId := uuid.NewString()
+	_, err := service.AddItemToShoppingCart(ctx, &pbcart.AddItemToShoppingCartRequest{CartId: cart.Id, Item: buildMockCartItem(cartItemProductCode1), RequestId: requestId})
+	req.Nil(err, "should not have seen an error adding the item: %v", err)
+	_, err = service.AbandonShoppingCart(ctx, &pbcart.AbandonShoppingCartRequest{CartId: cart.Id, RequestId: requestId})
+	req.NotNil(err, "should have seen an error reusing a request ID")
+	req.Equal(codes.InvalidArgument, status.Code(err), "reused request ID should have been an invalid argument")
+	req.Contains(err.Error(), "request ID has already been used for a different request", "did not see the expected reuse error")
+}
+
+// TestRequestIdFailure looks at how errors reading and writing request records are handled.
+func TestRequestIdFailure(t *testing.T) {
+
+	// Do the common setup that most of our tests require
+	req, ctx, service, cart := commonTestSetup(t)
+
+	// Have reading the request record fail
+	service.drProxy = &UTDocRefProxy{Err: mockError}
+	_, err := service.AbandonShoppingCart(ctx, &pbcart.AbandonShoppingCartRequest{CartId: cart.Id, RequestId: uuid.NewString()})
+	req.NotNil(err, "should have seen an error reading the request record")
+	req.Contains(err.Error(), "failed to retrieve processed request with ID", "did not see the expected read error")
+
+	// Have writing the request record fail, after reading it, reading the cart, and updating the cart
+	service.drProxy = &UTDocRefProxy{Err: mockError, AllowCount: 3}
+	_, err = service.AbandonShoppingCart(ctx, &pbcart.AbandonShoppingCartRequest{CartId: cart.Id, RequestId: uuid.NewString()})
+	req.NotNil(err, "should have seen an error writing the request record")
+	req.Contains(err.Error(), "failed recording processed request with ID", "did not see the expected write error")
+	service.drProxy = &DocRefProxy{}
+	requireCartStatus(ctx, req, service, cart.Id, pbcart.ShoppingCartStatus_SCS_OPEN)
+
+	// Have unmarshalling the request record fail
+	requestId := uuid.NewString()
+	_, err = service.AddItemToShoppingCart(ctx, &pbcart.AddItemToShoppingCartRequest{CartId: cart.Id, Item: buildMockCartItem(cartItemProductCode1), RequestId: requestId})
+	req.Nil(err, "should not have seen an error adding the item: %v", err)
+	service.dsProxy = &UTDocSnapProxy{Err: mockError}
+	_, err = service.AbandonShoppingCart(ctx, &pbcart.AbandonShoppingCartRequest{CartId: cart.Id, RequestId: requestId})
+	req.NotNil(err, "should have seen an error unmarshalling the request record")
+	req.Contains(err.Error(), "failed to unmarshal processed request with ID", "did not see the expected unmarshal error")
+}
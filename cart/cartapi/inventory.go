@@ -0,0 +1,70 @@
+package cartapi
+
+import (
+	"errors"
+
+	"cloud.google.com/go/firestore"
+	"github.com/mikebway/poc-gcp-ecomm/cart/schema"
+	"github.com/mikebway/poc-gcp-ecomm/inventory"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// reserveStock sets the number of units of each product that the cart with the given ID holds in the inventory to
+// the quantity given for the product, within the given transaction. A codes.ResourceExhausted status error is
+// returned if there are not enough units to go round.
+//
+// Like all the inventory operations that read as well as write, this must be called after all the other reads of
+// the transaction and before any of its writes.
+func (cs *CartService) reserveStock(tx *firestore.Transaction, cartId string, quantities map[string]int64) error {
+	return stockError(cs.inventory.Reserve(tx, cartId, quantities))
+}
+
+// transferStock hands the inventory reservations of the products in the quantities map from one cart to another,
+// within the given transaction, setting the quantities held by the receiving cart. A codes.ResourceExhausted status
+// error is returned if there are not enough units to go round.
+func (cs *CartService) transferStock(tx *firestore.Transaction, fromCartId string, toCartId string, quantities map[string]int64) error {
+	return stockError(cs.inventory.Transfer(tx, fromCartId, toCartId, quantities))
+}
+
+// commitStock takes the units of the given cart items out of the inventory for good, within the given transaction,
+// as the cart that they belong to is checked out. A codes.ResourceExhausted status error is returned if there are
+// not enough units to go round.
+func (cs *CartService) commitStock(tx *firestore.Transaction, cartId string, items []*schema.ShoppingCartItem) error {
+	return stockError(cs.inventory.Commit(tx, cartId, itemQuantities(items)))
+}
+
+// releaseStock gives up the inventory reservations that the given cart holds for the products of the given items,
+// within the given transaction.
+func (cs *CartService) releaseStock(tx *firestore.Transaction, cartId string, items []*schema.ShoppingCartItem) error {
+	quantities := itemQuantities(items)
+	productCodes := make([]string, 0, len(quantities))
+	for code := range quantities {
+		productCodes = append(productCodes, code)
+	}
+	return cs.inventory.Release(tx, cartId, productCodes)
+}
+
+// itemQuantities totals up the quantities of the given cart items by product code.
+func itemQuantities(items []*schema.ShoppingCartItem) map[string]int64 {
+	quantities := make(map[string]int64, len(items))
+	for _, item := range items {
+		quantities[item.ProductCode] += int64(item.Quantity)
+	}
+	return quantities
+}
+
+// productQuantity totals up the quantities of those of the given cart items that are for the product with the given
+// code.
+func productQuantity(items []*schema.ShoppingCartItem, productCode string) int64 {
+	return itemQuantities(items)[productCode]
+}
+
+// stockError translates an inventory.ErrInsufficientStock error into a codes.ResourceExhausted status error,
+// passing any other error, or nil, back unchanged.
+func stockError(err error) error {
+	if errors.Is(err, inventory.ErrInsufficientStock) {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	return err
+}
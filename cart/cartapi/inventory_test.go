@@ -0,0 +1,238 @@
+package cartapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/google/uuid"
+	"github.com/mikebway/poc-gcp-ecomm/cart/schema"
+	"github.com/mikebway/poc-gcp-ecomm/inventory"
+	pbcart "github.com/mikebway/poc-gcp-ecomm/pb/cart"
+	pbtypes "github.com/mikebway/poc-gcp-ecomm/pb/types"
+	"github.com/mikebway/poc-gcp-ecomm/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// storeStockedProduct adds a product of its very own to the catalog, so that other tests cannot interfere with its
+// stock, with the given number of units on hand, and returns its product code.
+func storeStockedProduct(ctx context.Context, req *require.Assertions, service *CartService, onHand int64) string {
+	code := "ut_stocked_yoyo_" + uuid.NewString()
+	err := storeProduct(ctx, service, code, types.NewMoney(cartItemPriceCurrency, 5, 0))
+	req.Nil(err, "failed to store product %s: %v", code, err)
+	level := &inventory.StockLevel{ProductCode: code, OnHand: onHand}
+	_, err = service.FsClient.Doc(level.StoreRefPath()).Set(ctx, level)
+	req.Nil(err, "failed to store stock level for product %s: %v", code, err)
+	return code
+}
+
+// reservedQuantity returns the number of units of the given product that the given cart has reserved, zero if it
+// has no reservation.
+func reservedQuantity(ctx context.Context, req *require.Assertions, service *CartService, cartId string, code string) int64 {
+	reservation := &inventory.Reservation{CartId: cartId, ProductCode: code}
+	snap, err := service.FsClient.Doc(reservation.StoreRefPath()).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return 0
+	}
+	req.Nil(err, "failed to retrieve reservation of product %s for cart %s: %v", code, cartId, err)
+	req.Nil(snap.DataTo(reservation), "failed to unmarshal reservation of product %s for cart %s", code, cartId)
+	return reservation.Quantity
+}
+
+// stockOnHand returns the number of units of the given product that are on hand.
+func stockOnHand(ctx context.Context, req *require.Assertions, service *CartService, code string) int64 {
+	level := &inventory.StockLevel{ProductCode: code}
+	snap, err := service.FsClient.Doc(level.StoreRefPath()).Get(ctx)
+	req.Nil(err, "failed to retrieve stock level of product %s: %v", code, err)
+	req.Nil(snap.DataTo(level), "failed to unmarshal stock level of product %s", code)
+	return level.OnHand
+}
+
+// addStockedItem adds the given quantity of the given product to the given cart, returning the cart as it now stands
+// and any error.
+func addStockedItem(ctx context.Context, service *CartService, cartId string, code string, quantity int32) (*pbcart.ShoppingCart, error) {
+	item := &pbcart.CartItem{ProductCode: code, Quantity: quantity}
+	response, err := service.AddItemToShoppingCart(ctx, &pbcart.AddItemToShoppingCartRequest{CartId: cartId, Item: item})
+	return response.GetCart(), err
+}
+
+// TestAddItemReservesStock confirms that adding and updating items reserves stock, and that carts cannot have more
+// than there is to go round.
+func TestAddItemReservesStock(t *testing.T) {
+
+	// Three units to share between two carts
+	req, ctx, service, cart1 := commonTestSetup(t)
+	_, cart2 := storeMockCart(ctx, req)
+	code := storeStockedProduct(ctx, req, service, 3)
+
+	// The first cart takes two, leaving the second cart with just the one
+	_, err := addStockedItem(ctx, service, cart1.Id, code, 2)
+	req.Nil(err, "should not have seen an error adding two units to the first cart: %v", err)
+	req.Equal(int64(2), reservedQuantity(ctx, req, service, cart1.Id, code), "first cart reservation did not match")
+	_, err = addStockedItem(ctx, service, cart2.Id, code, 2)
+	req.Equal(codes.ResourceExhausted, status.Code(err), "adding two units to the second cart should have exhausted the stock: %v", err)
+	cart, err := addStockedItem(ctx, service, cart2.Id, code, 1)
+	req.Nil(err, "should not have seen an error adding the last unit to the second cart: %v", err)
+	item2 := cart.CartItems[0]
+
+	// The first cart cannot have any more, and its item is left as it was when it tries
+	_, err = addStockedItem(ctx, service, cart1.Id, code, 1)
+	req.Equal(codes.ResourceExhausted, status.Code(err), "adding a third unit to the first cart should have exhausted the stock: %v", err)
+	getResp, err := service.GetShoppingCartByID(ctx, &pbcart.GetShoppingCartByIDRequest{CartId: cart1.Id})
+	req.Nil(err, "should not have seen an error retrieving the first cart: %v", err)
+	req.Equal(int32(2), getResp.Cart.CartItems[0].Quantity, "first cart item quantity should not have changed")
+	item1 := getResp.Cart.CartItems[0]
+
+	// Until it cuts back to one, when the second cart can have two
+	item1.Quantity = 1
+	_, err = service.UpdateCartItem(ctx, &pbcart.UpdateCartItemRequest{CartId: cart1.Id, Item: item1})
+	req.Nil(err, "should not have seen an error cutting the first cart back to one unit: %v", err)
+	req.Equal(int64(1), reservedQuantity(ctx, req, service, cart1.Id, code), "first cart reservation should have been cut back")
+	item2.Quantity = 3
+	_, err = service.UpdateCartItem(ctx, &pbcart.UpdateCartItemRequest{CartId: cart2.Id, Item: item2})
+	req.Equal(codes.ResourceExhausted, status.Code(err), "raising the second cart to three units should have exhausted the stock: %v", err)
+	item2.Quantity = 2
+	_, err = service.UpdateCartItem(ctx, &pbcart.UpdateCartItemRequest{CartId: cart2.Id, Item: item2})
+	req.Nil(err, "should not have seen an error raising the second cart to two units: %v", err)
+	req.Equal(int64(2), reservedQuantity(ctx, req, service, cart2.Id, code), "second cart reservation should have been raised")
+
+	// Products that are not stock controlled can be had in any quantity
+	_, err = addStockedItem(ctx, service, cart1.Id, cartItemProductCode1, 1_000_000)
+	req.Nil(err, "should not have seen an error adding lots of a product that is not stock controlled: %v", err)
+}
+
+// TestRemoveItemReleasesStock confirms that the stock held for an item is released when the item is removed.
+func TestRemoveItemReleasesStock(t *testing.T) {
+
+	// Two units, both in one cart
+	req, ctx, service, cart := commonTestSetup(t)
+	code := storeStockedProduct(ctx, req, service, 2)
+	addedCart, err := addStockedItem(ctx, service, cart.Id, code, 2)
+	req.Nil(err, "should not have seen an error adding two units to the cart: %v", err)
+
+	// Take them out again, twice over since removing an item that has already gone is harmless
+	for i := 0; i < 2; i++ {
+		_, err = service.RemoveItemFromShoppingCart(ctx, &pbcart.RemoveItemFromShoppingCartRequest{CartId: cart.Id, ItemId: addedCart.CartItems[0].Id})
+		req.Nil(err, "should not have seen an error removing the item: %v", err)
+	}
+	req.Equal(int64(0), reservedQuantity(ctx, req, service, cart.Id, code), "removed item's reservation should have been released")
+
+	// Somebody else can have them now
+	_, otherCart := storeMockCart(ctx, req)
+	_, err = addStockedItem(ctx, service, otherCart.Id, code, 2)
+	req.Nil(err, "should not have seen an error adding the released units to another cart: %v", err)
+}
+
+// TestAbandonReleasesStock confirms that the stock held by a cart is released when the cart is abandoned, whether by
+// the shopper, by being merged into another cart, or by timing out.
+func TestAbandonReleasesStock(t *testing.T) {
+
+	// Three units, one in each of three carts
+	req, ctx, service, cart := commonTestSetup(t)
+	code := storeStockedProduct(ctx, req, service, 3)
+	_, targetCart := storeMockCart(ctx, req)
+	_, staleCart := storeMockCart(ctx, req)
+	for _, cartId := range []string{cart.Id, targetCart.Id, staleCart.Id} {
+		_, err := addStockedItem(ctx, service, cartId, code, 1)
+		req.Nil(err, "should not have seen an error adding a unit to cart %s: %v", cartId, err)
+	}
+
+	// The shopper walks away from the first cart
+	_, err := service.AbandonShoppingCart(ctx, &pbcart.AbandonShoppingCartRequest{CartId: cart.Id})
+	req.Nil(err, "should not have seen an error abandoning the cart: %v", err)
+	req.Equal(int64(0), reservedQuantity(ctx, req, service, cart.Id, code), "abandoned cart reservation should have been released")
+
+	// The guest cart's unit goes to the target cart when they are merged
+	source := storeGuestCart(ctx, req, service)
+	_, err = addStockedItem(ctx, service, source.Id, code, 1)
+	req.Nil(err, "should not have seen an error adding a unit to the guest cart: %v", err)
+	_, err = service.MergeShoppingCarts(ctx, &pbcart.MergeShoppingCartsRequest{CartId: targetCart.Id, SourceCartId: source.Id})
+	req.Nil(err, "should not have seen an error merging the carts: %v", err)
+	req.Equal(int64(0), reservedQuantity(ctx, req, service, source.Id, code), "guest cart reservation should have been handed over")
+	req.Equal(int64(2), reservedQuantity(ctx, req, service, targetCart.Id, code), "target cart should have been given the guest cart's reservation")
+
+	// The last cart is left to go stale, and the sweeper closes it
+	longAgo := time.Now().Add(-2 * sweepTTL)
+	_, err = service.FsClient.Doc((&schema.ShoppingCart{Id: staleCart.Id}).StoreRefPath()).Update(ctx, []firestore.Update{
+		{Path: "creationTime", Value: longAgo},
+		{Path: "modifiedTime", Value: longAgo},
+	})
+	req.Nil(err, "failed to age the stale cart: %v", err)
+	result, err := service.SweepAbandonedCarts(ctx, &SweepRequest{TTL: sweepTTL})
+	req.Nil(err, "sweep should not have failed: %v", err)
+	req.Contains(result.CartIds, staleCart.Id, "sweep should have closed the stale cart")
+	req.Equal(int64(0), reservedQuantity(ctx, req, service, staleCart.Id, code), "timed out cart reservation should have been released")
+
+	// None of which touches the stock on hand
+	req.Equal(int64(3), stockOnHand(ctx, req, service, code), "units on hand should not have changed")
+}
+
+// TestCheckoutCommitsStock confirms that checking a cart out takes its items out of stock, and that a cart whose
+// reservation lapsed cannot be checked out if somebody else has taken the stock in the meantime.
+func TestCheckoutCommitsStock(t *testing.T) {
+
+	// Five units, two of which go in a cart that is ready to be checked out
+	req, ctx, service, cart, _ := prepareCartForCheckout(t)
+	code := storeStockedProduct(ctx, req, service, 5)
+	_, err := addStockedItem(ctx, service, cart.Id, code, 2)
+	req.Nil(err, "should not have seen an error adding two units to the cart: %v", err)
+
+	// Check it out and the two units are gone for good
+	_, err = service.CheckoutShoppingCart(ctx, &pbcart.CheckoutShoppingCartRequest{CartId: cart.Id})
+	req.Nil(err, "should not have seen an error checking out the cart: %v", err)
+	req.Equal(int64(3), stockOnHand(ctx, req, service, code), "checked out units should have been taken out of stock")
+	req.Equal(int64(0), reservedQuantity(ctx, req, service, cart.Id, code), "checked out cart reservation should have been dropped")
+
+	// A second cart takes two of the remaining three, but its reservation lapses and a third cart takes all three
+	_, _, _, slowCart, _ := prepareCartForCheckout(t)
+	service.inventory.ReservationTTL = -time.Minute
+	_, err = addStockedItem(ctx, service, slowCart.Id, code, 2)
+	req.Nil(err, "should not have seen an error adding two units to the slow cart: %v", err)
+	service.inventory.ReservationTTL = inventory.DefaultReservationTTL
+	_, fastCart := storeMockCart(ctx, req)
+	_, err = addStockedItem(ctx, service, fastCart.Id, code, 3)
+	req.Nil(err, "should not have seen an error adding three units to the fast cart: %v", err)
+
+	// The second cart is too late
+	_, err = service.CheckoutShoppingCart(ctx, &pbcart.CheckoutShoppingCartRequest{CartId: slowCart.Id})
+	req.Equal(codes.ResourceExhausted, status.Code(err), "checking out the slow cart should have exhausted the stock: %v", err)
+	requireCartStatus(ctx, req, service, slowCart.Id, pbcart.ShoppingCartStatus_SCS_OPEN)
+	req.Equal(int64(3), stockOnHand(ctx, req, service, code), "units on hand should not have changed")
+}
+
+// TestCustomizedItemsShareStock confirms that the stock held for a product covers all of a cart's items for that
+// product, however they are customized.
+func TestCustomizedItemsShareStock(t *testing.T) {
+
+	// Three units, two of which go in the cart plain and one engraved
+	req, ctx, service, cart := commonTestSetup(t)
+	code := storeStockedProduct(ctx, req, service, 3)
+	plainCart, err := addStockedItem(ctx, service, cart.Id, code, 2)
+	req.Nil(err, "should not have seen an error adding two plain units: %v", err)
+	engraved := &pbcart.CartItem{ProductCode: code, Quantity: 1, Attributes: []*pbtypes.Attribute{{Name: "engraving", Value: "Rupert"}}}
+	response, err := service.AddItemToShoppingCart(ctx, &pbcart.AddItemToShoppingCartRequest{CartId: cart.Id, Item: engraved})
+	req.Nil(err, "should not have seen an error adding an engraved unit: %v", err)
+	req.Equal(int64(3), reservedQuantity(ctx, req, service, cart.Id, code), "reservation should have covered both items")
+
+	// There is no fourth unit to engrave
+	_, err = service.AddItemToShoppingCart(ctx, &pbcart.AddItemToShoppingCartRequest{CartId: cart.Id, Item: engraved})
+	req.Equal(codes.ResourceExhausted, status.Code(err), "adding a fourth unit should have exhausted the stock: %v", err)
+
+	// Unless the plain ones go back on the shelf
+	_, err = service.RemoveItemFromShoppingCart(ctx, &pbcart.RemoveItemFromShoppingCartRequest{CartId: cart.Id, ItemId: plainCart.CartItems[0].Id})
+	req.Nil(err, "should not have seen an error removing the plain item: %v", err)
+	req.Equal(int64(1), reservedQuantity(ctx, req, service, cart.Id, code), "reservation should have been cut back to the engraved item")
+	var engravedItem *pbcart.CartItem
+	for _, item := range response.Cart.CartItems {
+		if len(item.Attributes) > 0 {
+			engravedItem = item
+		}
+	}
+	engravedItem.Quantity = 3
+	_, err = service.UpdateCartItem(ctx, &pbcart.UpdateCartItemRequest{CartId: cart.Id, Item: engravedItem})
+	req.Nil(err, "should not have seen an error engraving all three units: %v", err)
+	req.Equal(int64(3), reservedQuantity(ctx, req, service, cart.Id, code), "reservation should have covered all three engraved units")
+}
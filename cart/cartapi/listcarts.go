@@ -0,0 +1,148 @@
+package cartapi
+
+import (
+	"context"
+
+	"cloud.google.com/go/firestore"
+	"github.com/mikebway/poc-gcp-ecomm/cart/schema"
+	"github.com/mikebway/poc-gcp-ecomm/paging"
+	pbcart "github.com/mikebway/poc-gcp-ecomm/pb/cart"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultPageSize is used if no page size is supplied in a pbcart.ListShoppingCartsRequest, or the value is
+	// less than one.
+	defaultPageSize = 20
+
+	// maxPageSize is used if the page size is supplied in a pbcart.ListShoppingCartsRequest is greater than 100.
+	maxPageSize = 100
+)
+
+// ListShoppingCarts retrieves a page of the carts belonging to the shopper identified in the
+// pbcart.ListShoppingCartsRequest, optionally narrowed down by status and creation time, oldest first.
+//
+// Unless the request asks for them to be included, the carts are returned without their items or delivery address.
+// Either way, the etag of the carts is not populated; retrieve a cart by its ID to obtain its etag.
+//
+// The next page token that is returned can only be used to ask for the next page of the same query, with the same
+// filters and page size; see the paging package.
+func (cs *CartService) ListShoppingCarts(ctx context.Context, req *pbcart.ListShoppingCartsRequest) (*pbcart.ListShoppingCartsResponse, error) {
+
+	// Log what we have been asked to do as context for any later logging on this thread
+	l := zap.L()
+	l.Info("listing carts", zap.String("shopperId", req.ShopperId), zap.Int("statusCount", len(req.Statuses)), zap.String("pageToken", req.PageToken))
+
+	// TODO: Access control - shoppers should only be able to list their own carts
+
+	// We only list the carts of one shopper at a time
+	if req.ShopperId == "" {
+		return nil, status.Error(codes.InvalidArgument, "shopper ID must be specified")
+	}
+
+	// Adjust the page size if it is unreasonable
+	pageSize := int(req.PageSize)
+	if pageSize < 1 {
+		l.Warn("negative/zero page size adjusted to default", zap.Int32("requested", req.PageSize), zap.Int("default", defaultPageSize))
+		pageSize = defaultPageSize
+	} else if pageSize > maxPageSize {
+		l.Warn("excessive page size adjusted to maximum", zap.Int32("requested", req.PageSize), zap.Int("max", maxPageSize))
+		pageSize = maxPageSize
+	}
+
+	// Start with the whole collection and build up the query from there
+	query, err := buildCartQuery(cs.FsClient.Collection(schema.CartCollectionId).Query, req, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// Run the query to obtain the set of matching carts
+	carts, err := cs.getCartBatch(ctx, query)
+	if err != nil {
+		l.Error(err.Error(), zap.String("shopperId", req.ShopperId))
+		return nil, err
+	}
+
+	// Fill in the items and delivery address of each cart if we have been asked to
+	if req.IncludeItems {
+		err = cs.loadCartDescendants(ctx, carts)
+		if err != nil {
+			l.Error(err.Error(), zap.String("shopperId", req.ShopperId))
+			return nil, err
+		}
+	}
+
+	// Could there be another page? We use the last cart's creation time and ID as our position marker
+	nextPageToken := ""
+	cartCount := len(carts)
+	if cartCount >= pageSize {
+		lastCart := carts[cartCount-1]
+		nextPageToken, err = paging.NewToken(req, &paging.Cursor{SubmissionTime: lastCart.CreationTime, Id: lastCart.Id})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Loop, converting the slice of internal format carts to their protobuf equivalents
+	pbCarts := make([]*pbcart.ShoppingCart, cartCount)
+	for i, cart := range carts {
+		pbCarts[i] = cart.AsPBShoppingCart()
+	}
+
+	// And we are all done
+	l.Info("carts listed successfully", zap.String("shopperId", req.ShopperId), zap.Int("count", cartCount))
+	return &pbcart.ListShoppingCartsResponse{
+		Carts:         pbCarts,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// buildCartQuery translates the pbcart.ListShoppingCartsRequest parameters into filters on the given firestore.Query.
+// Always check the error return value - a query is returned whether an error occurred or not.
+func buildCartQuery(query firestore.Query, req *pbcart.ListShoppingCartsRequest, pageSize int) (firestore.Query, error) {
+
+	// Only the given shopper's carts are of interest
+	query = query.Where("shopper.id", "==", req.ShopperId)
+
+	// Narrow down by status if asked to
+	if len(req.Statuses) > 0 {
+		statuses := make([]schema.CartStatus, len(req.Statuses))
+		for i, s := range req.Statuses {
+			statuses[i] = schema.CartStatus(s)
+		}
+		query = query.Where("status", "in", statuses)
+	}
+
+	// Ignore documents that fall outside the time window
+	if req.StartTime != nil {
+		query = query.Where("creationTime", ">=", req.GetStartTime().AsTime())
+	}
+	if req.EndTime != nil {
+		query = query.Where("creationTime", "<", req.GetEndTime().AsTime())
+	}
+
+	// Order the results by creation time first, then by cart ID (necessary for us to have a unique cursor position for paging)
+	query = query.OrderBy("creationTime", firestore.Asc).OrderBy("id", firestore.Asc)
+
+	// If a page token was specified, use that as the marker for the last document that has
+	// already been returned, i.e. start after that one.
+	if len(req.PageToken) > 0 {
+
+		// Recover the creation time and ID from the page token, so long as it was issued for this same query
+		cursor, err := paging.ParseToken(req, req.PageToken)
+		if err != nil {
+			return query, err
+		}
+
+		// Add the start after factors to our query
+		query = query.StartAfter(cursor.SubmissionTime, cursor.Id)
+	}
+
+	// Limit the size of the result set to the page size
+	query = query.Limit(pageSize)
+
+	// All done
+	return query, nil
+}
@@ -0,0 +1,142 @@
+package cartapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	pbcart "github.com/mikebway/poc-gcp-ecomm/pb/cart"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TestListShoppingCarts examines paging through a shopper's carts and filtering them by status and creation time.
+func TestListShoppingCarts(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+	ctx := context.Background()
+	service, err := NewCartService()
+	req.Nil(err, "failed to obtain cart service: %v", err)
+
+	// Give a shopper of our very own three carts: one with an item, one empty, and one abandoned
+	shopper := buildMockShopper()
+	shopper.Id = uuid.NewString()
+	before := time.Now()
+	var cartIds []string
+	for i := 0; i < 3; i++ {
+		createResp, err := service.CreateShoppingCart(ctx, &pbcart.CreateShoppingCartRequest{Shopper: shopper})
+		req.Nil(err, "should not have seen an error creating cart %d: %v", i, err)
+		cartIds = append(cartIds, createResp.Cart.Id)
+	}
+	_, err = service.AddItemToShoppingCart(ctx, &pbcart.AddItemToShoppingCartRequest{CartId: cartIds[0], Item: buildMockCartItem(cartItemProductCode1)})
+	req.Nil(err, "should not have seen an error adding an item: %v", err)
+	_, err = service.AbandonShoppingCart(ctx, &pbcart.AbandonShoppingCartRequest{CartId: cartIds[2]})
+	req.Nil(err, "should not have seen an error abandoning a cart: %v", err)
+
+	// Page through all of them, two at a time
+	listReq := &pbcart.ListShoppingCartsRequest{ShopperId: shopper.Id, PageSize: 2}
+	response, err := service.ListShoppingCarts(ctx, listReq)
+	req.Nil(err, "should not have seen an error listing the first page: %v", err)
+	req.Equal(2, len(response.Carts), "first page should have had two carts")
+	req.Equal(cartIds[0], response.Carts[0].Id, "first cart should have been the oldest")
+	req.Equal(cartIds[1], response.Carts[1].Id, "second cart should have been the next oldest")
+	req.Empty(response.Carts[0].CartItems, "items should not have been included unless asked for")
+	req.NotEmpty(response.NextPageToken, "first page should have had a next page token")
+	listReq.PageToken = response.NextPageToken
+
+	// The token is only good for the query that it came from
+	_, err = service.ListShoppingCarts(ctx, &pbcart.ListShoppingCartsRequest{ShopperId: shopper.Id, PageSize: 3, PageToken: listReq.PageToken})
+	req.Equal(codes.InvalidArgument, status.Code(err), "page token should not have been accepted for a different page size: %v", err)
+	response, err = service.ListShoppingCarts(ctx, listReq)
+	req.Nil(err, "should not have seen an error listing the second page: %v", err)
+	req.Equal(1, len(response.Carts), "second page should have had one cart")
+	req.Equal(cartIds[2], response.Carts[0].Id, "third cart should have been the newest")
+	req.Empty(response.NextPageToken, "second page should not have had a next page token")
+
+	// Just the open ones, with their items this time
+	response, err = service.ListShoppingCarts(ctx, &pbcart.ListShoppingCartsRequest{
+		ShopperId:    shopper.Id,
+		Statuses:     []pbcart.ShoppingCartStatus{pbcart.ShoppingCartStatus_SCS_OPEN},
+		IncludeItems: true,
+	})
+	req.Nil(err, "should not have seen an error listing open carts: %v", err)
+	req.Equal(2, len(response.Carts), "should have had two open carts")
+	req.Equal(cartIds[0], response.Carts[0].Id, "first open cart ID did not match")
+	req.Equal(1, len(response.Carts[0].CartItems), "first open cart should have had its item included")
+	validateCartItem1(req, response.Carts[0].CartItems[0], "listed cart")
+
+	// Nothing was created after now
+	response, err = service.ListShoppingCarts(ctx, &pbcart.ListShoppingCartsRequest{
+		ShopperId: shopper.Id,
+		StartTime: timestamppb.Now(),
+	})
+	req.Nil(err, "should not have seen an error listing future carts: %v", err)
+	req.Empty(response.Carts, "should not have found any carts created in the future")
+
+	// Nor before we started
+	response, err = service.ListShoppingCarts(ctx, &pbcart.ListShoppingCartsRequest{
+		ShopperId: shopper.Id,
+		EndTime:   timestamppb.New(before),
+	})
+	req.Nil(err, "should not have seen an error listing past carts: %v", err)
+	req.Empty(response.Carts, "should not have found any carts created before the test started")
+}
+
+// TestListShoppingCartsInvalid confirms that requests without a shopper ID or with a bad page token are rejected.
+func TestListShoppingCartsInvalid(t *testing.T) {
+
+	// Do the common setup that most of our tests require
+	req, ctx, service, _ := commonTestSetup(t)
+
+	// No shopper
+	response, err := service.ListShoppingCarts(ctx, &pbcart.ListShoppingCartsRequest{})
+	req.NotNil(err, "should have seen an error listing carts without a shopper ID")
+	req.Equal(codes.InvalidArgument, status.Code(err), "missing shopper ID should have been an invalid argument")
+	req.Nil(response, "should not have had a response without a shopper ID")
+
+	// Nonsense page token
+	response, err = service.ListShoppingCarts(ctx, &pbcart.ListShoppingCartsRequest{ShopperId: shopperId, PageToken: "sausages"})
+	req.NotNil(err, "should have seen an error listing carts with a bad page token")
+	req.Equal(codes.InvalidArgument, status.Code(err), "bad page token should have been an invalid argument")
+	req.Contains(err.Error(), "invalid page token: sausages", "did not see the expected page token error")
+	req.Nil(response, "should not have had a response with a bad page token")
+
+	// A page token that was not issued for this query, in this case one hand made in the form that they used to take
+	response, err = service.ListShoppingCarts(ctx, &pbcart.ListShoppingCartsRequest{ShopperId: shopperId, PageToken: "MTczMmIyZjc0YjRjMzQwMCxjYXJ0LTE"})
+	req.Equal(codes.InvalidArgument, status.Code(err), "forged page token should have been an invalid argument: %v", err)
+	req.Nil(response, "should not have had a response with a forged page token")
+}
+
+// TestListShoppingCartsFailure looks at how the code handles errors running the query and loading the cart items.
+func TestListShoppingCartsFailure(t *testing.T) {
+
+	// Do the common setup, including establishing a cart with an item in it
+	req, ctx, service, cart, _ := addFirstItemToCart(t)
+
+	// Have the query fail, asking for a silly page size while we are at it
+	service.queryProxy = &UTQueryExecProxy{Err: mockError}
+	response, err := service.ListShoppingCarts(ctx, &pbcart.ListShoppingCartsRequest{ShopperId: shopperId, PageSize: 1000})
+	req.NotNil(err, "should have seen an error from the query")
+	req.Contains(err.Error(), "failed to retrieve cart matching query", "did not see the expected query error")
+	req.Nil(response, "should not have had a response from a failed query")
+
+	// Have loading the delivery address fail
+	service.queryProxy = &QueryExecProxy{}
+	service.drProxy = &UTDocRefProxy{Err: mockError}
+	response, err = service.ListShoppingCarts(ctx, &pbcart.ListShoppingCartsRequest{ShopperId: cart.Shopper.Id, IncludeItems: true})
+	req.NotNil(err, "should have seen an error loading the delivery address")
+	req.Contains(err.Error(), "failed to retrieve delivery addresses for", "did not see the expected address error")
+	req.Nil(response, "should not have had a response from a failed address load")
+
+	// Have loading the items fail
+	service.drProxy = &DocRefProxy{}
+	service.itemsGetterProxy = &UTItemCollGetterProxy{FsClient: service.FsClient, Err: mockError}
+	response, err = service.ListShoppingCarts(ctx, &pbcart.ListShoppingCartsRequest{ShopperId: cart.Shopper.Id, IncludeItems: true})
+	req.NotNil(err, "should have seen an error loading the cart items")
+	req.Contains(err.Error(), "failed to retrieve cart item for cart with ID", "did not see the expected item error")
+	req.Nil(response, "should not have had a response from a failed item load")
+}
@@ -0,0 +1,202 @@
+package cartapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/google/uuid"
+	"github.com/mikebway/poc-gcp-ecomm/cart/schema"
+	pbcart "github.com/mikebway/poc-gcp-ecomm/pb/cart"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MergeShoppingCarts moves the items and delivery address of the source cart identified in the
+// pbcart.MergeShoppingCartsRequest into the target cart, then marks the source cart as abandoned by the user. This is
+// how the cart that a shopper builds before signing in is consolidated with the cart that they already had.
+//
+// Items in the source cart with the same product code and attributes as an item in the target cart are merged with
+// that item, their quantities being added together. If the source cart has a delivery address, it replaces that of the target cart
+// since it is the more recent expression of the shopper's wishes, and the delivery option chosen for the source cart,
+// if any, replaces that of the target cart along with it. Any promotion codes applied to the source cart are applied
+// to the target cart too.
+//
+// Both carts must be open, and if both have a shopper ID then those IDs must match; a guest cart, with no shopper ID,
+// can be merged into any shopper's cart. Otherwise, a codes.FailedPrecondition status error is returned. All of the
+// changes are made within a single Firestore transaction, so the merge happens entirely or not at all.
+//
+// The stock reserved for the source cart's items is handed over to the target cart, topped up as necessary to cover
+// the merged quantities. If there is not enough stock, a codes.ResourceExhausted status error is returned and
+// neither cart is changed.
+func (cs *CartService) MergeShoppingCarts(ctx context.Context, req *pbcart.MergeShoppingCartsRequest) (*pbcart.MergeShoppingCartsResponse, error) {
+
+	// Obtain a shortcut handle on our globally configured logger then log what we are about to do
+	l := zap.L()
+	l.Info("merging carts", zap.String("cartId", req.CartId), zap.String("sourceCartId", req.SourceCartId))
+
+	// TODO: Access control - shoppers should only be able to merge their own carts
+
+	// We need two different carts to merge
+	if req.CartId == "" || req.SourceCartId == "" {
+		return nil, status.Error(codes.InvalidArgument, "both target and source cart IDs must be specified")
+	}
+	if req.CartId == req.SourceCartId {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot merge a cart into itself: cart ID=%s", req.CartId)
+	}
+
+	// Move everything from the source to the target within the same transaction that confirms both are open
+	movedCount := 0
+	processed, err := cs.updateOpenCart(ctx, req, "merge into", func(tx *firestore.Transaction, target *schema.ShoppingCart) ([]firestore.Update, error) {
+		var updates []firestore.Update
+		var err error
+		movedCount, updates, err = cs.mergeCartInto(tx, req, target)
+		return updates, err
+	})
+	if err != nil {
+		l.Error(err.Error(), zap.String("cartId", req.CartId), zap.String("sourceCartId", req.SourceCartId))
+		return nil, err
+	}
+
+	// All good, log our joy before returning the protocol buffer transliteration of the merged cart
+	if processed == nil {
+		l.Info("carts merged successfully", zap.String("cartId", req.CartId), zap.String("sourceCartId", req.SourceCartId), zap.Int("itemCount", movedCount))
+	}
+
+	// Have our internal sibling do all the remaining work to return the complete cart as it now stands
+	pbCart, err := cs.cartForResponse(ctx, req, req.CartId, processed)
+	if err != nil {
+		return nil, err
+	}
+	return &pbcart.MergeShoppingCartsResponse{Cart: pbCart}, nil
+}
+
+// mergeCartInto is the body of the MergeShoppingCarts transaction. It reads the source cart and everything else that
+// it needs before making any writes, as Firestore transactions require, and returns the number of source items that
+// were moved. The target cart document itself is left for updateOpenCart to touch; the changes to be made to it, for
+// the promotion codes and delivery option brought across from the source cart, are returned for the caller to write.
+func (cs *CartService) mergeCartInto(tx *firestore.Transaction, req *pbcart.MergeShoppingCartsRequest, target *schema.ShoppingCart) (int, []firestore.Update, error) {
+
+	// The source cart has to be open too
+	source, sourceSnap, err := cs.getTransactionalOpenCart(tx, req.SourceCartId, req.SourceEtag, "merge from")
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// Refuse to hand one shopper's items to another
+	sourceShopperId := shopperIdOf(source)
+	targetShopperId := shopperIdOf(target)
+	if sourceShopperId != "" && targetShopperId != "" && sourceShopperId != targetShopperId {
+		return 0, nil, status.Errorf(codes.FailedPrecondition, "cannot merge carts belonging to different shoppers: cart ID=%s, source cart ID=%s", target.Id, source.Id)
+	}
+
+	// Bring across any promotion codes that the target cart does not already have
+	for _, code := range source.PromotionCodes {
+		if indexOfString(target.PromotionCodes, code) < 0 {
+			target.PromotionCodes = append(target.PromotionCodes, code)
+		}
+	}
+
+	// Load the items of both carts and the delivery address of the source
+	sourceItems, err := cs.getTransactionalCartItems(tx, source)
+	if err != nil {
+		return 0, nil, err
+	}
+	targetItems, err := cs.getTransactionalCartItems(tx, target)
+	if err != nil {
+		return 0, nil, err
+	}
+	sourceAddress, err := cs.getTransactionalDeliveryAddress(tx, source)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// Everything has been read, so hand the source cart's stock over to the target, enough for the merged quantities
+	quantities := itemQuantities(sourceItems)
+	for code, quantity := range quantities {
+		quantities[code] = quantity + productQuantity(targetItems, code)
+	}
+	err = cs.transferStock(tx, source.Id, target.Id, quantities)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// Move each source item across, merging it with a target item for the same product and attributes if there is one
+	for _, item := range sourceItems {
+		err = cs.drProxy.TransactionalDelete(cs.FsClient.Doc(item.StoreRefPath()), tx)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed deleting cart item %s from source cart %s in firestore: %w", item.Id, source.Id, err)
+		}
+		if existing := findMergeableItem(targetItems, item); existing != nil {
+			existing.Quantity += item.Quantity
+			err = cs.drProxy.TransactionalUpdate(cs.FsClient.Doc(existing.StoreRefPath()), tx, []firestore.Update{{Path: "quantity", Value: existing.Quantity}})
+			if err != nil {
+				return 0, nil, fmt.Errorf("failed merging cart item into existing item %s in firestore for cart: %w", existing.Id, err)
+			}
+			continue
+		}
+		moved := *item
+		moved.Id = uuid.NewString()
+		moved.CartId = target.Id
+		err = cs.drProxy.TransactionalSet(cs.FsClient.Doc(moved.StoreRefPath()), tx, &moved)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed setting cart item to firestore for cart: %w", err)
+		}
+		targetItems = append(targetItems, &moved)
+	}
+
+	// Move the delivery address across, if there is one, and the delivery option chosen for it
+	var updates []firestore.Update
+	if len(target.PromotionCodes) > 0 {
+		updates = append(updates, firestore.Update{Path: "promotionCodes", Value: target.PromotionCodes})
+	}
+	if sourceAddress != nil {
+		err = cs.drProxy.TransactionalSet(cs.FsClient.Doc(target.DeliveryAddressPath()), tx, sourceAddress)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed setting delivery address to firestore for cart: %w", err)
+		}
+		err = cs.drProxy.TransactionalDelete(cs.FsClient.Doc(source.DeliveryAddressPath()), tx)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed deleting delivery address from source cart %s in firestore: %w", source.Id, err)
+		}
+		if source.DeliveryOption != nil {
+			updates = append(updates, firestore.Update{Path: "deliveryOption", Value: source.DeliveryOption})
+		} else if target.DeliveryOption != nil {
+			updates = append(updates, firestore.Update{Path: "deliveryOption", Value: firestore.Delete})
+		}
+	}
+
+	// Finally, close the source cart, provided nobody else has changed it since we read it
+	now := time.Now()
+	err = cs.drProxy.TransactionalUpdate(sourceSnap.Ref, tx, []firestore.Update{
+		{Path: "status", Value: schema.CsAbandonedByUser},
+		{Path: "closedTime", Value: now},
+		{Path: "modifiedTime", Value: now},
+	}, firestore.LastUpdateTime(sourceSnap.UpdateTime))
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed putting abandoned source cart to datastore with ID %s: %w", source.Id, err)
+	}
+	return len(sourceItems), updates, nil
+}
+
+// findMergeableItem returns the first of the given items that the given item could be merged into, or nil if there
+// is none.
+func findMergeableItem(items []*schema.ShoppingCartItem, item *schema.ShoppingCartItem) *schema.ShoppingCartItem {
+	for _, candidate := range items {
+		if candidate.IsMergeableWith(item) {
+			return candidate
+		}
+	}
+	return nil
+}
+
+// shopperIdOf returns the ID of the shopper that the given cart belongs to, or an empty string if the cart has no
+// shopper or the shopper has no ID, as for a guest.
+func shopperIdOf(cart *schema.ShoppingCart) string {
+	if cart.Shopper == nil {
+		return ""
+	}
+	return cart.Shopper.Id
+}
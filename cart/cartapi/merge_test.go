@@ -0,0 +1,157 @@
+package cartapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mikebway/poc-gcp-ecomm/cart/schema"
+	pbcart "github.com/mikebway/poc-gcp-ecomm/pb/cart"
+	pbtypes "github.com/mikebway/poc-gcp-ecomm/pb/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestMergeShoppingCarts merges a guest cart, with a delivery address and two items, one of which matches an item
+// in the target cart, into a shopper's cart.
+func TestMergeShoppingCarts(t *testing.T) {
+
+	// Start with the shopper's own cart with a single item in it, and a guest cart to merge into it
+	req, ctx, service, target, _ := addFirstItemToCart(t)
+	source := storeGuestCart(ctx, req, service, cartItemProductCode1, cartItemProductCode2)
+	promotion := storePromotion(ctx, req, service, &schema.Promotion{Type: schema.PtPercentOff, PercentOff: 10})
+	_, err := service.ApplyPromotionCode(ctx, &pbcart.ApplyPromotionCodeRequest{CartId: source.Id, PromotionCode: promotion.Code})
+	req.Nil(err, "should not have seen an error applying a promotion code to the guest cart: %v", err)
+	_, err = service.SetDeliveryOption(ctx, &pbcart.SetDeliveryOptionRequest{CartId: source.Id, DeliveryOptionCode: "STANDARD"})
+	req.Nil(err, "should not have seen an error choosing a delivery option for the guest cart: %v", err)
+
+	// Merge the carts
+	response, err := service.MergeShoppingCarts(ctx, &pbcart.MergeShoppingCartsRequest{CartId: target.Id, SourceCartId: source.Id})
+	req.Nil(err, "should not have seen an error merging the carts: %v", err)
+	merged := response.GetCart()
+	req.NotNil(merged, "response should have contained the merged cart")
+	req.Equal(target.Id, merged.Id, "merged cart should have been the target cart")
+	req.Equal(pbcart.ShoppingCartStatus_SCS_OPEN, merged.Status, "merged cart should still be open")
+	req.Equal(shopperId, merged.Shopper.Id, "merged cart should still belong to the shopper")
+	req.NotNil(merged.DeliveryAddress, "merged cart should have taken the delivery address of the guest cart")
+	req.Equal("STANDARD", merged.DeliveryOption.GetCode(), "merged cart should have taken the delivery option of the guest cart")
+	req.Equal([]string{promotion.Code}, merged.PromotionCodes, "merged cart should have taken the promotion code of the guest cart")
+	req.Equal(1, len(merged.Discounts), "merged cart should have earned a discount from the guest cart's promotion code")
+	req.Equal(2, len(merged.CartItems), "merged cart should have had two items")
+	for _, item := range merged.CartItems {
+		req.Equal(target.Id, item.CartId, "merged item %s should have belonged to the target cart", item.ProductCode)
+		if item.ProductCode == cartItemProductCode1 {
+			req.Equal(2*cartItemQuantity1, item.Quantity, "matching items should have been merged")
+		} else {
+			req.Equal(cartItemProductCode2, item.ProductCode, "unexpected item in merged cart")
+			req.Equal(cartItemQuantity2, item.Quantity, "moved item had the wrong quantity")
+		}
+	}
+
+	// The guest cart should have been emptied and abandoned
+	sourceResp, err := service.GetShoppingCartByID(ctx, &pbcart.GetShoppingCartByIDRequest{CartId: source.Id})
+	req.Nil(err, "failed to retrieve the guest cart: %v", err)
+	req.Equal(pbcart.ShoppingCartStatus_SCS_ABANDONED_BY_USER, sourceResp.Cart.Status, "guest cart should have been abandoned")
+	req.NotNil(sourceResp.Cart.ClosedTime, "guest cart should have a closed time")
+	req.Empty(sourceResp.Cart.CartItems, "guest cart should have had its items moved out")
+	req.Nil(sourceResp.Cart.DeliveryAddress, "guest cart should have had its delivery address moved out")
+}
+
+// TestMergeShoppingCartsRejected confirms that merges that make no sense, or that would mix up different shoppers'
+// carts or involve closed carts, are rejected.
+func TestMergeShoppingCartsRejected(t *testing.T) {
+
+	// Do the common setup that most of our tests require
+	req, ctx, service, target := commonTestSetup(t)
+	source := storeGuestCart(ctx, req, service)
+
+	// Missing or identical cart IDs
+	for _, mergeReq := range []*pbcart.MergeShoppingCartsRequest{
+		{CartId: target.Id},
+		{SourceCartId: source.Id},
+		{CartId: target.Id, SourceCartId: target.Id},
+	} {
+		_, err := service.MergeShoppingCarts(ctx, mergeReq)
+		req.NotNil(err, "should have seen an error merging %s into %s", mergeReq.SourceCartId, mergeReq.CartId)
+		req.Equal(codes.InvalidArgument, status.Code(err), "merging %s into %s should have been an invalid argument", mergeReq.SourceCartId, mergeReq.CartId)
+	}
+
+	// Another shopper's cart
+	otherShopper := buildMockShopper()
+	otherShopper.Id = uuid.NewString()
+	otherResp, err := service.CreateShoppingCart(ctx, &pbcart.CreateShoppingCartRequest{Shopper: otherShopper})
+	req.Nil(err, "should not have seen an error creating the other shopper's cart: %v", err)
+	_, err = service.MergeShoppingCarts(ctx, &pbcart.MergeShoppingCartsRequest{CartId: target.Id, SourceCartId: otherResp.Cart.Id})
+	req.NotNil(err, "should have seen an error merging another shopper's cart")
+	req.Equal(codes.FailedPrecondition, status.Code(err), "merging another shopper's cart should have been a failed precondition")
+	req.Contains(err.Error(), "cannot merge carts belonging to different shoppers", "did not see the expected shopper conflict error")
+
+	// A closed source cart
+	_, err = service.AbandonShoppingCart(ctx, &pbcart.AbandonShoppingCartRequest{CartId: otherResp.Cart.Id})
+	req.Nil(err, "should not have seen an error abandoning the other shopper's cart: %v", err)
+	_, err = service.MergeShoppingCarts(ctx, &pbcart.MergeShoppingCartsRequest{CartId: source.Id, SourceCartId: otherResp.Cart.Id})
+	req.NotNil(err, "should have seen an error merging a closed cart")
+	req.Equal(codes.FailedPrecondition, status.Code(err), "merging a closed cart should have been a failed precondition")
+	req.Contains(err.Error(), "cannot merge from cart that is not open", "did not see the expected closed source error")
+
+	// A closed target cart
+	_, err = service.MergeShoppingCarts(ctx, &pbcart.MergeShoppingCartsRequest{CartId: otherResp.Cart.Id, SourceCartId: source.Id})
+	req.NotNil(err, "should have seen an error merging into a closed cart")
+	req.Equal(codes.FailedPrecondition, status.Code(err), "merging into a closed cart should have been a failed precondition")
+	req.Contains(err.Error(), "cannot merge into cart that is not open", "did not see the expected closed target error")
+
+	// A stale source etag
+	_, err = service.MergeShoppingCarts(ctx, &pbcart.MergeShoppingCartsRequest{CartId: target.Id, SourceCartId: source.Id, SourceEtag: "stale"})
+	req.NotNil(err, "should have seen an error merging with a stale source etag")
+	req.Equal(codes.Aborted, status.Code(err), "merging with a stale source etag should have been aborted")
+
+	// None of that should have disturbed the guest cart
+	requireCartStatus(ctx, req, service, source.Id, pbcart.ShoppingCartStatus_SCS_OPEN)
+}
+
+// TestMergeShoppingCartsFailure looks at how the merge handles Firestore errors, confirming that a failure part way
+// through leaves both carts untouched.
+func TestMergeShoppingCartsFailure(t *testing.T) {
+
+	// Do the common setup that most of our tests require
+	req, ctx, service, target := commonTestSetup(t)
+	source := storeGuestCart(ctx, req, service, cartItemProductCode1)
+
+	// Fail reading the source cart, then fail writing the moved item
+	for allowCount, expected := range map[int]string{
+		1: "failed to retrieve cart snapshot with ID " + source.Id,
+		4: "failed setting cart item to firestore for cart",
+	} {
+		service.drProxy = &UTDocRefProxy{Err: mockError, AllowCount: allowCount}
+		_, err := service.MergeShoppingCarts(ctx, &pbcart.MergeShoppingCartsRequest{CartId: target.Id, SourceCartId: source.Id})
+		req.NotNil(err, "should have seen an error with allow count %d", allowCount)
+		req.Contains(err.Error(), expected, "did not see the expected error with allow count %d", allowCount)
+	}
+
+	// Neither cart should have changed
+	service.drProxy = &DocRefProxy{}
+	requireCartStatus(ctx, req, service, source.Id, pbcart.ShoppingCartStatus_SCS_OPEN)
+	response, err := service.GetShoppingCartByID(ctx, &pbcart.GetShoppingCartByIDRequest{CartId: target.Id})
+	req.Nil(err, "failed to retrieve the target cart: %v", err)
+	req.Empty(response.Cart.CartItems, "target cart should not have gained any items")
+}
+
+// storeGuestCart creates a cart for a shopper who has not signed in, with a delivery address and an item for each
+// of the given product codes, and returns it.
+func storeGuestCart(ctx context.Context, req *require.Assertions, service *CartService, productCodes ...string) *pbcart.ShoppingCart {
+
+	// A guest has a name, perhaps, but no ID
+	createResp, err := service.CreateShoppingCart(ctx, &pbcart.CreateShoppingCartRequest{Shopper: &pbtypes.Person{DisplayName: "Guest"}})
+	req.Nil(err, "should not have seen an error creating a guest cart: %v", err)
+	cartId := createResp.Cart.Id
+
+	// Fill it up
+	_, err = service.SetDeliveryAddress(ctx, &pbcart.SetDeliveryAddressRequest{CartId: cartId, DeliveryAddress: buildMockDeliveryAddress()})
+	req.Nil(err, "should not have seen an error setting the guest cart delivery address: %v", err)
+	for _, productCode := range productCodes {
+		_, err = service.AddItemToShoppingCart(ctx, &pbcart.AddItemToShoppingCartRequest{CartId: cartId, Item: buildMockCartItem(productCode)})
+		req.Nil(err, "should not have seen an error adding %s to the guest cart: %v", productCode, err)
+	}
+	return createResp.Cart
+}
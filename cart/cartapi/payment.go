@@ -0,0 +1,73 @@
+package cartapi
+
+import (
+	"context"
+	"errors"
+
+	"cloud.google.com/go/firestore"
+	"github.com/mikebway/poc-gcp-ecomm/cart/schema"
+	"github.com/mikebway/poc-gcp-ecomm/payments"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// authorizePayment works out what the given cart, which is being checked out, comes to and has the shopper's payment
+// of that amount authorized, recording the payment within the given transaction under the ID of the order that the
+// cart will become, i.e. the cart ID. The items, delivery address, discounts, and delivery option of the cart must
+// already have been loaded. A codes.FailedPrecondition status error is returned if the payment is declined.
+//
+// The payment is recorded with a write, so this must be called after all the other reads of the transaction.
+func (cs *CartService) authorizePayment(ctx context.Context, tx *firestore.Transaction, cart *schema.ShoppingCart) (*payments.Payment, error) {
+
+	// The amount is that of the order that the cart will become, tax and all
+	cs.estimateTax([]*schema.ShoppingCart{cart})
+	total, err := cart.CalculateTotal()
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot total cart for payment: cart ID=%s: %v", cart.Id, err)
+	}
+
+	// Ask for the money
+	payment, err := cs.payments.Authorize(ctx, tx, cart.Id, total)
+	if errors.Is(err, payments.ErrPaymentDeclined) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return payment, err
+}
+
+// voidPayments gives up the given payments, authorized by authorizePayment for checkout attempts that failed or were
+// retried, other than any that was recorded in the end; see voidPayment. The same authorization may appear more than
+// once, since the payment provider hands out the same authorization for the same cart and amount; each is only
+// voided once.
+func (cs *CartService) voidPayments(ctx context.Context, authorizations []*payments.Payment) {
+	voided := make(map[string]bool)
+	for _, payment := range authorizations {
+		if !voided[payment.AuthorizationId] {
+			voided[payment.AuthorizationId] = true
+			cs.voidPayment(ctx, payment)
+		}
+	}
+}
+
+// voidPayment gives up a payment authorized by authorizePayment for a checkout attempt that did not go through.
+// Failing to do so is logged, but is not otherwise an error; the authorization will lapse in time.
+//
+// The payment provider hands out the same authorization for the same cart and amount, so a concurrent checkout of
+// the cart that won the race, or a later attempt at the same checkout, may have recorded the very authorization that
+// the failed attempt is holding. The payment is only voided if no such record can be found, lest the order that did
+// go through be left unpaid for.
+func (cs *CartService) voidPayment(ctx context.Context, payment *payments.Payment) {
+	recorded, err := cs.payments.GetPayment(ctx, payment.OrderId)
+	if err != nil {
+		zap.L().Warn("unable to confirm that payment for failed checkout was not recorded, leaving it to lapse", zap.String("cartId", payment.OrderId), zap.Error(err))
+		return
+	}
+	if recorded != nil && recorded.AuthorizationId == payment.AuthorizationId {
+		zap.L().Info("payment for failed checkout was recorded by another checkout of the cart, not voiding it", zap.String("cartId", payment.OrderId))
+		return
+	}
+	err = cs.payments.Void(ctx, payment)
+	if err != nil {
+		zap.L().Warn("unable to void payment for failed checkout", zap.String("cartId", payment.OrderId), zap.Error(err))
+	}
+}
@@ -0,0 +1,92 @@
+package cartapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mikebway/poc-gcp-ecomm/payments"
+	pbcart "github.com/mikebway/poc-gcp-ecomm/pb/cart"
+	"github.com/mikebway/poc-gcp-ecomm/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestCheckoutAuthorizesPayment confirms that checking a cart out authorizes a payment for the cart total, recorded
+// against the ID of the order that the cart will become.
+func TestCheckoutAuthorizesPayment(t *testing.T) {
+
+	// Register a cart with a single item and a delivery address in it
+	req, ctx, service, cart, _ := prepareCartForCheckout(t)
+	req.NotNil(cart.Total, "cart should have had a total to pay")
+
+	// Check it out and the money should have been set aside
+	_, err := service.CheckoutShoppingCart(ctx, &pbcart.CheckoutShoppingCartRequest{CartId: cart.Id})
+	req.Nil(err, "failed to check cart out: %v", err)
+	payment, err := service.payments.GetPayment(ctx, cart.Id)
+	req.Nil(err, "failed to retrieve payment: %v", err)
+	req.NotNil(payment, "payment should have been recorded for the checked out cart")
+	req.Equal(payments.PsAuthorized, payment.Status, "payment should have been authorized")
+	req.Equal(types.MoneyFromPB(cart.Total).String(), payment.Amount.String(), "payment should have been for the cart total")
+}
+
+// TestCheckoutPaymentDeclined confirms that a cart whose payment is declined is not checked out and that no payment
+// is recorded for it.
+func TestCheckoutPaymentDeclined(t *testing.T) {
+
+	// Register a cart with a single item and a delivery address in it, then have the bank turn the shopper down
+	req, ctx, service, cart, _ := prepareCartForCheckout(t)
+	service.payments.Provider = &payments.FakeProvider{CreditLimit: 0}
+
+	// Try to check it out
+	_, err := service.CheckoutShoppingCart(ctx, &pbcart.CheckoutShoppingCartRequest{CartId: cart.Id})
+	req.Equal(codes.FailedPrecondition, status.Code(err), "checkout should have been refused: %v", err)
+	req.Contains(err.Error(), "payment declined", "should have been told that the payment was declined")
+	requireCartStatus(ctx, req, service, cart.Id, pbcart.ShoppingCartStatus_SCS_OPEN)
+	payment, err := service.payments.GetPayment(ctx, cart.Id)
+	req.Nil(err, "failed to look for payment: %v", err)
+	req.Nil(payment, "declined payment should not have been recorded")
+}
+
+// UTVoidCountingProvider is a payment provider that counts the authorizations that it is asked to void.
+type UTVoidCountingProvider struct {
+	payments.FakeProvider
+	voids int
+}
+
+// Void counts the void before letting the FakeProvider do its thing.
+func (p *UTVoidCountingProvider) Void(ctx context.Context, authorizationId string) error {
+	p.voids++
+	return p.FakeProvider.Void(ctx, authorizationId)
+}
+
+// TestVoidPaymentRecordedElsewhere confirms that a failed checkout does not void an authorization that a concurrent
+// checkout of the same cart succeeded in recording, but does void one that was never recorded, including one for a
+// total that has since changed.
+func TestVoidPaymentRecordedElsewhere(t *testing.T) {
+
+	// Register a cart with a single item and a delivery address in it, then check it out
+	req, ctx, service, cart, _ := prepareCartForCheckout(t)
+	provider := &UTVoidCountingProvider{FakeProvider: *payments.NewFakeProvider()}
+	service.payments.Provider = provider
+	_, err := service.CheckoutShoppingCart(ctx, &pbcart.CheckoutShoppingCartRequest{CartId: cart.Id})
+	req.Nil(err, "failed to check cart out: %v", err)
+
+	// The loser of the race holds the same authorization as the winner, which must be left alone
+	recorded, err := service.payments.GetPayment(ctx, cart.Id)
+	req.Nil(err, "failed to retrieve payment: %v", err)
+	service.voidPayment(ctx, &payments.Payment{OrderId: cart.Id, AuthorizationId: recorded.AuthorizationId})
+	req.Equal(0, provider.voids, "recorded payment should not have been voided")
+
+	// But an authorization that never made it into Firestore is given up
+	service.voidPayment(ctx, &payments.Payment{OrderId: cart.Id + "_unrecorded", AuthorizationId: payments.FakeAuthorizationPrefix + "unrecorded"})
+	req.Equal(1, provider.voids, "unrecorded payment should have been voided")
+
+	// As is an authorization for a total that the cart no longer came to by the time the checkout went through,
+	// however many attempts at the checkout held it
+	stale, err := provider.Authorize(ctx, cart.Id, types.NewMoney("USD", 1, 0))
+	req.Nil(err, "failed to authorize stale total: %v", err)
+	req.NotEqual(recorded.AuthorizationId, stale, "stale total should have had its own authorization")
+	stalePayment := &payments.Payment{OrderId: cart.Id, AuthorizationId: stale}
+	service.voidPayments(ctx, []*payments.Payment{stalePayment, stalePayment, recorded})
+	req.Equal(2, provider.voids, "only the stale authorization should have been voided, and only once")
+}
@@ -0,0 +1,231 @@
+package cartapi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/mikebway/poc-gcp-ecomm/cart/schema"
+	pbcart "github.com/mikebway/poc-gcp-ecomm/pb/cart"
+	"github.com/mikebway/poc-gcp-ecomm/types"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxPromotionCodeLength is the longest promotion code that we will accept
+	maxPromotionCodeLength = 64
+)
+
+// ApplyPromotionCode applies the promotion code given in the pbcart.ApplyPromotionCodeRequest to an open cart.
+// Promotion codes are case-insensitive; they are stored and returned in upper case.
+//
+// The promotion must exist and be active at the time that the code is applied, otherwise a codes.NotFound or
+// codes.FailedPrecondition status error is returned respectively. Whether the promotion actually earns a discount,
+// e.g. whether the cart reaches its minimum spend, is worked out afresh every time that the cart is retrieved, so
+// a code can be applied before the cart qualifies for it. Applying a code that the cart already has changes nothing.
+func (cs *CartService) ApplyPromotionCode(ctx context.Context, req *pbcart.ApplyPromotionCodeRequest) (*pbcart.ApplyPromotionCodeResponse, error) {
+
+	// Obtain a shortcut handle on our globally configured logger then log what we are about to do
+	l := zap.L()
+	l.Info("applying promotion code", zap.String("cartId", req.CartId), zap.String("promotionCode", req.PromotionCode))
+
+	// Make sure that the code could be the ID of a promotion document
+	code, err := normalizePromotionCode(req.PromotionCode)
+	if err != nil {
+		return nil, err
+	}
+
+	// Confirm that the promotion can be used and add its code to the cart within the same transaction
+	processed, err := cs.updateOpenCart(ctx, req, "apply promotion code to", func(tx *firestore.Transaction, cart *schema.ShoppingCart) ([]firestore.Update, error) {
+		if indexOfString(cart.PromotionCodes, code) >= 0 {
+			return nil, nil
+		}
+		promotion, err := cs.getTransactionalPromotion(tx, code)
+		if err != nil {
+			return nil, err
+		}
+		if !promotion.IsActive(time.Now()) {
+			return nil, status.Errorf(codes.FailedPrecondition, "promotion is not active: promotion code=%s", code)
+		}
+		return []firestore.Update{{Path: "promotionCodes", Value: append(cart.PromotionCodes, code)}}, nil
+	})
+	if err != nil {
+		l.Error(err.Error(), zap.String("cartId", req.CartId), zap.String("promotionCode", code))
+		return nil, err
+	}
+
+	// All good, log our joy before returning the protocol buffer transliteration of the updated cart
+	if processed == nil {
+		l.Info("promotion code applied successfully", zap.String("cartId", req.CartId), zap.String("promotionCode", code))
+	}
+
+	// Have our internal sibling do all the remaining work to return the complete cart as it now stands
+	pbCart, err := cs.cartForResponse(ctx, req, req.CartId, processed)
+	if err != nil {
+		return nil, err
+	}
+	return &pbcart.ApplyPromotionCodeResponse{Cart: pbCart}, nil
+}
+
+// RemovePromotionCode removes the promotion code given in the pbcart.RemovePromotionCodeRequest from an open cart,
+// along with any discount that it earned. A codes.NotFound status error is returned if the code has not been applied
+// to the cart.
+func (cs *CartService) RemovePromotionCode(ctx context.Context, req *pbcart.RemovePromotionCodeRequest) (*pbcart.RemovePromotionCodeResponse, error) {
+
+	// Obtain a shortcut handle on our globally configured logger then log what we are about to do
+	l := zap.L()
+	l.Info("removing promotion code", zap.String("cartId", req.CartId), zap.String("promotionCode", req.PromotionCode))
+
+	// Codes are stored in upper case so we have to match them that way
+	code, err := normalizePromotionCode(req.PromotionCode)
+	if err != nil {
+		return nil, err
+	}
+
+	// Take the code out of the cart, provided that it is still open and that the code was there to start with
+	processed, err := cs.updateOpenCart(ctx, req, "remove promotion code from", func(tx *firestore.Transaction, cart *schema.ShoppingCart) ([]firestore.Update, error) {
+		i := indexOfString(cart.PromotionCodes, code)
+		if i < 0 {
+			return nil, status.Errorf(codes.NotFound, "promotion code not applied to cart: cart ID=%s, promotion code=%s", cart.Id, code)
+		}
+		remaining := append(append([]string{}, cart.PromotionCodes[:i]...), cart.PromotionCodes[i+1:]...)
+		return []firestore.Update{{Path: "promotionCodes", Value: remaining}}, nil
+	})
+	if err != nil {
+		l.Error(err.Error(), zap.String("cartId", req.CartId), zap.String("promotionCode", code))
+		return nil, err
+	}
+
+	// All good, log our joy before returning the protocol buffer transliteration of the updated cart
+	if processed == nil {
+		l.Info("promotion code removed successfully", zap.String("cartId", req.CartId), zap.String("promotionCode", code))
+	}
+
+	// Have our internal sibling do all the remaining work to return the complete cart as it now stands
+	pbCart, err := cs.cartForResponse(ctx, req, req.CartId, processed)
+	if err != nil {
+		return nil, err
+	}
+	return &pbcart.RemovePromotionCodeResponse{Cart: pbCart}, nil
+}
+
+// normalizePromotionCode returns the given promotion code in the upper case form in which promotion codes are
+// stored, having confirmed that it could be the ID of a Firestore document. A codes.InvalidArgument status error is
+// returned if not.
+func normalizePromotionCode(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if !types.IsValidDocumentId(code, maxPromotionCodeLength) {
+		return "", status.Errorf(codes.InvalidArgument, "invalid promotion code: %s", code)
+	}
+	return code, nil
+}
+
+// getTransactionalPromotion returns the promotion with the given code, read within the given Firestore transaction.
+// A codes.NotFound status error is returned if there is no such promotion.
+func (cs *CartService) getTransactionalPromotion(tx *firestore.Transaction, code string) (*schema.Promotion, error) {
+	promotion := &schema.Promotion{Code: code}
+	snap, err := cs.drProxy.TransactionalGet(cs.FsClient.Doc(promotion.StoreRefPath()), tx)
+	if status.Code(err) == codes.NotFound {
+		return nil, status.Errorf(codes.NotFound, "promotion not found: promotion code=%s", code)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve promotion with code %s: %w", code, err)
+	}
+	return cs.promotionFromSnapshot(promotion, snap)
+}
+
+// getTransactionalPromotions returns the promotions for the codes that have been applied to the given cart, read
+// within the given Firestore transaction. Promotions that no longer exist are quietly left out.
+func (cs *CartService) getTransactionalPromotions(tx *firestore.Transaction, cart *schema.ShoppingCart) ([]*schema.Promotion, error) {
+	var promotions []*schema.Promotion
+	for _, code := range cart.PromotionCodes {
+		promotion, err := cs.getTransactionalPromotion(tx, code)
+		if status.Code(err) == codes.NotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		promotions = append(promotions, promotion)
+	}
+	return promotions, nil
+}
+
+// applyDiscounts works out the discounts earned by the promotion codes applied to each of the given open carts,
+// the items of which must already have been loaded. The promotions of all the carts are fetched in a single
+// Firestore request. Closed carts keep the discounts that were frozen into them when they were checked out.
+func (cs *CartService) applyDiscounts(ctx context.Context, carts []*schema.ShoppingCart) error {
+
+	// Gather up the promotions that we need, each only once however many carts it has been applied to
+	var refs []*firestore.DocumentRef
+	requested := make(map[string]bool)
+	for _, cart := range carts {
+		if cart.Status != schema.CsOpen {
+			continue
+		}
+		for _, code := range cart.PromotionCodes {
+			if !requested[code] {
+				requested[code] = true
+				refs = append(refs, cs.FsClient.Doc((&schema.Promotion{Code: code}).StoreRefPath()))
+			}
+		}
+	}
+
+	// Nothing to do if none of the carts have any promotion codes
+	if len(refs) == 0 {
+		return nil
+	}
+
+	// Ask for all the promotions at once
+	snaps, err := cs.drProxy.GetAll(cs.FsClient, ctx, refs)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve promotions: %w", err)
+	}
+	var promotions []*schema.Promotion
+	for i, snap := range snaps {
+		if !snap.Exists() {
+			continue
+		}
+		promotion, err := cs.promotionFromSnapshot(&schema.Promotion{Code: refs[i].ID}, snap)
+		if err != nil {
+			return err
+		}
+		promotions = append(promotions, promotion)
+	}
+
+	// Now let each cart work out what it has earned
+	now := time.Now()
+	for _, cart := range carts {
+		if cart.Status == schema.CsOpen {
+			cart.Discounts = cart.CalculateDiscounts(promotions, now)
+		}
+	}
+	return nil
+}
+
+// promotionFromSnapshot unmarshals a promotion from the given snapshot into the given structure. The code of the
+// promotion is taken from the structure as given, i.e. from the ID of the document, rather than from the document
+// content.
+func (cs *CartService) promotionFromSnapshot(promotion *schema.Promotion, snap *firestore.DocumentSnapshot) (*schema.Promotion, error) {
+	code := promotion.Code
+	err := cs.dsProxy.DataTo(snap, promotion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal promotion with code %s: %w", code, err)
+	}
+	promotion.Code = code
+	return promotion, nil
+}
+
+// indexOfString returns the index of the given value in the given slice, or -1 if the slice does not contain it.
+func indexOfString(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
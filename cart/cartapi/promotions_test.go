@@ -0,0 +1,155 @@
+package cartapi
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikebway/poc-gcp-ecomm/cart/schema"
+	pbcart "github.com/mikebway/poc-gcp-ecomm/pb/cart"
+	"github.com/mikebway/poc-gcp-ecomm/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestApplyPromotionCode applies and removes promotion codes, confirming that the discounts they earn follow the
+// contents of the cart until it is checked out, when they are frozen.
+func TestApplyPromotionCode(t *testing.T) {
+
+	// Start with a cart holding three gold yoyos, for a subtotal of USD 4955.82
+	req, ctx, service, cart, _ := addFirstItemToCart(t)
+	percentOff := storePromotion(ctx, req, service, &schema.Promotion{Type: schema.PtPercentOff, PercentOff: 10})
+	buyTwoGetOne := storePromotion(ctx, req, service, &schema.Promotion{Type: schema.PtBuyXGetY, BuyQuantity: 2, FreeQuantity: 1, ProductCode: cartItemProductCode2})
+
+	// Apply the percentage code, in lower case, twice over
+	for i := 0; i < 2; i++ {
+		response, err := service.ApplyPromotionCode(ctx, &pbcart.ApplyPromotionCodeRequest{CartId: cart.Id, PromotionCode: strings.ToLower(percentOff.Code)})
+		req.Nil(err, "should not have seen an error applying the percentage code: %v", err)
+		req.Equal([]string{percentOff.Code}, response.Cart.PromotionCodes, "cart should have had the percentage code just once")
+		req.Equal(1, len(response.Cart.Discounts), "cart should have had one discount")
+		req.Equal("USD 495.582", types.MoneyFromPB(response.Cart.Discounts[0].Amount).String(), "percentage discount did not match")
+		req.Equal("USD 4460.238", types.MoneyFromPB(response.Cart.Total).String(), "discounted total did not match")
+	}
+
+	// The buy two get one code earns nothing until there are plastic yoyos in the cart
+	response, err := service.ApplyPromotionCode(ctx, &pbcart.ApplyPromotionCodeRequest{CartId: cart.Id, PromotionCode: buyTwoGetOne.Code})
+	req.Nil(err, "should not have seen an error applying the buy two get one code: %v", err)
+	req.Equal([]string{percentOff.Code, buyTwoGetOne.Code}, response.Cart.PromotionCodes, "cart should have had both codes")
+	req.Equal(1, len(response.Cart.Discounts), "cart should still have had one discount")
+	addResp, err := service.AddItemToShoppingCart(ctx, &pbcart.AddItemToShoppingCartRequest{CartId: cart.Id, Item: buildMockCartItem(cartItemProductCode2)})
+	req.Nil(err, "should not have seen an error adding plastic yoyos: %v", err)
+	req.Equal(2, len(addResp.Cart.Discounts), "cart should have had two discounts once there were plastic yoyos")
+	req.Equal("USD 498.169", types.MoneyFromPB(addResp.Cart.Discounts[0].Amount).String(), "percentage discount should have followed the subtotal")
+	req.Equal(cartItemProductCode2, addResp.Cart.Discounts[1].ProductCode, "second discount should have been for the plastic yoyos")
+	req.Equal("USD 7.96", types.MoneyFromPB(addResp.Cart.Discounts[1].Amount).String(), "four of the thirteen plastic yoyos should have been free")
+
+	// Take the percentage code away again, twice over
+	removeResp, err := service.RemovePromotionCode(ctx, &pbcart.RemovePromotionCodeRequest{CartId: cart.Id, PromotionCode: percentOff.Code})
+	req.Nil(err, "should not have seen an error removing the percentage code: %v", err)
+	req.Equal([]string{buyTwoGetOne.Code}, removeResp.Cart.PromotionCodes, "cart should have had only the buy two get one code left")
+	req.Equal(1, len(removeResp.Cart.Discounts), "cart should have had only one discount left")
+	_, err = service.RemovePromotionCode(ctx, &pbcart.RemovePromotionCodeRequest{CartId: cart.Id, PromotionCode: percentOff.Code})
+	req.NotNil(err, "should have seen an error removing the percentage code a second time")
+	req.Equal(codes.NotFound, status.Code(err), "removing a code that is not applied should have been not found")
+
+	// Check out, then withdraw the promotion; the checked out cart keeps its discount
+	_, err = service.SetDeliveryAddress(ctx, &pbcart.SetDeliveryAddressRequest{CartId: cart.Id, DeliveryAddress: buildMockDeliveryAddress()})
+	req.Nil(err, "should not have seen an error setting the delivery address: %v", err)
+	checkoutResp, err := service.CheckoutShoppingCart(ctx, &pbcart.CheckoutShoppingCartRequest{CartId: cart.Id})
+	req.Nil(err, "should not have seen an error checking out: %v", err)
+	req.Equal(1, len(checkoutResp.Cart.Discounts), "checked out cart should have had its discount")
+	_, err = service.FsClient.Doc(buyTwoGetOne.StoreRefPath()).Delete(ctx)
+	req.Nil(err, "failed to delete the buy two get one promotion: %v", err)
+	getResp, err := service.GetShoppingCartByID(ctx, &pbcart.GetShoppingCartByIDRequest{CartId: cart.Id})
+	req.Nil(err, "should not have seen an error retrieving the checked out cart: %v", err)
+	req.Equal(1, len(getResp.Cart.Discounts), "checked out cart should have kept its discount")
+	req.Equal(checkoutResp.Cart.Total.String(), getResp.Cart.Total.String(), "checked out cart total should not have changed")
+}
+
+// TestApplyPromotionCodeRejected confirms that invalid, unknown and inactive promotion codes, and codes applied to
+// closed carts, are rejected.
+func TestApplyPromotionCodeRejected(t *testing.T) {
+
+	// Do the common setup that most of our tests require
+	req, ctx, service, cart := commonTestSetup(t)
+
+	// Codes that could never be the ID of a promotion document
+	for _, code := range []string{"", " ", "A/B", "__RESERVED__", strings.Repeat("X", maxPromotionCodeLength+1)} {
+		_, err := service.ApplyPromotionCode(ctx, &pbcart.ApplyPromotionCodeRequest{CartId: cart.Id, PromotionCode: code})
+		req.NotNil(err, "should have seen an error applying code %q", code)
+		req.Equal(codes.InvalidArgument, status.Code(err), "code %q should have been an invalid argument", code)
+	}
+
+	// A code for which there is no promotion
+	_, err := service.ApplyPromotionCode(ctx, &pbcart.ApplyPromotionCodeRequest{CartId: cart.Id, PromotionCode: uuid.NewString()})
+	req.NotNil(err, "should have seen an error applying an unknown code")
+	req.Equal(codes.NotFound, status.Code(err), "unknown code should have been not found")
+
+	// Promotions that have expired or not yet started
+	now := time.Now()
+	for _, promotion := range []*schema.Promotion{
+		{Type: schema.PtPercentOff, PercentOff: 10, EndTime: now.Add(-time.Hour)},
+		{Type: schema.PtPercentOff, PercentOff: 10, StartTime: now.Add(time.Hour)},
+	} {
+		storePromotion(ctx, req, service, promotion)
+		_, err = service.ApplyPromotionCode(ctx, &pbcart.ApplyPromotionCodeRequest{CartId: cart.Id, PromotionCode: promotion.Code})
+		req.NotNil(err, "should have seen an error applying an inactive code")
+		req.Equal(codes.FailedPrecondition, status.Code(err), "inactive code should have been a failed precondition")
+		req.Contains(err.Error(), "promotion is not active", "did not see the expected inactive promotion error")
+	}
+
+	// A perfectly good promotion cannot be applied to a closed cart
+	promotion := storePromotion(ctx, req, service, &schema.Promotion{Type: schema.PtPercentOff, PercentOff: 10})
+	_, err = service.AbandonShoppingCart(ctx, &pbcart.AbandonShoppingCartRequest{CartId: cart.Id})
+	req.Nil(err, "should not have seen an error abandoning the cart: %v", err)
+	_, err = service.ApplyPromotionCode(ctx, &pbcart.ApplyPromotionCodeRequest{CartId: cart.Id, PromotionCode: promotion.Code})
+	req.NotNil(err, "should have seen an error applying a code to a closed cart")
+	req.Equal(codes.FailedPrecondition, status.Code(err), "applying a code to a closed cart should have been a failed precondition")
+	req.Contains(err.Error(), "cannot apply promotion code to cart that is not open", "did not see the expected closed cart error")
+}
+
+// TestPromotionFailure looks at how Firestore errors reading promotions are handled.
+func TestPromotionFailure(t *testing.T) {
+
+	// Do the common setup that most of our tests require
+	req, ctx, service, cart, _ := addFirstItemToCart(t)
+	promotion := storePromotion(ctx, req, service, &schema.Promotion{Type: schema.PtAmountOff, AmountOff: types.NewMoney(cartItemPriceCurrency, 5, 0)})
+
+	// Fail reading the promotion, after reading the cart
+	service.drProxy = &UTDocRefProxy{Err: mockError, AllowCount: 1}
+	_, err := service.ApplyPromotionCode(ctx, &pbcart.ApplyPromotionCodeRequest{CartId: cart.Id, PromotionCode: promotion.Code})
+	req.NotNil(err, "should have seen an error reading the promotion")
+	req.Contains(err.Error(), "failed to retrieve promotion with code "+promotion.Code, "did not see the expected promotion read error")
+
+	// Apply the code for real, and give the cart somewhere to go, then fail reading the promotions, after reading the cart, when retrieving the cart
+	service.drProxy = &DocRefProxy{}
+	_, err = service.ApplyPromotionCode(ctx, &pbcart.ApplyPromotionCodeRequest{CartId: cart.Id, PromotionCode: promotion.Code})
+	req.Nil(err, "should not have seen an error applying the code: %v", err)
+	_, err = service.SetDeliveryAddress(ctx, &pbcart.SetDeliveryAddressRequest{CartId: cart.Id, DeliveryAddress: buildMockDeliveryAddress()})
+	req.Nil(err, "should not have seen an error setting the delivery address: %v", err)
+	service.drProxy = &UTDocRefProxy{Err: mockError, AllowCount: 1}
+	_, err = service.GetShoppingCartByID(ctx, &pbcart.GetShoppingCartByIDRequest{CartId: cart.Id})
+	req.NotNil(err, "should have seen an error reading the promotions")
+	req.Contains(err.Error(), "failed to retrieve promotions", "did not see the expected promotions read error")
+
+	// Fail reading the promotion, after reading the cart and its delivery address, during checkout
+	service.drProxy = &UTDocRefProxy{Err: mockError, AllowCount: 2}
+	_, err = service.CheckoutShoppingCart(ctx, &pbcart.CheckoutShoppingCartRequest{CartId: cart.Id})
+	req.NotNil(err, "should have seen an error reading the promotion at checkout")
+	req.Contains(err.Error(), "failed to retrieve promotion with code "+promotion.Code, "did not see the expected checkout promotion read error")
+	service.drProxy = &DocRefProxy{}
+	requireCartStatus(ctx, req, service, cart.Id, pbcart.ShoppingCartStatus_SCS_OPEN)
+}
+
+// storePromotion writes the given promotion to Firestore under a unique code, so that tests do not trip over each
+// other's promotions, and returns it.
+func storePromotion(ctx context.Context, req *require.Assertions, service *CartService, promotion *schema.Promotion) *schema.Promotion {
+	promotion.Code = "UT-" + strings.ToUpper(uuid.NewString())
+	promotion.Description = "A promotion for unit testing"
+	_, err := service.FsClient.Doc(promotion.StoreRefPath()).Set(ctx, promotion)
+	req.Nil(err, "failed to store promotion %s: %v", promotion.Code, err)
+	return promotion
+}
@@ -22,6 +22,7 @@ type ItemCollectionGetterProxy interface {
 // See https://pkg.go.dev/cloud.google.com/go/firestore#CollectionRef
 type ItemsCollectionProxy interface {
 	GetAll(ctx context.Context) DocumentIteratorProxy
+	TransactionalGetAll(tx *firestore.Transaction) DocumentIteratorProxy
 }
 
 // QueryExecutionProxy defines an interface that a Firestore client service can use to obtain an DocumentIteratorProxy
@@ -42,9 +43,14 @@ type DocumentRefProxy interface {
 	Create(doc *firestore.DocumentRef, ctx context.Context, data interface{}) (*firestore.WriteResult, error)
 	TransactionalCreate(doc *firestore.DocumentRef, tx *firestore.Transaction, data interface{}) error
 	Get(doc *firestore.DocumentRef, ctx context.Context) (*firestore.DocumentSnapshot, error)
+	GetAll(client *firestore.Client, ctx context.Context, docs []*firestore.DocumentRef) ([]*firestore.DocumentSnapshot, error)
+	TransactionalGet(doc *firestore.DocumentRef, tx *firestore.Transaction) (*firestore.DocumentSnapshot, error)
 	Set(doc *firestore.DocumentRef, ctx context.Context, data interface{}) (*firestore.WriteResult, error)
+	TransactionalSet(doc *firestore.DocumentRef, tx *firestore.Transaction, data interface{}) error
 	Update(doc *firestore.DocumentRef, ctx context.Context, updates []firestore.Update) (*firestore.WriteResult, error)
+	TransactionalUpdate(doc *firestore.DocumentRef, tx *firestore.Transaction, updates []firestore.Update, preconds ...firestore.Precondition) error
 	Delete(doc *firestore.DocumentRef, ctx context.Context) (*firestore.WriteResult, error)
+	TransactionalDelete(doc *firestore.DocumentRef, tx *firestore.Transaction) error
 }
 
 // DocumentSnapshotProxy defines the interface for a swappable junction that will allow us to maximize unit test
@@ -104,6 +110,15 @@ func (p *ItemsCollProxy) GetAll(ctx context.Context) DocumentIteratorProxy {
 	}
 }
 
+// TransactionalGetAll is a wrapper around the firestore.Transaction Documents function that will return an
+// iterator over the items from a Firestore collection, read within the given transaction.
+func (p *ItemsCollProxy) TransactionalGetAll(tx *firestore.Transaction) DocumentIteratorProxy {
+	return &DocIteratorProxy{
+		docsIterator: tx.Documents(p.ref),
+		dsProxy:      &DocSnapProxy{},
+	}
+}
+
 // QueryExecProxy implements a wrapper function around firestore.Query that will return an iterator over items
 // that match the query.
 type QueryExecProxy struct {
@@ -147,6 +162,20 @@ func (p *DocRefProxy) Get(doc *firestore.DocumentRef, ctx context.Context) (*fir
 	return doc.Get(ctx)
 }
 
+// GetAll is a direct pass through to the firestore.Client GetAll function. We use this rather than calling the
+// firestore.Client function directly so that we can replace this implementation with one that allows errors to be
+// inserted into the response when executing unit tests.
+func (p *DocRefProxy) GetAll(client *firestore.Client, ctx context.Context, docs []*firestore.DocumentRef) ([]*firestore.DocumentSnapshot, error) {
+	return client.GetAll(ctx, docs)
+}
+
+// TransactionalGet is a direct pass through to the firestore.Transaction Get function. We use this rather than
+// calling the firestore.Transaction function directly so that we can replace this implementation with
+// one that allows errors to be inserted into the response when executing unit tests.
+func (p *DocRefProxy) TransactionalGet(doc *firestore.DocumentRef, tx *firestore.Transaction) (*firestore.DocumentSnapshot, error) {
+	return tx.Get(doc)
+}
+
 // Set is a direct pass through to the firestore.DocumentRef Set function. We use this rather than
 // calling the firestore.DocumentRef function directly so that we can replace this implementation with one that
 // allows errors to be inserted into the response when executing unit tests.
@@ -154,10 +183,24 @@ func (p *DocRefProxy) Set(doc *firestore.DocumentRef, ctx context.Context, data
 	return doc.Set(ctx, data)
 }
 
+// TransactionalSet is a direct pass through to the firestore.Transaction Set function. We use this rather than
+// calling the firestore.Transaction function directly so that we can replace this implementation with
+// one that allows errors to be inserted into the response when executing unit tests.
+func (p *DocRefProxy) TransactionalSet(doc *firestore.DocumentRef, tx *firestore.Transaction, data interface{}) error {
+	return tx.Set(doc, data)
+}
+
 func (p *DocRefProxy) Update(doc *firestore.DocumentRef, ctx context.Context, updates []firestore.Update) (*firestore.WriteResult, error) {
 	return doc.Update(ctx, updates)
 }
 
+// TransactionalUpdate is a direct pass through to the firestore.Transaction Update function. We use this rather
+// than calling the firestore.Transaction function directly so that we can replace this implementation with
+// one that allows errors to be inserted into the response when executing unit tests.
+func (p *DocRefProxy) TransactionalUpdate(doc *firestore.DocumentRef, tx *firestore.Transaction, updates []firestore.Update, preconds ...firestore.Precondition) error {
+	return tx.Update(doc, updates, preconds...)
+}
+
 // Delete is a direct pass through to the firestore.DocumentRef Delete function. We use this rather than
 // calling the firestore.DocumentRef function directly so that we can replace this implementation with one that
 // allows errors to be inserted into the response when executing unit tests.
@@ -165,6 +208,13 @@ func (p *DocRefProxy) Delete(doc *firestore.DocumentRef, ctx context.Context) (*
 	return doc.Delete(ctx)
 }
 
+// TransactionalDelete is a direct pass through to the firestore.Transaction Delete function. We use this rather
+// than calling the firestore.Transaction function directly so that we can replace this implementation with
+// one that allows errors to be inserted into the response when executing unit tests.
+func (p *DocRefProxy) TransactionalDelete(doc *firestore.DocumentRef, tx *firestore.Transaction) error {
+	return tx.Delete(doc)
+}
+
 // DocSnapProxy is the production (i.e. non-unit test) implementation of the DocumentSnapshotProxy interface.
 // NewCartService configures it as the default in new CartService structure constructions.
 type DocSnapProxy struct {
@@ -57,6 +57,17 @@ func (p *UTItemsCollProxy) GetAll(ctx context.Context) DocumentIteratorProxy {
 	}
 }
 
+// TransactionalGetAll is a wrapper around the firestore.Transaction Documents function that will return an
+// iterator over shopping cart items from a Firestore cart representation, read within the given transaction.
+func (p *UTItemsCollProxy) TransactionalGetAll(tx *firestore.Transaction) DocumentIteratorProxy {
+	return &UTDocIteratorProxy{
+		DocsIterator: tx.Documents(p.Ref),
+		Err:          p.Err,
+		AllowCount:   p.AllowCount,
+		DsProxy:      &DocSnapProxy{},
+	}
+}
+
 // UTDocRefProxy is a unit test implementation of the DocumentRefProxy interface that allows
 // unit tests to have Firestore operations return errors.
 type UTDocRefProxy struct {
@@ -97,6 +108,34 @@ func (p *UTDocRefProxy) Get(doc *firestore.DocumentRef, ctx context.Context) (*f
 	return doc.Get(ctx)
 }
 
+// GetAll is a pass through to the firestore.Client GetAll function that allows
+// unit tests to have Firestore operations return errors.
+func (p *UTDocRefProxy) GetAll(client *firestore.Client, ctx context.Context, docs []*firestore.DocumentRef) ([]*firestore.DocumentSnapshot, error) {
+
+	// Are we to return an error and if so, do we return it now or after some later call?
+	if p.Err != nil && p.AllowCount <= 0 {
+		return nil, p.Err
+	}
+
+	// We are to allow the call through this time, but maybe not next time
+	p.AllowCount--
+	return client.GetAll(ctx, docs)
+}
+
+// TransactionalGet is a pass through to the firestore.Transaction Get function that allows
+// unit tests to have Firestore operations return errors.
+func (p *UTDocRefProxy) TransactionalGet(doc *firestore.DocumentRef, tx *firestore.Transaction) (*firestore.DocumentSnapshot, error) {
+
+	// Are we to return an error and if so, do we return it now or after some later call?
+	if p.Err != nil && p.AllowCount <= 0 {
+		return nil, p.Err
+	}
+
+	// We are to allow the call through this time, but maybe not next time
+	p.AllowCount--
+	return tx.Get(doc)
+}
+
 // Set is a pass through to the firestore.DocumentRef Set function that allows
 // unit tests to have Firestore operations return errors.
 func (p *UTDocRefProxy) Set(doc *firestore.DocumentRef, ctx context.Context, data interface{}) (*firestore.WriteResult, error) {
@@ -111,6 +150,34 @@ func (p *UTDocRefProxy) Set(doc *firestore.DocumentRef, ctx context.Context, dat
 	return doc.Set(ctx, data)
 }
 
+// TransactionalSet is a pass through to the firestore.Transaction Set function that allows
+// unit tests to have Firestore operations return errors.
+func (p *UTDocRefProxy) TransactionalSet(doc *firestore.DocumentRef, tx *firestore.Transaction, data interface{}) error {
+
+	// Are we to return an error and if so, do we return it now or after some later call?
+	if p.Err != nil && p.AllowCount <= 0 {
+		return p.Err
+	}
+
+	// We are to allow the call through this time, but maybe not next time
+	p.AllowCount--
+	return tx.Set(doc, data)
+}
+
+// TransactionalUpdate is a pass through to the firestore.Transaction Update function that allows
+// unit tests to have Firestore operations return errors.
+func (p *UTDocRefProxy) TransactionalUpdate(doc *firestore.DocumentRef, tx *firestore.Transaction, updates []firestore.Update, preconds ...firestore.Precondition) error {
+
+	// Are we to return an error and if so, do we return it now or after some later call?
+	if p.Err != nil && p.AllowCount <= 0 {
+		return p.Err
+	}
+
+	// We are to allow the call through this time, but maybe not next time
+	p.AllowCount--
+	return tx.Update(doc, updates, preconds...)
+}
+
 // Delete is a pass through to the firestore.DocumentRef Delete function that allows
 // unit tests to have Firestore operations return errors.
 func (p *UTDocRefProxy) Delete(doc *firestore.DocumentRef, ctx context.Context) (*firestore.WriteResult, error) {
@@ -125,6 +192,20 @@ func (p *UTDocRefProxy) Delete(doc *firestore.DocumentRef, ctx context.Context)
 	return doc.Delete(ctx)
 }
 
+// TransactionalDelete is a pass through to the firestore.Transaction Delete function that allows
+// unit tests to have Firestore operations return errors.
+func (p *UTDocRefProxy) TransactionalDelete(doc *firestore.DocumentRef, tx *firestore.Transaction) error {
+
+	// Are we to return an error and if so, do we return it now or after some later call?
+	if p.Err != nil && p.AllowCount <= 0 {
+		return p.Err
+	}
+
+	// We are to allow the call through this time, but maybe not next time
+	p.AllowCount--
+	return tx.Delete(doc)
+}
+
 // UTDocSnapProxy is a unit test implementation of the DocumentSnapshotProxy interface that allows
 // unit tests to have Firestore operations return errors.
 type UTDocSnapProxy struct {
@@ -195,3 +276,26 @@ func (p *UTDocIteratorProxy) Next(target interface{}) error {
 func (p *UTDocIteratorProxy) Stop() {
 	p.DocsIterator.Stop()
 }
+
+// UTQueryExecProxy is a unit test implementation of the QueryExecutionProxy interface that allows
+// unit tests to have Firestore query iterations return errors.
+type UTQueryExecProxy struct {
+	QueryExecutionProxy
+
+	// Err is the error to be returned if it is not nil
+	Err error
+
+	// AllowCount, if greater than zero, is the number of calls to allow before returning the error
+	AllowCount int
+}
+
+// Documents returns a DocumentIteratorProxy wrapping the results of the given query that will return the
+// configured error once the allowed number of documents have been iterated over.
+func (q *UTQueryExecProxy) Documents(ctx context.Context, query firestore.Query) DocumentIteratorProxy {
+	return &UTDocIteratorProxy{
+		DocsIterator: query.Documents(ctx),
+		Err:          q.Err,
+		AllowCount:   q.AllowCount,
+		DsProxy:      &DocSnapProxy{},
+	}
+}
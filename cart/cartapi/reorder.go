@@ -0,0 +1,212 @@
+package cartapi
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	products "github.com/mikebway/poc-gcp-ecomm/catalog/schema"
+	pbcart "github.com/mikebway/poc-gcp-ecomm/pb/cart"
+	pborder "github.com/mikebway/poc-gcp-ecomm/pb/order"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// OrderReader is the part of the order service API that the cart service needs in order to build a new cart from a
+// previous order. It is implemented by orderapi.OrderService and, over gRPC, by OrderAPIReader. The order module
+// depends on this one, so the cart service main reaches the order service over gRPC rather than importing it.
+type OrderReader interface {
+
+	// GetOrderByID retrieves an order matching the specified UUID ID in the pborder.GetOrderByIDRequest.
+	GetOrderByID(ctx context.Context, req *pborder.GetOrderByIDRequest) (*pborder.GetOrderByIDResponse, error)
+}
+
+// OrderAPIReader is an OrderReader that reads orders from the order service through a gRPC client.
+type OrderAPIReader struct {
+
+	// Client is the gRPC client of the order service
+	Client pborder.OrderAPIClient
+}
+
+// GetOrderByID retrieves an order matching the specified UUID ID in the pborder.GetOrderByIDRequest from the order
+// service.
+func (r *OrderAPIReader) GetOrderByID(ctx context.Context, req *pborder.GetOrderByIDRequest) (*pborder.GetOrderByIDResponse, error) {
+	return r.Client.GetOrderByID(ctx, req)
+}
+
+// ReorderFromOrder starts a new shopping cart for the shopper who placed the order identified in the
+// pbcart.ReorderFromOrderRequest, holding the same items and delivery address, so that repeat customers can buy
+// again what they bought before.
+//
+// The items are added to the new cart as though the shopper had added them one at a time: each is priced from the
+// catalog as it stands today, not at the price paid for the order, and stock is reserved for it. Items for products
+// that are no longer in the catalog, that can no longer be added as they were ordered, or for which there is not
+// enough stock, are left out of the cart and listed in the response with the reason that they were skipped. The delivery option chosen for the order, and any promotion
+// codes applied to it, are not carried over; delivery options and promotions may have changed since.
+//
+// If the new cart cannot be filled for any other reason, it is abandoned and the error returned.
+func (cs *CartService) ReorderFromOrder(ctx context.Context, req *pbcart.ReorderFromOrderRequest) (*pbcart.ReorderFromOrderResponse, error) {
+
+	// Obtain a shortcut handle on our globally configured logger then log what we are about to do
+	l := zap.L()
+	l.Info("reordering from order", zap.String("orderId", req.OrderId))
+
+	// TODO: Access control - shoppers should only be able to reorder their own orders
+
+	// We need to know which order to copy and have some way to read it
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order ID must be specified")
+	}
+	if cs.Orders == nil {
+		return nil, status.Error(codes.Unimplemented, "no order service is available to read orders from")
+	}
+
+	// Fetch the order that is to be repeated
+	orderResp, err := cs.Orders.GetOrderByID(ctx, &pborder.GetOrderByIDRequest{OrderId: req.OrderId})
+	if err != nil {
+		l.Error(err.Error(), zap.String("orderId", req.OrderId))
+		return nil, err
+	}
+	order := orderResp.Order
+
+	// Open a new cart for the same shopper
+	createResp, err := cs.CreateShoppingCart(ctx, &pbcart.CreateShoppingCartRequest{Shopper: order.OrderedBy})
+	if err != nil {
+		return nil, err
+	}
+	pbCart := createResp.Cart
+
+	// Fill it and, if that cannot be done, give up on the cart rather than leaving it half full
+	pbCart, skipped, err := cs.fillReorderedCart(ctx, pbCart, order)
+	if err != nil {
+		l.Error(err.Error(), zap.String("orderId", req.OrderId), zap.String("cartId", pbCart.Id))
+		_, abandonErr := cs.AbandonShoppingCart(ctx, &pbcart.AbandonShoppingCartRequest{CartId: pbCart.Id})
+		if abandonErr != nil {
+			l.Warn("unable to abandon cart for failed reorder", zap.String("cartId", pbCart.Id), zap.Error(abandonErr))
+		}
+		return nil, err
+	}
+
+	// All good, log our joy and return the new cart along with the items that did not make it in
+	l.Info("reordered from order successfully", zap.String("orderId", req.OrderId), zap.String("cartId", pbCart.Id),
+		zap.Int("itemCount", len(order.OrderItems)-len(skipped)), zap.Int("skippedCount", len(skipped)))
+	return &pbcart.ReorderFromOrderResponse{
+		Cart:         pbCart,
+		SkippedItems: skipped,
+	}, nil
+}
+
+// fillReorderedCart copies the delivery address and items of the given order to the given new cart, returning the
+// cart as it then stands along with the order items that could not be added to it. An error is only returned for
+// failures other than those of a product being unknown, unavailable, or out of stock.
+func (cs *CartService) fillReorderedCart(ctx context.Context, pbCart *pbcart.ShoppingCart, order *pborder.Order) (*pbcart.ShoppingCart, []*pbcart.SkippedOrderItem, error) {
+
+	// Find out which of the ordered products are no longer in the catalog, so that they can be told apart from
+	// those that cannot be added for other reasons
+	unknown, err := cs.unknownProducts(ctx, order.OrderItems)
+	if err != nil {
+		return pbCart, nil, err
+	}
+
+	// Send the new cart wherever the order was sent
+	if order.DeliveryAddress != nil {
+		resp, err := cs.SetDeliveryAddress(ctx, &pbcart.SetDeliveryAddressRequest{CartId: pbCart.Id, DeliveryAddress: order.DeliveryAddress})
+		if err != nil {
+			return pbCart, nil, err
+		}
+		pbCart = resp.Cart
+	}
+
+	// Add the items one by one so that one that cannot be added does not hold up the others
+	var skipped []*pbcart.SkippedOrderItem
+	for _, orderItem := range order.OrderItems {
+		if unknown[orderItem.ProductCode] {
+			skipped = append(skipped, &pbcart.SkippedOrderItem{
+				OrderItemId: orderItem.Id,
+				ProductCode: orderItem.ProductCode,
+				Quantity:    orderItem.Quantity,
+				Reason:      pbcart.SkippedItemReason_SIR_UNKNOWN_PRODUCT,
+				Detail:      fmt.Sprintf("unknown product: product code=%s", orderItem.ProductCode),
+			})
+			continue
+		}
+		resp, err := cs.AddItemToShoppingCart(ctx, &pbcart.AddItemToShoppingCartRequest{
+			CartId: pbCart.Id,
+			Item: &pbcart.CartItem{
+				ProductCode: orderItem.ProductCode,
+				Quantity:    orderItem.Quantity,
+				Attributes:  orderItem.Attributes,
+			},
+		})
+		if err == nil {
+			pbCart = resp.Cart
+			continue
+		}
+		reason := skippedItemReason(err)
+		if reason == pbcart.SkippedItemReason_SIR_UNSPECIFIED {
+			return pbCart, nil, err
+		}
+		skipped = append(skipped, &pbcart.SkippedOrderItem{
+			OrderItemId: orderItem.Id,
+			ProductCode: orderItem.ProductCode,
+			Quantity:    orderItem.Quantity,
+			Reason:      reason,
+			Detail:      status.Convert(err).Message(),
+		})
+	}
+	return pbCart, skipped, nil
+}
+
+// unknownProducts returns the set of the product codes of the given order items that are not in the catalog, read
+// all at once. Codes that could not be the ID of a product document cannot be in the catalog either.
+func (cs *CartService) unknownProducts(ctx context.Context, items []*pborder.OrderItem) (map[string]bool, error) {
+
+	// Gather up references to the products that might be in the catalog, once each since the same product may have
+	// been ordered more than once with different attributes
+	unknown := make(map[string]bool)
+	seen := make(map[string]bool)
+	var productCodes []string
+	var refs []*firestore.DocumentRef
+	for _, item := range items {
+		if !products.IsValidProductCode(item.ProductCode) {
+			unknown[item.ProductCode] = true
+			continue
+		}
+		if seen[item.ProductCode] {
+			continue
+		}
+		seen[item.ProductCode] = true
+		productCodes = append(productCodes, item.ProductCode)
+		refs = append(refs, cs.FsClient.Doc((&products.Product{Code: item.ProductCode}).StoreRefPath()))
+	}
+	if len(refs) == 0 {
+		return unknown, nil
+	}
+
+	// And see which of them are missing
+	snaps, err := cs.drProxy.GetAll(cs.FsClient, ctx, refs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve ordered products: %w", err)
+	}
+	for i, snap := range snaps {
+		if !snap.Exists() {
+			unknown[productCodes[i]] = true
+		}
+	}
+	return unknown, nil
+}
+
+// skippedItemReason returns the reason for which an order item, for a product that is in the catalog, that
+// AddItemToShoppingCart failed to add to a cart with the given error can be skipped by ReorderFromOrder, or
+// pbcart.SkippedItemReason_SIR_UNSPECIFIED if the error is not one that an item can be skipped for.
+func skippedItemReason(err error) pbcart.SkippedItemReason {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return pbcart.SkippedItemReason_SIR_UNAVAILABLE
+	case codes.ResourceExhausted:
+		return pbcart.SkippedItemReason_SIR_OUT_OF_STOCK
+	default:
+		return pbcart.SkippedItemReason_SIR_UNSPECIFIED
+	}
+}
@@ -0,0 +1,147 @@
+package cartapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	pbcart "github.com/mikebway/poc-gcp-ecomm/pb/cart"
+	pborder "github.com/mikebway/poc-gcp-ecomm/pb/order"
+	pbtypes "github.com/mikebway/poc-gcp-ecomm/pb/types"
+	"github.com/mikebway/poc-gcp-ecomm/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UTOrderReader is a stand-in for the order service that serves up whatever orders a unit test gives it.
+type UTOrderReader struct {
+	orders map[string]*pborder.Order
+}
+
+// GetOrderByID returns the order with the requested ID, or a codes.NotFound status error if there is no such order.
+func (r *UTOrderReader) GetOrderByID(ctx context.Context, req *pborder.GetOrderByIDRequest) (*pborder.GetOrderByIDResponse, error) {
+	order, ok := r.orders[req.OrderId]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "order not found: order ID=%s", req.OrderId)
+	}
+	return &pborder.GetOrderByIDResponse{Order: order}, nil
+}
+
+// TestReorderFromOrder confirms that a new cart is filled with the items and delivery address of a previous order,
+// priced from the catalog as it is now, and that the items that cannot be had any more are reported.
+func TestReorderFromOrder(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Initialize our target cart service, and a product of which there is only one left
+	ctx := context.Background()
+	service, _ := storeMockCart(ctx, req)
+	scarceCode := storeStockedProduct(ctx, req, service, 1)
+
+	// Rupert once bought an engraved gold yoyo, back when it was cheap, two of the scarce product, something that we
+	// no longer sell, and a gold yoyo with an attribute that we would not accept today
+	order := &pborder.Order{
+		Id:              uuid.NewString(),
+		OrderedBy:       buildMockShopper(),
+		DeliveryAddress: buildMockDeliveryAddress(),
+		OrderItems: []*pborder.OrderItem{
+			{
+				Id:          uuid.NewString(),
+				ProductCode: cartItemProductCode1,
+				Quantity:    2,
+				UnitPrice:   types.NewMoney(cartItemPriceCurrency, 1, 0).AsPBMoney(),
+				Attributes:  []*pbtypes.Attribute{{Name: "engraving", Value: "Rupert"}},
+			},
+			{Id: uuid.NewString(), ProductCode: scarceCode, Quantity: 2},
+			{Id: uuid.NewString(), ProductCode: "ut_discontinued_yoyo_" + uuid.NewString(), Quantity: 1},
+			{
+				Id:          uuid.NewString(),
+				ProductCode: cartItemProductCode1,
+				Quantity:    1,
+				Attributes:  []*pbtypes.Attribute{{Value: "nameless"}},
+			},
+		},
+	}
+	service.Orders = &UTOrderReader{orders: map[string]*pborder.Order{order.Id: order}}
+
+	// Have him buy it all again
+	response, err := service.ReorderFromOrder(ctx, &pbcart.ReorderFromOrderRequest{OrderId: order.Id})
+	req.Nil(err, "should not have seen an error reordering: %v", err)
+	cart := response.Cart
+	req.Equal(pbcart.ShoppingCartStatus_SCS_OPEN, cart.Status, "new cart should have been open")
+	req.Equal(shopperId, cart.Shopper.Id, "new cart should have belonged to the shopper who placed the order")
+	req.Equal(addrPostalCode, cart.DeliveryAddress.PostalCode, "new cart should have been going to the same address")
+
+	// Only the gold yoyo made it, at today's price and engraved as before
+	req.Equal(1, len(cart.CartItems), "new cart should have held just the one item")
+	item := cart.CartItems[0]
+	req.Equal(cartItemProductCode1, item.ProductCode, "cart item product code did not match")
+	req.Equal(int32(2), item.Quantity, "cart item quantity did not match")
+	req.Equal(types.NewMoney(cartItemPriceCurrency, cartItemPriceUnits1, cartItemPriceNanos1).String(), types.MoneyFromPB(item.UnitPrice).String(), "cart item should have been priced from the catalog")
+	req.Equal(1, len(item.Attributes), "cart item should have kept its attribute")
+	req.Equal("Rupert", item.Attributes[0].Value, "cart item attribute value did not match")
+
+	// The others were skipped, for the reasons we expected
+	req.Equal(3, len(response.SkippedItems), "unexpected number of skipped items")
+	req.Equal(order.OrderItems[1].Id, response.SkippedItems[0].OrderItemId, "first skipped item should have been the scarce product")
+	req.Equal(pbcart.SkippedItemReason_SIR_OUT_OF_STOCK, response.SkippedItems[0].Reason, "scarce product should have been out of stock")
+	req.Equal(int32(2), response.SkippedItems[0].Quantity, "skipped item quantity did not match")
+	req.Equal(order.OrderItems[2].Id, response.SkippedItems[1].OrderItemId, "second skipped item should have been the discontinued product")
+	req.Equal(pbcart.SkippedItemReason_SIR_UNKNOWN_PRODUCT, response.SkippedItems[1].Reason, "discontinued product should have been unknown")
+	req.Contains(response.SkippedItems[1].Detail, "unknown product", "skipped item detail should have explained the problem")
+	req.Equal(order.OrderItems[3].Id, response.SkippedItems[2].OrderItemId, "third skipped item should have been the unacceptable gold yoyo")
+	req.Equal(pbcart.SkippedItemReason_SIR_UNAVAILABLE, response.SkippedItems[2].Reason, "unacceptable gold yoyo should have been unavailable")
+	req.Contains(response.SkippedItems[2].Detail, "attribute name must be specified", "skipped item detail should have explained the problem")
+	req.Equal(int64(0), reservedQuantity(ctx, req, service, cart.Id, scarceCode), "no stock should have been held for the skipped item")
+}
+
+// UTOrderAPIClient is a stand-in for the gRPC client of the order service that only knows how to get orders.
+type UTOrderAPIClient struct {
+	pborder.OrderAPIClient
+	reader *UTOrderReader
+}
+
+// GetOrderByID has the UTOrderReader that we were given find the order.
+func (c *UTOrderAPIClient) GetOrderByID(ctx context.Context, req *pborder.GetOrderByIDRequest, _ ...grpc.CallOption) (*pborder.GetOrderByIDResponse, error) {
+	return c.reader.GetOrderByID(ctx, req)
+}
+
+// TestOrderAPIReader confirms that orders are read through the order service's gRPC client.
+func TestOrderAPIReader(t *testing.T) {
+	req := require.New(t)
+	order := &pborder.Order{Id: uuid.NewString()}
+	reader := &OrderAPIReader{Client: &UTOrderAPIClient{reader: &UTOrderReader{orders: map[string]*pborder.Order{order.Id: order}}}}
+	response, err := reader.GetOrderByID(context.Background(), &pborder.GetOrderByIDRequest{OrderId: order.Id})
+	req.Nil(err, "should not have seen an error reading the order: %v", err)
+	req.Equal(order.Id, response.Order.Id, "order ID did not match")
+	_, err = reader.GetOrderByID(context.Background(), &pborder.GetOrderByIDRequest{OrderId: uuid.NewString()})
+	req.Equal(codes.NotFound, status.Code(err), "missing order should not have been found: %v", err)
+}
+
+// TestReorderFromOrderFailures confirms that requests that cannot be met are rejected with suitable status codes.
+func TestReorderFromOrderFailures(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Initialize our target cart service, without an order service to start with
+	ctx := context.Background()
+	service, _ := storeMockCart(ctx, req)
+	service.Orders = nil
+
+	// We must be told which order to reorder from
+	_, err := service.ReorderFromOrder(ctx, &pbcart.ReorderFromOrderRequest{})
+	req.Equal(codes.InvalidArgument, status.Code(err), "reorder without an order ID should have been rejected: %v", err)
+
+	// And have somewhere to read it from
+	_, err = service.ReorderFromOrder(ctx, &pbcart.ReorderFromOrderRequest{OrderId: uuid.NewString()})
+	req.Equal(codes.Unimplemented, status.Code(err), "reorder without an order service should have been rejected: %v", err)
+
+	// Orders that do not exist cannot be repeated
+	service.Orders = &UTOrderReader{}
+	_, err = service.ReorderFromOrder(ctx, &pbcart.ReorderFromOrderRequest{OrderId: uuid.NewString()})
+	req.Equal(codes.NotFound, status.Code(err), "reorder of a missing order should have been rejected: %v", err)
+}
@@ -0,0 +1,288 @@
+package cartapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/mikebway/poc-gcp-ecomm/cart/schema"
+	"go.uber.org/zap"
+	"google.golang.org/api/iterator"
+)
+
+const (
+	// DefaultAbandonedCartTTL is how long an open cart may go without any activity before the sweeper considers it
+	// to have been abandoned, if the caller does not say otherwise.
+	DefaultAbandonedCartTTL = 7 * 24 * time.Hour
+
+	// DefaultSweepBatchSize is the number of open carts examined, and at most closed, in each batch if the caller
+	// does not say otherwise.
+	DefaultSweepBatchSize = 100
+
+	// maxSweepBatchSize is the largest batch size that we allow
+	maxSweepBatchSize = 500
+)
+
+var (
+	// maxSweepWrites is the most writes that we make in any one of the transactions that close a batch of carts;
+	// Firestore allows no more than 500. It is a variable so that unit tests can make it smaller.
+	maxSweepWrites = 500
+)
+
+// SweepRequest describes how SweepAbandonedCarts should go about its business.
+type SweepRequest struct {
+	// TTL is how long an open cart may go without activity before it is considered abandoned. If zero or less,
+	// DefaultAbandonedCartTTL is used.
+	TTL time.Duration
+
+	// BatchSize is the number of open carts to be examined, and closed, at a time. If zero or less,
+	// DefaultSweepBatchSize is used.
+	BatchSize int
+
+	// DryRun, if true, causes the sweep to report the carts that it would have closed without actually
+	// closing them.
+	DryRun bool
+}
+
+// SweepResult reports what SweepAbandonedCarts found and what it did about it.
+type SweepResult struct {
+	// DryRun is true if the carts listed in CartIds were not actually closed
+	DryRun bool `json:"dryRun"`
+
+	// Cutoff is the time before which the last activity on an open cart must have taken place for the cart to
+	// be considered abandoned.
+	Cutoff time.Time `json:"cutoff"`
+
+	// Examined is the number of open carts that were looked at
+	Examined int `json:"examined"`
+
+	// CartIds lists the IDs of the carts that were closed or, for a dry run, that would have been closed
+	CartIds []string `json:"cartIds"`
+}
+
+// SweepAbandonedCarts pages through the open carts in the Firestore cart collection, in batches, looking for those
+// that have seen no activity for longer than the requested TTL. Unless the request is for a dry run, each batch of
+// such carts is transitioned to the schema.CsAbandonedByTimeout status in as few transactions as the Firestore
+// limit on the number of writes in a transaction allows.
+//
+// Each cart is re-examined within the transaction that closes it so that a cart that is checked out, abandoned by
+// its shopper, or otherwise modified in the meantime is left alone.
+func (cs *CartService) SweepAbandonedCarts(ctx context.Context, req *SweepRequest) (*SweepResult, error) {
+
+	// Obtain a shortcut handle on our globally configured logger
+	l := zap.L()
+
+	// Fill in the blanks and rein in anything excessive
+	ttl := req.TTL
+	if ttl <= 0 {
+		ttl = DefaultAbandonedCartTTL
+	}
+	batchSize := req.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultSweepBatchSize
+	} else if batchSize > maxSweepBatchSize {
+		l.Warn("excessive sweep batch size adjusted to maximum", zap.Int("requested", batchSize), zap.Int("max", maxSweepBatchSize))
+		batchSize = maxSweepBatchSize
+	}
+
+	// Anything that has not been touched since the cutoff time is fair game
+	result := &SweepResult{
+		DryRun: req.DryRun,
+		Cutoff: time.Now().Add(-ttl),
+	}
+	l.Info("sweeping abandoned carts", zap.Time("cutoff", result.Cutoff), zap.Int("batchSize", batchSize), zap.Bool("dryRun", req.DryRun))
+
+	// Page through the open carts in document ID order
+	query := cs.FsClient.Collection(schema.CartCollectionId).
+		Where("status", "==", schema.CsOpen).
+		OrderBy(firestore.DocumentID, firestore.Asc).
+		Limit(batchSize)
+	lastCartId := ""
+	for {
+		// Load the next batch of open carts
+		pageQuery := query
+		if lastCartId != "" {
+			pageQuery = query.StartAfter(lastCartId)
+		}
+		carts, err := cs.getCartBatch(ctx, pageQuery)
+		if err != nil {
+			l.Error(err.Error())
+			return nil, err
+		}
+		result.Examined += len(carts)
+
+		// Pick out the ones that have been left unattended for too long
+		var staleIds []string
+		for _, cart := range carts {
+			if cart.LastActivityTime().Before(result.Cutoff) {
+				staleIds = append(staleIds, cart.Id)
+			}
+		}
+
+		// Close them, unless we are only looking
+		if len(staleIds) > 0 {
+			if !req.DryRun {
+				staleIds, err = cs.timeoutCarts(ctx, staleIds, result.Cutoff)
+				if err != nil {
+					l.Error(err.Error())
+					return nil, err
+				}
+			}
+			result.CartIds = append(result.CartIds, staleIds...)
+		}
+
+		// A short page means that we have reached the end of the collection
+		if len(carts) < batchSize {
+			break
+		}
+		lastCartId = carts[len(carts)-1].Id
+	}
+
+	// All done, report what we did
+	l.Info("abandoned cart sweep complete", zap.Int("examined", result.Examined), zap.Int("abandoned", len(result.CartIds)), zap.Bool("dryRun", req.DryRun))
+	return result, nil
+}
+
+// getCartBatch runs the given query and returns the shopping carts that it yields, without their delivery
+// addresses or items.
+func (cs *CartService) getCartBatch(ctx context.Context, query firestore.Query) ([]*schema.ShoppingCart, error) {
+
+	// Run the query to obtain an iterator over the matching documents
+	docs := cs.queryProxy.Documents(ctx, query)
+
+	// Close the iterator when we are done with it regardless of whether we are successful or not
+	defer docs.Stop()
+
+	// Do the iteration: gather a slice containing all the internal package cart representations
+	var carts []*schema.ShoppingCart
+	for {
+		// Get the next document, if there is one
+		cart := &schema.ShoppingCart{}
+		err := docs.Next(cart)
+		if err != nil {
+
+			// We are either out of documents or have a real error
+			if err != iterator.Done {
+				return nil, fmt.Errorf("failed to retrieve cart matching query: %w", err)
+			}
+
+			//  For better or worse, we are done with this batch
+			break
+		}
+
+		// Add this one to our result set and loop around for the next
+		carts = append(carts, cart)
+	}
+
+	// All is well if we reach this point
+	return carts, nil
+}
+
+// timeoutCarts transitions the carts with the given IDs to the schema.CsAbandonedByTimeout status, provided that
+// they are still open and have still seen no activity since the given cutoff time. The IDs of the carts that were
+// actually closed are returned.
+//
+// Closing a cart takes one write for the cart and one for each product that it holds stock of, so the carts are
+// closed a chunk at a time, each chunk in a transaction of its own that makes no more than maxSweepWrites writes.
+func (cs *CartService) timeoutCarts(ctx context.Context, cartIds []string, cutoff time.Time) ([]string, error) {
+	var closedIds []string
+	for len(cartIds) > 0 {
+		chunkClosedIds, examined, err := cs.timeoutCartChunk(ctx, cartIds, cutoff)
+		if err != nil {
+			return nil, err
+		}
+		closedIds = append(closedIds, chunkClosedIds...)
+		cartIds = cartIds[examined:]
+	}
+	return closedIds, nil
+}
+
+// timeoutCartChunk transitions as many of the carts with the given IDs, from the first, as it can in a single
+// Firestore transaction of no more than maxSweepWrites writes to the schema.CsAbandonedByTimeout status, provided
+// that they are still open and have still seen no activity since the given cutoff time. The IDs of the carts that
+// were actually closed are returned, along with the number of the given IDs that were dealt with, closed or not;
+// the rest are left for another transaction. At least one cart is always dealt with.
+//
+// Any stock reserved for the items in the closed carts is released. The reservations will long since have lapsed,
+// reservations lasting minutes where carts are swept after days, but there is no sense in leaving them lying around.
+func (cs *CartService) timeoutCartChunk(ctx context.Context, cartIds []string, cutoff time.Time) ([]string, int, error) {
+
+	// Firestore may run our transaction function more than once so the closed list must be rebuilt each time
+	var closedIds []string
+	var examined int
+	err := cs.FsClient.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		closedIds = nil
+		examined = 0
+
+		// Firestore requires that all the reads in a transaction come before any of the writes
+		var refs []*firestore.DocumentRef
+		var updateTimes []time.Time
+		var cartItems [][]*schema.ShoppingCartItem
+		writes := 0
+		for _, cartId := range cartIds {
+
+			// Load the cart as it stands now
+			cart := &schema.ShoppingCart{Id: cartId}
+			ref := cs.FsClient.Doc(cart.StoreRefPath())
+			snap, err := cs.drProxy.TransactionalGet(ref, tx)
+			if err != nil {
+				return fmt.Errorf("failed to retrieve cart snapshot with ID %s: %w", cartId, err)
+			}
+			err = cs.dsProxy.DataTo(snap, cart)
+			if err != nil {
+				return fmt.Errorf("failed to unmarshal cart snapshot with ID %s: %w", cartId, err)
+			}
+
+			// Leave it alone if somebody has done something with it since we found it
+			if cart.Status != schema.CsOpen || !cart.LastActivityTime().Before(cutoff) {
+				examined++
+				continue
+			}
+
+			// Find out what stock it is holding, leaving the cart for the next transaction if closing it would take
+			// this one over its write limit
+			items, err := cs.getTransactionalCartItems(tx, cart)
+			if err != nil {
+				return err
+			}
+			cartWrites := 1 + len(itemQuantities(items))
+			if writes > 0 && writes+cartWrites > maxSweepWrites {
+				break
+			}
+			writes += cartWrites
+			examined++
+			refs = append(refs, ref)
+			cartItems = append(cartItems, items)
+			updateTimes = append(updateTimes, snap.UpdateTime)
+			closedIds = append(closedIds, cartId)
+		}
+
+		// Now close all the carts that are still abandoned
+		now := time.Now()
+		for i, ref := range refs {
+			err := cs.drProxy.TransactionalUpdate(ref, tx, []firestore.Update{
+				{Path: "status", Value: schema.CsAbandonedByTimeout},
+				{Path: "closedTime", Value: now},
+				{Path: "modifiedTime", Value: now},
+			}, firestore.LastUpdateTime(updateTimes[i]))
+			if err != nil {
+				return fmt.Errorf("failed putting abandoned cart to datastore with ID %s: %w", closedIds[i], err)
+			}
+			err = cs.releaseStock(tx, closedIds[i], cartItems[i])
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Note each cart that we closed
+	for _, cartId := range closedIds {
+		zap.L().Info("cart abandoned by timeout", zap.String("cartId", cartId))
+	}
+	return closedIds, examined, nil
+}
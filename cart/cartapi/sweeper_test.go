@@ -0,0 +1,156 @@
+package cartapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikebway/poc-gcp-ecomm/cart/schema"
+	pbcart "github.com/mikebway/poc-gcp-ecomm/pb/cart"
+	"github.com/mikebway/poc-gcp-ecomm/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// sweepTTL is the time to live that we give open carts in our sweeper tests
+	sweepTTL = time.Hour
+)
+
+// TestSweepAbandonedCarts examines both a dry run and a real sweep of a mix of stale and active carts.
+func TestSweepAbandonedCarts(t *testing.T) {
+
+	// Register a cart that has been left alone for a long time, one that was created long ago but touched
+	// recently, and one that is brand new
+	req, ctx, service, freshCart := commonTestSetup(t)
+	longAgo := time.Now().Add(-2 * sweepTTL)
+	staleId := storeAgedCart(ctx, req, service, longAgo, time.Time{})
+	touchedId := storeAgedCart(ctx, req, service, longAgo, time.Now())
+
+	// A dry run should spot the stale cart but leave it open. A batch size of one makes us page through every cart.
+	result, err := service.SweepAbandonedCarts(ctx, &SweepRequest{TTL: sweepTTL, BatchSize: 1, DryRun: true})
+	req.Nil(err, "dry run sweep should not have failed: %v", err)
+	req.True(result.DryRun, "dry run result should say that it was a dry run")
+	req.GreaterOrEqual(result.Examined, 3, "dry run should have examined at least our three open carts")
+	req.Contains(result.CartIds, staleId, "dry run should have reported the stale cart")
+	req.NotContains(result.CartIds, touchedId, "dry run should not have reported the recently touched cart")
+	req.NotContains(result.CartIds, freshCart.Id, "dry run should not have reported the new cart")
+	requireCartStatus(ctx, req, service, staleId, pbcart.ShoppingCartStatus_SCS_OPEN)
+
+	// Now for real
+	result, err = service.SweepAbandonedCarts(ctx, &SweepRequest{TTL: sweepTTL, BatchSize: 2})
+	req.Nil(err, "sweep should not have failed: %v", err)
+	req.False(result.DryRun, "result should not say that it was a dry run")
+	req.Contains(result.CartIds, staleId, "sweep should have closed the stale cart")
+	req.NotContains(result.CartIds, touchedId, "sweep should not have closed the recently touched cart")
+	req.NotContains(result.CartIds, freshCart.Id, "sweep should not have closed the new cart")
+	requireCartStatus(ctx, req, service, staleId, pbcart.ShoppingCartStatus_SCS_ABANDONED_BY_TIMEOUT)
+	requireCartStatus(ctx, req, service, touchedId, pbcart.ShoppingCartStatus_SCS_OPEN)
+	requireCartStatus(ctx, req, service, freshCart.Id, pbcart.ShoppingCartStatus_SCS_OPEN)
+
+	// A second sweep should find nothing of ours to do since the stale cart is no longer open
+	result, err = service.SweepAbandonedCarts(ctx, &SweepRequest{TTL: sweepTTL})
+	req.Nil(err, "second sweep should not have failed: %v", err)
+	req.NotContains(result.CartIds, staleId, "second sweep should not have closed the stale cart again")
+}
+
+// TestSweepDefaults confirms that silly TTL and batch size values are replaced with sensible ones.
+func TestSweepDefaults(t *testing.T) {
+
+	// Do the common setup that most of our tests require
+	req, ctx, service, _ := commonTestSetup(t)
+
+	// Ask for a dry run with no TTL and a huge batch size
+	result, err := service.SweepAbandonedCarts(ctx, &SweepRequest{BatchSize: 1_000_000, DryRun: true})
+	req.Nil(err, "sweep should not have failed: %v", err)
+	req.WithinDuration(time.Now().Add(-DefaultAbandonedCartTTL), result.Cutoff, time.Minute, "cutoff should reflect the default TTL")
+}
+
+// TestSweepWriteLimit confirms that a batch of carts that would take more writes to close than are allowed in a
+// single transaction is closed in several.
+func TestSweepWriteLimit(t *testing.T) {
+
+	// Register three stale carts, each holding two products so that closing one takes three writes
+	req, ctx, service, _ := commonTestSetup(t)
+	var staleIds []string
+	for i := 0; i < 3; i++ {
+		cartId := storeAgedCart(ctx, req, service, time.Now().Add(-2*sweepTTL), time.Time{})
+		for _, productCode := range []string{"sweep_1", "sweep_2"} {
+			item := &schema.ShoppingCartItem{Id: uuid.NewString(), CartId: cartId, ProductCode: productCode, Quantity: 1}
+			_, err := service.FsClient.Doc(item.StoreRefPath()).Set(ctx, item)
+			req.Nil(err, "failed to store aged cart item: %v", err)
+		}
+		staleIds = append(staleIds, cartId)
+	}
+
+	// Allow only enough writes to close one cart at a time
+	originalMaxSweepWrites := maxSweepWrites
+	maxSweepWrites = 4
+	defer func() { maxSweepWrites = originalMaxSweepWrites }()
+
+	// All three should be closed nonetheless
+	result, err := service.SweepAbandonedCarts(ctx, &SweepRequest{TTL: sweepTTL, BatchSize: maxSweepBatchSize})
+	req.Nil(err, "sweep should not have failed: %v", err)
+	for _, cartId := range staleIds {
+		req.Contains(result.CartIds, cartId, "sweep should have closed every stale cart")
+		requireCartStatus(ctx, req, service, cartId, pbcart.ShoppingCartStatus_SCS_ABANDONED_BY_TIMEOUT)
+	}
+}
+
+// TestSweepQueryFailure looks at how the sweeper handles an error while paging through the open carts.
+func TestSweepQueryFailure(t *testing.T) {
+
+	// Do the common setup that most of our tests require then have the query go wrong
+	req, ctx, service, _ := commonTestSetup(t)
+	service.queryProxy = &UTQueryExecProxy{Err: mockError}
+
+	// See what we get
+	result, err := service.SweepAbandonedCarts(ctx, &SweepRequest{TTL: sweepTTL, DryRun: true})
+	req.NotNil(err, "should have seen an error from the query")
+	req.Contains(err.Error(), "failed to retrieve cart matching query", "did not see the expected query error")
+	req.Nil(result, "should not have obtained a result from a failed sweep")
+}
+
+// TestSweepCloseFailure looks at how the sweeper handles an error while closing a stale cart.
+func TestSweepCloseFailure(t *testing.T) {
+
+	// Register a stale cart then have the transaction write fail
+	req, ctx, service, _ := commonTestSetup(t)
+	storeAgedCart(ctx, req, service, time.Now().Add(-2*sweepTTL), time.Time{})
+	service.drProxy = &UTDocRefProxy{Err: mockError, AllowCount: 1}
+
+	// See what we get, using a batch size of one so that the only cart read in the transaction is the stale one
+	result, err := service.SweepAbandonedCarts(ctx, &SweepRequest{TTL: sweepTTL, BatchSize: 1})
+	req.NotNil(err, "should have seen an error closing the stale cart")
+	req.Contains(err.Error(), "failed putting abandoned cart to datastore with ID", "did not see the expected close error")
+	req.Nil(result, "should not have obtained a result from a failed sweep")
+
+	// The read can fail too
+	service.drProxy = &UTDocRefProxy{Err: mockError}
+	result, err = service.SweepAbandonedCarts(ctx, &SweepRequest{TTL: sweepTTL, BatchSize: 1})
+	req.NotNil(err, "should have seen an error reading the stale cart")
+	req.Contains(err.Error(), "failed to retrieve cart snapshot with ID", "did not see the expected read error")
+	req.Nil(result, "should not have obtained a result from a failed sweep")
+}
+
+// storeAgedCart writes an open cart with the given creation and modification times directly to Firestore,
+// bypassing the cart service which would insist on the current time, and returns its ID.
+func storeAgedCart(ctx context.Context, req *require.Assertions, service *CartService, created time.Time, modified time.Time) string {
+	cart := &schema.ShoppingCart{
+		Id:           uuid.NewString(),
+		CreationTime: created,
+		ModifiedTime: modified,
+		Status:       schema.CsOpen,
+		Shopper:      types.PersonFromPB(buildMockShopper()),
+	}
+	_, err := service.FsClient.Doc(cart.StoreRefPath()).Set(ctx, cart)
+	req.Nil(err, "failed to store aged cart: %v", err)
+	return cart.Id
+}
+
+// requireCartStatus retrieves the cart with the given ID and confirms that it has the expected status.
+func requireCartStatus(ctx context.Context, req *require.Assertions, service *CartService, cartId string, expected pbcart.ShoppingCartStatus) {
+	response, err := service.GetShoppingCartByID(ctx, &pbcart.GetShoppingCartByIDRequest{CartId: cartId})
+	req.Nil(err, "failed to retrieve cart %s: %v", cartId, err)
+	req.Equal(expected, response.Cart.Status, "cart %s did not have the expected status", cartId)
+}
@@ -0,0 +1,33 @@
+package cartapi
+
+import (
+	"errors"
+
+	"github.com/mikebway/poc-gcp-ecomm/cart/schema"
+	"github.com/mikebway/poc-gcp-ecomm/cart/tax"
+	"go.uber.org/zap"
+)
+
+// estimateTax estimates the tax due on each of the given open carts, the items, delivery addresses, and discounts of
+// which must already have been loaded. Closed carts are left alone; the tax on a checked out cart is recorded on the
+// order that it becomes.
+//
+// An estimate is a nicety rather than a necessity, so a cart for which no estimate can be made, e.g. because it
+// has no delivery address yet, is simply left without one.
+func (cs *CartService) estimateTax(carts []*schema.ShoppingCart) {
+	for _, cart := range carts {
+		if cart.Status != schema.CsOpen || len(cart.CartItems) == 0 {
+			continue
+		}
+		estimate, err := cart.CalculateTax(cs.taxCalculator)
+		if err != nil {
+
+			// Not having an address yet is nothing to write home about, anything else might be
+			if !errors.Is(err, tax.ErrNoTaxRegion) {
+				zap.L().Warn("unable to estimate tax for cart", zap.String("cartId", cart.Id), zap.Error(err))
+			}
+			continue
+		}
+		cart.EstimatedTax = estimate
+	}
+}
@@ -0,0 +1,46 @@
+package cartapi
+
+import (
+	"testing"
+
+	"github.com/mikebway/poc-gcp-ecomm/cart/tax"
+	pbcart "github.com/mikebway/poc-gcp-ecomm/pb/cart"
+	"github.com/mikebway/poc-gcp-ecomm/types"
+)
+
+// TestEstimatedTax confirms that open carts carry an estimate of their tax once they have a delivery address in a
+// region that the tax table knows about, that the estimate is included in their total, and that the tax is fixed
+// once the cart is checked out.
+func TestEstimatedTax(t *testing.T) {
+
+	// Start with a cart holding three gold yoyos, for a subtotal of USD 4955.82, but no delivery address
+	req, ctx, service, cart, _ := addFirstItemToCart(t)
+	req.Nil(cart.EstimatedTax, "cart without a delivery address should not have had a tax estimate")
+	req.Equal("USD 4955.82", types.MoneyFromPB(cart.Total).String(), "total without tax did not match")
+
+	// Send it to the UK, where the default tax table charges 20% on everything
+	response, err := service.SetDeliveryAddress(ctx, &pbcart.SetDeliveryAddressRequest{CartId: cart.Id, DeliveryAddress: buildMockDeliveryAddress()})
+	req.Nil(err, "should not have seen an error setting the delivery address: %v", err)
+	req.Equal("USD 991.16", types.MoneyFromPB(response.Cart.EstimatedTax).String(), "estimated tax did not match")
+	req.Equal("USD 5946.98", types.MoneyFromPB(response.Cart.Total).String(), "total with tax did not match")
+
+	// A tax table that knows nothing of the UK can make no estimate
+	service.taxCalculator = &tax.TableCalculator{Rates: []*tax.Rate{{RegionCode: "US", BasisPoints: 500}}}
+	getResp, err := service.GetShoppingCartByID(ctx, &pbcart.GetShoppingCartByIDRequest{CartId: cart.Id})
+	req.Nil(err, "should not have seen an error retrieving the cart: %v", err)
+	req.Nil(getResp.Cart.EstimatedTax, "cart in an unknown tax region should not have had a tax estimate")
+
+	// Back to the default table, the tax that the shopper pays for is frozen into the cart as it is checked out ...
+	service.taxCalculator, err = tax.NewDefaultCalculator()
+	req.Nil(err, "failed to load the default tax table: %v", err)
+	checkoutResp, err := service.CheckoutShoppingCart(ctx, &pbcart.CheckoutShoppingCartRequest{CartId: cart.Id})
+	req.Nil(err, "should not have seen an error checking out: %v", err)
+	req.Equal("USD 991.16", types.MoneyFromPB(checkoutResp.Cart.EstimatedTax).String(), "checked out cart tax did not match")
+
+	// ... where it stays, whatever the tax table says later, for the order to record
+	service.taxCalculator = &tax.TableCalculator{Rates: []*tax.Rate{{RegionCode: "GB", BasisPoints: 100}}}
+	getResp, err = service.GetShoppingCartByID(ctx, &pbcart.GetShoppingCartByIDRequest{CartId: cart.Id})
+	req.Nil(err, "should not have seen an error retrieving the checked out cart: %v", err)
+	req.Equal("USD 991.16", types.MoneyFromPB(getResp.Cart.EstimatedTax).String(), "checked out cart tax should not have changed")
+	req.Equal("USD 5946.98", types.MoneyFromPB(getResp.Cart.Total).String(), "checked out cart total should not have changed")
+}
@@ -0,0 +1,110 @@
+package cartapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mikebway/poc-gcp-ecomm/cart/schema"
+	pbcart "github.com/mikebway/poc-gcp-ecomm/pb/cart"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// WatchShoppingCart streams the cart identified in the pbcart.WatchShoppingCartRequest back to the caller, first as
+// it stands when the watch begins and then again every time that the cart or its items change. The stream is
+// closed, without error, after the cart has been sent showing that it is no longer open.
+//
+// Firestore snapshot listeners are placed on the cart document and on its items subcollection. Every change that
+// either reports causes the whole cart to be read afresh, so that what is sent is always internally consistent, but
+// a cart that is identical to the last one sent is not sent again. A single update often touches both the cart
+// document and its items and would otherwise be reported twice.
+func (cs *CartService) WatchShoppingCart(req *pbcart.WatchShoppingCartRequest, stream pbcart.CartAPI_WatchShoppingCartServer) error {
+
+	// Log what we have been asked to do as context for any later logging on this thread
+	l := zap.L()
+	l.Info("watching cart", zap.String("cartId", req.CartId))
+
+	// TODO: Access control - shoppers should only be able to watch their own carts
+
+	// Make sure that our listeners are shut down, however we leave
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	// Have the cart document and its items collection tell us when anything changes
+	cart := &schema.ShoppingCart{Id: req.CartId}
+	changes := make(chan struct{}, 1)
+	failures := make(chan error, 2)
+	cartIter := cs.FsClient.Doc(cart.StoreRefPath()).Snapshots(ctx)
+	itemsIter := cs.FsClient.Collection(cart.ItemCollectionPath()).Snapshots(ctx)
+	go listenForChanges(ctx, func() error { _, err := cartIter.Next(); return err }, cartIter.Stop, changes, failures)
+	go listenForChanges(ctx, func() error { _, err := itemsIter.Next(); return err }, itemsIter.Stop, changes, failures)
+
+	// Loop, sending the cart every time that it changes, until the cart is closed or the caller goes away
+	var lastSent *pbcart.ShoppingCart
+	for {
+
+		// Don't bother reading the cart again if the caller has already gone away
+		if ctx.Err() != nil {
+			l.Info("cart watch ended by caller", zap.String("cartId", req.CartId))
+			return status.FromContextError(ctx.Err()).Err()
+		}
+
+		select {
+		case <-ctx.Done():
+			continue
+
+		case err := <-failures:
+			err = fmt.Errorf("failed listening for changes to cart with ID %s: %w", req.CartId, err)
+			l.Error(err.Error(), zap.String("cartId", req.CartId))
+			return err
+
+		case <-changes:
+			pbCart, err := cs.getShoppingCart(ctx, req.CartId)
+			if err != nil && ctx.Err() != nil {
+				continue // the read failed because the caller went away
+			} else if err != nil {
+				l.Error("failed to retrieve watched cart", zap.String("cartId", req.CartId), zap.Error(err))
+				return err
+			}
+
+			// Only send the cart if it differs from what the caller already has
+			if !proto.Equal(pbCart, lastSent) {
+				err = stream.Send(&pbcart.WatchShoppingCartResponse{Cart: pbCart})
+				if err != nil {
+					l.Error("failed to send watched cart", zap.String("cartId", req.CartId), zap.Error(err))
+					return err
+				}
+				lastSent = pbCart
+			}
+
+			// Once the cart is no longer open, it can no longer change and there is nothing more to watch for
+			if pbCart.Status != pbcart.ShoppingCartStatus_SCS_OPEN {
+				l.Info("cart watch ended by cart closure", zap.String("cartId", req.CartId), zap.Stringer("status", pbCart.Status))
+				return nil
+			}
+		}
+	}
+}
+
+// listenForChanges calls the next function, which waits for the next snapshot from a Firestore listener, until the
+// context is cancelled, posting a notification to the changes channel for each snapshot. We only care that
+// something has changed, not what the change was. Notifications are not queued up behind each other; if one is already
+// waiting, that is enough to cause the cart to be read again. Any error other than that caused by the context being
+// cancelled is posted to the failures channel.
+func listenForChanges(ctx context.Context, next func() error, stop func(), changes chan<- struct{}, failures chan<- error) {
+	defer stop()
+	for {
+		err := next()
+		if err != nil {
+			if ctx.Err() == nil {
+				failures <- err
+			}
+			return
+		}
+		select {
+		case changes <- struct{}{}:
+		default:
+		}
+	}
+}
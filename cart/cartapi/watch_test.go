@@ -0,0 +1,146 @@
+package cartapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	pbcart "github.com/mikebway/poc-gcp-ecomm/pb/cart"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// watchTimeout is how long we are prepared to wait for a watched cart to be sent to us
+	watchTimeout = 5 * time.Second
+)
+
+// UTWatchStream is a unit test implementation of pbcart.CartAPI_WatchShoppingCartServer that passes the carts sent
+// to it down a channel, or fails to send them if Err is set.
+type UTWatchStream struct {
+	grpc.ServerStream
+	Ctx   context.Context
+	Carts chan *pbcart.ShoppingCart
+	Err   error
+}
+
+// Send passes the cart down the Carts channel or returns the configured error.
+func (s *UTWatchStream) Send(resp *pbcart.WatchShoppingCartResponse) error {
+	if s.Err != nil {
+		return s.Err
+	}
+	s.Carts <- resp.Cart
+	return nil
+}
+
+// Context returns the context that the stream was created with.
+func (s *UTWatchStream) Context() context.Context {
+	return s.Ctx
+}
+
+// TestWatchShoppingCart follows a cart as an item is added to it and it is then abandoned.
+func TestWatchShoppingCart(t *testing.T) {
+
+	// Do the common setup, including establishing a cart with an item in it, then start watching it
+	req, ctx, service, cart, _ := addFirstItemToCart(t)
+	stream, done := startWatch(ctx, service, cart.Id)
+
+	// We should be told about the cart as it stands right away
+	watched := receiveWatchedCart(req, stream)
+	req.Equal(cart.Id, watched.Id, "first watched cart had the wrong ID")
+	req.Equal(1, len(watched.CartItems), "first watched cart should have had one item")
+	req.NotEmpty(watched.Etag, "watched carts should have an etag")
+
+	// Add another item and we should hear about that
+	_, err := service.AddItemToShoppingCart(ctx, &pbcart.AddItemToShoppingCartRequest{CartId: cart.Id, Item: buildMockCartItem(cartItemProductCode2)})
+	req.Nil(err, "should not have seen an error adding a second item: %v", err)
+	watched = receiveWatchedCart(req, stream)
+	req.Equal(2, len(watched.CartItems), "second watched cart should have had two items")
+
+	// Abandon the cart and we should hear about that too, after which the watch should end cleanly
+	_, err = service.AbandonShoppingCart(ctx, &pbcart.AbandonShoppingCartRequest{CartId: cart.Id})
+	req.Nil(err, "should not have seen an error abandoning the cart: %v", err)
+	watched = receiveWatchedCart(req, stream)
+	req.Equal(pbcart.ShoppingCartStatus_SCS_ABANDONED_BY_USER, watched.Status, "final watched cart should have been abandoned")
+	req.Nil(waitForWatch(req, done), "watch should have ended without error once the cart was closed")
+}
+
+// TestWatchShoppingCartCancel confirms that the watch ends when the caller goes away.
+func TestWatchShoppingCartCancel(t *testing.T) {
+
+	// Do the common setup that most of our tests require, then start watching the cart
+	req, ctx, service, cart := commonTestSetup(t)
+	ctx, cancel := context.WithCancel(ctx)
+	stream, done := startWatch(ctx, service, cart.Id)
+	receiveWatchedCart(req, stream)
+
+	// Walk away
+	cancel()
+	err := waitForWatch(req, done)
+	req.NotNil(err, "should have seen an error from a cancelled watch")
+	req.Equal(codes.Canceled, status.Code(err), "cancelled watch should have reported that it was cancelled")
+}
+
+// TestWatchShoppingCartFailure looks at how the watch handles carts that do not exist, carts that cannot be read
+// and carts that cannot be sent.
+func TestWatchShoppingCartFailure(t *testing.T) {
+
+	// Do the common setup that most of our tests require
+	req, ctx, service, cart := commonTestSetup(t)
+
+	// A cart that does not exist
+	_, done := startWatch(ctx, service, uuid.NewString())
+	err := waitForWatch(req, done)
+	req.NotNil(err, "should have seen an error watching a cart that does not exist")
+	req.Equal(codes.NotFound, status.Code(err), "missing cart should have been reported as not found")
+
+	// A cart that cannot be read
+	service.drProxy = &UTDocRefProxy{Err: mockError}
+	_, done = startWatch(ctx, service, cart.Id)
+	err = waitForWatch(req, done)
+	req.NotNil(err, "should have seen an error watching a cart that could not be read")
+	req.Contains(err.Error(), "failed to retrieve cart snapshot with ID", "did not see the expected read error")
+
+	// A cart that cannot be sent
+	service.drProxy = &DocRefProxy{}
+	stream := &UTWatchStream{Ctx: ctx, Err: mockError}
+	err = service.WatchShoppingCart(&pbcart.WatchShoppingCartRequest{CartId: cart.Id}, stream)
+	req.NotNil(err, "should have seen an error sending the watched cart")
+	req.Equal(mockError, err, "did not see the expected send error")
+}
+
+// startWatch begins watching the cart with the given ID in the background, returning the stream that the carts will
+// be sent to and a channel that will receive the result of the watch when it ends.
+func startWatch(ctx context.Context, service *CartService, cartId string) (*UTWatchStream, chan error) {
+	stream := &UTWatchStream{Ctx: ctx, Carts: make(chan *pbcart.ShoppingCart, 10)}
+	done := make(chan error, 1)
+	go func() {
+		done <- service.WatchShoppingCart(&pbcart.WatchShoppingCartRequest{CartId: cartId}, stream)
+	}()
+	return stream, done
+}
+
+// receiveWatchedCart waits for the next cart to be sent to the stream, failing the test if none arrives in time.
+func receiveWatchedCart(req *require.Assertions, stream *UTWatchStream) *pbcart.ShoppingCart {
+	select {
+	case cart := <-stream.Carts:
+		return cart
+	case <-time.After(watchTimeout):
+		req.Fail("timed out waiting for a watched cart")
+		return nil
+	}
+}
+
+// waitForWatch waits for a watch to end, failing the test if it does not end in time, and returns its result.
+func waitForWatch(req *require.Assertions, done chan error) error {
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(watchTimeout):
+		req.Fail("timed out waiting for the watch to end")
+		return nil
+	}
+}
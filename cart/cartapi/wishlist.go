@@ -0,0 +1,523 @@
+package cartapi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/google/uuid"
+	"github.com/mikebway/poc-gcp-ecomm/cart/schema"
+	pbcart "github.com/mikebway/poc-gcp-ecomm/pb/cart"
+	"github.com/mikebway/poc-gcp-ecomm/types"
+	"go.uber.org/zap"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SaveForLater moves the item identified in the pbcart.SaveForLaterRequest out of the cart and onto the wishlist of
+// the shopper who owns the cart, releasing the stock reserved for it. If the wishlist already has an item for the
+// same product and attributes, the quantities are added together.
+//
+// The cart must be open and must belong to a shopper with an ID; guest carts have nobody's wishlist to save to, so
+// a codes.FailedPrecondition status error is returned for them. A codes.NotFound status error is returned if the
+// cart has no such item. The cart and wishlist are changed within a single Firestore transaction.
+func (cs *CartService) SaveForLater(ctx context.Context, req *pbcart.SaveForLaterRequest) (*pbcart.SaveForLaterResponse, error) {
+
+	// Obtain a shortcut handle on our globally configured logger then log what we are about to do
+	l := zap.L()
+	l.Info("saving cart item for later", zap.String("cartId", req.CartId), zap.String("itemId", req.ItemId))
+
+	// We have to know which item is to be saved
+	if req.ItemId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "cart item ID must be specified: cart ID=%s", req.CartId)
+	}
+
+	// Move the item across within the same transaction that confirms the cart is open
+	processed, err := cs.updateOpenCart(ctx, req, "save item for later from", func(tx *firestore.Transaction, cart *schema.ShoppingCart) ([]firestore.Update, error) {
+		return nil, cs.saveTransactionalItemForLater(tx, cart, req.ItemId)
+	})
+	if err != nil {
+		l.Error(err.Error(), zap.String("cartId", req.CartId), zap.String("itemId", req.ItemId))
+		return nil, err
+	}
+	if processed == nil {
+		l.Info("cart item saved for later successfully", zap.String("cartId", req.CartId), zap.String("itemId", req.ItemId))
+	}
+
+	// Have our internal siblings do all the remaining work to return the cart and wishlist as they now stand
+	pbCart, err := cs.cartForResponse(ctx, req, req.CartId, processed)
+	if err != nil {
+		return nil, err
+	}
+	wishlist, err := cs.loadWishlist(ctx, pbCart.GetShopper().GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &pbcart.SaveForLaterResponse{Cart: pbCart, Wishlist: wishlist.AsPBWishlist()}, nil
+}
+
+// saveTransactionalItemForLater is the body of the SaveForLater transaction. It reads everything that it needs
+// before making any writes, as Firestore transactions require. The cart document itself is left for updateOpenCart
+// to touch.
+func (cs *CartService) saveTransactionalItemForLater(tx *firestore.Transaction, cart *schema.ShoppingCart, itemId string) error {
+
+	// Only shoppers that we know have wishlists
+	shopperId := shopperIdOf(cart)
+	if shopperId == "" {
+		return status.Errorf(codes.FailedPrecondition, "cannot save items for later from a guest cart: cart ID=%s", cart.Id)
+	}
+
+	// Find the item among the cart's items; we need them all to work out how much stock to keep for the others
+	items, err := cs.getTransactionalCartItems(tx, cart)
+	if err != nil {
+		return err
+	}
+	var target *schema.ShoppingCartItem
+	for _, item := range items {
+		if item.Id == itemId {
+			target = item
+		}
+	}
+	if target == nil {
+		return status.Errorf(codes.NotFound, "cart item not found: cart ID=%s, item ID=%s", cart.Id, itemId)
+	}
+
+	// Load the wishlist that the item is going to
+	wishlist, exists, err := cs.getTransactionalWishlist(tx, shopperId)
+	if err != nil {
+		return err
+	}
+	wishlistItems, err := cs.getTransactionalWishlistItems(tx, wishlist)
+	if err != nil {
+		return err
+	}
+
+	// Everything has been read, so let go of the stock held for the item, keeping enough for any other items for the
+	// same product
+	reserved := productQuantity(items, target.ProductCode) - int64(target.Quantity)
+	err = cs.reserveStock(tx, cart.Id, map[string]int64{target.ProductCode: reserved})
+	if err != nil {
+		return err
+	}
+
+	// Then move the item across
+	err = cs.drProxy.TransactionalDelete(cs.FsClient.Doc(target.StoreRefPath()), tx)
+	if err != nil {
+		return fmt.Errorf("failed deleting cart item from firestore: %w", err)
+	}
+	saved := schema.WishlistItemFromCartItem(shopperId, uuid.NewString(), target, time.Now())
+	return cs.putTransactionalWishlistItem(tx, wishlist, exists, wishlistItems, saved)
+}
+
+// MoveToCart moves the wishlist item identified in the pbcart.MoveToCartRequest off the wishlist of the shopper who
+// owns the cart and into the cart. The item is added to the cart just as AddItemToShoppingCart would add it: priced
+// from the catalog, with stock reserved for it, and merged with any existing cart item for the same product and
+// attributes.
+//
+// The cart must be open and must belong to a shopper with an ID, otherwise a codes.FailedPrecondition status error
+// is returned. A codes.NotFound status error is returned if the shopper's wishlist has no such item, and the same
+// errors as AddItemToShoppingCart if the product is no longer in the catalog or is out of stock, in which case the
+// item stays on the wishlist. The cart and wishlist are changed within a single Firestore transaction.
+func (cs *CartService) MoveToCart(ctx context.Context, req *pbcart.MoveToCartRequest) (*pbcart.MoveToCartResponse, error) {
+
+	// Obtain a shortcut handle on our globally configured logger then log what we are about to do
+	l := zap.L()
+	l.Info("moving wishlist item to cart", zap.String("cartId", req.CartId), zap.String("wishlistItemId", req.WishlistItemId))
+
+	// We have to know which item is to be moved
+	if req.WishlistItemId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "wishlist item ID must be specified: cart ID=%s", req.CartId)
+	}
+
+	// Move the item across within the same transaction that confirms the cart is open
+	processed, err := cs.updateOpenCart(ctx, req, "move item to", func(tx *firestore.Transaction, cart *schema.ShoppingCart) ([]firestore.Update, error) {
+		return nil, cs.moveTransactionalItemToCart(tx, cart, req.WishlistItemId)
+	})
+	if err != nil {
+		l.Error(err.Error(), zap.String("cartId", req.CartId), zap.String("wishlistItemId", req.WishlistItemId))
+		return nil, err
+	}
+	if processed == nil {
+		l.Info("wishlist item moved to cart successfully", zap.String("cartId", req.CartId), zap.String("wishlistItemId", req.WishlistItemId))
+	}
+
+	// Have our internal siblings do all the remaining work to return the cart and wishlist as they now stand
+	pbCart, err := cs.cartForResponse(ctx, req, req.CartId, processed)
+	if err != nil {
+		return nil, err
+	}
+	wishlist, err := cs.loadWishlist(ctx, pbCart.GetShopper().GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &pbcart.MoveToCartResponse{Cart: pbCart, Wishlist: wishlist.AsPBWishlist()}, nil
+}
+
+// moveTransactionalItemToCart is the body of the MoveToCart transaction. It reads the wishlist item before
+// addTransactionalItem does its reads and writes, and only then removes the item from the wishlist, as Firestore
+// transactions require. The cart document itself is left for updateOpenCart to touch.
+func (cs *CartService) moveTransactionalItemToCart(tx *firestore.Transaction, cart *schema.ShoppingCart, wishlistItemId string) error {
+
+	// Only shoppers that we know have wishlists
+	shopperId := shopperIdOf(cart)
+	if shopperId == "" {
+		return status.Errorf(codes.FailedPrecondition, "cannot move wishlist items to a guest cart: cart ID=%s", cart.Id)
+	}
+
+	// Find the item on the shopper's wishlist
+	wishlistItem := &schema.WishlistItem{ShopperId: shopperId, Id: wishlistItemId}
+	ref := cs.FsClient.Doc(wishlistItem.StoreRefPath())
+	snap, err := cs.drProxy.TransactionalGet(ref, tx)
+	if status.Code(err) == codes.NotFound {
+		return status.Errorf(codes.NotFound, "wishlist item not found: shopper ID=%s, item ID=%s", shopperId, wishlistItemId)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to retrieve wishlist item snapshot with ID %s: %w", wishlistItemId, err)
+	}
+	err = cs.dsProxy.DataTo(snap, wishlistItem)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal wishlist item snapshot with ID %s: %w", wishlistItemId, err)
+	}
+
+	// Put it in the cart
+	_, err = cs.addTransactionalItem(tx, cart, wishlistItem.AsShoppingCartItem(cart.Id, uuid.NewString()))
+	if err != nil {
+		return err
+	}
+
+	// And take it off the wishlist
+	err = cs.drProxy.TransactionalDelete(ref, tx)
+	if err != nil {
+		return fmt.Errorf("failed deleting wishlist item from firestore: %w", err)
+	}
+	wishlist := &schema.Wishlist{ShopperId: shopperId}
+	err = cs.drProxy.TransactionalUpdate(cs.FsClient.Doc(wishlist.StoreRefPath()), tx, []firestore.Update{{Path: "modifiedTime", Value: time.Now()}})
+	if err != nil {
+		return fmt.Errorf("failed putting updated wishlist to datastore for shopper %s: %w", shopperId, err)
+	}
+	return nil
+}
+
+// GetWishlist retrieves the wishlist of the shopper identified in the pbcart.GetWishlistRequest. A shopper who has
+// never added anything to their wishlist has an empty one, without a share ID.
+func (cs *CartService) GetWishlist(ctx context.Context, req *pbcart.GetWishlistRequest) (*pbcart.GetWishlistResponse, error) {
+
+	// Obtain a shortcut handle on our globally configured logger and log some context information
+	l := zap.L()
+	l.Info("retrieving wishlist", zap.String("shopperId", req.ShopperId))
+
+	// TODO: Access control - shoppers should only be able to see their own wishlists, other than by sharing them
+
+	// Have our internal sibling do all the hard work
+	err := validateShopperId(req.ShopperId)
+	if err != nil {
+		return nil, err
+	}
+	wishlist, err := cs.loadWishlist(ctx, req.ShopperId)
+	if err != nil {
+		l.Error(err.Error(), zap.String("shopperId", req.ShopperId))
+		return nil, err
+	}
+
+	// All good, log our joy and return the protocol buffer transliteration of the wishlist
+	l.Info("wishlist retrieved successfully", zap.String("shopperId", req.ShopperId), zap.Int("itemCount", len(wishlist.Items)))
+	return &pbcart.GetWishlistResponse{Wishlist: wishlist.AsPBWishlist()}, nil
+}
+
+// AddToWishlist adds the item in the pbcart.AddToWishlistRequest to the wishlist of the shopper identified in the
+// request, creating the wishlist if the shopper does not yet have one. If the wishlist already has an item for the
+// same product and attributes, the quantities are added together.
+//
+// The product must be in the product catalog, and attribute names must be given and must be unique within the
+// item, otherwise a codes.InvalidArgument status error is returned. A quantity of zero is taken to mean one.
+func (cs *CartService) AddToWishlist(ctx context.Context, req *pbcart.AddToWishlistRequest) (*pbcart.AddToWishlistResponse, error) {
+
+	// Obtain a shortcut handle on our globally configured logger
+	l := zap.L()
+	l.Info("adding wishlist item", zap.String("shopperId", req.ShopperId))
+
+	// TODO: Access control - shoppers should only be able to change their own wishlists
+
+	// Make sure that we have been asked for something that could go on a wishlist
+	err := validateShopperId(req.ShopperId)
+	if err != nil {
+		return nil, err
+	}
+	if req.Item == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "wishlist item must be specified: shopper ID=%s", req.ShopperId)
+	}
+	item := schema.WishlistItemFromPB(req.ShopperId, req.Item)
+	item.Id = uuid.NewString()
+	item.AddedTime = time.Now()
+	if item.Quantity < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "wishlist item quantity cannot be negative: product code=%s", item.ProductCode)
+	}
+	if item.Quantity == 0 {
+		item.Quantity = 1
+	}
+	err = validateItemAttributes(item.AsShoppingCartItem("", item.Id))
+	if err != nil {
+		return nil, err
+	}
+
+	// Add the item within a transaction so that concurrent additions for the same product are merged correctly
+	err = cs.FsClient.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+
+		// The product must be one that we sell
+		_, err := cs.getTransactionalProduct(tx, item.ProductCode)
+		if err != nil {
+			return err
+		}
+
+		// Load the wishlist, or start a new one, and add the item to it
+		wishlist, exists, err := cs.getTransactionalWishlist(tx, req.ShopperId)
+		if err != nil {
+			return err
+		}
+		items, err := cs.getTransactionalWishlistItems(tx, wishlist)
+		if err != nil {
+			return err
+		}
+		return cs.putTransactionalWishlistItem(tx, wishlist, exists, items, item)
+	})
+	if err != nil {
+		l.Error(err.Error(), zap.String("shopperId", req.ShopperId))
+		return nil, err
+	}
+
+	// All good, log our joy and return the wishlist as it now stands
+	l.Info("wishlist item added successfully", zap.String("shopperId", req.ShopperId), zap.String("itemId", item.Id))
+	wishlist, err := cs.loadWishlist(ctx, req.ShopperId)
+	if err != nil {
+		return nil, err
+	}
+	return &pbcart.AddToWishlistResponse{Wishlist: wishlist.AsPBWishlist()}, nil
+}
+
+// RemoveFromWishlist removes the item identified in the pbcart.RemoveFromWishlistRequest from the wishlist of the
+// shopper identified in the request. Removing an item that is not on the wishlist is not an error.
+func (cs *CartService) RemoveFromWishlist(ctx context.Context, req *pbcart.RemoveFromWishlistRequest) (*pbcart.RemoveFromWishlistResponse, error) {
+
+	// Obtain a shortcut handle on our globally configured logger then log what we are about to do
+	l := zap.L()
+	l.Info("removing wishlist item", zap.String("shopperId", req.ShopperId), zap.String("itemId", req.ItemId))
+
+	// TODO: Access control - shoppers should only be able to change their own wishlists
+
+	// We have to know whose item is to be removed, and which
+	err := validateShopperId(req.ShopperId)
+	if err != nil {
+		return nil, err
+	}
+	if req.ItemId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "wishlist item ID must be specified: shopper ID=%s", req.ShopperId)
+	}
+
+	// Remove the item, touching the wishlist if there is one
+	err = cs.FsClient.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		wishlist, exists, err := cs.getTransactionalWishlist(tx, req.ShopperId)
+		if err != nil || !exists {
+			return err
+		}
+		item := &schema.WishlistItem{ShopperId: req.ShopperId, Id: req.ItemId}
+		err = cs.drProxy.TransactionalDelete(cs.FsClient.Doc(item.StoreRefPath()), tx)
+		if err != nil {
+			return fmt.Errorf("failed deleting wishlist item from firestore: %w", err)
+		}
+		err = cs.drProxy.TransactionalUpdate(cs.FsClient.Doc(wishlist.StoreRefPath()), tx, []firestore.Update{{Path: "modifiedTime", Value: time.Now()}})
+		if err != nil {
+			return fmt.Errorf("failed putting updated wishlist to datastore for shopper %s: %w", req.ShopperId, err)
+		}
+		return nil
+	})
+	if err != nil {
+		l.Error(err.Error(), zap.String("shopperId", req.ShopperId), zap.String("itemId", req.ItemId))
+		return nil, err
+	}
+
+	// All good, log our joy and return the wishlist as it now stands
+	l.Info("wishlist item removed successfully", zap.String("shopperId", req.ShopperId), zap.String("itemId", req.ItemId))
+	wishlist, err := cs.loadWishlist(ctx, req.ShopperId)
+	if err != nil {
+		return nil, err
+	}
+	return &pbcart.RemoveFromWishlistResponse{Wishlist: wishlist.AsPBWishlist()}, nil
+}
+
+// GetSharedWishlist retrieves the wishlist with the share ID given in the pbcart.GetSharedWishlistRequest. This is
+// how a shopper lets friends and family see what they would like; the ID of the shopper is left out of the
+// response. A codes.NotFound status error is returned if there is no wishlist with the share ID.
+func (cs *CartService) GetSharedWishlist(ctx context.Context, req *pbcart.GetSharedWishlistRequest) (*pbcart.GetSharedWishlistResponse, error) {
+
+	// Obtain a shortcut handle on our globally configured logger and log some context information
+	l := zap.L()
+	l.Info("retrieving shared wishlist", zap.String("shareId", req.ShareId))
+
+	// We have to be told which wishlist to look for
+	if req.ShareId == "" {
+		return nil, status.Error(codes.InvalidArgument, "wishlist share ID must be specified")
+	}
+
+	// Look for the wishlist with the share ID
+	query := cs.FsClient.Collection(schema.WishlistCollectionId).Where("shareId", "==", req.ShareId).Limit(1)
+	docs := cs.queryProxy.Documents(ctx, query)
+	defer docs.Stop()
+	wishlist := &schema.Wishlist{}
+	err := docs.Next(wishlist)
+	if err == iterator.Done {
+		return nil, status.Errorf(codes.NotFound, "wishlist not found: share ID=%s", req.ShareId)
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to retrieve wishlist with share ID %s: %w", req.ShareId, err)
+		l.Error(err.Error(), zap.String("shareId", req.ShareId))
+		return nil, err
+	}
+
+	// Fill in its items
+	wishlist.Items, err = cs.getWishlistItems(ctx, wishlist)
+	if err != nil {
+		l.Error(err.Error(), zap.String("shareId", req.ShareId))
+		return nil, err
+	}
+
+	// All good, log our joy and return the wishlist without giving away whose it is
+	l.Info("shared wishlist retrieved successfully", zap.String("shareId", req.ShareId), zap.Int("itemCount", len(wishlist.Items)))
+	pbWishlist := wishlist.AsPBWishlist()
+	pbWishlist.ShopperId = ""
+	return &pbcart.GetSharedWishlistResponse{Wishlist: pbWishlist}, nil
+}
+
+// loadWishlist retrieves the wishlist, complete with its items, of the shopper with the given ID. An empty wishlist
+// is returned if the shopper does not have one.
+func (cs *CartService) loadWishlist(ctx context.Context, shopperId string) (*schema.Wishlist, error) {
+
+	// Ask the firestore client for the wishlist document, going without if there is none
+	wishlist := &schema.Wishlist{ShopperId: shopperId}
+	snap, err := cs.drProxy.Get(cs.FsClient.Doc(wishlist.StoreRefPath()), ctx)
+	if status.Code(err) == codes.NotFound {
+		return wishlist, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve wishlist snapshot for shopper %s: %w", shopperId, err)
+	}
+	err = cs.dsProxy.DataTo(snap, wishlist)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal wishlist snapshot for shopper %s: %w", shopperId, err)
+	}
+
+	// Then its items
+	wishlist.Items, err = cs.getWishlistItems(ctx, wishlist)
+	if err != nil {
+		return nil, err
+	}
+	return wishlist, nil
+}
+
+// getTransactionalWishlist reads the wishlist document of the shopper with the given ID within the given Firestore
+// transaction, without its items. If the shopper does not have a wishlist yet, a new one is returned, with a new
+// share ID, for the caller to store, and the returned boolean is false.
+func (cs *CartService) getTransactionalWishlist(tx *firestore.Transaction, shopperId string) (*schema.Wishlist, bool, error) {
+	wishlist := &schema.Wishlist{ShopperId: shopperId}
+	snap, err := cs.drProxy.TransactionalGet(cs.FsClient.Doc(wishlist.StoreRefPath()), tx)
+	if status.Code(err) == codes.NotFound {
+		wishlist.ShareId = uuid.NewString()
+		return wishlist, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to retrieve wishlist snapshot for shopper %s: %w", shopperId, err)
+	}
+	err = cs.dsProxy.DataTo(snap, wishlist)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to unmarshal wishlist snapshot for shopper %s: %w", shopperId, err)
+	}
+	return wishlist, true, nil
+}
+
+// getWishlistItems returns the items of the given wishlist, oldest first.
+func (cs *CartService) getWishlistItems(ctx context.Context, wishlist *schema.Wishlist) ([]*schema.WishlistItem, error) {
+	docs := cs.itemsGetterProxy.Items(wishlist.ItemCollectionPath()).GetAll(ctx)
+	return collectWishlistItems(docs, wishlist)
+}
+
+// getTransactionalWishlistItems returns the items of the given wishlist, read within the given Firestore
+// transaction, oldest first.
+func (cs *CartService) getTransactionalWishlistItems(tx *firestore.Transaction, wishlist *schema.Wishlist) ([]*schema.WishlistItem, error) {
+	docs := cs.itemsGetterProxy.Items(wishlist.ItemCollectionPath()).TransactionalGetAll(tx)
+	return collectWishlistItems(docs, wishlist)
+}
+
+// collectWishlistItems walks the given wishlist item document iterator, gathering all the items and sorting them
+// into the order in which they were added. The iterator is stopped before returning.
+func collectWishlistItems(docs DocumentIteratorProxy, wishlist *schema.Wishlist) ([]*schema.WishlistItem, error) {
+	defer docs.Stop()
+	var items []*schema.WishlistItem
+	for {
+		item := &schema.WishlistItem{}
+		err := docs.Next(item)
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve wishlist item for shopper %s: %w", wishlist.ShopperId, err)
+		}
+		items = append(items, item)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].AddedTime.Before(items[j].AddedTime)
+	})
+	return items, nil
+}
+
+// putTransactionalWishlistItem writes the given item to the given wishlist within the given Firestore transaction,
+// merging it with one of the wishlist's existing items for the same product and attributes if there is one. The
+// wishlist document is stored if it does not already exist, otherwise it is touched.
+func (cs *CartService) putTransactionalWishlistItem(tx *firestore.Transaction, wishlist *schema.Wishlist, exists bool, items []*schema.WishlistItem, item *schema.WishlistItem) error {
+
+	// Store or touch the wishlist
+	now := time.Now()
+	ref := cs.FsClient.Doc(wishlist.StoreRefPath())
+	var err error
+	if exists {
+		err = cs.drProxy.TransactionalUpdate(ref, tx, []firestore.Update{{Path: "modifiedTime", Value: now}})
+	} else {
+		wishlist.CreationTime = now
+		wishlist.ModifiedTime = now
+		err = cs.drProxy.TransactionalSet(ref, tx, wishlist)
+	}
+	if err != nil {
+		return fmt.Errorf("failed putting wishlist to datastore for shopper %s: %w", wishlist.ShopperId, err)
+	}
+
+	// Add the item's quantity to an existing item if there is one like it
+	for _, existing := range items {
+		if existing.IsMergeableWith(item) {
+			err = cs.drProxy.TransactionalUpdate(cs.FsClient.Doc(existing.StoreRefPath()), tx, []firestore.Update{{Path: "quantity", Value: existing.Quantity + item.Quantity}})
+			if err != nil {
+				return fmt.Errorf("failed merging wishlist item into existing item %s in firestore: %w", existing.Id, err)
+			}
+			return nil
+		}
+	}
+
+	// Otherwise, it is a new item
+	err = cs.drProxy.TransactionalSet(cs.FsClient.Doc(item.StoreRefPath()), tx, item)
+	if err != nil {
+		return fmt.Errorf("failed setting wishlist item to firestore: %w", err)
+	}
+	return nil
+}
+
+// validateShopperId returns a codes.InvalidArgument status error if the given shopper ID is missing or could not
+// be used as the ID of a wishlist document.
+func validateShopperId(shopperId string) error {
+	if shopperId == "" {
+		return status.Error(codes.InvalidArgument, "shopper ID must be specified")
+	}
+	if !types.IsValidDocumentId(shopperId, types.MaxDocumentIdLength) {
+		return status.Errorf(codes.InvalidArgument, "invalid shopper ID: %s", shopperId)
+	}
+	return nil
+}
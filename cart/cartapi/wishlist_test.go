@@ -0,0 +1,179 @@
+package cartapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	pbcart "github.com/mikebway/poc-gcp-ecomm/pb/cart"
+	pbtypes "github.com/mikebway/poc-gcp-ecomm/pb/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// wishlistTestSetup establishes a cart for a brand new shopper, so that the wishlist of the shopper is not shared
+// with any other test, and returns everything that the wishlist tests need.
+func wishlistTestSetup(t *testing.T) (*require.Assertions, context.Context, *CartService, *pbcart.ShoppingCart) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Initialize our target cart service, with the products that our tests need in the catalog
+	ctx := context.Background()
+	service, _ := storeMockCart(ctx, req)
+
+	// Open a cart for a shopper of our very own
+	shopper := buildMockShopper()
+	shopper.Id = uuid.NewString()
+	createResp, err := service.CreateShoppingCart(ctx, &pbcart.CreateShoppingCartRequest{Shopper: shopper})
+	req.Nil(err, "should not have seen an error creating a new cart: %v", err)
+	return req, ctx, service, createResp.Cart
+}
+
+// TestSaveForLaterAndMoveToCart confirms that items can be moved from a cart to the shopper's wishlist and back,
+// with their stock being released and reserved again as they go.
+func TestSaveForLaterAndMoveToCart(t *testing.T) {
+
+	// Put two of a stock controlled product in a new shopper's cart
+	req, ctx, service, cart := wishlistTestSetup(t)
+	code := storeStockedProduct(ctx, req, service, 3)
+	cart, err := addStockedItem(ctx, service, cart.Id, code, 2)
+	req.Nil(err, "should not have seen an error adding the stocked item: %v", err)
+	itemId := cart.CartItems[0].Id
+
+	// Save them for later
+	saveResp, err := service.SaveForLater(ctx, &pbcart.SaveForLaterRequest{CartId: cart.Id, ItemId: itemId})
+	req.Nil(err, "should not have seen an error saving the item for later: %v", err)
+	req.Equal(0, len(saveResp.Cart.CartItems), "the item should have left the cart")
+	req.Equal(int64(0), reservedQuantity(ctx, req, service, cart.Id, code), "the stock held for the item should have been released")
+	wishlist := saveResp.Wishlist
+	req.Equal(cart.Shopper.Id, wishlist.ShopperId, "wishlist should have belonged to the cart's shopper")
+	req.NotEmpty(wishlist.ShareId, "new wishlist should have been given a share ID")
+	req.Equal(1, len(wishlist.Items), "the item should have been on the wishlist")
+	req.Equal(code, wishlist.Items[0].ProductCode, "wishlist item product code did not match")
+	req.Equal(int32(2), wishlist.Items[0].Quantity, "wishlist item quantity did not match")
+
+	// It cannot be saved twice
+	_, err = service.SaveForLater(ctx, &pbcart.SaveForLaterRequest{CartId: cart.Id, ItemId: itemId})
+	req.Equal(codes.NotFound, status.Code(err), "saving an item that is no longer in the cart should have failed: %v", err)
+
+	// Move it back into the cart
+	moveResp, err := service.MoveToCart(ctx, &pbcart.MoveToCartRequest{CartId: cart.Id, WishlistItemId: wishlist.Items[0].Id})
+	req.Nil(err, "should not have seen an error moving the item to the cart: %v", err)
+	req.Equal(1, len(moveResp.Cart.CartItems), "the item should have been back in the cart")
+	req.Equal(int32(2), moveResp.Cart.CartItems[0].Quantity, "cart item quantity did not match")
+	req.NotNil(moveResp.Cart.CartItems[0].UnitPrice, "cart item should have been priced from the catalog")
+	req.Equal(int64(2), reservedQuantity(ctx, req, service, cart.Id, code), "the stock for the item should have been reserved again")
+	req.Equal(0, len(moveResp.Wishlist.Items), "the item should have left the wishlist")
+	req.Equal(wishlist.ShareId, moveResp.Wishlist.ShareId, "wishlist share ID should not have changed")
+
+	// And it cannot be moved twice
+	_, err = service.MoveToCart(ctx, &pbcart.MoveToCartRequest{CartId: cart.Id, WishlistItemId: wishlist.Items[0].Id})
+	req.Equal(codes.NotFound, status.Code(err), "moving an item that is no longer on the wishlist should have failed: %v", err)
+}
+
+// TestMoveToCartOutOfStock confirms that a wishlist item stays on the wishlist if there is not enough stock to move
+// it to the cart.
+func TestMoveToCartOutOfStock(t *testing.T) {
+
+	// Wish for more of a product than there is
+	req, ctx, service, cart := wishlistTestSetup(t)
+	code := storeStockedProduct(ctx, req, service, 1)
+	addResp, err := service.AddToWishlist(ctx, &pbcart.AddToWishlistRequest{ShopperId: cart.Shopper.Id, Item: &pbcart.WishlistItem{ProductCode: code, Quantity: 2}})
+	req.Nil(err, "should not have seen an error adding to the wishlist: %v", err)
+
+	// The move fails and nothing changes
+	_, err = service.MoveToCart(ctx, &pbcart.MoveToCartRequest{CartId: cart.Id, WishlistItemId: addResp.Wishlist.Items[0].Id})
+	req.Equal(codes.ResourceExhausted, status.Code(err), "moving more than there is in stock should have failed: %v", err)
+	getResp, err := service.GetWishlist(ctx, &pbcart.GetWishlistRequest{ShopperId: cart.Shopper.Id})
+	req.Nil(err, "should not have seen an error retrieving the wishlist: %v", err)
+	req.Equal(1, len(getResp.Wishlist.Items), "the item should have stayed on the wishlist")
+	requireCartItemCount(ctx, req, service, cart.Id, 0)
+}
+
+// TestWishlistGuestCart confirms that guest carts, which have no shopper to own a wishlist, cannot save items for
+// later or have items moved to them, and that requests missing an item ID are rejected.
+func TestWishlistGuestCart(t *testing.T) {
+
+	// Put an item in a guest cart
+	req, ctx, service, _ := wishlistTestSetup(t)
+	createResp, err := service.CreateShoppingCart(ctx, &pbcart.CreateShoppingCartRequest{Shopper: &pbtypes.Person{}})
+	req.Nil(err, "should not have seen an error creating a guest cart: %v", err)
+	cart, err := addStockedItem(ctx, service, createResp.Cart.Id, cartItemProductCode1, 1)
+	req.Nil(err, "should not have seen an error adding an item to the guest cart: %v", err)
+
+	// Neither way works
+	_, err = service.SaveForLater(ctx, &pbcart.SaveForLaterRequest{CartId: cart.Id, ItemId: cart.CartItems[0].Id})
+	req.Equal(codes.FailedPrecondition, status.Code(err), "saving for later from a guest cart should have failed: %v", err)
+	_, err = service.MoveToCart(ctx, &pbcart.MoveToCartRequest{CartId: cart.Id, WishlistItemId: uuid.NewString()})
+	req.Equal(codes.FailedPrecondition, status.Code(err), "moving to a guest cart should have failed: %v", err)
+
+	// Nor does not saying which item
+	_, err = service.SaveForLater(ctx, &pbcart.SaveForLaterRequest{CartId: cart.Id})
+	req.Equal(codes.InvalidArgument, status.Code(err), "saving for later without an item ID should have failed: %v", err)
+	_, err = service.MoveToCart(ctx, &pbcart.MoveToCartRequest{CartId: cart.Id})
+	req.Equal(codes.InvalidArgument, status.Code(err), "moving to the cart without an item ID should have failed: %v", err)
+}
+
+// TestWishlistMaintenance exercises adding items to, and removing them from, a wishlist directly, and sharing it.
+func TestWishlistMaintenance(t *testing.T) {
+
+	// A new shopper has an empty wishlist that nobody can see
+	req, ctx, service, cart := wishlistTestSetup(t)
+	shopperId := cart.Shopper.Id
+	getResp, err := service.GetWishlist(ctx, &pbcart.GetWishlistRequest{ShopperId: shopperId})
+	req.Nil(err, "should not have seen an error retrieving an empty wishlist: %v", err)
+	req.Equal(0, len(getResp.Wishlist.Items), "new shopper should have had an empty wishlist")
+	req.Empty(getResp.Wishlist.ShareId, "empty wishlist should not have had a share ID")
+
+	// Wish for a gold yoyo, then two more
+	item := &pbcart.WishlistItem{ProductCode: cartItemProductCode1}
+	_, err = service.AddToWishlist(ctx, &pbcart.AddToWishlistRequest{ShopperId: shopperId, Item: item})
+	req.Nil(err, "should not have seen an error adding to the wishlist: %v", err)
+	item.Quantity = 2
+	addResp, err := service.AddToWishlist(ctx, &pbcart.AddToWishlistRequest{ShopperId: shopperId, Item: item})
+	req.Nil(err, "should not have seen an error adding to the wishlist again: %v", err)
+	req.Equal(1, len(addResp.Wishlist.Items), "the yoyos should have been merged into one item")
+	req.Equal(int32(3), addResp.Wishlist.Items[0].Quantity, "merged wishlist item quantity did not match")
+	req.NotNil(addResp.Wishlist.Items[0].AddedTime, "wishlist item should have had an added time")
+
+	// Products that we do not sell cannot be wished for, nor can negative quantities or nothing at all
+	_, err = service.AddToWishlist(ctx, &pbcart.AddToWishlistRequest{ShopperId: shopperId, Item: &pbcart.WishlistItem{ProductCode: "ut_unknown_yoyo_" + uuid.NewString()}})
+	req.Equal(codes.InvalidArgument, status.Code(err), "adding an unknown product should have failed: %v", err)
+	_, err = service.AddToWishlist(ctx, &pbcart.AddToWishlistRequest{ShopperId: shopperId, Item: &pbcart.WishlistItem{ProductCode: cartItemProductCode1, Quantity: -1}})
+	req.Equal(codes.InvalidArgument, status.Code(err), "adding a negative quantity should have failed: %v", err)
+	_, err = service.AddToWishlist(ctx, &pbcart.AddToWishlistRequest{ShopperId: shopperId})
+	req.Equal(codes.InvalidArgument, status.Code(err), "adding nothing should have failed: %v", err)
+
+	// Share it, without giving away whose it is
+	sharedResp, err := service.GetSharedWishlist(ctx, &pbcart.GetSharedWishlistRequest{ShareId: addResp.Wishlist.ShareId})
+	req.Nil(err, "should not have seen an error retrieving the shared wishlist: %v", err)
+	req.Empty(sharedResp.Wishlist.ShopperId, "shared wishlist should not have revealed the shopper ID")
+	req.Equal(1, len(sharedResp.Wishlist.Items), "shared wishlist should have had the item")
+	_, err = service.GetSharedWishlist(ctx, &pbcart.GetSharedWishlistRequest{ShareId: uuid.NewString()})
+	req.Equal(codes.NotFound, status.Code(err), "retrieving an unknown shared wishlist should have failed: %v", err)
+	_, err = service.GetSharedWishlist(ctx, &pbcart.GetSharedWishlistRequest{})
+	req.Equal(codes.InvalidArgument, status.Code(err), "retrieving a shared wishlist without a share ID should have failed: %v", err)
+
+	// Change of heart, twice over
+	itemId := addResp.Wishlist.Items[0].Id
+	removeResp, err := service.RemoveFromWishlist(ctx, &pbcart.RemoveFromWishlistRequest{ShopperId: shopperId, ItemId: itemId})
+	req.Nil(err, "should not have seen an error removing from the wishlist: %v", err)
+	req.Equal(0, len(removeResp.Wishlist.Items), "the item should have left the wishlist")
+	_, err = service.RemoveFromWishlist(ctx, &pbcart.RemoveFromWishlistRequest{ShopperId: shopperId, ItemId: itemId})
+	req.Nil(err, "should not have seen an error removing from the wishlist a second time: %v", err)
+
+	// Finally, shopper IDs have to be specified, and be usable
+	_, err = service.GetWishlist(ctx, &pbcart.GetWishlistRequest{})
+	req.Equal(codes.InvalidArgument, status.Code(err), "retrieving a wishlist without a shopper ID should have failed: %v", err)
+	_, err = service.RemoveFromWishlist(ctx, &pbcart.RemoveFromWishlistRequest{ShopperId: "not/valid", ItemId: itemId})
+	req.Equal(codes.InvalidArgument, status.Code(err), "removing from a wishlist with an invalid shopper ID should have failed: %v", err)
+}
+
+// requireCartItemCount fails the test if the cart with the given ID does not hold the given number of items.
+func requireCartItemCount(ctx context.Context, req *require.Assertions, service *CartService, cartId string, count int) {
+	getResp, err := service.GetShoppingCartByID(ctx, &pbcart.GetShoppingCartByIDRequest{CartId: cartId})
+	req.Nil(err, "should not have seen an error retrieving the cart: %v", err)
+	req.Equal(count, len(getResp.Cart.CartItems), "unexpected number of cart items")
+}
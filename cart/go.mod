@@ -3,29 +3,33 @@ module github.com/mikebway/poc-gcp-ecomm/cart
 go 1.19
 
 require (
-	cloud.google.com/go/firestore v1.8.0
+	cloud.google.com/go/firestore v1.9.0
 	github.com/google/uuid v1.3.0
-	github.com/mikebway/poc-gcp-ecomm/pb v0.0.0-20230106151957-dedb32a889cf
-	github.com/mikebway/poc-gcp-ecomm/testutil v0.0.0-20221129030511-0a76472b6df9
-	github.com/mikebway/poc-gcp-ecomm/types v0.0.0-20230106151957-dedb32a889cf
+	github.com/mikebway/poc-gcp-ecomm/catalog v0.0.0-20261017014604-dec16ff83702
+	github.com/mikebway/poc-gcp-ecomm/inventory v0.0.0-20261017014604-dec16ff83702
+	github.com/mikebway/poc-gcp-ecomm/paging v0.0.0-20261017014604-dec16ff83702
+	github.com/mikebway/poc-gcp-ecomm/payments v0.0.0-20261017014604-dec16ff83702
+	github.com/mikebway/poc-gcp-ecomm/pb v0.0.0-20261017014604-dec16ff83702
+	github.com/mikebway/poc-gcp-ecomm/testutil v0.0.0-20261017014604-dec16ff83702
+	github.com/mikebway/poc-gcp-ecomm/types v0.0.0-20261017014604-dec16ff83702
 	github.com/stretchr/testify v1.8.1
-	go.uber.org/zap v1.23.0
-	google.golang.org/api v0.103.0
-	google.golang.org/genproto v0.0.0-20221027153422-115e99e71e1c
+	go.uber.org/zap v1.24.0
+	google.golang.org/api v0.106.0
+	google.golang.org/genproto v0.0.0-20221227171554-f9683d7f8bef
 	google.golang.org/grpc v1.51.0
 	google.golang.org/protobuf v1.28.1
 )
 
 require (
 	cloud.google.com/go v0.105.0 // indirect
-	cloud.google.com/go/compute v1.12.1 // indirect
-	cloud.google.com/go/compute/metadata v0.2.1 // indirect
-	cloud.google.com/go/longrunning v0.1.1 // indirect
+	cloud.google.com/go/compute v1.14.0 // indirect
+	cloud.google.com/go/compute/metadata v0.2.3 // indirect
+	cloud.google.com/go/longrunning v0.3.0 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/golang/groupcache v0.0.0-20200121045136-8c9f03a8e57e // indirect
 	github.com/golang/protobuf v1.5.2 // indirect
 	github.com/google/go-cmp v0.5.9 // indirect
-	github.com/googleapis/enterprise-certificate-proxy v0.2.0 // indirect
+	github.com/googleapis/enterprise-certificate-proxy v0.2.1 // indirect
 	github.com/googleapis/gax-go/v2 v2.7.0 // indirect
 	github.com/kr/pretty v0.1.0 // indirect
 	github.com/kr/text v0.2.0 // indirect
@@ -33,12 +37,12 @@ require (
 	go.opencensus.io v0.24.0 // indirect
 	go.uber.org/atomic v1.7.0 // indirect
 	go.uber.org/multierr v1.6.0 // indirect
-	golang.org/x/net v0.0.0-20221014081412-f15817d10f9b // indirect
+	golang.org/x/net v0.5.0 // indirect
 	golang.org/x/oauth2 v0.0.0-20221014153046-6fdb5e3db783 // indirect
 	golang.org/x/sync v0.1.0 // indirect
-	golang.org/x/sys v0.0.0-20220728004956-3c1f35247d10 // indirect
-	golang.org/x/text v0.4.0 // indirect
-	golang.org/x/time v0.0.0-20220922220347-f3bd1da661af // indirect
+	golang.org/x/sys v0.4.0 // indirect
+	golang.org/x/text v0.6.0 // indirect
+	golang.org/x/time v0.1.0 // indirect
 	golang.org/x/xerrors v0.0.0-20220907171357-04be3eba64a2 // indirect
 	google.golang.org/appengine v1.6.7 // indirect
 	gopkg.in/check.v1 v1.0.0-20180628173108-788fd7840127 // indirect
@@ -1,15 +1,23 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
+	"net/url"
 	"os"
 
 	"github.com/mikebway/poc-gcp-ecomm/cart/cartapi"
+	"github.com/mikebway/poc-gcp-ecomm/paging"
+	"google.golang.org/api/idtoken"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/oauth"
 
 	pb "github.com/mikebway/poc-gcp-ecomm/pb/cart"
+	pborder "github.com/mikebway/poc-gcp-ecomm/pb/order"
 	"go.uber.org/zap"
 )
 
@@ -20,6 +28,11 @@ const (
 
 	// DefaultGRPCPort defines the default gRPC TCP port number as a string
 	DefaultGRPCPort = "8080"
+
+	// EnvOrderServiceURL names the environment variable that gives the URL of the order gRPC service, e.g.
+	// https://order-service-abc123-uc.a.run.app, that the cart service reads previous orders from so that shoppers
+	// can reorder them. Reordering is refused if it is not set.
+	EnvOrderServiceURL = "ORDER_SERVICE_URL"
 )
 
 // init is the static initializer used to configure our local and global static variables.
@@ -59,6 +72,13 @@ func initializeService() (*grpc.Server, net.Listener, error) {
 	}
 	zap.L().Info("poc-cart-service: starting server", zap.String("port", port))
 
+	// Refuse to start without the key with which the page tokens handed out to our callers are signed
+	err := paging.RequireKey()
+	if err != nil {
+		zap.L().Error("page token key error", zap.String("error", err.Error()))
+		return nil, nil, err
+	}
+
 	listener, err := net.Listen("tcp", ":"+port)
 	if err != nil {
 		zap.L().Error("net.Listen error", zap.String("error", err.Error()))
@@ -79,6 +99,19 @@ func initializeService() (*grpc.Server, net.Listener, error) {
 		return nil, listener, fmt.Errorf("failed to initialize the CartService: %v", err)
 	}
 
+	// Give the cart service access to the order service, if we know where it is, so that shoppers can reorder from
+	// previous orders
+	if orderServiceURL := os.Getenv(EnvOrderServiceURL); orderServiceURL != "" {
+		service.Orders, err = dialOrderService(orderServiceURL)
+		if err != nil {
+			zap.L().Error("order service dial error", zap.String("error", err.Error()))
+			_ = listener.Close()
+			return nil, listener, fmt.Errorf("failed to connect to the order service: %v", err)
+		}
+	} else {
+		zap.L().Warn("poc-cart-service: no order service URL configured, reordering is disabled", zap.String("envVar", EnvOrderServiceURL))
+	}
+
 	// Initialize the gRPC server
 	grpcServer := grpc.NewServer()
 	pb.RegisterCartAPIServer(grpcServer, service)
@@ -86,3 +119,34 @@ func initializeService() (*grpc.Server, net.Listener, error) {
 	// All went well
 	return grpcServer, listener, nil
 }
+
+// dialOrderService returns an OrderReader that reads orders from the order gRPC service at the given URL. The order
+// service does not allow unauthenticated calls, so each call carries a Google-signed ID token for the service
+// account that we run as, with the URL as its audience. The connection itself is made lazily, on the first call.
+func dialOrderService(orderServiceURL string) (cartapi.OrderReader, error) {
+
+	// The gRPC connection wants a host and port rather than a URL
+	u, err := url.Parse(orderServiceURL)
+	if err != nil || u.Scheme != "https" || u.Hostname() == "" {
+		return nil, fmt.Errorf("invalid order service URL: %s", orderServiceURL)
+	}
+	port := u.Port()
+	if port == "" {
+		port = "443"
+	}
+
+	// Obtain ID tokens for the order service
+	tokenSource, err := idtoken.NewTokenSource(context.Background(), orderServiceURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain ID token source for the order service: %w", err)
+	}
+
+	// And set up the connection
+	conn, err := grpc.Dial(net.JoinHostPort(u.Hostname(), port),
+		grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})),
+		grpc.WithPerRPCCredentials(oauth.TokenSource{TokenSource: tokenSource}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial the order service: %w", err)
+	}
+	return &cartapi.OrderAPIReader{Client: pborder.NewOrderAPIClient(conn)}, nil
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	svc "github.com/mikebway/poc-gcp-ecomm/cart/cartapi"
+	"github.com/mikebway/poc-gcp-ecomm/paging"
 	"github.com/mikebway/poc-gcp-ecomm/testutil"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
@@ -16,8 +17,12 @@ import (
 // the initializeService function and anything related.
 func resetEnvironment() {
 
-	// Clear the gRPC port number environment variable
+	// Clear the gRPC port number and order service URL environment variables
 	_ = os.Setenv(EnvGRPCPort, "")
+	_ = os.Setenv(EnvOrderServiceURL, "")
+
+	// Provide a key with which page tokens can be signed
+	_ = os.Setenv(paging.EnvPageTokenKey, "unit-test-page-token-key")
 
 	// Clear the request for the NewCartService to return a mock error
 	svc.UnitTestNewCartServiceError = nil
@@ -84,6 +89,7 @@ func TestDefaultInitialization(t *testing.T) {
 	req.Nil(err, "should have successfully initialized the gRPC service but got an error: %v", err)
 	req.Contains(logged, "poc-cart-service: starting server", "should have seen server starting message in log")
 	req.Contains(logged, "{\"port\": \"8080\"}", "should have seen default port number in log")
+	req.Contains(logged, "reordering is disabled", "should have been warned that there is no order service to reorder from")
 }
 
 // TestCustomPortInitialization examines the handling of a custom TCP port configuration
@@ -205,3 +211,72 @@ func TestNoCartServiceInitialization(t *testing.T) {
 	req.NotNil(listener, "listener should have been returned")
 	req.Nil(service, "no gRPC service should have been returned")
 }
+
+// TestInvalidOrderServiceInitialization examines the handling of an order service URL that cannot be dialled.
+func TestInvalidOrderServiceInitialization(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Start with a clean slate and leave it that way too
+	resetEnvironment()
+	defer resetEnvironment()
+
+	// Configure an order service URL that is not a URL
+	_ = os.Setenv(EnvOrderServiceURL, "order-service:443")
+
+	// Initialize the service while capturing its log output
+	var service *grpc.Server
+	var listener net.Listener
+	var err error
+	logged := testutil.CaptureLogging(func() {
+		service, listener, err = initializeService()
+	})
+
+	// If a service was returned, stop it immediately
+	if service != nil {
+		service.Stop()
+	}
+
+	// Now, see whether we like what happened
+	req.NotNil(err, "should have failed initialized the gRPC service")
+	req.Contains(err.Error(), "invalid order service URL", "should have been told that the order service URL was bad")
+	req.Contains(logged, "order service dial error", "should have seen an error reported about the order service in log")
+	req.Nil(service, "no gRPC service should have been returned")
+
+	// The listener should have been closed for us
+	if listener != nil {
+		_, err = net.Dial("tcp", listener.Addr().String())
+		req.NotNil(err, "listener should have been closed")
+	}
+}
+
+// TestNoPageTokenKeyInitialization confirms that the service refuses to start if no page token key has been
+// configured.
+func TestNoPageTokenKeyInitialization(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Start with a clean slate and leave it that way too
+	resetEnvironment()
+	defer resetEnvironment()
+
+	// Remove the page token key
+	_ = os.Setenv(paging.EnvPageTokenKey, "")
+
+	// Initialize the service while capturing its log output
+	var service *grpc.Server
+	var listener net.Listener
+	var err error
+	logged := testutil.CaptureLogging(func() {
+		service, listener, err = initializeService()
+	})
+
+	// Now, see whether we like what happened
+	req.NotNil(err, "should have failed initialized the gRPC service")
+	req.Contains(err.Error(), paging.EnvPageTokenKey, "error should have named the page token key environment variable")
+	req.Contains(logged, "page token key error", "should have seen an error reported about the page token key in log")
+	req.Nil(listener, "no listener should have been returned")
+	req.Nil(service, "no gRPC service should have been returned")
+}
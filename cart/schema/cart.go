@@ -3,16 +3,21 @@
 package schema
 
 import (
+	"strconv"
+	"time"
+
 	pbcart "github.com/mikebway/poc-gcp-ecomm/pb/cart"
 	pbtypes "github.com/mikebway/poc-gcp-ecomm/pb/types"
 	"github.com/mikebway/poc-gcp-ecomm/types"
 	"google.golang.org/protobuf/types/known/timestamppb"
-	"time"
 )
 
 const (
-	// CartCollection names the firestore collection under which all of our documents are stored
-	CartCollection = "carts/"
+	// CartCollectionId names the firestore collection under which all of our documents are stored
+	CartCollectionId = "carts"
+
+	// CartCollection is the path prefix of the firestore collection under which all of our documents are stored
+	CartCollection = CartCollectionId + "/"
 
 	// ItemCollection names the sub-collection of an individual cart in which shopping cart item documents are stored
 	ItemCollection = "/items"
@@ -42,6 +47,11 @@ type ShoppingCart struct {
 	// as abandoned or submitted / checked out.
 	ClosedTime time.Time `firestore:"closedTime,omitempty" json:"closedTime,omitempty"`
 
+	// ModifiedTime (Optional) is the time at which the cart, or any of its items or delivery address, was last
+	// changed. Touching this field on every change ensures that the Firestore update time of the cart document,
+	// and hence its Etag, reflects changes to the cart's descendant documents too.
+	ModifiedTime time.Time `firestore:"modifiedTime,omitempty" json:"modifiedTime,omitempty"`
+
 	// Status describes the state of the shopping cart (duh!).
 	Status CartStatus `firestore:"status" json:"status"`
 
@@ -60,6 +70,28 @@ type ShoppingCart struct {
 	// NOTE: cart items are stored as separate sub-documents in the Google Firestore with
 	// the cart reference as their ancestor.
 	CartItems []*ShoppingCartItem `firestore:"-" json:"cartItems"`
+
+	// PromotionCodes (Optional) lists the codes of the promotions that the shopper has applied to the cart, in the
+	// order that they were applied.
+	PromotionCodes []string `firestore:"promotionCodes,omitempty" json:"promotionCodes,omitempty"`
+
+	// Discounts (Optional) lists the discounts earned by the cart's promotion codes. While the cart is open these
+	// are calculated afresh whenever the cart is retrieved, since the cart contents and the promotions themselves
+	// may change. They are frozen into the cart document when the cart is checked out.
+	Discounts []*Discount `firestore:"discounts,omitempty" json:"discounts,omitempty"`
+
+	// EstimatedTax (Optional) is an estimate of the tax due on a cart that has a delivery address. While the cart is
+	// open it is calculated afresh whenever the cart is retrieved. It is frozen into the cart document when the cart
+	// is checked out, as the tax that the shopper's payment was authorized for, and is copied onto the order.
+	EstimatedTax *types.Money `firestore:"estimatedTax,omitempty" json:"estimatedTax,omitempty"`
+
+	// DeliveryOption (Optional) is the delivery option chosen by the shopper. While the cart is open its cost is
+	// requoted whenever the cart is retrieved; it is frozen into the cart document when the cart is checked out.
+	DeliveryOption *DeliveryOption `firestore:"deliveryOption,omitempty" json:"deliveryOption,omitempty"`
+
+	// Etag is an opaque value derived from the Firestore update time of the cart document. It is not stored
+	// as a field of the document but is populated when the cart is retrieved.
+	Etag string `firestore:"-" json:"etag,omitempty"`
 }
 
 // CartStatus is an enumeration type defining the overall status of a shopping cart
@@ -73,6 +105,20 @@ const (
 	CsAbandonedByTimeout CartStatus = 4
 )
 
+// EtagFromUpdateTime returns the opaque etag string representation of the given Firestore document update time.
+func EtagFromUpdateTime(updateTime time.Time) string {
+	return strconv.FormatInt(updateTime.UnixNano(), 16)
+}
+
+// LastActivityTime returns the time at which the cart was last changed or, if it has never been changed, the time at
+// which it was created.
+func (c *ShoppingCart) LastActivityTime() time.Time {
+	if c.ModifiedTime.After(c.CreationTime) {
+		return c.ModifiedTime
+	}
+	return c.CreationTime
+}
+
 // StoreRefPath returns the string representation of the document reference path for this ShoppingCart.
 func (c *ShoppingCart) StoreRefPath() string {
 	return CartCollection + c.Id
@@ -124,6 +170,16 @@ func (c *ShoppingCart) AsPBShoppingCart() *pbcart.ShoppingCart {
 		pbItems[i] = item.AsPBCartItem()
 	}
 
+	// As are the discounts
+	var pbDiscounts []*pbcart.Discount
+	for _, discount := range c.Discounts {
+		pbDiscounts = append(pbDiscounts, discount.AsPBDiscount())
+	}
+
+	// The cart subtotal and total are only meaningful if all the items have prices in the same currency
+	subtotal, _ := c.CalculateSubtotal()
+	total, _ := c.CalculateTotal()
+
 	// Return a populated protocol buffer version of the cart
 	return &pbcart.ShoppingCart{
 		Id:              c.Id,
@@ -133,7 +189,26 @@ func (c *ShoppingCart) AsPBShoppingCart() *pbcart.ShoppingCart {
 		Shopper:         pbShopper,
 		DeliveryAddress: pbAddress,
 		CartItems:       pbItems,
+		Etag:            c.Etag,
+		Subtotal:        subtotal.AsPBMoney(),
+		PromotionCodes:  c.PromotionCodes,
+		Discounts:       pbDiscounts,
+		Total:           total.AsPBMoney(),
+		EstimatedTax:    c.EstimatedTax.AsPBMoney(),
+		DeliveryOption:  c.DeliveryOption.AsPBDeliveryOption(),
+	}
+}
+
+// CalculateSubtotal returns the sum of the subtotals of all the items in the cart. An error is returned if the cart
+// is empty, if any item has no unit price, or if the items are not all priced in the same currency.
+func (c *ShoppingCart) CalculateSubtotal() (*types.Money, error) {
+
+	// Gather up the item subtotals and add them up
+	subtotals := make([]*types.Money, len(c.CartItems))
+	for i, item := range c.CartItems {
+		subtotals[i] = item.Subtotal()
 	}
+	return types.SumMoney(subtotals...)
 }
 
 // ShoppingCartFromPB is a factory method that populates a ShoppingCart structure from its
@@ -171,6 +246,12 @@ func ShoppingCartFromPB(pbc *pbcart.ShoppingCart) *ShoppingCart {
 		items[i] = ShoppingCartItemFromPB(pbItem)
 	}
 
+	// And the discounts
+	var discounts []*Discount
+	for _, pbDiscount := range pbc.Discounts {
+		discounts = append(discounts, DiscountFromPB(pbDiscount))
+	}
+
 	// Return a populated protocol buffer version of the cart
 	return &ShoppingCart{
 		Id:              pbc.Id,
@@ -180,6 +261,11 @@ func ShoppingCartFromPB(pbc *pbcart.ShoppingCart) *ShoppingCart {
 		Shopper:         shopper,
 		DeliveryAddress: address,
 		CartItems:       items,
+		PromotionCodes:  pbc.PromotionCodes,
+		Discounts:       discounts,
+		EstimatedTax:    types.MoneyFromPB(pbc.EstimatedTax),
+		DeliveryOption:  DeliveryOptionFromPB(pbc.DeliveryOption),
+		Etag:            pbc.Etag,
 	}
 }
 
@@ -202,6 +288,17 @@ type ShoppingCartItem struct {
 	// UnitPrice is the price that the customer was shown for a single item
 	// when they selected the item for their cart
 	UnitPrice *types.Money `firestore:"unitPrice" json:"unitPrice"`
+
+	// Attributes describe how the item is to be customized, e.g. the text to be engraved on it
+	Attributes []*types.Attribute `firestore:"attributes,omitempty" json:"attributes,omitempty"`
+
+	// Digital is true if the product does not need to be delivered to a postal address, as recorded in the catalog
+	// when the item was priced
+	Digital bool `firestore:"digital,omitempty" json:"digital,omitempty"`
+
+	// TaxCategory is the tax category of the product, as recorded in the catalog when the item was priced. Items
+	// without one are in the standard category.
+	TaxCategory string `firestore:"taxCategory,omitempty" json:"taxCategory,omitempty"`
 }
 
 // StoreRefPath returns the string representation of the document reference path for this ShoppingCartItem.
@@ -209,6 +306,20 @@ func (item *ShoppingCartItem) StoreRefPath() string {
 	return CartCollection + item.CartId + ItemCollection + "/" + item.Id
 }
 
+// IsMergeableWith returns true if the given item is for the same product as this one, customized in the same way,
+// so that the two could be combined into a single item with their quantities added together.
+func (item *ShoppingCartItem) IsMergeableWith(other *ShoppingCartItem) bool {
+	return item.ProductCode == other.ProductCode && types.SameAttributes(item.Attributes, other.Attributes)
+}
+
+// Subtotal returns the unit price of this cart item multiplied by its quantity, or nil if the item has no unit price.
+func (item *ShoppingCartItem) Subtotal() *types.Money {
+	if item.UnitPrice == nil {
+		return nil
+	}
+	return item.UnitPrice.Multiply(item.Quantity)
+}
+
 // AsPBCartItem returns the protocol buffer representation of this cart item.
 func (item *ShoppingCartItem) AsPBCartItem() *pbcart.CartItem {
 	return &pbcart.CartItem{
@@ -217,6 +328,8 @@ func (item *ShoppingCartItem) AsPBCartItem() *pbcart.CartItem {
 		ProductCode: item.ProductCode,
 		Quantity:    item.Quantity,
 		UnitPrice:   item.UnitPrice.AsPBMoney(),
+		Subtotal:    item.Subtotal().AsPBMoney(),
+		Attributes:  types.AttributesAsPB(item.Attributes),
 	}
 }
 
@@ -229,5 +342,6 @@ func ShoppingCartItemFromPB(pbItem *pbcart.CartItem) *ShoppingCartItem {
 		ProductCode: pbItem.ProductCode,
 		Quantity:    pbItem.Quantity,
 		UnitPrice:   types.MoneyFromPB(pbItem.UnitPrice),
+		Attributes:  types.AttributesFromPB(pbItem.Attributes),
 	}
 }
@@ -16,6 +16,10 @@ const (
 	// A UUID string value that we can use as a shopping cart ID in our tests
 	shoppingCartId = "d1cecab3-5bc0-43d4-aef1-99ad69794313"
 
+	// An etag value that we can use in our tests, and the etag that corresponds to the earlyTimeString
+	shoppingCartEtag = "1722c45e0ff11b15"
+	earlyTimeEtag    = "1722aa6d72217315"
+
 	// Define the person fields that we will use multiple times to define a shopper
 	shopperId          = "10615145-2010-4c5f-8347-2bb556232c31"
 	shopperFamilyName  = "Grint"
@@ -43,6 +47,11 @@ const (
 	itemPriceNanos1       = 550_000_000
 	itemPriceUnits2       = 1598
 	itemPriceNanos2       = 960_000_000
+
+	// Define the promotion field values for the discount applied to our mock cart
+	promotionCode        = "SAVE100"
+	promotionDescription = "$100 off"
+	promotionAmountOff   = 100
 )
 
 var (
@@ -115,11 +124,19 @@ func TestFullConversion(t *testing.T) {
 	req.NotNil(pbCart.DeliveryAddress, "should have found a delivery address")
 	req.Equal(addrPostalCode, pbCart.DeliveryAddress.PostalCode, "delivery address postal code did not match")
 	req.Equal(len(srcCart.CartItems), len(pbCart.CartItems), "item count did not match")
+	req.Equal(shoppingCartEtag, pbCart.Etag, "cart etags did not match")
+	req.Equal("USD 5097.47", types.MoneyFromPB(pbCart.Subtotal).String(), "cart subtotal did not match")
+	req.Equal([]string{promotionCode}, pbCart.PromotionCodes, "cart promotion codes did not match")
+	req.Equal(1, len(pbCart.Discounts), "cart discount count did not match")
+	req.Equal("USD 4997.47", types.MoneyFromPB(pbCart.Total).String(), "cart total did not match")
+	req.Equal("USD 1899.55", types.MoneyFromPB(pbCart.CartItems[0].Subtotal).String(), "cart item 1 subtotal did not match")
+	req.Equal("USD 3197.92", types.MoneyFromPB(pbCart.CartItems[1].Subtotal).String(), "cart item 2 subtotal did not match")
 	for i, item := range srcCart.CartItems {
 		req.Equal(item.Id, pbCart.CartItems[i].Id, "cart item ID did not match: %d", i)
 		req.Equal(item.CartId, pbCart.CartItems[i].CartId, "cart item cart ID did not match: %d", i)
 		req.NotNil(pbCart.CartItems[i].UnitPrice, "cart item price was not set: %d", i)
 		req.Equal(item.UnitPrice.AsPBMoney().String(), pbCart.CartItems[i].UnitPrice.String(), "cart item price did not match: %d", i)
+		req.Equal(len(item.Attributes), len(pbCart.CartItems[i].Attributes), "cart item attribute count did not match: %d", i)
 	}
 
 	// Convert the protocol buffer cart back to its local form
@@ -160,6 +177,11 @@ func TestMinimumConversion(t *testing.T) {
 	req.Equal(pbcart.ShoppingCartStatus_SCS_UNSPECIFIED, pbCart.Status, "cart status should not be specified")
 	req.Nil(pbCart.DeliveryAddress, "delivery address was not nil")
 	req.Equal(0, len(pbCart.CartItems), "item count should be zero")
+	req.Empty(pbCart.Etag, "cart etag should be empty")
+	req.Nil(pbCart.Subtotal, "cart subtotal should be nil")
+	req.Nil(pbCart.Total, "cart total should be nil")
+	req.Empty(pbCart.Discounts, "cart should have no discounts")
+	req.Nil(pbCart.DeliveryOption, "cart delivery option should be nil")
 
 	// Convert the protocol buffer cart back to its local form.
 	finalCart := ShoppingCartFromPB(pbCart)
@@ -170,6 +192,82 @@ func TestMinimumConversion(t *testing.T) {
 	req.Equal(CsUnspecified, finalCart.Status, "final cart status should not be specified")
 	req.Nil(finalCart.DeliveryAddress, "final delivery address was not nil")
 	req.Equal(0, len(finalCart.CartItems), "final item count should be zero")
+	req.Nil(finalCart.DeliveryOption, "final delivery option was not nil")
+}
+
+// TestSubtotalMixedCurrencies confirms that a cart with items priced in different currencies, or with an unpriced
+// item, has line subtotals for its priced items but no overall subtotal.
+func TestSubtotalMixedCurrencies(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Obtain a fully populated shopping cart and mess up the currency of one item
+	cart := buildMockCart()
+	cart.CartItems[1].UnitPrice = &types.Money{CurrencyCode: "GBP", Units: itemPriceUnits2, Nanos: itemPriceNanos2}
+	_, err := cart.CalculateSubtotal()
+	req.ErrorIs(err, types.ErrCurrencyMismatch, "should have seen a currency mismatch calculating the subtotal")
+	pbCart := cart.AsPBShoppingCart()
+	req.Nil(pbCart.Subtotal, "cart with mixed currencies should not have a subtotal")
+	req.Equal("GBP 3197.92", types.MoneyFromPB(pbCart.CartItems[1].Subtotal).String(), "cart item 2 subtotal did not match")
+
+	// Now remove the price altogether
+	cart.CartItems[1].UnitPrice = nil
+	pbCart = cart.AsPBShoppingCart()
+	req.Nil(pbCart.Subtotal, "cart with an unpriced item should not have a subtotal")
+	req.Nil(pbCart.CartItems[1].Subtotal, "unpriced cart item should not have a subtotal")
+	req.NotNil(pbCart.CartItems[0].Subtotal, "priced cart item should have a subtotal")
+}
+
+// TestIsMergeableWith confirms that cart items are only mergeable if they are for the same product, customized in the
+// same way.
+func TestIsMergeableWith(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Start with two plain gold yoyos
+	items := buildMockCartItems()
+	plain := &ShoppingCartItem{ProductCode: itemProdCode2}
+	req.True(items[1].IsMergeableWith(plain), "plain items for the same product should have been mergeable")
+	req.False(items[0].IsMergeableWith(plain), "items for different products should not have been mergeable")
+
+	// Engraving one makes it different
+	plain.ProductCode = itemProdCode1
+	req.False(items[0].IsMergeableWith(plain), "engraved item should not have been mergeable with a plain one")
+	plain.Attributes = []*types.Attribute{{Name: "engraving", Value: "Happy Birthday Rupert!"}}
+	req.True(items[0].IsMergeableWith(plain), "items with the same engraving should have been mergeable")
+}
+
+// TestEtagFromUpdateTime confirms that etags are derived consistently from Firestore update times and that
+// different update times yield different etags.
+func TestEtagFromUpdateTime(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Confirm that we get the etag we expect for a known time, and the same again for the same time
+	req.Equal(earlyTimeEtag, EtagFromUpdateTime(shoppingCartCreationTime), "etag did not match the expected value")
+	req.Equal(EtagFromUpdateTime(shoppingCartCreationTime), EtagFromUpdateTime(shoppingCartCreationTime), "etags for the same time should match")
+
+	// Confirm that even a microsecond difference produces a different etag
+	req.NotEqual(earlyTimeEtag, EtagFromUpdateTime(shoppingCartCreationTime.Add(time.Microsecond)), "etags for different times should not match")
+}
+
+// TestLastActivityTime confirms that the last activity time of a cart is its modification time, if it has one, or
+// its creation time otherwise.
+func TestLastActivityTime(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// A cart that has never been modified was last active when it was created
+	cart := buildMockCart()
+	req.Equal(shoppingCartCreationTime, cart.LastActivityTime(), "unmodified cart should have been last active at creation")
+
+	// Once modified, the modification time is what counts
+	cart.ModifiedTime = shoppingCartCreationTime.Add(time.Hour)
+	req.Equal(cart.ModifiedTime, cart.LastActivityTime(), "modified cart should have been last active at modification")
 }
 
 // buildMockCart returns a ShoppingCart structure populated with a shopper that can be used to
@@ -183,6 +281,13 @@ func buildMockCart() *ShoppingCart {
 		Shopper:         buildMockShopper(),
 		DeliveryAddress: buildMockDeliveryAddress(),
 		CartItems:       buildMockCartItems(),
+		PromotionCodes:  []string{promotionCode},
+		Discounts: []*Discount{{
+			PromotionCode: promotionCode,
+			Description:   promotionDescription,
+			Amount:        types.NewMoney(itemPriceCurrencyCode, promotionAmountOff, 0),
+		}},
+		Etag: shoppingCartEtag,
 	}
 }
 
@@ -219,6 +324,7 @@ func buildMockCartItems() []*ShoppingCartItem {
 			ProductCode: itemProdCode1,
 			Quantity:    itemQuantity1,
 			UnitPrice:   &itemPrice1,
+			Attributes:  []*types.Attribute{{Name: "engraving", Value: "Happy Birthday Rupert!"}},
 		},
 		&ShoppingCartItem{
 			Id:          itemId2,
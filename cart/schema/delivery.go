@@ -0,0 +1,89 @@
+package schema
+
+import (
+	"github.com/mikebway/poc-gcp-ecomm/cart/shipping"
+	pbcart "github.com/mikebway/poc-gcp-ecomm/pb/cart"
+	"github.com/mikebway/poc-gcp-ecomm/types"
+)
+
+// DeliveryOption records a way in which the physical items of a cart can be delivered, and what it costs.
+type DeliveryOption struct {
+	// Code identifies the delivery option, e.g. "STANDARD" or "EXPRESS"
+	Code string `firestore:"code" json:"code"`
+
+	// Description is a human readable description of the delivery option
+	Description string `firestore:"description" json:"description"`
+
+	// Cost is the price of delivering the cart items by this option
+	Cost *types.Money `firestore:"cost" json:"cost"`
+}
+
+// QuoteDeliveryOptions returns the delivery options that the given shipping.ShippingRateProvider offers for the
+// cart's items and delivery address, priced in the currency of the cart. An error is returned if the cart cannot be
+// totalled, and so has no single currency, or if the provider cannot quote, e.g. because there is no delivery
+// address.
+func (c *ShoppingCart) QuoteDeliveryOptions(provider shipping.ShippingRateProvider) ([]*DeliveryOption, error) {
+
+	// There is no point going any further with a cart that cannot be totalled
+	subtotal, err := c.CalculateSubtotal()
+	if err != nil {
+		return nil, err
+	}
+
+	// Describe the parcel and ask for the quotes
+	items := make([]*shipping.Item, len(c.CartItems))
+	for i, item := range c.CartItems {
+		items[i] = &shipping.Item{ProductCode: item.ProductCode, Quantity: item.Quantity}
+	}
+	quotes, err := provider.QuoteDeliveryOptions(c.DeliveryAddress, subtotal.CurrencyCode, items)
+	if err != nil {
+		return nil, err
+	}
+	options := make([]*DeliveryOption, len(quotes))
+	for i, quote := range quotes {
+		options[i] = &DeliveryOption{Code: quote.Code, Description: quote.Description, Cost: quote.Cost}
+	}
+	return options, nil
+}
+
+// QuoteDeliveryOption returns the delivery option with the given code as currently quoted for the cart by the given
+// shipping.ShippingRateProvider, or nil if the option is not available for the cart. An error is returned if the
+// options cannot be quoted at all.
+func (c *ShoppingCart) QuoteDeliveryOption(provider shipping.ShippingRateProvider, code string) (*DeliveryOption, error) {
+	options, err := c.QuoteDeliveryOptions(provider)
+	if err != nil {
+		return nil, err
+	}
+	for _, option := range options {
+		if option.Code == code {
+			return option, nil
+		}
+	}
+	return nil, nil
+}
+
+// AsPBDeliveryOption returns the protocol buffer representation of this delivery option. Nil is returned for a nil
+// delivery option, saving callers from having to check whether one has been chosen.
+func (o *DeliveryOption) AsPBDeliveryOption() *pbcart.DeliveryOption {
+	if o == nil {
+		return nil
+	}
+	return &pbcart.DeliveryOption{
+		Code:        o.Code,
+		Description: o.Description,
+		Cost:        o.Cost.AsPBMoney(),
+	}
+}
+
+// DeliveryOptionFromPB is a factory method that returns a DeliveryOption representation derived from its protocol
+// buffer equivalent. Nil is returned for nil.
+func DeliveryOptionFromPB(pbo *pbcart.DeliveryOption) *DeliveryOption {
+	if pbo == nil {
+		return nil
+	}
+	return &DeliveryOption{
+		Code:        pbo.Code,
+		Description: pbo.Description,
+		Cost:        types.MoneyFromPB(pbo.Cost),
+	}
+}
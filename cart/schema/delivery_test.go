@@ -0,0 +1,61 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/mikebway/poc-gcp-ecomm/cart/shipping"
+	"github.com/mikebway/poc-gcp-ecomm/types"
+	"github.com/stretchr/testify/require"
+)
+
+// TestQuoteDeliveryOptions confirms that delivery options are quoted for the cart's items, address, and currency,
+// that the chosen option survives conversion to and from protocol buffer form, and that its cost is included in the
+// cart total.
+func TestQuoteDeliveryOptions(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Standard delivery is charged per item, express delivery only takes small parcels
+	provider := &shipping.RuleProvider{
+		Rules: []*shipping.Rule{
+			{OptionCode: "STANDARD", Description: "Standard", RegionCode: addrRegionCode, Cost: types.NewMoney(itemPriceCurrencyCode, 5, 0), PerItemCost: types.NewMoney(itemPriceCurrencyCode, 1, 0)},
+			{OptionCode: "EXPRESS", Description: "Express", RegionCode: addrRegionCode, MaxItemCount: 2, Cost: types.NewMoney(itemPriceCurrencyCode, 20, 0)},
+		},
+		DefaultWeightGrams: 1000,
+	}
+
+	// The mock cart holds three items in all, too many for express delivery
+	cart := buildMockCart()
+	options, err := cart.QuoteDeliveryOptions(provider)
+	req.Nil(err, "should not have seen an error quoting delivery options: %v", err)
+	req.Equal(1, len(options), "should have been offered just the one delivery option")
+	req.Equal("STANDARD", options[0].Code, "delivery option code did not match")
+	req.Equal("USD 8.00", options[0].Cost.String(), "delivery option cost did not match")
+
+	// Asking for a single option finds it, or not
+	option, err := cart.QuoteDeliveryOption(provider, "STANDARD")
+	req.Nil(err, "should not have seen an error quoting the standard option: %v", err)
+	req.Equal("Standard", option.Description, "standard option description did not match")
+	option, err = cart.QuoteDeliveryOption(provider, "EXPRESS")
+	req.Nil(err, "should not have seen an error quoting the express option: %v", err)
+	req.Nil(option, "express option should not have been available")
+
+	// The chosen option adds to the total and survives the round trip through protocol buffer form
+	cart.DeliveryOption = options[0]
+	total, err := cart.CalculateTotal()
+	req.Nil(err, "should not have seen an error calculating the total: %v", err)
+	req.Equal("USD 5005.47", total.String(), "total including delivery did not match")
+	final := ShoppingCartFromPB(cart.AsPBShoppingCart())
+	req.Equal(*cart.DeliveryOption, *final.DeliveryOption, "delivery option did not survive conversion")
+
+	// No options can be quoted for a cart without an address or that cannot be totalled
+	cart.DeliveryAddress = nil
+	_, err = cart.QuoteDeliveryOptions(provider)
+	req.ErrorIs(err, shipping.ErrNoDeliveryRegion, "should have seen a missing region error")
+	_, err = cart.QuoteDeliveryOption(provider, "STANDARD")
+	req.ErrorIs(err, shipping.ErrNoDeliveryRegion, "should have seen a missing region error for a single option")
+	cart.CartItems[0].UnitPrice = nil
+	_, err = cart.QuoteDeliveryOptions(provider)
+	req.NotNil(err, "should have seen an error for a cart that cannot be totalled")
+}
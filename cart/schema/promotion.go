@@ -0,0 +1,238 @@
+package schema
+
+import (
+	"time"
+
+	pbcart "github.com/mikebway/poc-gcp-ecomm/pb/cart"
+	"github.com/mikebway/poc-gcp-ecomm/types"
+)
+
+const (
+	// PromotionCollection names the firestore collection under which promotion documents are stored, keyed by
+	// their upper case promotion codes
+	PromotionCollection = "promotions"
+)
+
+// Promotion describes a discount that shoppers can claim by applying its code to their shopping cart. A promotion
+// may be limited to a window of time, to carts that reach a minimum spend, and, for all but whole cart percentage
+// and amount off promotions, to the cart items for a single product.
+//
+// It is persisted in the promotions firestore collection. There is no API to maintain promotions; they are
+// managed directly in Firestore.
+type Promotion struct {
+	// Code is the promotion code that shoppers apply to their carts, in upper case. It doubles as the ID of the
+	// promotion document.
+	Code string `firestore:"code" json:"code"`
+
+	// Description is a human readable description of the promotion, e.g. "10% off everything"
+	Description string `firestore:"description" json:"description"`
+
+	// Type determines which of the discount fields below apply
+	Type PromotionType `firestore:"type" json:"type"`
+
+	// ProductCode (Optional) limits the discount to the cart items for the given product. It must be set for
+	// buy X get Y promotions.
+	ProductCode string `firestore:"productCode,omitempty" json:"productCode,omitempty"`
+
+	// PercentOff is the percentage, between 1 and 100, taken off the price of a PtPercentOff promotion
+	PercentOff int32 `firestore:"percentOff,omitempty" json:"percentOff,omitempty"`
+
+	// AmountOff is the amount taken off the price of a PtAmountOff promotion, up to the price itself
+	AmountOff *types.Money `firestore:"amountOff,omitempty" json:"amountOff,omitempty"`
+
+	// BuyQuantity is the number of items that must be bought to earn free items with a PtBuyXGetY promotion
+	BuyQuantity int32 `firestore:"buyQuantity,omitempty" json:"buyQuantity,omitempty"`
+
+	// FreeQuantity is the number of items that are free for every BuyQuantity bought with a PtBuyXGetY promotion
+	FreeQuantity int32 `firestore:"freeQuantity,omitempty" json:"freeQuantity,omitempty"`
+
+	// MinimumSpend (Optional) is the cart subtotal that must be reached before the promotion earns a discount
+	MinimumSpend *types.Money `firestore:"minimumSpend,omitempty" json:"minimumSpend,omitempty"`
+
+	// StartTime (Optional) is the time from which the promotion may be used
+	StartTime time.Time `firestore:"startTime,omitempty" json:"startTime,omitempty"`
+
+	// EndTime (Optional) is the time at which the promotion expires
+	EndTime time.Time `firestore:"endTime,omitempty" json:"endTime,omitempty"`
+}
+
+// PromotionType is an enumeration type defining the kinds of discount that a promotion can offer
+type PromotionType int32
+
+const (
+	PtUnspecified PromotionType = 0
+	PtPercentOff  PromotionType = 1
+	PtAmountOff   PromotionType = 2
+	PtBuyXGetY    PromotionType = 3
+)
+
+// Discount records a discount earned by applying a promotion code to a cart.
+type Discount struct {
+	// PromotionCode is the code of the promotion that earned the discount
+	PromotionCode string `firestore:"promotionCode" json:"promotionCode"`
+
+	// Description is the description of the promotion that earned the discount
+	Description string `firestore:"description" json:"description"`
+
+	// ProductCode (Optional) is the product that the discount applies to, if not the cart as a whole
+	ProductCode string `firestore:"productCode,omitempty" json:"productCode,omitempty"`
+
+	// Amount is the amount taken off the cart subtotal, expressed as a positive value
+	Amount *types.Money `firestore:"amount" json:"amount"`
+}
+
+// StoreRefPath returns the string representation of the document reference path for this Promotion.
+func (p *Promotion) StoreRefPath() string {
+	return PromotionCollection + "/" + p.Code
+}
+
+// IsActive returns true if the promotion may be used at the given time.
+func (p *Promotion) IsActive(now time.Time) bool {
+	return !now.Before(p.StartTime) && (p.EndTime.IsZero() || now.Before(p.EndTime))
+}
+
+// DiscountFor returns the discount that the promotion earns for the given cart at the given time, or nil if it
+// earns none. A promotion earns nothing if it is not active, if the cart does not reach its minimum spend or does
+// not contain the product that it applies to, if the cart cannot be totalled, or if the promotion is priced in a
+// different currency to the cart.
+func (p *Promotion) DiscountFor(cart *ShoppingCart, now time.Time) *Discount {
+
+	// Is the promotion live and has the shopper spent enough?
+	subtotal, err := cart.CalculateSubtotal()
+	if err != nil || !p.IsActive(now) {
+		return nil
+	}
+	if p.MinimumSpend != nil {
+		comparison, err := subtotal.Compare(p.MinimumSpend)
+		if err != nil || comparison < 0 {
+			return nil
+		}
+	}
+
+	// Work out what the discount applies to: either the whole cart or the items for just one product
+	base := subtotal
+	var unitPrice *types.Money
+	var quantity int32
+	if p.ProductCode != "" {
+		var prices []*types.Money
+		for _, item := range cart.CartItems {
+			if item.ProductCode == p.ProductCode {
+				prices = append(prices, item.Subtotal())
+				unitPrice = item.UnitPrice
+				quantity += item.Quantity
+			}
+		}
+		if len(prices) == 0 {
+			return nil
+		}
+		base, _ = types.SumMoney(prices...)
+	}
+
+	// Now apply the rule for the type of promotion
+	var amount *types.Money
+	switch p.Type {
+	case PtPercentOff:
+		if p.PercentOff > 0 && p.PercentOff <= 100 {
+			amount = base.Percent(p.PercentOff)
+		}
+	case PtAmountOff:
+		if comparison, err := base.Compare(p.AmountOff); err == nil {
+			amount = p.AmountOff
+			if comparison < 0 {
+				amount = base
+			}
+		}
+	case PtBuyXGetY:
+		if unitPrice != nil && p.BuyQuantity > 0 && p.FreeQuantity > 0 {
+			amount = unitPrice.Multiply(quantity / (p.BuyQuantity + p.FreeQuantity) * p.FreeQuantity)
+		}
+	}
+
+	// A discount of nothing is no discount at all
+	if amount == nil || amount.IsZero() {
+		return nil
+	}
+	return &Discount{
+		PromotionCode: p.Code,
+		Description:   p.Description,
+		ProductCode:   p.ProductCode,
+		Amount:        amount.Normalize(),
+	}
+}
+
+// CalculateDiscounts returns the discounts that the given promotions earn for the cart at the given time, in the
+// order of the cart's promotion codes. Promotions whose codes have not been applied to the cart are ignored. The
+// discounts never add up to more than the cart subtotal; the last one to take the total past that point is reduced
+// so that the cart is free rather than owing the shopper money.
+func (c *ShoppingCart) CalculateDiscounts(promotions []*Promotion, now time.Time) []*Discount {
+
+	// Index the promotions by code
+	byCode := make(map[string]*Promotion, len(promotions))
+	for _, promotion := range promotions {
+		byCode[promotion.Code] = promotion
+	}
+
+	// Work through the codes in the order that they were applied, keeping track of what is left to discount
+	var discounts []*Discount
+	remaining, err := c.CalculateSubtotal()
+	if err != nil {
+		return nil
+	}
+	for _, code := range c.PromotionCodes {
+		promotion := byCode[code]
+		if promotion == nil || remaining.IsZero() {
+			continue
+		}
+		discount := promotion.DiscountFor(c, now)
+		if discount == nil {
+			continue
+		}
+		if comparison, _ := remaining.Compare(discount.Amount); comparison < 0 {
+			discount.Amount = remaining
+		}
+		remaining, _ = remaining.Subtract(discount.Amount)
+		discounts = append(discounts, discount)
+	}
+	return discounts
+}
+
+// CalculateTotal returns the cart subtotal less all of its discounts, plus the cost of any chosen delivery option and
+// any estimated tax. An error is returned if the subtotal cannot be calculated or if any discount, the delivery cost,
+// or the tax is in a different currency.
+func (c *ShoppingCart) CalculateTotal() (*types.Money, error) {
+	total, err := c.CalculateSubtotal()
+	for _, discount := range c.Discounts {
+		if err != nil {
+			break
+		}
+		total, err = total.Subtract(discount.Amount)
+	}
+	if err == nil && c.DeliveryOption != nil && c.DeliveryOption.Cost != nil {
+		total, err = total.Add(c.DeliveryOption.Cost)
+	}
+	if err == nil && c.EstimatedTax != nil {
+		total, err = total.Add(c.EstimatedTax)
+	}
+	return total, err
+}
+
+// AsPBDiscount returns the protocol buffer representation of this discount.
+func (d *Discount) AsPBDiscount() *pbcart.Discount {
+	return &pbcart.Discount{
+		PromotionCode: d.PromotionCode,
+		Description:   d.Description,
+		ProductCode:   d.ProductCode,
+		Amount:        d.Amount.AsPBMoney(),
+	}
+}
+
+// DiscountFromPB is a factory method that returns a Discount representation derived from its protocol buffer
+// equivalent.
+func DiscountFromPB(pbd *pbcart.Discount) *Discount {
+	return &Discount{
+		PromotionCode: pbd.PromotionCode,
+		Description:   pbd.Description,
+		ProductCode:   pbd.ProductCode,
+		Amount:        types.MoneyFromPB(pbd.Amount),
+	}
+}
@@ -0,0 +1,120 @@
+package schema
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mikebway/poc-gcp-ecomm/types"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPromotionPath evaluates the Firestore path of a promotion.
+func TestPromotionPath(t *testing.T) {
+	req := require.New(t)
+	promotion := &Promotion{Code: promotionCode}
+	req.Equal("promotions/SAVE100", promotion.StoreRefPath(), "promotion path content does not match expected value")
+}
+
+// TestPromotionActive confirms that promotions are only active between their start and end times, where they
+// have them.
+func TestPromotionActive(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// A promotion without start and end times is always active
+	promotion := &Promotion{Code: promotionCode}
+	req.True(promotion.IsActive(shoppingCartCreationTime), "promotion without a window should be active")
+
+	// One with a window is active from the start time up until, but not including, the end time
+	promotion.StartTime = shoppingCartCreationTime
+	promotion.EndTime = shoppingCartClosedTime
+	req.False(promotion.IsActive(shoppingCartCreationTime.Add(-time.Nanosecond)), "promotion should not be active before its start time")
+	req.True(promotion.IsActive(shoppingCartCreationTime), "promotion should be active from its start time")
+	req.False(promotion.IsActive(shoppingCartClosedTime), "promotion should have expired at its end time")
+}
+
+// TestDiscountFor works through each of the promotion rules in turn, applying them to a cart with a subtotal of
+// USD 5097.47 made up of one item at 1899.55 and two at 1598.96.
+func TestDiscountFor(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// The cart is the same for all of the promotions, as is the time at which they are applied
+	cart := buildMockCart()
+	now := shoppingCartCreationTime
+
+	// Each promotion and the discount that it should earn
+	for _, tc := range []struct {
+		expected  string
+		promotion *Promotion
+	}{
+		{"USD 509.747", &Promotion{Type: PtPercentOff, PercentOff: 10}},
+		{"USD 1598.96", &Promotion{Type: PtPercentOff, PercentOff: 50, ProductCode: itemProdCode2}},
+		{"USD 100.00", &Promotion{Type: PtAmountOff, AmountOff: types.NewMoney(itemPriceCurrencyCode, 100, 0)}},
+		{"USD 1899.55", &Promotion{Type: PtAmountOff, AmountOff: types.NewMoney(itemPriceCurrencyCode, 2000, 0), ProductCode: itemProdCode1}},
+		{"USD 1598.96", &Promotion{Type: PtBuyXGetY, BuyQuantity: 1, FreeQuantity: 1, ProductCode: itemProdCode2}},
+		{"USD 10.00", &Promotion{Type: PtAmountOff, AmountOff: types.NewMoney(itemPriceCurrencyCode, 10, 0), MinimumSpend: types.NewMoney(itemPriceCurrencyCode, 5000, 0)}},
+	} {
+		tc.promotion.Code = promotionCode
+		tc.promotion.Description = promotionDescription
+		discount := tc.promotion.DiscountFor(cart, now)
+		req.NotNil(discount, "promotion should have earned %s", tc.expected)
+		req.Equal(promotionCode, discount.PromotionCode, "discount promotion code did not match")
+		req.Equal(promotionDescription, discount.Description, "discount description did not match")
+		req.Equal(tc.promotion.ProductCode, discount.ProductCode, "discount product code did not match")
+		req.Equal(tc.expected, discount.Amount.String(), "discount amount did not match")
+	}
+
+	// Promotions that should earn nothing at all
+	for reason, promotion := range map[string]*Promotion{
+		"not yet started":      {Type: PtPercentOff, PercentOff: 10, StartTime: now.Add(time.Hour)},
+		"expired":              {Type: PtPercentOff, PercentOff: 10, EndTime: now},
+		"below minimum spend":  {Type: PtPercentOff, PercentOff: 10, MinimumSpend: types.NewMoney(itemPriceCurrencyCode, 6000, 0)},
+		"foreign minimum":      {Type: PtPercentOff, PercentOff: 10, MinimumSpend: types.NewMoney("GBP", 1, 0)},
+		"foreign amount off":   {Type: PtAmountOff, AmountOff: types.NewMoney("GBP", 1, 0)},
+		"product not in cart":  {Type: PtPercentOff, PercentOff: 10, ProductCode: "nothing"},
+		"not enough bought":    {Type: PtBuyXGetY, BuyQuantity: 2, FreeQuantity: 1, ProductCode: itemProdCode2},
+		"silly percentage":     {Type: PtPercentOff, PercentOff: 101},
+		"unspecified":          {Type: PtUnspecified},
+		"missing buy quantity": {Type: PtBuyXGetY, FreeQuantity: 1, ProductCode: itemProdCode2},
+	} {
+		req.Nil(promotion.DiscountFor(cart, now), "promotion should not have earned a discount: %s", reason)
+	}
+
+	// Nor does an empty cart earn anything
+	req.Nil((&Promotion{Type: PtPercentOff, PercentOff: 10}).DiscountFor(&ShoppingCart{}, now), "empty cart should not have earned a discount")
+}
+
+// TestCalculateDiscounts confirms that discounts are calculated in the order that promotion codes were applied and
+// never take the cart total below zero.
+func TestCalculateDiscounts(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Apply three codes, one of which has no promotion, to a cart with a subtotal of USD 5097.47
+	cart := buildMockCart()
+	cart.PromotionCodes = []string{"BIG", "MISSING", "SMALL", "NOTHING_LEFT"}
+	promotions := []*Promotion{
+		{Code: "NOTHING_LEFT", Type: PtPercentOff, PercentOff: 10},
+		{Code: "SMALL", Type: PtAmountOff, AmountOff: types.NewMoney(itemPriceCurrencyCode, 500, 0)},
+		{Code: "BIG", Type: PtAmountOff, AmountOff: types.NewMoney(itemPriceCurrencyCode, 5000, 0)},
+	}
+	cart.Discounts = cart.CalculateDiscounts(promotions, shoppingCartCreationTime)
+
+	// The second discount should have been cut down to what was left, leaving nothing for the third
+	req.Equal(2, len(cart.Discounts), "should have had two discounts")
+	req.Equal("BIG", cart.Discounts[0].PromotionCode, "first discount should have been for the first code")
+	req.Equal("USD 5000.00", cart.Discounts[0].Amount.String(), "first discount amount did not match")
+	req.Equal("SMALL", cart.Discounts[1].PromotionCode, "second discount should have been for the third code")
+	req.Equal("USD 97.47", cart.Discounts[1].Amount.String(), "second discount should have been cut down to what was left")
+	total, err := cart.CalculateTotal()
+	req.Nil(err, "should not have seen an error calculating the total: %v", err)
+	req.True(total.IsZero(), "cart should have been free, not %s", total)
+
+	// A cart that cannot be totalled earns nothing
+	cart.CartItems[1].UnitPrice = nil
+	req.Nil(cart.CalculateDiscounts(promotions, shoppingCartCreationTime), "untotalled cart should not have earned discounts")
+}
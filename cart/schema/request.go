@@ -0,0 +1,50 @@
+package schema
+
+import (
+	"time"
+)
+
+const (
+	// RequestCollection names the sub-collection of an individual cart in which the records of processed
+	// mutating requests that carried a client supplied request ID are stored
+	RequestCollection = "/requests"
+)
+
+// ProcessedRequest records that a mutating API request carrying a client supplied request ID has been applied to
+// a cart, so that a retry of the same request can be answered with the original response rather than the change
+// being applied a second time.
+//
+// It is persisted in the requests sub-collection of the cart that the request was applied to. Records are of no
+// use once their ExpiryTime has passed; in production, a Firestore TTL policy on the expiryTime field of the
+// requests collection group, set up by the deploy target of the cart Makefile, removes them.
+type ProcessedRequest struct {
+	// RequestId is the client supplied request ID; it doubles as the ID of the request document
+	RequestId string `firestore:"requestId" json:"requestId"`
+
+	// CartId is the ID of the cart that the request was applied to
+	CartId string `firestore:"cartId" json:"cartId"`
+
+	// Method is the full protobuf name of the request message, e.g. "mikebway.cart.AddItemToShoppingCartRequest",
+	// so that a request ID reused for a different kind of request can be detected
+	Method string `firestore:"method" json:"method"`
+
+	// ProcessedTime is the time at which the request was applied
+	ProcessedTime time.Time `firestore:"processedTime" json:"processedTime"`
+
+	// ExpiryTime is the time after which a retry of the request will no longer be recognized as such
+	ExpiryTime time.Time `firestore:"expiryTime" json:"expiryTime"`
+
+	// Cart (Optional) is the protobuf binary encoding of the cart returned in the response to the original request.
+	// It is recorded after the request has been applied and may be missing if that recording failed.
+	Cart []byte `firestore:"cart,omitempty" json:"cart,omitempty"`
+}
+
+// StoreRefPath returns the string representation of the document reference path for this ProcessedRequest.
+func (r *ProcessedRequest) StoreRefPath() string {
+	return CartCollection + r.CartId + RequestCollection + "/" + r.RequestId
+}
+
+// HasExpired returns true if the request record is too old to be relied upon at the given time.
+func (r *ProcessedRequest) HasExpired(now time.Time) bool {
+	return !now.Before(r.ExpiryTime)
+}
@@ -0,0 +1,31 @@
+package schema
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestProcessedRequest evaluates the Firestore path and expiry of a processed request record.
+func TestProcessedRequest(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Build a record that expires an hour after the cart was created
+	processed := &ProcessedRequest{
+		RequestId:     "e5b3ac31-92c6-4d7a-8e41-d4a4fd1b7ae2",
+		CartId:        shoppingCartId,
+		Method:        "mikebway.cart.AddItemToShoppingCartRequest",
+		ProcessedTime: shoppingCartCreationTime,
+		ExpiryTime:    shoppingCartCreationTime.Add(time.Hour),
+	}
+
+	// Get the firestore path for the request and confirm that it looks as we expect
+	req.Equal("carts/d1cecab3-5bc0-43d4-aef1-99ad69794313/requests/e5b3ac31-92c6-4d7a-8e41-d4a4fd1b7ae2", processed.StoreRefPath(), "request path content does not match expected value")
+
+	// The record is good right up until its expiry time
+	req.False(processed.HasExpired(shoppingCartCreationTime), "record should not have expired as soon as it was processed")
+	req.True(processed.HasExpired(processed.ExpiryTime), "record should have expired at its expiry time")
+}
@@ -0,0 +1,43 @@
+package schema
+
+import (
+	"github.com/mikebway/poc-gcp-ecomm/cart/tax"
+	"github.com/mikebway/poc-gcp-ecomm/types"
+)
+
+// CalculateTax returns the tax due on the cart, as calculated by the given tax.TaxCalculator for the cart's
+// delivery address. Tax is due on the price of the items less any discounts that apply to their products; discounts
+// on the cart as a whole do not reduce the tax. An error is returned if the cart cannot be totalled or if the
+// calculator cannot work out the tax, e.g. because there is no delivery address.
+func (c *ShoppingCart) CalculateTax(calculator tax.TaxCalculator) (*types.Money, error) {
+
+	// There is no point going any further with a cart that cannot be totalled
+	if _, err := c.CalculateSubtotal(); err != nil {
+		return nil, err
+	}
+
+	// Build a line for each product, merging any items that share a product code
+	var lines []*tax.Line
+	byProduct := make(map[string]*tax.Line, len(c.CartItems))
+	for _, item := range c.CartItems {
+		if line, found := byProduct[item.ProductCode]; found {
+			line.Amount, _ = line.Amount.Add(item.Subtotal())
+			continue
+		}
+		line := &tax.Line{ProductCode: item.ProductCode, Category: item.TaxCategory, Amount: item.Subtotal()}
+		byProduct[item.ProductCode] = line
+		lines = append(lines, line)
+	}
+
+	// Take product discounts off the price of their products
+	for _, discount := range c.Discounts {
+		if line, found := byProduct[discount.ProductCode]; found {
+			amount, err := line.Amount.Subtract(discount.Amount)
+			if err != nil {
+				return nil, err
+			}
+			line.Amount = amount
+		}
+	}
+	return calculator.CalculateTax(c.DeliveryAddress, lines)
+}
@@ -0,0 +1,55 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/mikebway/poc-gcp-ecomm/cart/tax"
+	"github.com/mikebway/poc-gcp-ecomm/types"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCalculateTax confirms that tax is calculated on the price of the cart items less product discounts, but not
+// less whole cart discounts, and that the estimated tax is included in the cart total.
+func TestCalculateTax(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// A 10% tax on everything in the mock cart's region, which the mock cart's $100 whole cart discount does not reduce
+	calculator := &tax.TableCalculator{Rates: []*tax.Rate{{RegionCode: addrRegionCode, BasisPoints: 1000}}}
+	cart := buildMockCart()
+	estimate, err := cart.CalculateTax(calculator)
+	req.Nil(err, "should not have seen an error calculating tax: %v", err)
+	req.Equal("USD 509.75", estimate.String(), "tax did not match")
+
+	// A product discount does reduce the tax, even when the product is spread over more than one item
+	cart.CartItems = append(cart.CartItems, &ShoppingCartItem{ProductCode: itemProdCode2, Quantity: 1, UnitPrice: &itemPrice2})
+	cart.Discounts = append(cart.Discounts, &Discount{ProductCode: itemProdCode2, Amount: types.NewMoney(itemPriceCurrencyCode, 1000, 0)})
+	estimate, err = cart.CalculateTax(calculator)
+	req.Nil(err, "should not have seen an error calculating tax with a product discount: %v", err)
+	req.Equal("USD 569.64", estimate.String(), "tax with a product discount did not match")
+
+	// The estimate is included in the total
+	cart.EstimatedTax = estimate
+	total, err := cart.CalculateTotal()
+	req.Nil(err, "should not have seen an error calculating the total: %v", err)
+	req.Equal("USD 6166.07", total.String(), "total including tax did not match")
+	req.Equal(estimate.String(), types.MoneyFromPB(cart.AsPBShoppingCart().EstimatedTax).String(), "estimated tax did not survive conversion")
+
+	// Items are taxed by the category that the catalog gave their products
+	calculator.Rates = append(calculator.Rates, &tax.Rate{RegionCode: addrRegionCode, Category: "zero", BasisPoints: 0})
+	for _, item := range cart.CartItems {
+		item.TaxCategory = "zero"
+	}
+	estimate, err = cart.CalculateTax(calculator)
+	req.Nil(err, "should not have seen an error calculating zero rated tax: %v", err)
+	req.Equal("USD 0.00", estimate.String(), "zero rated tax did not match")
+
+	// No tax can be calculated for a cart without an address or that cannot be totalled
+	cart.DeliveryAddress = nil
+	_, err = cart.CalculateTax(calculator)
+	req.ErrorIs(err, tax.ErrNoTaxRegion, "should have seen a missing region error")
+	cart.CartItems[0].UnitPrice = nil
+	_, err = cart.CalculateTax(calculator)
+	req.NotNil(err, "should have seen an error for a cart that cannot be totalled")
+}
@@ -0,0 +1,158 @@
+package schema
+
+import (
+	"time"
+
+	pbcart "github.com/mikebway/poc-gcp-ecomm/pb/cart"
+	"github.com/mikebway/poc-gcp-ecomm/types"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// WishlistCollectionId names the firestore collection under which wishlist documents are stored, keyed by the
+	// ID of the shopper whose wishlist each is. It is kept apart from the carts collection since a wishlist outlives
+	// any one cart.
+	WishlistCollectionId = "wishlists"
+
+	// WishlistCollection is the path prefix of the firestore collection under which wishlist documents are stored
+	WishlistCollection = WishlistCollectionId + "/"
+)
+
+// Wishlist holds the products that a shopper would like to buy one day but not in the cart that they have open
+// today, whether they were saved for later from a cart or added to the wishlist directly. Each shopper has at most
+// one wishlist.
+//
+// It is persisted in the wishlists firestore collection, its items in an items sub-collection of the wishlist
+// document in the same way as the items of a cart.
+type Wishlist struct {
+	// ShopperId is the ID of the shopper whose wishlist this is. It doubles as the ID of the wishlist document.
+	ShopperId string `firestore:"shopperId" json:"shopperId"`
+
+	// ShareId is a UUID ID in hexadecimal string form that the shopper can give to others to let them see the
+	// wishlist without being able to change it, or find out who the shopper is
+	ShareId string `firestore:"shareId" json:"shareId"`
+
+	// CreationTime is the time at which the wishlist was created, i.e. when the first item was added to it
+	CreationTime time.Time `firestore:"creationTime" json:"creationTime"`
+
+	// ModifiedTime is the time at which an item was last added to or removed from the wishlist
+	ModifiedTime time.Time `firestore:"modifiedTime" json:"modifiedTime"`
+
+	// Items is the list of items on the wishlist. They are stored in their own sub-collection, not as part of the
+	// wishlist document.
+	Items []*WishlistItem `firestore:"-" json:"items,omitempty"`
+}
+
+// WishlistItem is a single entry in a wishlist. Unlike a cart item, it has no price; the product is priced from the
+// catalog if and when it is moved to a cart.
+type WishlistItem struct {
+	// Id is a UUID ID in hexadecimal string form - a unique ID for this wishlist item.
+	Id string `firestore:"id" json:"id,omitempty"`
+
+	// ShopperId is the ID of the shopper whose wishlist the item is on
+	ShopperId string `firestore:"shopperId" json:"shopperId,omitempty"`
+
+	// ProductCode is the equivalent of a SKU code identifying the type of product or service wished for
+	ProductCode string `firestore:"productCode" json:"productCode"`
+
+	// Quantity is the number of this item type that is wished for
+	Quantity int32 `firestore:"quantity" json:"quantity"`
+
+	// Attributes describe how the item is to be customized, e.g. the text to be engraved on it
+	Attributes []*types.Attribute `firestore:"attributes,omitempty" json:"attributes,omitempty"`
+
+	// AddedTime is the time at which the item was added to the wishlist
+	AddedTime time.Time `firestore:"addedTime" json:"addedTime"`
+}
+
+// StoreRefPath returns the string representation of the document reference path for this Wishlist.
+func (w *Wishlist) StoreRefPath() string {
+	return WishlistCollection + w.ShopperId
+}
+
+// ItemCollectionPath returns the string representation of the collection reference path under which the items
+// of this Wishlist are stored
+func (w *Wishlist) ItemCollectionPath() string {
+	return WishlistCollection + w.ShopperId + ItemCollection
+}
+
+// AsPBWishlist returns the protocol buffer representation of this wishlist.
+func (w *Wishlist) AsPBWishlist() *pbcart.Wishlist {
+	pbItems := make([]*pbcart.WishlistItem, len(w.Items))
+	for i, item := range w.Items {
+		pbItems[i] = item.AsPBWishlistItem()
+	}
+	return &pbcart.Wishlist{
+		ShopperId: w.ShopperId,
+		ShareId:   w.ShareId,
+		Items:     pbItems,
+	}
+}
+
+// StoreRefPath returns the string representation of the document reference path for this WishlistItem.
+func (item *WishlistItem) StoreRefPath() string {
+	return WishlistCollection + item.ShopperId + ItemCollection + "/" + item.Id
+}
+
+// IsMergeableWith returns true if the given item is for the same product as this one, customized in the same way,
+// so that the two could be combined into a single item with their quantities added together.
+func (item *WishlistItem) IsMergeableWith(other *WishlistItem) bool {
+	return item.ProductCode == other.ProductCode && types.SameAttributes(item.Attributes, other.Attributes)
+}
+
+// AsShoppingCartItem returns a new item for the cart with the given ID for the same product, quantity, and
+// attributes as this wishlist item. The cart item is given a new ID but no price; that is for the catalog to set.
+func (item *WishlistItem) AsShoppingCartItem(cartId string, itemId string) *ShoppingCartItem {
+	return &ShoppingCartItem{
+		Id:          itemId,
+		CartId:      cartId,
+		ProductCode: item.ProductCode,
+		Quantity:    item.Quantity,
+		Attributes:  item.Attributes,
+	}
+}
+
+// AsPBWishlistItem returns the protocol buffer representation of this wishlist item.
+func (item *WishlistItem) AsPBWishlistItem() *pbcart.WishlistItem {
+	var addedTimePB *timestamppb.Timestamp
+	if !item.AddedTime.IsZero() {
+		addedTimePB = timestamppb.New(item.AddedTime)
+	}
+	return &pbcart.WishlistItem{
+		Id:          item.Id,
+		ProductCode: item.ProductCode,
+		Quantity:    item.Quantity,
+		Attributes:  types.AttributesAsPB(item.Attributes),
+		AddedTime:   addedTimePB,
+	}
+}
+
+// WishlistItemFromPB is a factory method that returns a WishlistItem representation derived from its protocol
+// buffer equivalent, to be added to the wishlist of the shopper with the given ID.
+func WishlistItemFromPB(shopperId string, pbItem *pbcart.WishlistItem) *WishlistItem {
+	var addedTime time.Time
+	if pbItem.AddedTime != nil {
+		addedTime = pbItem.AddedTime.AsTime()
+	}
+	return &WishlistItem{
+		Id:          pbItem.Id,
+		ShopperId:   shopperId,
+		ProductCode: pbItem.ProductCode,
+		Quantity:    pbItem.Quantity,
+		Attributes:  types.AttributesFromPB(pbItem.Attributes),
+		AddedTime:   addedTime,
+	}
+}
+
+// WishlistItemFromCartItem is a factory method that returns a new item for the wishlist of the shopper with the
+// given ID, with the given item ID, for the same product, quantity, and attributes as the given cart item.
+func WishlistItemFromCartItem(shopperId string, itemId string, cartItem *ShoppingCartItem, addedTime time.Time) *WishlistItem {
+	return &WishlistItem{
+		Id:          itemId,
+		ShopperId:   shopperId,
+		ProductCode: cartItem.ProductCode,
+		Quantity:    cartItem.Quantity,
+		Attributes:  cartItem.Attributes,
+		AddedTime:   addedTime,
+	}
+}
@@ -0,0 +1,72 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/mikebway/poc-gcp-ecomm/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// A UUID string value that we can use as a wishlist share ID in our tests
+	wishlistShareId = "0b1e3f56-7a0c-4f0e-8a51-3c5f1c6a9d27"
+)
+
+// TestWishlistPaths evaluates the Firestore paths of a wishlist and its items.
+func TestWishlistPaths(t *testing.T) {
+	req := require.New(t)
+	wishlist := &Wishlist{ShopperId: shopperId}
+	req.Equal("wishlists/10615145-2010-4c5f-8347-2bb556232c31", wishlist.StoreRefPath(), "wishlist path content does not match expected value")
+	req.Equal("wishlists/10615145-2010-4c5f-8347-2bb556232c31/items", wishlist.ItemCollectionPath(), "wishlist item collection path content does not match expected value")
+	item := &WishlistItem{ShopperId: shopperId, Id: itemId1}
+	req.Equal("wishlists/10615145-2010-4c5f-8347-2bb556232c31/items/54f34cb9-fea6-4786-a475-cebd95d93742", item.StoreRefPath(), "wishlist item path content does not match expected value")
+}
+
+// TestWishlistConversion examines what happens when a cart item is saved to a wishlist, the wishlist converted to
+// its protocol buffer form, and the item converted back again and moved to another cart.
+func TestWishlistConversion(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Save the first of our mock cart items for later
+	cartItem := buildMockCartItems()[0]
+	item := WishlistItemFromCartItem(shopperId, itemId2, cartItem, shoppingCartCreationTime)
+	req.Equal(itemId2, item.Id, "wishlist item ID did not match")
+	req.Equal(shopperId, item.ShopperId, "wishlist item shopper ID did not match")
+	req.Equal(cartItem.ProductCode, item.ProductCode, "wishlist item product code did not match")
+	req.Equal(cartItem.Quantity, item.Quantity, "wishlist item quantity did not match")
+	req.True(types.SameAttributes(cartItem.Attributes, item.Attributes), "wishlist item attributes did not match")
+
+	// Convert the wishlist to protocol buffer form and back
+	wishlist := &Wishlist{ShopperId: shopperId, ShareId: wishlistShareId, Items: []*WishlistItem{item}}
+	pbWishlist := wishlist.AsPBWishlist()
+	req.Equal(shopperId, pbWishlist.ShopperId, "PB wishlist shopper ID did not match")
+	req.Equal(wishlistShareId, pbWishlist.ShareId, "PB wishlist share ID did not match")
+	req.Equal(1, len(pbWishlist.Items), "PB wishlist item count did not match")
+	req.Equal(shoppingCartCreationTime, pbWishlist.Items[0].AddedTime.AsTime(), "PB wishlist item added time did not match")
+	roundTrip := WishlistItemFromPB(shopperId, pbWishlist.Items[0])
+	req.Equal(item, roundTrip, "wishlist item did not survive the round trip")
+
+	// Move it to a cart, where it will have to be priced afresh
+	moved := roundTrip.AsShoppingCartItem(shoppingCartId, itemId1)
+	req.Equal(itemId1, moved.Id, "moved cart item ID did not match")
+	req.Equal(shoppingCartId, moved.CartId, "moved cart item cart ID did not match")
+	req.Nil(moved.UnitPrice, "moved cart item should not have been priced")
+	req.True(moved.IsMergeableWith(cartItem), "moved cart item should have been the same as the original")
+
+	// Items without added times do not get one on the way through
+	pbItem := (&WishlistItem{ProductCode: itemProdCode2}).AsPBWishlistItem()
+	req.Nil(pbItem.AddedTime, "PB wishlist item should not have had an added time")
+	req.True(WishlistItemFromPB(shopperId, pbItem).AddedTime.IsZero(), "wishlist item should not have had an added time")
+}
+
+// TestWishlistItemIsMergeableWith confirms that wishlist items are only mergeable with items for the same product
+// customized in the same way.
+func TestWishlistItemIsMergeableWith(t *testing.T) {
+	req := require.New(t)
+	item := &WishlistItem{ProductCode: itemProdCode1, Attributes: []*types.Attribute{{Name: "engraving", Value: "Rupert"}}}
+	req.True(item.IsMergeableWith(&WishlistItem{ProductCode: itemProdCode1, Attributes: []*types.Attribute{{Name: "engraving", Value: "Rupert"}}}), "identical items should have been mergeable")
+	req.False(item.IsMergeableWith(&WishlistItem{ProductCode: itemProdCode1}), "differently customized items should not have been mergeable")
+	req.False(item.IsMergeableWith(&WishlistItem{ProductCode: itemProdCode2, Attributes: item.Attributes}), "items for different products should not have been mergeable")
+}
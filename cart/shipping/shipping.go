@@ -0,0 +1,196 @@
+// Package shipping defines how the delivery options offered to shoppers, and the cost of each, are worked out. The
+// cart service consults a ShippingRateProvider to list the options available for a cart and to price the option that
+// the shopper chooses.
+//
+// RuleProvider, the one implementation provided, works from a table of rules keyed by region, parcel weight, and item
+// count. The table is embedded in the package so that everything, unit tests included, can run offline, but may be
+// replaced by pointing the SHIPPING_RULES environment variable at a JSON file of the same form.
+package shipping
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mikebway/poc-gcp-ecomm/types"
+)
+
+const (
+	// EnvShippingRules names the environment variable that may be set to the path of a JSON file holding the
+	// shipping rules to be used in place of the embedded default
+	EnvShippingRules = "SHIPPING_RULES"
+)
+
+var (
+	// ErrNoDeliveryRegion is returned when delivery options cannot be quoted because the address to which the goods
+	// are to be delivered is missing or has no region code
+	ErrNoDeliveryRegion = errors.New("no region to deliver to")
+
+	// defaultRules is the JSON content of the shipping rules that are used if the SHIPPING_RULES environment
+	// variable is not set
+	//go:embed rules.json
+	defaultRules []byte
+)
+
+// ShippingRateProvider is the interface through which delivery options are quoted.
+type ShippingRateProvider interface {
+
+	// QuoteDeliveryOptions returns the delivery options available for the given items when delivered to the given
+	// address, each priced in the given currency. An empty list is returned if there are none. ErrNoDeliveryRegion
+	// is returned if the address is missing or has no region code.
+	QuoteDeliveryOptions(address *types.PostalAddress, currencyCode string, items []*Item) ([]*Quote, error)
+}
+
+// Item is a single line of goods to be delivered.
+type Item struct {
+
+	// ProductCode identifies the product being delivered and, through it, the weight of each unit
+	ProductCode string
+
+	// Quantity is the number of units of the product to be delivered
+	Quantity int32
+}
+
+// Quote is a delivery option that is available for a parcel, and what it costs.
+type Quote struct {
+
+	// Code identifies the delivery option, e.g. "STANDARD" or "EXPRESS"
+	Code string
+
+	// Description is a human readable description of the delivery option, e.g. "Express delivery (1-2 days)"
+	Description string
+
+	// Cost is the price of delivering the parcel by this option
+	Cost *types.Money
+}
+
+// Rule is an entry in the table of a RuleProvider, offering a delivery option to a region for parcels up to a given
+// weight and item count.
+type Rule struct {
+
+	// OptionCode identifies the delivery option that the rule offers
+	OptionCode string `json:"optionCode"`
+
+	// Description is a human readable description of the delivery option
+	Description string `json:"description"`
+
+	// RegionCode is the CLDR region code of the country or region to which the rule applies, e.g. "US" or "GB"
+	RegionCode string `json:"regionCode"`
+
+	// MaxWeightGrams (Optional) is the heaviest parcel, in grams, to which the rule applies. Zero means no limit.
+	MaxWeightGrams int64 `json:"maxWeightGrams,omitempty"`
+
+	// MaxItemCount (Optional) is the largest number of items to which the rule applies. Zero means no limit.
+	MaxItemCount int32 `json:"maxItemCount,omitempty"`
+
+	// Cost is the base price of delivery by the option. Its currency determines the carts that the rule applies to.
+	Cost *types.Money `json:"cost"`
+
+	// PerItemCost (Optional) is added to the base price for every item in the parcel
+	PerItemCost *types.Money `json:"perItemCost,omitempty"`
+}
+
+// RuleProvider is a ShippingRateProvider that works from a table of rules keyed by region, parcel weight, and item
+// count.
+//
+// The rules are considered in the order that they appear in the table. For each delivery option, the first rule
+// that matches the region, currency, weight, and item count of the parcel sets the price; any later rules for the
+// same option are ignored. Options are returned in the order that their matching rules appear.
+type RuleProvider struct {
+
+	// Rules is the table of shipping rules
+	Rules []*Rule `json:"rules"`
+
+	// ProductWeights maps product codes to the weight, in grams, of a single unit of the product
+	ProductWeights map[string]int64 `json:"productWeights,omitempty"`
+
+	// DefaultWeightGrams is the weight, in grams, of a single unit of any product not listed in ProductWeights
+	DefaultWeightGrams int64 `json:"defaultWeightGrams"`
+}
+
+// NewDefaultProvider returns a RuleProvider loaded from the JSON file named by the SHIPPING_RULES environment variable
+// or, if that is not set, from the rules embedded in this package.
+func NewDefaultProvider() (*RuleProvider, error) {
+	path := os.Getenv(EnvShippingRules)
+	if path == "" {
+		return NewRuleProvider(defaultRules)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open shipping rules %s: %w", path, err)
+	}
+	defer file.Close()
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read shipping rules %s: %w", path, err)
+	}
+	return NewRuleProvider(content)
+}
+
+// NewRuleProvider returns a RuleProvider loaded from the given JSON shipping rules content.
+func NewRuleProvider(content []byte) (*RuleProvider, error) {
+	provider := &RuleProvider{}
+	err := json.Unmarshal(content, provider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal shipping rules: %w", err)
+	}
+	return provider, nil
+}
+
+// QuoteDeliveryOptions returns the delivery options available for the given items when delivered to the given
+// address. See the ShippingRateProvider interface for details.
+func (p *RuleProvider) QuoteDeliveryOptions(address *types.PostalAddress, currencyCode string, items []*Item) ([]*Quote, error) {
+
+	// We cannot do anything without knowing where the goods are going
+	if address == nil || address.RegionCode == "" {
+		return nil, ErrNoDeliveryRegion
+	}
+
+	// Weigh and count the parcel
+	var weight int64
+	var count int32
+	for _, item := range items {
+		weight += p.weightOf(item.ProductCode) * int64(item.Quantity)
+		count += item.Quantity
+	}
+
+	// Find the first matching rule for each option
+	quotes := make([]*Quote, 0)
+	quoted := make(map[string]bool)
+	for _, rule := range p.Rules {
+		if quoted[rule.OptionCode] || !rule.matches(address.RegionCode, currencyCode, weight, count) {
+			continue
+		}
+		cost := rule.Cost
+		if rule.PerItemCost != nil {
+			var err error
+			cost, err = cost.Add(rule.PerItemCost.Multiply(count))
+			if err != nil {
+				return nil, fmt.Errorf("invalid shipping rule for option %s: %w", rule.OptionCode, err)
+			}
+		}
+		quoted[rule.OptionCode] = true
+		quotes = append(quotes, &Quote{Code: rule.OptionCode, Description: rule.Description, Cost: cost.Normalize()})
+	}
+	return quotes, nil
+}
+
+// weightOf returns the weight, in grams, of a single unit of the product with the given code.
+func (p *RuleProvider) weightOf(productCode string) int64 {
+	if weight, found := p.ProductWeights[productCode]; found {
+		return weight
+	}
+	return p.DefaultWeightGrams
+}
+
+// matches returns true if the rule applies to a parcel of the given weight and item count, priced in the given
+// currency, going to the given region.
+func (r *Rule) matches(regionCode, currencyCode string, weight int64, count int32) bool {
+	return r.RegionCode == regionCode &&
+		r.Cost != nil && r.Cost.CurrencyCode == currencyCode &&
+		(r.MaxWeightGrams == 0 || weight <= r.MaxWeightGrams) &&
+		(r.MaxItemCount == 0 || count <= r.MaxItemCount)
+}
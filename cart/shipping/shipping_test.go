@@ -0,0 +1,106 @@
+package shipping
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mikebway/poc-gcp-ecomm/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// The currency and product codes used throughout our tests
+	currency         = "USD"
+	anvilProductCode = "anvil"
+	yoyoProductCode  = "yoyo"
+)
+
+// TestRuleProvider works through a table of rules that exercises the region, currency, weight, and item count keys.
+func TestRuleProvider(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Yoyos are light, anvils are not, and express delivery is for small parcels only
+	provider, err := NewRuleProvider([]byte(`{
+		"rules": [
+			{"optionCode": "STANDARD", "description": "Standard", "regionCode": "US", "maxWeightGrams": 10000, "cost": {"currencyCode": "USD", "units": 5}, "perItemCost": {"currencyCode": "USD", "nanos": 250000000}},
+			{"optionCode": "STANDARD", "description": "Freight", "regionCode": "US", "cost": {"currencyCode": "USD", "units": 50}},
+			{"optionCode": "EXPRESS", "description": "Express", "regionCode": "US", "maxWeightGrams": 2000, "maxItemCount": 3, "cost": {"currencyCode": "USD", "units": 20}},
+			{"optionCode": "STANDARD", "description": "Standard", "regionCode": "GB", "cost": {"currencyCode": "GBP", "units": 4}}
+		],
+		"productWeights": {"anvil": 25000},
+		"defaultWeightGrams": 100
+	}`))
+	req.Nil(err, "failed to load the shipping rules: %v", err)
+
+	// Each address and parcel, and the options that should be offered for it
+	us := &types.PostalAddress{RegionCode: "US"}
+	for _, tc := range []struct {
+		name     string
+		address  *types.PostalAddress
+		currency string
+		items    []*Item
+		expected []string
+	}{
+		{"small", us, currency, []*Item{{ProductCode: yoyoProductCode, Quantity: 2}}, []string{"STANDARD Standard USD 5.50", "EXPRESS Express USD 20.00"}},
+		{"too many for express", us, currency, []*Item{{ProductCode: yoyoProductCode, Quantity: 4}}, []string{"STANDARD Standard USD 6.00"}},
+		{"heavy", us, currency, []*Item{{ProductCode: yoyoProductCode, Quantity: 1}, {ProductCode: anvilProductCode, Quantity: 1}}, []string{"STANDARD Freight USD 50.00"}},
+		{"other currency", us, "GBP", []*Item{{ProductCode: yoyoProductCode, Quantity: 1}}, []string{}},
+		{"other region", &types.PostalAddress{RegionCode: "GB"}, "GBP", []*Item{{ProductCode: anvilProductCode, Quantity: 3}}, []string{"STANDARD Standard GBP 4.00"}},
+		{"nowhere we go", &types.PostalAddress{RegionCode: "AQ"}, currency, []*Item{{ProductCode: yoyoProductCode, Quantity: 1}}, []string{}},
+	} {
+		quotes, err := provider.QuoteDeliveryOptions(tc.address, tc.currency, tc.items)
+		req.Nil(err, "should not have seen an error quoting for the %s parcel: %v", tc.name, err)
+		actual := make([]string, len(quotes))
+		for i, quote := range quotes {
+			actual[i] = quote.Code + " " + quote.Description + " " + quote.Cost.String()
+		}
+		req.Equal(tc.expected, actual, "delivery options for the %s parcel did not match", tc.name)
+	}
+
+	// Nothing can be quoted without a region
+	_, err = provider.QuoteDeliveryOptions(nil, currency, nil)
+	req.ErrorIs(err, ErrNoDeliveryRegion, "should have seen a missing region error for a missing address")
+	_, err = provider.QuoteDeliveryOptions(&types.PostalAddress{Locality: "Springfield"}, currency, nil)
+	req.ErrorIs(err, ErrNoDeliveryRegion, "should have seen a missing region error for an address without a region")
+
+	// A rule with a per item cost in a different currency to its base cost is broken
+	provider.Rules[0].PerItemCost = types.NewMoney("GBP", 1, 0)
+	_, err = provider.QuoteDeliveryOptions(us, currency, []*Item{{ProductCode: yoyoProductCode, Quantity: 1}})
+	req.ErrorIs(err, types.ErrCurrencyMismatch, "should have seen a currency mismatch error")
+}
+
+// TestNewDefaultProvider confirms that the embedded shipping rules load and that they can be replaced by a file named
+// in the SHIPPING_RULES environment variable.
+func TestNewDefaultProvider(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// The embedded rules
+	t.Setenv(EnvShippingRules, "")
+	provider, err := NewDefaultProvider()
+	req.Nil(err, "failed to load the embedded shipping rules: %v", err)
+	req.NotEmpty(provider.Rules, "embedded shipping rules should have had some rules")
+	req.Greater(provider.DefaultWeightGrams, int64(0), "embedded shipping rules should have had a default weight")
+
+	// Rules of our own
+	path := filepath.Join(t.TempDir(), "rules.json")
+	req.Nil(os.WriteFile(path, []byte(`{"rules": [{"optionCode": "POST", "regionCode": "FR", "cost": {"currencyCode": "EUR", "units": 3}}]}`), 0o600), "failed to write shipping rules")
+	t.Setenv(EnvShippingRules, path)
+	provider, err = NewDefaultProvider()
+	req.Nil(err, "failed to load our own shipping rules: %v", err)
+	req.Equal(1, len(provider.Rules), "our own shipping rules should have had one rule")
+	req.Equal("POST", provider.Rules[0].OptionCode, "our own shipping rule did not match")
+
+	// Missing and broken rules
+	t.Setenv(EnvShippingRules, filepath.Join(t.TempDir(), "missing.json"))
+	_, err = NewDefaultProvider()
+	req.NotNil(err, "should have seen an error loading missing shipping rules")
+	req.Nil(os.WriteFile(path, []byte(`not json`), 0o600), "failed to write broken shipping rules")
+	t.Setenv(EnvShippingRules, path)
+	_, err = NewDefaultProvider()
+	req.NotNil(err, "should have seen an error loading broken shipping rules")
+}
@@ -0,0 +1,191 @@
+// Package tax defines how the sales tax due on shopping carts and orders is calculated. The cart service consults a
+// TaxCalculator to show shoppers an estimate of the tax on their open carts while the order from cart consumer
+// consults one to lock in the tax recorded against each order.
+//
+// TableCalculator, the one implementation provided, looks tax rates up in a table keyed by region and product tax
+// category, the latter being recorded against each product in the catalog. The table is embedded in the package so that everything, unit tests included, can run offline, but may
+// be replaced by pointing the TAX_TABLE environment variable at a JSON file of the same form.
+package tax
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mikebway/poc-gcp-ecomm/types"
+)
+
+const (
+	// EnvTaxTable names the environment variable that may be set to the path of a JSON file holding the tax table
+	// to be used in place of the embedded default
+	EnvTaxTable = "TAX_TABLE"
+
+	// StandardCategory is the tax category of products that the catalog does not assign to any other category
+	StandardCategory = "standard"
+)
+
+var (
+	// ErrNoTaxRegion is returned when tax cannot be calculated because the address to which the goods are to be
+	// delivered is missing or has no region code
+	ErrNoTaxRegion = errors.New("no region to calculate tax for")
+
+	// ErrNoTaxRate is returned, wrapped, when the tax table has no rate for the region and tax category of a line
+	ErrNoTaxRate = errors.New("no tax rate")
+
+	// defaultTable is the JSON content of the tax table that is used if the TAX_TABLE environment variable is not set
+	//go:embed rates.json
+	defaultTable []byte
+)
+
+// TaxCalculator is the interface through which the tax due on a cart or order is calculated.
+type TaxCalculator interface {
+
+	// CalculateTax returns the tax due on the given lines when delivered to the given address. ErrNoTaxRegion is
+	// returned if the address is missing or has no region code and an error wrapping ErrNoTaxRate if the tax rate
+	// for any line is not known.
+	CalculateTax(address *types.PostalAddress, lines []*Line) (*types.Money, error)
+}
+
+// Line is a single taxable line of a cart or order.
+type Line struct {
+
+	// ProductCode identifies the product being purchased
+	ProductCode string
+
+	// Category (Optional) is the tax category of the product, as recorded in the catalog. Lines without one are in
+	// the StandardCategory.
+	Category string
+
+	// Amount is the price on which tax is due, i.e. the line subtotal less any discount that applies to the product
+	Amount *types.Money
+}
+
+// Rate is an entry in the tax table of a TableCalculator, giving the tax rate for a region, or part of a region,
+// and tax category.
+type Rate struct {
+
+	// RegionCode is the CLDR region code of the country or region to which the rate applies, e.g. "US" or "GB"
+	RegionCode string `json:"regionCode"`
+
+	// AdministrativeArea (Optional) limits the rate to a state, province, or similar, e.g. "CA" in the US
+	AdministrativeArea string `json:"administrativeArea,omitempty"`
+
+	// Category (Optional) limits the rate to products of a single tax category
+	Category string `json:"category,omitempty"`
+
+	// BasisPoints is the rate in hundredths of a percent, e.g. 825 for 8.25%
+	BasisPoints int32 `json:"basisPoints"`
+}
+
+// TableCalculator is a TaxCalculator that looks tax rates up in a table keyed by region and product tax category.
+//
+// The most specific rate that matches a line is used: one for the administrative area and category of the line in
+// preference to one for the administrative area alone, then one for the region and category, and finally one for
+// the region alone. Tax is rounded to cents once it has been totalled across all of the lines.
+type TableCalculator struct {
+
+	// Rates is the table of tax rates
+	Rates []*Rate `json:"rates"`
+}
+
+// NewDefaultCalculator returns a TableCalculator loaded from the JSON file named by the TAX_TABLE environment
+// variable or, if that is not set, from the tax table embedded in this package.
+func NewDefaultCalculator() (*TableCalculator, error) {
+	path := os.Getenv(EnvTaxTable)
+	if path == "" {
+		return NewTableCalculator(defaultTable)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open tax table %s: %w", path, err)
+	}
+	defer file.Close()
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tax table %s: %w", path, err)
+	}
+	return NewTableCalculator(content)
+}
+
+// NewTableCalculator returns a TableCalculator loaded from the given JSON tax table content.
+func NewTableCalculator(content []byte) (*TableCalculator, error) {
+	calculator := &TableCalculator{}
+	err := json.Unmarshal(content, calculator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tax table: %w", err)
+	}
+	return calculator, nil
+}
+
+// CalculateTax returns the tax due on the given lines when delivered to the given address. See the TaxCalculator
+// interface for details.
+func (c *TableCalculator) CalculateTax(address *types.PostalAddress, lines []*Line) (*types.Money, error) {
+
+	// We cannot do anything without knowing where the goods are going
+	if address == nil || address.RegionCode == "" {
+		return nil, ErrNoTaxRegion
+	}
+	if len(lines) == 0 {
+		return nil, errors.New("no lines to calculate tax on")
+	}
+
+	// Add up the tax on each line, then round it
+	var total *types.Money
+	for _, line := range lines {
+		rate := c.rateFor(address, line.category())
+		if rate == nil {
+			return nil, fmt.Errorf("%w: region=%s, administrative area=%s, product code=%s, category=%s", ErrNoTaxRate, address.RegionCode, address.AdministrativeArea, line.ProductCode, line.category())
+		}
+		if line.Amount == nil {
+			return nil, fmt.Errorf("no amount to calculate tax on: product code=%s", line.ProductCode)
+		}
+		tax := line.Amount.BasisPoints(rate.BasisPoints)
+		if total == nil {
+			total = tax
+			continue
+		}
+		var err error
+		total, err = total.Add(tax)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return total.RoundToCents(), nil
+}
+
+// category returns the tax category of the line, the StandardCategory if it has none of its own.
+func (l *Line) category() string {
+	if l.Category == "" {
+		return StandardCategory
+	}
+	return l.Category
+}
+
+// rateFor returns the most specific rate for the given address and tax category, or nil if there is none.
+func (c *TableCalculator) rateFor(address *types.PostalAddress, category string) *Rate {
+
+	// Score each matching rate on how specific it is, preferring administrative area matches over category matches
+	var best *Rate
+	bestScore := -1
+	for _, rate := range c.Rates {
+		if rate.RegionCode != address.RegionCode ||
+			(rate.AdministrativeArea != "" && rate.AdministrativeArea != address.AdministrativeArea) ||
+			(rate.Category != "" && rate.Category != category) {
+			continue
+		}
+		score := 0
+		if rate.AdministrativeArea != "" {
+			score += 2
+		}
+		if rate.Category != "" {
+			score++
+		}
+		if score > bestScore {
+			best, bestScore = rate, score
+		}
+	}
+	return best
+}
@@ -0,0 +1,117 @@
+package tax
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mikebway/poc-gcp-ecomm/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// The currency and product codes used throughout our tests
+	currency        = "USD"
+	bookProductCode = "paperback"
+	toyProductCode  = "yoyo"
+	shirtProduct    = "t_shirt"
+)
+
+// TestTableCalculator works through a table that exercises each level of rate specificity.
+func TestTableCalculator(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// A table where books are zero rated in the region and clothing is exempt in one state
+	calculator, err := NewTableCalculator([]byte(`{
+		"rates": [
+			{"regionCode": "US", "basisPoints": 500},
+			{"regionCode": "US", "category": "books", "basisPoints": 0},
+			{"regionCode": "US", "administrativeArea": "TX", "basisPoints": 625},
+			{"regionCode": "US", "administrativeArea": "NY", "category": "clothing", "basisPoints": 0},
+			{"regionCode": "GB", "category": "books", "basisPoints": 0}
+		]
+	}`))
+	req.Nil(err, "failed to load the tax table: %v", err)
+
+	// Ten dollars and ninety nine cents worth of each product, in the tax category that the catalog would give it
+	categories := map[string]string{bookProductCode: "books", shirtProduct: "clothing"}
+	lines := func(productCodes ...string) []*Line {
+		result := make([]*Line, len(productCodes))
+		for i, productCode := range productCodes {
+			result[i] = &Line{ProductCode: productCode, Category: categories[productCode], Amount: types.NewMoney(currency, 10, 990_000_000)}
+		}
+		return result
+	}
+
+	// Each address and set of products, and the tax that should be due
+	for _, tc := range []struct {
+		expected string
+		address  *types.PostalAddress
+		lines    []*Line
+	}{
+		{"USD 0.55", &types.PostalAddress{RegionCode: "US"}, lines(toyProductCode)},
+		{"USD 0.55", &types.PostalAddress{RegionCode: "US"}, lines(toyProductCode, bookProductCode)},
+		{"USD 0.69", &types.PostalAddress{RegionCode: "US", AdministrativeArea: "TX"}, lines(toyProductCode)},
+		{"USD 1.37", &types.PostalAddress{RegionCode: "US", AdministrativeArea: "TX"}, lines(toyProductCode, bookProductCode)},
+		{"USD 0.55", &types.PostalAddress{RegionCode: "US", AdministrativeArea: "NY"}, lines(toyProductCode, shirtProduct)},
+		{"USD 0.00", &types.PostalAddress{RegionCode: "GB"}, lines(bookProductCode)},
+	} {
+		tax, err := calculator.CalculateTax(tc.address, tc.lines)
+		req.Nil(err, "should not have seen an error calculating %s tax: %v", tc.address.RegionCode, err)
+		req.Equal(tc.expected, tax.String(), "tax in %s/%s did not match", tc.address.RegionCode, tc.address.AdministrativeArea)
+	}
+
+	// Tax cannot be calculated without a region, or a rate for the region
+	_, err = calculator.CalculateTax(nil, lines(toyProductCode))
+	req.ErrorIs(err, ErrNoTaxRegion, "should have seen a missing region error for a missing address")
+	_, err = calculator.CalculateTax(&types.PostalAddress{AdministrativeArea: "TX"}, lines(toyProductCode))
+	req.ErrorIs(err, ErrNoTaxRegion, "should have seen a missing region error for an address without a region")
+	_, err = calculator.CalculateTax(&types.PostalAddress{RegionCode: "GB"}, lines(bookProductCode, toyProductCode))
+	req.ErrorIs(err, ErrNoTaxRate, "should have seen a missing rate error for a product without a rate")
+
+	// Nor without something to tax
+	_, err = calculator.CalculateTax(&types.PostalAddress{RegionCode: "US"}, nil)
+	req.NotNil(err, "should have seen an error calculating tax on nothing")
+	_, err = calculator.CalculateTax(&types.PostalAddress{RegionCode: "US"}, []*Line{{ProductCode: toyProductCode}})
+	req.NotNil(err, "should have seen an error calculating tax on an unpriced line")
+
+	// Nor with mixed currencies
+	mixed := lines(toyProductCode, toyProductCode)
+	mixed[1].Amount = types.NewMoney("GBP", 1, 0)
+	_, err = calculator.CalculateTax(&types.PostalAddress{RegionCode: "US"}, mixed)
+	req.ErrorIs(err, types.ErrCurrencyMismatch, "should have seen a currency mismatch error")
+}
+
+// TestNewDefaultCalculator confirms that the embedded tax table loads and that it can be replaced by a file named in
+// the TAX_TABLE environment variable.
+func TestNewDefaultCalculator(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// The embedded table
+	t.Setenv(EnvTaxTable, "")
+	calculator, err := NewDefaultCalculator()
+	req.Nil(err, "failed to load the embedded tax table: %v", err)
+	req.NotEmpty(calculator.Rates, "embedded tax table should have had some rates")
+
+	// A table of our own
+	path := filepath.Join(t.TempDir(), "rates.json")
+	req.Nil(os.WriteFile(path, []byte(`{"rates": [{"regionCode": "FR", "basisPoints": 2000}]}`), 0o600), "failed to write tax table")
+	t.Setenv(EnvTaxTable, path)
+	calculator, err = NewDefaultCalculator()
+	req.Nil(err, "failed to load our own tax table: %v", err)
+	req.Equal(1, len(calculator.Rates), "our own tax table should have had one rate")
+	req.Equal("FR", calculator.Rates[0].RegionCode, "our own tax table rate did not match")
+
+	// Missing and broken tables
+	t.Setenv(EnvTaxTable, filepath.Join(t.TempDir(), "missing.json"))
+	_, err = NewDefaultCalculator()
+	req.NotNil(err, "should have seen an error loading a missing tax table")
+	req.Nil(os.WriteFile(path, []byte(`not json`), 0o600), "failed to write broken tax table")
+	t.Setenv(EnvTaxTable, path)
+	_, err = NewDefaultCalculator()
+	req.NotNil(err, "should have seen an error loading a broken tax table")
+}
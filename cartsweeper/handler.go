@@ -0,0 +1,156 @@
+// Package cartsweeper implements an HTTP triggered Google Cloud Function, intended to be invoked periodically by
+// Cloud Scheduler, that closes open shopping carts that have been left unattended for too long, giving them the
+// CsAbandonedByTimeout status.
+package cartsweeper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/mikebway/poc-gcp-ecomm/cart/cartapi"
+	"go.uber.org/zap"
+)
+
+const (
+	// EnvCartTTL names the environment variable that may be used to configure how long an open cart may go
+	// without activity before it is considered abandoned, expressed as a Go duration string, e.g. "72h". The
+	// "ttl" request parameter takes precedence over this.
+	EnvCartTTL = "ABANDONED_CART_TTL"
+)
+
+var (
+	// lazyCartService is the lazy-loaded cart service implementation that we use to sweep carts in Firestore
+	lazyCartService *cartapi.CartService
+)
+
+// init is the static initializer used to configure our local and global static variables.
+func init() {
+	// Initialize our Zap logger
+	serviceLogger, _ := zap.NewProduction()
+	zap.ReplaceGlobals(serviceLogger)
+}
+
+// SweepAbandonedCarts is the Cloud Function entry point. All of its parameters are optional and are given as URL
+// query parameters:
+//
+//   - ttl: how long an open cart may go without activity before it is abandoned, as a Go duration string, e.g. "72h"
+//   - batchSize: the number of carts to examine and close at a time
+//   - dryRun: if "true", report the carts that would have been closed without closing them
+//
+// The response body is a JSON description of the carts that were, or would have been, closed.
+func SweepAbandonedCarts(w http.ResponseWriter, r *http.Request) {
+
+	// Flush the logs before exiting each invocation of this Cloud Function
+	//goland:noinspection GoUnhandledErrorResult
+	defer zap.L().Sync()
+
+	// Have our big brother sibling do all the real work while we just handle the HTTP interfacing here
+	status, body, err := doSweepAbandonedCarts(r.Context(), r.URL.Query())
+	if err != nil {
+
+		// Dang - log the error and return it to the caller as well
+		zap.L().Error("failed to sweep abandoned carts", zap.Error(err))
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	// Return the successful status code and our report
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+// doSweepAbandonedCarts does all the heavy lifting for SweepAbandonedCarts. It is implemented as a separate
+// function to isolate the sweep from the transport interface.
+//
+// An HTTP status code is always returned, this should be set in the response regardless of whether
+// an error is also returned. If no error is returned, the JSON response body is returned as well.
+func doSweepAbandonedCarts(ctx context.Context, params url.Values) (int, []byte, error) {
+
+	// Translate the request parameters into a sweep request
+	req, err := parseSweepRequest(params)
+	if err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+
+	// Lazy load the cart service that we wil use to do the actual sweeping
+	svc, err := getCartService()
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	// Sweep away!
+	result, err := svc.SweepAbandonedCarts(ctx, req)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	// Report what we did, or would have done
+	body, err := json.Marshal(result)
+	if err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("failed to marshal sweep result: %w", err)
+	}
+	return http.StatusOK, body, nil
+}
+
+// parseSweepRequest translates the URL query parameters of the HTTP request, and the EnvCartTTL environment
+// variable, into a cartapi.SweepRequest. Parameters that are not given are left for cartapi to default.
+func parseSweepRequest(params url.Values) (*cartapi.SweepRequest, error) {
+
+	// Start with an empty request that will take all the cartapi defaults
+	req := &cartapi.SweepRequest{}
+
+	// The TTL can come from the request or the environment, the request wins
+	ttl := params.Get("ttl")
+	if ttl == "" {
+		ttl = os.Getenv(EnvCartTTL)
+	}
+	if ttl != "" {
+		d, err := time.ParseDuration(ttl)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid ttl, must be a positive duration such as 72h: %s", ttl)
+		}
+		req.TTL = d
+	}
+
+	// The batch size has to be a positive number if it is given at all
+	if batchSize := params.Get("batchSize"); batchSize != "" {
+		n, err := strconv.Atoi(batchSize)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid batchSize, must be a positive integer: %s", batchSize)
+		}
+		req.BatchSize = n
+	}
+
+	// Are we just looking?
+	if dryRun := params.Get("dryRun"); dryRun != "" {
+		b, err := strconv.ParseBool(dryRun)
+		if err != nil {
+			return nil, fmt.Errorf("invalid dryRun, must be true or false: %s", dryRun)
+		}
+		req.DryRun = b
+	}
+
+	// All good
+	return req, nil
+}
+
+// getCartService lazy loads the cart service that we use to sweep carts in Firestore
+func getCartService() (*cartapi.CartService, error) {
+
+	// if we already have the service in hand, return it fast
+	if lazyCartService != nil {
+		return lazyCartService, nil
+	}
+
+	// Try to load the service and cache it for posterity
+	var err error
+	lazyCartService, err = cartapi.NewCartService()
+	return lazyCartService, err
+}
@@ -0,0 +1,144 @@
+package cartsweeper
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikebway/poc-gcp-ecomm/cart/cartapi"
+	"github.com/mikebway/poc-gcp-ecomm/cart/schema"
+	"github.com/mikebway/poc-gcp-ecomm/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// EnvFirestoreEmulator defines the environment variable name that is used to convey that the Firestore emulator
+	// is running, should be used, and how to connect to it
+	EnvFirestoreEmulator = "FIRESTORE_EMULATOR_HOST"
+
+	// FirestoreEmulatorHost defines the server name and port (in TCP6 terms) of the Firestore emulator
+	FirestoreEmulatorHost = "[::1]:8219"
+)
+
+// TestMain, if defined (it's optional), allows setup code to be run before and after the suite of unit tests
+// for this package.
+func TestMain(m *testing.M) {
+
+	// Ensure that our Firestore requests do not get routed to the live project by mistake
+	cartapi.ProjectId = "demo-" + cartapi.ProjectId
+
+	// Configure the environment variable that informs the Firestore client that it should connect to the
+	// emulator and how to reach it.
+	_ = os.Setenv(EnvFirestoreEmulator, FirestoreEmulatorHost)
+
+	// Run all the unit tests
+	m.Run()
+}
+
+// TestSweepHappyPath exercises the main handler function with a dry run followed by a real sweep.
+func TestSweepHappyPath(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Store a cart that nobody has touched for a couple of days
+	cartId := storeStaleCart(req, 48*time.Hour)
+
+	// A dry run should report the cart without closing it
+	result := sweep(req, "/?ttl=24h&dryRun=true")
+	req.True(result.DryRun, "should have been a dry run")
+	req.Contains(result.CartIds, cartId, "dry run should have reported the stale cart")
+
+	// Now for real, this time with the TTL coming from the environment
+	t.Setenv(EnvCartTTL, "24h")
+	var logged string
+	logged = testutil.CaptureLogging(func() {
+		result = sweep(req, "/?batchSize=10")
+	})
+	req.False(result.DryRun, "should not have been a dry run")
+	req.Contains(result.CartIds, cartId, "sweep should have closed the stale cart")
+	req.Contains(logged, "cart abandoned by timeout", "should have seen the cart closure logged")
+
+	// The cart should not be found again
+	result = sweep(req, "/?ttl=24h&dryRun=true")
+	req.NotContains(result.CartIds, cartId, "closed cart should not have been reported again")
+}
+
+// TestSweepBadParameters confirms that invalid request parameters are rejected with a 400 status.
+func TestSweepBadParameters(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Try each bad parameter in turn
+	for _, target := range []string{"/?ttl=forever", "/?ttl=-1h", "/?batchSize=lots", "/?batchSize=0", "/?dryRun=maybe"} {
+		responseRecorder := httptest.NewRecorder()
+		SweepAbandonedCarts(responseRecorder, httptest.NewRequest("POST", target, nil))
+		req.Equal(http.StatusBadRequest, responseRecorder.Code, "should have had a 400 response code for %s", target)
+	}
+
+	// A bad environment variable is just as bad
+	t.Setenv(EnvCartTTL, "a week")
+	_, err := parseSweepRequest(url.Values{})
+	req.NotNil(err, "should have rejected the TTL environment variable")
+	req.Contains(err.Error(), "invalid ttl", "did not see the expected error for the TTL environment variable")
+}
+
+// TestSweepServiceFailure looks at what happens if we cannot obtain a cart service.
+func TestSweepServiceFailure(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Have the service fail to load
+	lazyCartService = nil
+	cartapi.UnitTestNewCartServiceError = errors.New("mock service failure")
+	defer func() {
+		cartapi.UnitTestNewCartServiceError = nil
+	}()
+
+	// See what happens
+	responseRecorder := httptest.NewRecorder()
+	SweepAbandonedCarts(responseRecorder, httptest.NewRequest("POST", "/?dryRun=true", nil))
+	req.Equal(http.StatusInternalServerError, responseRecorder.Code, "should have had a 500 response code")
+	req.Contains(responseRecorder.Body.String(), "mock service failure", "should have seen the service failure in the response")
+}
+
+// sweep invokes the handler with the given request target and returns the decoded result, confirming that it
+// succeeded along the way.
+func sweep(req *require.Assertions, target string) *cartapi.SweepResult {
+	responseRecorder := httptest.NewRecorder()
+	SweepAbandonedCarts(responseRecorder, httptest.NewRequest("POST", target, nil))
+	req.Equal(http.StatusOK, responseRecorder.Code, "should have a 200 OK response code: %s", responseRecorder.Body.String())
+	req.Equal("application/json", responseRecorder.Header().Get("Content-Type"), "should have had a JSON response")
+	result := &cartapi.SweepResult{}
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), result)
+	req.Nil(err, "failed to unmarshal sweep response: %v", err)
+	return result
+}
+
+// storeStaleCart writes an open cart that was created the given duration ago directly to Firestore and returns
+// its ID.
+func storeStaleCart(req *require.Assertions, age time.Duration) string {
+
+	// We need a cart service to give us a Firestore client
+	svc, err := getCartService()
+	req.Nil(err, "failed to obtain cart service: %v", err)
+
+	// Write the cart
+	cart := &schema.ShoppingCart{
+		Id:           uuid.NewString(),
+		CreationTime: time.Now().Add(-age),
+		Status:       schema.CsOpen,
+	}
+	_, err = svc.FsClient.Doc(cart.StoreRefPath()).Set(context.Background(), cart)
+	req.Nil(err, "failed to store stale cart: %v", err)
+	return cart.Id
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/mikebway/poc-gcp-ecomm/cart/cartapi"
 	"github.com/mikebway/poc-gcp-ecomm/cart/schema"
+	products "github.com/mikebway/poc-gcp-ecomm/catalog/schema"
 	pbcart "github.com/mikebway/poc-gcp-ecomm/pb/cart"
 	pbtypes "github.com/mikebway/poc-gcp-ecomm/pb/types"
 	"github.com/mikebway/poc-gcp-ecomm/testutil"
@@ -112,6 +113,12 @@ func TestMain(m *testing.M) {
 		zap.L().Panic("unable to instantiate cart service / firestore client", zap.Error(err))
 	}
 
+	// The cart service prices items from the product catalog so that needs to be stocked with our mock products
+	err = storeMockProducts()
+	if err != nil {
+		zap.L().Panic("unable to store mock products in the catalog", zap.Error(err))
+	}
+
 	// Create our Pub/Sub topic if it does not already exist
 	err = createPubSubTopic()
 	if err != nil {
@@ -412,6 +419,19 @@ func buildMockDeliveryAddress() *pbtypes.PostalAddress {
 	}
 }
 
+// storeMockProducts writes the products that our mock cart items are for into the catalog collection of the
+// Firestore emulator, at the prices that the tests expect them to have.
+func storeMockProducts() error {
+	for _, item := range []*pbcart.CartItem{buildMockCartItem(cartItemProductCode1), buildMockCartItem(cartItemProductCode2)} {
+		product := &products.Product{Code: item.ProductCode, Name: item.ProductCode, UnitPrice: types.MoneyFromPB(item.UnitPrice)}
+		_, err := cartService.FsClient.Doc(product.StoreRefPath()).Set(context.Background(), product)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // buildMockCartItem returns a pbcart.CartItem structure populated with the constant attributes
 // defined at the head of this file to be used to create new shopping carts in our tests.
 //
@@ -0,0 +1,255 @@
+// Package catalogapi contains the gRPC Product Catalog microservice implementation.
+package catalogapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	cartapi "github.com/mikebway/poc-gcp-ecomm/cart/cartapi"
+	"github.com/mikebway/poc-gcp-ecomm/catalog/schema"
+	pbcatalog "github.com/mikebway/poc-gcp-ecomm/pb/catalog"
+	"go.uber.org/zap"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultPageSize is used if no page size is supplied in a pbcatalog.ListProductsRequest, or the value is less
+	// than one.
+	defaultPageSize = 20
+
+	// maxPageSize is used if the page size is supplied in a pbcatalog.ListProductsRequest is greater than 100.
+	maxPageSize = 100
+)
+
+var (
+	// ProjectId is a variable so that unit tests can override it to ensures that test requests are not routed to
+	// the live project! See https://firebase.google.com/doos/emulator-suite/connect_firestore
+	ProjectId string
+
+	// UnitTestNewCatalogServiceError should be returned by NewCatalogService if we are running unit tests
+	// and UnitTestNewCatalogServiceError is not nil.
+	UnitTestNewCatalogServiceError error
+)
+
+// init is the static initializer used to configure our local and global static variables.
+func init() {
+
+	// Set the project ID to be used for live Firestore etc. connections
+	ProjectId = "poc-gcp-ecomm"
+}
+
+// CatalogService is a structure class with methods that implements the catalog.CatalogAPIServer gRPC API
+// storing the product catalog in a Google Cloud Firestore document collection.
+type CatalogService struct {
+	pbcatalog.UnimplementedCatalogAPIServer
+
+	// FsClient is the GCP Firestore client - it is thread safe and can be reused concurrently
+	FsClient *firestore.Client
+
+	// drProxy is used to allow unit tests to intercept firestore.DocumentRef function calls
+	// and insert errors etc. into the responses.
+	drProxy cartapi.DocumentRefProxy
+
+	// dsProxy is used to allow unit tests to intercept firestore.DocumentSnapshot function calls
+	// and insert errors etc. into the responses.
+	dsProxy cartapi.DocumentSnapshotProxy
+
+	// queryProxy is used to allow unit tests to intercept firestore.Query function calls
+	// and insert errors etc. into the responses of the document iterator that the query returns.
+	queryProxy cartapi.QueryExecutionProxy
+}
+
+// NewCatalogService is a factory method returning an instance of our product catalog service.
+func NewCatalogService() (*CatalogService, error) {
+
+	// Build our service instance here with our default, direct passthrough, interception proxies
+	// for firestore.DocumentRef and firestore.DocumentSnapshot function calls
+	svc := &CatalogService{
+		drProxy:    &cartapi.DocRefProxy{},
+		dsProxy:    &cartapi.DocSnapProxy{},
+		queryProxy: &cartapi.QueryExecProxy{},
+	}
+
+	// Obtain a firestore client and stuff that in the service instance
+	ctx := context.Background()
+	var err error
+	if UnitTestNewCatalogServiceError == nil {
+		// Set the Firestore client if we are not unit testing an error situation.
+		svc.FsClient, err = firestore.NewClient(ctx, ProjectId)
+
+	} else {
+		// We are unit testing and required to report an error
+		err = UnitTestNewCatalogServiceError
+	}
+
+	// Check that we obtained a firestore client successfully
+	if err != nil {
+		return nil, fmt.Errorf("could not obtain firestore client: %w", err)
+	}
+
+	// All done - return the populated service instance
+	return svc, nil
+}
+
+// GetProduct retrieves the product matching the product code given in the pbcatalog.GetProductRequest. A
+// codes.NotFound status error is returned if there is no such product.
+func (cs *CatalogService) GetProduct(ctx context.Context, req *pbcatalog.GetProductRequest) (*pbcatalog.GetProductResponse, error) {
+
+	// Obtain a shortcut handle on our globally configured logger and log some context information
+	l := zap.L()
+	l.Info("retrieving product", zap.String("productCode", req.ProductCode))
+
+	// Make sure that the code could be the ID of a product document
+	if !schema.IsValidProductCode(req.ProductCode) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid product code: %s", req.ProductCode)
+	}
+
+	// Ask the firestore client for the specified product
+	product := &schema.Product{Code: req.ProductCode}
+	ref := cs.FsClient.Doc(product.StoreRefPath())
+	snap, err := cs.drProxy.Get(ref, ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, status.Errorf(codes.NotFound, "product not found: product code=%s", req.ProductCode)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve product snapshot with code %s: %w", req.ProductCode, err)
+	}
+
+	// Unmarshall the snapshot into our internal structure form
+	err = cs.dsProxy.DataTo(snap, product)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal product snapshot with code %s: %w", req.ProductCode, err)
+	}
+
+	// Wrap the product in the response structure and we are done
+	l.Info("product retrieved successfully", zap.String("productCode", req.ProductCode), zap.String("path", ref.Path))
+	return &pbcatalog.GetProductResponse{Product: product.AsPBProduct()}, nil
+}
+
+// ListProducts retrieves a page of products in product code order. The next page token is simply the code of the
+// last product on the page.
+func (cs *CatalogService) ListProducts(ctx context.Context, req *pbcatalog.ListProductsRequest) (*pbcatalog.ListProductsResponse, error) {
+
+	// Log what we have been asked to do as context for any later logging on this thread
+	l := zap.L()
+	l.Info("listing products", zap.String("pageToken", req.PageToken))
+
+	// Adjust the page size if it is unreasonable
+	pageSize := int(req.PageSize)
+	if pageSize < 1 {
+		l.Warn("negative/zero page size adjusted to default", zap.Int32("requested", req.PageSize), zap.Int("default", defaultPageSize))
+		pageSize = defaultPageSize
+	} else if pageSize > maxPageSize {
+		l.Warn("excessive page size adjusted to maximum", zap.Int32("requested", req.PageSize), zap.Int("max", maxPageSize))
+		pageSize = maxPageSize
+	}
+
+	// Order the whole collection by product code, starting after the last product of the previous page if we are
+	// not on the first page
+	query := cs.FsClient.Collection(schema.ProductCollection).OrderBy("code", firestore.Asc)
+	if len(req.PageToken) > 0 {
+		query = query.StartAfter(req.PageToken)
+	}
+	query = query.Limit(pageSize)
+
+	// Run the query to obtain an iterator over the matching documents, closing it when we are done with it
+	docs := cs.queryProxy.Documents(ctx, query)
+	defer docs.Stop()
+
+	// Gather the page of products, converting them to their protobuf equivalents as we go
+	var products []*pbcatalog.Product
+	var lastCode string
+	for {
+		product := &schema.Product{}
+		err := docs.Next(product)
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			err = fmt.Errorf("failed to retrieve product matching query: %w", err)
+			l.Error(err.Error())
+			return nil, err
+		}
+		products = append(products, product.AsPBProduct())
+		lastCode = product.Code
+	}
+
+	// Could there be another page?
+	nextPageToken := ""
+	if len(products) >= pageSize {
+		nextPageToken = lastCode
+	}
+
+	// And we are all done
+	l.Info("products listed successfully", zap.Int("count", len(products)), zap.String("nextPageToken", nextPageToken))
+	return &pbcatalog.ListProductsResponse{
+		Products:      products,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// UpsertProduct stores the product given in the pbcatalog.UpsertProductRequest, adding it to the catalog or
+// replacing the existing product with the same code. The product code, name, and a non-negative unit price must be
+// supplied, otherwise a codes.InvalidArgument status error is returned.
+//
+// The creation and modified times given in the request are ignored. A new product is given a creation time of now;
+// a replaced product keeps the creation time it already had. The modified time is always set to now.
+func (cs *CatalogService) UpsertProduct(ctx context.Context, req *pbcatalog.UpsertProductRequest) (*pbcatalog.UpsertProductResponse, error) {
+
+	// Obtain a shortcut handle on our globally configured logger then log what we are about to do
+	l := zap.L()
+	pbProduct := req.GetProduct()
+	l.Info("upserting product", zap.String("productCode", pbProduct.GetCode()))
+
+	// Make sure that we have been given something that we can sell
+	if pbProduct == nil {
+		return nil, status.Error(codes.InvalidArgument, "product must be specified")
+	}
+	if !schema.IsValidProductCode(pbProduct.Code) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid product code: %s", pbProduct.Code)
+	}
+	if pbProduct.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "product name must be specified: product code=%s", pbProduct.Code)
+	}
+	price := pbProduct.UnitPrice
+	if price == nil || len(price.CurrencyCode) != 3 || price.Units < 0 || price.Nanos < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "product unit price must be a non-negative amount in a three letter currency: product code=%s", pbProduct.Code)
+	}
+
+	// Store the product, picking up the creation time of any existing product within the same transaction
+	product := schema.ProductFromPB(pbProduct)
+	ref := cs.FsClient.Doc(product.StoreRefPath())
+	err := cs.FsClient.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		now := time.Now()
+		product.CreationTime = now
+		product.ModifiedTime = now
+		snap, err := cs.drProxy.TransactionalGet(ref, tx)
+		if err == nil {
+			existing := &schema.Product{}
+			err = cs.dsProxy.DataTo(snap, existing)
+			if err != nil {
+				return fmt.Errorf("failed to unmarshal product snapshot with code %s: %w", product.Code, err)
+			}
+			product.CreationTime = existing.CreationTime
+		} else if status.Code(err) != codes.NotFound {
+			return fmt.Errorf("failed to retrieve product snapshot with code %s: %w", product.Code, err)
+		}
+		err = cs.drProxy.TransactionalSet(ref, tx, product)
+		if err != nil {
+			return fmt.Errorf("failed setting product to firestore: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		l.Error(err.Error(), zap.String("productCode", product.Code))
+		return nil, err
+	}
+
+	// All good, log our joy before returning the protocol buffer transliteration of the stored product
+	l.Info("product upserted successfully", zap.String("productCode", product.Code), zap.String("path", ref.Path))
+	return &pbcatalog.UpsertProductResponse{Product: product.AsPBProduct()}, nil
+}
@@ -0,0 +1,264 @@
+package catalogapi
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	cartapi "github.com/mikebway/poc-gcp-ecomm/cart/cartapi"
+	pbcatalog "github.com/mikebway/poc-gcp-ecomm/pb/catalog"
+	"github.com/mikebway/poc-gcp-ecomm/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// EnvFirestoreEmulator defines the environment variable name that is used to convey that the Firestore emulator
+	// is running, should be used, and how to connect to it
+	EnvFirestoreEmulator = "FIRESTORE_EMULATOR_HOST"
+
+	// FirestoreEmulatorHost defines the server name and port (in TCP6 terms) of the Firestore emulator
+	FirestoreEmulatorHost = "[::1]:8219"
+
+	// Values used to populate our mock products
+	productCode        = "catalog_test_yoyo"
+	productName        = "Catalog Test Yoyo"
+	productDescription = "A yoyo that only exists for the catalog unit tests"
+	productCurrency    = "USD"
+
+	// unitTestErrorMessage is used as the error description for error that are deliberately forced to
+	// test error handling.
+	unitTestErrorMessage = "unit test of error handling"
+)
+
+// UTQueryExecProxy implements a wrapper function around firestore.Query that will return an iterator over items
+// that match the query. For unit test purposes, this version always returns errors when trying to iterate over
+// the result set.
+type UTQueryExecProxy struct {
+	cartapi.QueryExecutionProxy
+}
+
+// UTDocIteratorProxy is a unit test implementation of the DocumentIteratorProxy interface that always returns
+// errors when trying to iterate over the result set.
+type UTDocIteratorProxy struct {
+	cartapi.DocumentIteratorProxy
+}
+
+// Documents returns a DocumentIteratorProxy that always fails.
+func (q *UTQueryExecProxy) Documents(ctx context.Context, query firestore.Query) cartapi.DocumentIteratorProxy {
+	return &UTDocIteratorProxy{}
+}
+
+// Next would normally return the next document is a result set but this unit test version always
+// returns an error.
+func (p *UTDocIteratorProxy) Next(target interface{}) error {
+	return errors.New(unitTestErrorMessage)
+}
+
+// Stop stops the iterator, freeing its resources. Always call Stop when you are done with a DocumentIterator.
+// It is not safe to call Stop concurrently with Next.
+func (p *UTDocIteratorProxy) Stop() {
+	// We have nothing to stop :-)
+}
+
+// UTDocRefProxy is a unit test implementation of the DocumentRefProxy interface that allows
+// unit tests to have Firestore operations return errors.
+type UTDocRefProxy struct {
+	cartapi.DocRefProxy
+}
+
+// TransactionalSet is a pass through to the firestore.Transaction Set function that allows
+// unit tests to have Firestore operations return errors.
+func (p *UTDocRefProxy) TransactionalSet(doc *firestore.DocumentRef, tx *firestore.Transaction, data interface{}) error {
+	return errors.New(unitTestErrorMessage)
+}
+
+// UTDocSnapProxy is a unit test implementation of the DocumentSnapshotProxy interface that allows
+// unit tests to have Firestore operations return errors.
+type UTDocSnapProxy struct {
+	cartapi.DocumentSnapshotProxy
+}
+
+// DataTo is a direct pass through to the firestore.DocumentSnapshot DataTo function that allows
+// unit tests to have Firestore operations return errors.
+func (p *UTDocSnapProxy) DataTo(snap *firestore.DocumentSnapshot, target interface{}) error {
+	return errors.New(unitTestErrorMessage)
+}
+
+// TestMain, if defined (it's optional), allows setup code to be run before and after the suite of unit tests
+// for this package.
+func TestMain(m *testing.M) {
+
+	// Ensure that our Firestore requests do not get routed to the live project by mistake
+	ProjectId = "demo-" + ProjectId
+
+	// Configure the environment variable that informs the Firestore client that it should connect to the
+	// emulator and how to reach it.
+	_ = os.Setenv(EnvFirestoreEmulator, FirestoreEmulatorHost)
+
+	// Run all the unit tests
+	m.Run()
+}
+
+// commonTestSetup performs the basic foundation stuff that most of this package's tests require.
+func commonTestSetup(t *testing.T) (*require.Assertions, context.Context, *CatalogService) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Initialize our target catalog service
+	service, err := NewCatalogService()
+	req.Nil(err, "failed to obtain catalog service: %v", err)
+	return req, context.Background(), service
+}
+
+// buildMockProduct returns a protocol buffer product with the given code, priced at the given number of dollars.
+func buildMockProduct(code string, units int64) *pbcatalog.Product {
+	return &pbcatalog.Product{
+		Code:        code,
+		Name:        productName,
+		Description: productDescription,
+		UnitPrice:   types.NewMoney(productCurrency, units, 0).AsPBMoney(),
+	}
+}
+
+// TestUpsertAndGetProduct adds a product to the catalog, retrieves it, then replaces it, confirming that the
+// creation time survives the replacement.
+func TestUpsertAndGetProduct(t *testing.T) {
+
+	// Do the common setup that most of our tests require
+	req, ctx, service := commonTestSetup(t)
+
+	// Start with a clean slate, in case an earlier run left our product behind
+	_, err := service.FsClient.Doc("products/" + productCode).Delete(ctx)
+	req.Nil(err, "failed to delete the mock product: %v", err)
+
+	// Add the product
+	startTime := time.Now()
+	upsertResp, err := service.UpsertProduct(ctx, &pbcatalog.UpsertProductRequest{Product: buildMockProduct(productCode, 10)})
+	req.Nil(err, "should not have seen an error adding the product: %v", err)
+	created := upsertResp.Product
+	req.Equal(productCode, created.Code, "added product code did not match")
+	req.False(created.CreationTime.AsTime().Before(startTime), "added product creation time should have been set")
+	req.Equal(created.CreationTime.AsTime(), created.ModifiedTime.AsTime(), "added product modified time should match its creation time")
+
+	// Read it back
+	getResp, err := service.GetProduct(ctx, &pbcatalog.GetProductRequest{ProductCode: productCode})
+	req.Nil(err, "should not have seen an error retrieving the product: %v", err)
+	req.Equal(productName, getResp.Product.Name, "retrieved product name did not match")
+	req.Equal(productDescription, getResp.Product.Description, "retrieved product description did not match")
+	req.Equal("USD 10.00", types.MoneyFromPB(getResp.Product.UnitPrice).String(), "retrieved product price did not match")
+
+	// Put the price up; the creation time supplied in the request is ignored in favour of the stored one
+	replacement := buildMockProduct(productCode, 12)
+	replacement.CreationTime = timestamppb.New(startTime.Add(-time.Hour))
+	upsertResp, err = service.UpsertProduct(ctx, &pbcatalog.UpsertProductRequest{Product: replacement})
+	req.Nil(err, "should not have seen an error replacing the product: %v", err)
+	req.Equal(created.CreationTime.AsTime().Unix(), upsertResp.Product.CreationTime.AsTime().Unix(), "replaced product should have kept its creation time")
+	req.False(upsertResp.Product.ModifiedTime.AsTime().Before(created.ModifiedTime.AsTime()), "replaced product modified time should have moved on")
+	getResp, err = service.GetProduct(ctx, &pbcatalog.GetProductRequest{ProductCode: productCode})
+	req.Nil(err, "should not have seen an error retrieving the replaced product: %v", err)
+	req.Equal("USD 12.00", types.MoneyFromPB(getResp.Product.UnitPrice).String(), "replaced product price did not match")
+}
+
+// TestGetProductFailures confirms that missing products, invalid product codes, and corrupt product documents are
+// all reported as errors.
+func TestGetProductFailures(t *testing.T) {
+
+	// Do the common setup that most of our tests require
+	req, ctx, service := commonTestSetup(t)
+
+	// Products that do not exist, or could not exist
+	_, err := service.GetProduct(ctx, &pbcatalog.GetProductRequest{ProductCode: "no_such_yoyo"})
+	req.Equal(codes.NotFound, status.Code(err), "missing product should have been not found: %v", err)
+	_, err = service.GetProduct(ctx, &pbcatalog.GetProductRequest{ProductCode: "yoyos/gold"})
+	req.Equal(codes.InvalidArgument, status.Code(err), "product code with a slash should have been an invalid argument: %v", err)
+
+	// A product that cannot be unmarshalled
+	_, err = service.UpsertProduct(ctx, &pbcatalog.UpsertProductRequest{Product: buildMockProduct(productCode, 10)})
+	req.Nil(err, "should not have seen an error adding the product: %v", err)
+	service.dsProxy = &UTDocSnapProxy{}
+	_, err = service.GetProduct(ctx, &pbcatalog.GetProductRequest{ProductCode: productCode})
+	req.NotNil(err, "should have seen an error unmarshalling the product")
+	req.Contains(err.Error(), unitTestErrorMessage, "did not see the specific error that we expected")
+}
+
+// TestUpsertProductRejected works through a table of products that should not be accepted into the catalog.
+func TestUpsertProductRejected(t *testing.T) {
+
+	// Do the common setup that most of our tests require
+	req, ctx, service := commonTestSetup(t)
+
+	// Each product should be refused as an invalid argument
+	noName := buildMockProduct(productCode, 10)
+	noName.Name = ""
+	noPrice := buildMockProduct(productCode, 10)
+	noPrice.UnitPrice = nil
+	badCurrency := buildMockProduct(productCode, 10)
+	badCurrency.UnitPrice.CurrencyCode = "DOLLARS"
+	negative := buildMockProduct(productCode, -10)
+	for name, product := range map[string]*pbcatalog.Product{
+		"missing":      nil,
+		"bad code":     buildMockProduct("", 10),
+		"no name":      noName,
+		"no price":     noPrice,
+		"bad currency": badCurrency,
+		"negative":     negative,
+	} {
+		_, err := service.UpsertProduct(ctx, &pbcatalog.UpsertProductRequest{Product: product})
+		req.Equal(codes.InvalidArgument, status.Code(err), "%s product should have been an invalid argument: %v", name, err)
+	}
+
+	// Failures to store a perfectly good product are passed back too
+	service.drProxy = &UTDocRefProxy{}
+	_, err := service.UpsertProduct(ctx, &pbcatalog.UpsertProductRequest{Product: buildMockProduct(productCode, 10)})
+	req.NotNil(err, "should have seen an error storing the product")
+	req.Contains(err.Error(), unitTestErrorMessage, "did not see the specific error that we expected")
+}
+
+// TestListProducts pages through the catalog, confirming that the products we add appear in product code order.
+// Other test packages share the Firestore emulator, so there may be other products in the catalog too.
+func TestListProducts(t *testing.T) {
+
+	// Do the common setup that most of our tests require
+	req, ctx, service := commonTestSetup(t)
+
+	// Add a handful of products
+	productCodes := []string{productCode + "_a", productCode + "_b", productCode + "_c", productCode + "_d", productCode + "_e"}
+	for i, code := range productCodes {
+		_, err := service.UpsertProduct(ctx, &pbcatalog.UpsertProductRequest{Product: buildMockProduct(code, int64(i+1))})
+		req.Nil(err, "should not have seen an error adding product %s: %v", code, err)
+	}
+
+	// Page through the whole catalog, two products at a time
+	var listed []string
+	listReq := &pbcatalog.ListProductsRequest{PageSize: 2}
+	for pages := 0; ; pages++ {
+		req.Less(pages, 1000, "paging through the catalog should have come to an end")
+		listResp, err := service.ListProducts(ctx, listReq)
+		req.Nil(err, "should not have seen an error listing products: %v", err)
+		req.LessOrEqual(len(listResp.Products), 2, "page should not have held more than two products")
+		for _, product := range listResp.Products {
+			if len(listed) > 0 {
+				req.Less(listed[len(listed)-1], product.Code, "products should have been listed in product code order")
+			}
+			listed = append(listed, product.Code)
+		}
+		if listResp.NextPageToken == "" {
+			break
+		}
+		listReq.PageToken = listResp.NextPageToken
+	}
+	req.Subset(listed, productCodes, "all of our products should have been listed")
+
+	// Failures to read the catalog are passed back
+	service.queryProxy = &UTQueryExecProxy{}
+	_, err := service.ListProducts(ctx, &pbcatalog.ListProductsRequest{PageSize: 500})
+	req.NotNil(err, "should have seen an error listing products")
+	req.Contains(err.Error(), unitTestErrorMessage, "did not see the specific error that we expected")
+}
@@ -0,0 +1,113 @@
+// Package schema defines product catalog document structures as they might be stored in a Google Firestore
+// or represented in JSON
+package schema
+
+import (
+	"time"
+
+	pbcatalog "github.com/mikebway/poc-gcp-ecomm/pb/catalog"
+	"github.com/mikebway/poc-gcp-ecomm/types"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// ProductCollection names the firestore collection under which all of our product documents are stored, keyed
+	// by their product codes
+	ProductCollection = "products"
+
+	// maxProductCodeLength is the longest product code that we will accept
+	maxProductCodeLength = 128
+)
+
+// Product describes something that can be bought, i.e. added to a shopping cart. The catalog is the authority on
+// which products exist and what they cost; the cart service looks up every item added to a cart here rather than
+// trusting the shopper's client to tell it.
+type Product struct {
+	// Code is the equivalent of a SKU code identifying the type of product or service. It doubles as the ID of the
+	// product document.
+	Code string `firestore:"code" json:"code"`
+
+	// Name is a short, human readable, name for the product
+	Name string `firestore:"name" json:"name"`
+
+	// Description is a longer, human readable, description of the product
+	Description string `firestore:"description" json:"description"`
+
+	// UnitPrice is the price of a single unit of the product
+	UnitPrice *types.Money `firestore:"unitPrice" json:"unitPrice"`
+
+	// CreationTime is the time at which the product was first added to the catalog
+	CreationTime time.Time `firestore:"creationTime" json:"creationTime"`
+
+	// ModifiedTime is the time at which the product was last updated
+	ModifiedTime time.Time `firestore:"modifiedTime" json:"modifiedTime"`
+
+	// Digital is true if the product does not need to be delivered to a postal address, e.g. a download. Products
+	// are physical unless they say otherwise.
+	Digital bool `firestore:"digital,omitempty" json:"digital,omitempty"`
+
+	// TaxCategory (Optional) is the tax category of the product, e.g. "books", that the tax rate charged on it is
+	// looked up by. Products without one are in the standard category.
+	TaxCategory string `firestore:"taxCategory,omitempty" json:"taxCategory,omitempty"`
+}
+
+// StoreRefPath returns the string representation of the document reference path for this Product.
+func (p *Product) StoreRefPath() string {
+	return ProductCollection + "/" + p.Code
+}
+
+// AsPBProduct returns the protocol buffer representation of this product.
+func (p *Product) AsPBProduct() *pbcatalog.Product {
+
+	// The times should be set for any product that has been stored, but we will play it safe just the same
+	var creationTimePB, modifiedTimePB *timestamppb.Timestamp
+	if !p.CreationTime.IsZero() {
+		creationTimePB = timestamppb.New(p.CreationTime)
+	}
+	if !p.ModifiedTime.IsZero() {
+		modifiedTimePB = timestamppb.New(p.ModifiedTime)
+	}
+
+	return &pbcatalog.Product{
+		Code:         p.Code,
+		Name:         p.Name,
+		Description:  p.Description,
+		UnitPrice:    p.UnitPrice.AsPBMoney(),
+		CreationTime: creationTimePB,
+		ModifiedTime: modifiedTimePB,
+		Digital:      p.Digital,
+		TaxCategory:  p.TaxCategory,
+	}
+}
+
+// ProductFromPB is a factory method that returns a Product representation derived from its protocol buffer
+// equivalent.
+func ProductFromPB(pbp *pbcatalog.Product) *Product {
+
+	// Either of the times may be missing, in which case we leave them as zero values
+	var creationTime, modifiedTime time.Time
+	if pbp.CreationTime != nil {
+		creationTime = pbp.CreationTime.AsTime()
+	}
+	if pbp.ModifiedTime != nil {
+		modifiedTime = pbp.ModifiedTime.AsTime()
+	}
+
+	return &Product{
+		Code:         pbp.Code,
+		Name:         pbp.Name,
+		Description:  pbp.Description,
+		UnitPrice:    types.MoneyFromPB(pbp.UnitPrice),
+		CreationTime: creationTime,
+		ModifiedTime: modifiedTime,
+		Digital:      pbp.Digital,
+		TaxCategory:  pbp.TaxCategory,
+	}
+}
+
+// IsValidProductCode returns true if the given product code can be used as the ID of a product document. Product
+// codes are case-sensitive, must not be empty or longer than 128 characters, and must not contain a slash or be
+// one of the names that Firestore reserves for itself.
+func IsValidProductCode(code string) bool {
+	return types.IsValidDocumentId(code, maxProductCodeLength)
+}
@@ -0,0 +1,87 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mikebway/poc-gcp-ecomm/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// Values used to populate our mock product
+	productCode        = "gold_yoyo"
+	productName        = "Gold Yoyo"
+	productDescription = "A solid gold yoyo for the discerning player"
+	productCurrency    = "USD"
+	productUnits       = 1651
+	productNanos       = 940000000
+	productTaxCategory = "luxury"
+)
+
+var (
+	// The creation and modification times of our mock product
+	productCreationTime = time.Date(2023, time.January, 6, 9, 30, 0, 0, time.UTC)
+	productModifiedTime = time.Date(2023, time.January, 7, 14, 45, 0, 0, time.UTC)
+)
+
+// buildMockProduct returns a fully populated Product structure
+func buildMockProduct() *Product {
+	return &Product{
+		Code:         productCode,
+		Name:         productName,
+		Description:  productDescription,
+		UnitPrice:    types.NewMoney(productCurrency, productUnits, productNanos),
+		CreationTime: productCreationTime,
+		ModifiedTime: productModifiedTime,
+		TaxCategory:  productTaxCategory,
+	}
+}
+
+// TestProductPath evaluates the Firestore path of a product.
+func TestProductPath(t *testing.T) {
+	req := require.New(t)
+	product := &Product{Code: productCode}
+	req.Equal("products/gold_yoyo", product.StoreRefPath(), "product path content does not match expected value")
+}
+
+// TestProductPBRoundTrip converts a product to its protocol buffer form and back again, confirming that nothing is
+// lost along the way.
+func TestProductPBRoundTrip(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Out and back again
+	product := buildMockProduct()
+	pbProduct := product.AsPBProduct()
+	req.Equal(productCode, pbProduct.Code, "protobuf product code does not match")
+	req.Equal("USD 1651.94", types.MoneyFromPB(pbProduct.UnitPrice).String(), "protobuf unit price does not match")
+	req.Equal(productCreationTime, pbProduct.CreationTime.AsTime(), "protobuf creation time does not match")
+	req.Equal(productTaxCategory, pbProduct.TaxCategory, "protobuf tax category does not match")
+	req.Equal(product, ProductFromPB(pbProduct), "product did not survive the round trip to protobuf and back")
+
+	// Digital products stay digital
+	product.Digital = true
+	pbProduct = product.AsPBProduct()
+	req.True(pbProduct.Digital, "protobuf product should have been digital")
+	req.Equal(product, ProductFromPB(pbProduct), "digital product did not survive the round trip to protobuf and back")
+
+	// A product that has never been stored has no times, and neither does its protocol buffer form
+	product = &Product{Code: productCode}
+	pbProduct = product.AsPBProduct()
+	req.Nil(pbProduct.CreationTime, "protobuf creation time should not have been set")
+	req.Nil(pbProduct.ModifiedTime, "protobuf modified time should not have been set")
+	req.Nil(pbProduct.UnitPrice, "protobuf unit price should not have been set")
+	req.Equal(product, ProductFromPB(pbProduct), "unstored product did not survive the round trip to protobuf and back")
+}
+
+// TestIsValidProductCode confirms that product codes that could not be used as Firestore document IDs are refused.
+func TestIsValidProductCode(t *testing.T) {
+	req := require.New(t)
+	req.True(IsValidProductCode(productCode), "%s should have been a valid product code", productCode)
+	for _, code := range []string{"", "gold/yoyo", ".", "..", "__yoyo__", strings.Repeat("y", 129)} {
+		req.False(IsValidProductCode(code), "%q should not have been a valid product code", code)
+	}
+}
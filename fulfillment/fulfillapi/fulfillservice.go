@@ -4,16 +4,19 @@ package fulfillapi
 import (
 	"context"
 	"fmt"
-	"strconv"
+	"sort"
 	"strings"
 	"time"
 
 	"cloud.google.com/go/firestore"
 	cartapi "github.com/mikebway/poc-gcp-ecomm/cart/cartapi"
 	"github.com/mikebway/poc-gcp-ecomm/fulfillment/schema"
+	"github.com/mikebway/poc-gcp-ecomm/paging"
 	pbfulfillment "github.com/mikebway/poc-gcp-ecomm/pb/fulfillment"
 	"go.uber.org/zap"
 	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -102,6 +105,18 @@ func NewFulfillmentService() (*FulfillmentService, error) {
 //
 // An error will be returned if the tasks are already present in Firestore.
 func (fs *FulfillmentService) SaveTasks(ctx context.Context, tasks []*schema.Task) error {
+	return fs.SaveTasksHeldForPayment(ctx, tasks, nil)
+}
+
+// SaveTasksHeldForPayment stores the given slice of schema.Task structures in the Firestore document collection,
+// in the same way as SaveTasks, except that, if awaitingPayment reports that the payment for the tasks' order has
+// yet to be captured, the tasks are held in WAITING_PAYMENT until ReleaseTasksHeldForPayment is called for the order.
+// The tasks themselves are left as they are; it is copies that are held.
+//
+// awaitingPayment is called within the transaction that stores the tasks, so that the tasks are held, or not, in
+// step with the payment being captured. It may read but must not write. A nil awaitingPayment is taken to mean that
+// the tasks are not to be held.
+func (fs *FulfillmentService) SaveTasksHeldForPayment(ctx context.Context, tasks []*schema.Task, awaitingPayment func(tx *firestore.Transaction) (bool, error)) error {
 
 	// Obtain a shortcut handle on our globally configured logger and log some context
 	l := zap.L()
@@ -112,28 +127,18 @@ func (fs *FulfillmentService) SaveTasks(ctx context.Context, tasks []*schema.Tas
 	// Open a transaction wrapping
 	err := fs.FsClient.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
 
-		// Loop through the tasks we have to save
-		for _, task := range tasks {
-
-			// Log the task information that we are going to try to store
-			l.Info("storing task", zap.String("taskId", task.Id), zap.String("orderId", task.OrderId),
-				zap.String("itemId", task.OrderItemId), zap.String("product", task.ProductCode), zap.String("task", task.TaskCode))
-
-			// Set the task submission time
-			task.SubmissionTime = time.Now()
-
-			// Store the task in Firestore
-			ref := fs.FsClient.Doc(task.StoreRefPath())
-			err := fs.drProxy.TransactionalCreate(ref, tx, task)
+		// Find out whether the tasks have to wait for the order to be paid for before anything else
+		hold := false
+		if awaitingPayment != nil {
+			var err error
+			hold, err = awaitingPayment(tx)
 			if err != nil {
-				err = fmt.Errorf("failed creating task document in Firestore: %w", err)
-				l.Error(err.Error(), zap.String("taskId", task.Id))
 				return err
 			}
 		}
 
-		// All is well if we get her
-		return nil
+		// Store the tasks
+		return fs.createTasks(tx, tasks, hold)
 	})
 
 	// How did that go?
@@ -145,6 +150,193 @@ func (fs *FulfillmentService) SaveTasks(ctx context.Context, tasks []*schema.Tas
 	return err
 }
 
+// SaveTransactionalTasks stores the given slice of schema.Task structures in the Firestore document collection, in
+// the same way as SaveTasks, but within the given transaction, so that the tasks are only created if everything
+// else that the caller does in the transaction is too. As with any other transaction, all reads must have been made
+// before this is called.
+func (fs *FulfillmentService) SaveTransactionalTasks(tx *firestore.Transaction, tasks []*schema.Task) error {
+	return fs.createTasks(tx, tasks, false)
+}
+
+// createTasks stores the given slice of schema.Task structures within the given transaction, setting their
+// submission times as it goes. If hold is true, it is copies of the tasks held in WAITING_PAYMENT that are stored.
+func (fs *FulfillmentService) createTasks(tx *firestore.Transaction, tasks []*schema.Task, hold bool) error {
+
+	// Obtain a shortcut handle on our globally configured logger
+	l := zap.L()
+
+	// Loop through the tasks we have to save
+	for _, task := range tasks {
+
+		// Log the task information that we are going to try to store
+		l.Info("storing task", zap.String("taskId", task.Id), zap.String("orderId", task.OrderId),
+			zap.String("itemId", task.OrderItemId), zap.String("product", task.ProductCode), zap.String("task", task.TaskCode))
+
+		// Set the task submission time
+		task.SubmissionTime = time.Now()
+
+		// Store the task in Firestore, held back if it has to wait to be paid for
+		stored := task
+		if hold {
+			stored = task.HeldForPayment()
+		}
+		ref := fs.FsClient.Doc(task.StoreRefPath())
+		err := fs.drProxy.TransactionalCreate(ref, tx, stored)
+		if err != nil {
+			err = fmt.Errorf("failed creating task document in Firestore: %w", err)
+			l.Error(err.Error(), zap.String("taskId", task.Id))
+			return err
+		}
+	}
+
+	// All is well if we get here
+	return nil
+}
+
+// ReleaseTasksHeldForPayment restores the tasks of the order with the given ID that are held in WAITING_PAYMENT, see
+// SaveTasksHeldForPayment, to the statuses and reason codes that they would have had if they had not been held, within
+// the given transaction. The number of tasks released is returned.
+//
+// The tasks are read, then written, so this must be called after all of the caller's other reads in the transaction
+// and before any of its writes.
+func (fs *FulfillmentService) ReleaseTasksHeldForPayment(tx *firestore.Transaction, orderId string) (int, error) {
+
+	// Find the held tasks first
+	query := fs.FsClient.Collection(schema.TaskCollection).Where("orderId", "==", orderId).Where("status", "==", schema.WAITING_PAYMENT)
+	docs := tx.Documents(query)
+	var held []*schema.Task
+	for {
+		task := &schema.Task{}
+		snap, err := docs.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err == nil {
+			err = fs.dsProxy.DataTo(snap, task)
+		}
+		if err != nil {
+			docs.Stop()
+			return 0, fmt.Errorf("failed to retrieve tasks held for payment: order ID=%s: %w", orderId, err)
+		}
+		held = append(held, task)
+	}
+	docs.Stop()
+
+	// Then let them go
+	for _, task := range held {
+		ref := fs.FsClient.Doc(task.StoreRefPath())
+		err := fs.drProxy.TransactionalUpdate(ref, tx, []firestore.Update{
+			{Path: "status", Value: task.HeldStatus},
+			{Path: "reasonCode", Value: task.HeldReasonCode},
+			{Path: "heldStatus", Value: firestore.Delete},
+			{Path: "heldReasonCode", Value: firestore.Delete},
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed releasing task held for payment: task ID=%s: %w", task.Id, err)
+		}
+		zap.L().Info("released task held for payment", zap.String("taskId", task.Id), zap.String("orderId", orderId))
+	}
+	return len(held), nil
+}
+
+// CancelOrderTasks moves every task of the order with the given ID that has not already been completed or canceled to
+// CANCELED, with the given reason code. This is for internal domain use only and so does not accept or return
+// protobuf structures.
+//
+// Order items that are past the point at which they can be cancelled, see schema.IrrevocableTaskCodes, have their
+// tasks left alone. If there are any such items, a codes.FailedPrecondition status error is returned and nothing is
+// cancelled unless allowPartial is true, in which case the tasks of the other items are cancelled and the IDs of the
+// items that were left alone are returned. Even then, if there are no other tasks to be cancelled, the cancellation
+// is refused.
+//
+// Tasks that handle the return of an order item, see schema.Task.IsReturnTask, are not part of the order's
+// fulfillment and are left alone regardless.
+//
+// The number of tasks cancelled is returned along with the IDs of any items left alone. The tasks are read and
+// updated in a single transaction so that no task can slip past the irrevocable point while we are not looking.
+func (fs *FulfillmentService) CancelOrderTasks(ctx context.Context, orderId string, reasonCode string, allowPartial bool) (int, []string, error) {
+
+	// Obtain a shortcut handle on our globally configured logger and log some context
+	l := zap.L()
+	l.Info("cancelling order tasks", zap.String("orderId", orderId), zap.String("reason", reasonCode), zap.Bool("allowPartial", allowPartial))
+
+	var cancelled []*schema.Task
+	var keptItemIds []string
+	err := fs.FsClient.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+
+		// Start afresh each time that the transaction is attempted
+		cancelled = nil
+		keptItemIds = nil
+
+		// Load all the tasks for the order
+		query := fs.FsClient.Collection(schema.TaskCollection).Where("orderId", "==", orderId)
+		docs := tx.Documents(query)
+		var tasks []*schema.Task
+		for {
+			task := &schema.Task{}
+			snap, err := docs.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err == nil {
+				err = fs.dsProxy.DataTo(snap, task)
+			}
+			if err != nil {
+				docs.Stop()
+				return fmt.Errorf("failed to retrieve tasks for order: order ID=%s: %w", orderId, err)
+			}
+			if !task.IsReturnTask() {
+				tasks = append(tasks, task)
+			}
+		}
+		docs.Stop()
+
+		// Find the items that it is too late to cancel
+		kept := make(map[string]bool)
+		for _, task := range tasks {
+			if task.IsIrrevocable() && !kept[task.OrderItemId] {
+				kept[task.OrderItemId] = true
+				keptItemIds = append(keptItemIds, task.OrderItemId)
+			}
+		}
+		sort.Strings(keptItemIds)
+
+		// And the tasks that can be cancelled
+		for _, task := range tasks {
+			if !task.IsFinished() && !kept[task.OrderItemId] {
+				cancelled = append(cancelled, task)
+			}
+		}
+
+		// Refuse to go any further if that is not what the caller wants
+		if len(keptItemIds) > 0 && (!allowPartial || len(cancelled) == 0) {
+			return status.Errorf(codes.FailedPrecondition, "order items are past the point of cancellation: order ID=%s, item IDs=%s",
+				orderId, strings.Join(keptItemIds, ","))
+		}
+
+		// Cancel the rest
+		for _, task := range cancelled {
+			ref := fs.FsClient.Doc(task.StoreRefPath())
+			err := fs.drProxy.TransactionalUpdate(ref, tx, []firestore.Update{
+				{Path: "status", Value: schema.CANCELED},
+				{Path: "reasonCode", Value: reasonCode},
+			})
+			if err != nil {
+				return fmt.Errorf("failed cancelling task: task ID=%s: %w", task.Id, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		l.Error("order task cancellation failed", zap.String("orderId", orderId), zap.Error(err))
+		return 0, nil, err
+	}
+
+	// All good, log our joy and return
+	l.Info("order tasks cancelled", zap.String("orderId", orderId), zap.Int("cancelled", len(cancelled)), zap.Strings("keptItemIds", keptItemIds))
+	return len(cancelled), keptItemIds, nil
+}
+
 // UpdateTaskStatus allows the caller to modify just the status of the task and the associate reason code, i.e.
 // giving a description of why the status was changed. The reason code is optional.
 func (fs *FulfillmentService) UpdateTaskStatus(ctx context.Context, req *pbfulfillment.UpdateTaskStatusRequest) (*pbfulfillment.UpdateTaskStatusResponse, error) {
@@ -240,7 +432,7 @@ func (fs *FulfillmentService) GetTasks(ctx context.Context, req *pbfulfillment.G
 	}
 
 	// Run the query to the set of matching tasks. Casting the page size is safe - we just checked that it lies between 1 and 100
-	tasks, nextPageToken, err := fs.executeQuery(ctx, query, int(req.PageSize))
+	tasks, nextPageToken, err := fs.executeQuery(ctx, query, req)
 	if err != nil {
 		return nil, err
 	}
@@ -260,8 +452,8 @@ func (fs *FulfillmentService) GetTasks(ctx context.Context, req *pbfulfillment.G
 }
 
 // executeQuery uses the supplied query to obtain an task document iterator, then build a slice of
-// results from that.
-func (fs *FulfillmentService) executeQuery(ctx context.Context, query firestore.Query, pageSize int) ([]*schema.Task, string, error) {
+// results from that. The page token for the next page is bound to the given request.
+func (fs *FulfillmentService) executeQuery(ctx context.Context, query firestore.Query, req *pbfulfillment.GetTasksRequest) ([]*schema.Task, string, error) {
 
 	// Run the query to obtain an iterator over the matching documents
 	docs := fs.queryProxy.Documents(ctx, query)
@@ -293,11 +485,15 @@ func (fs *FulfillmentService) executeQuery(ctx context.Context, query firestore.
 	// Could there be another page? The tasks slice should never be longer than pageSize, but we test for >= defensively
 	nextPageToken := ""
 	orderCount := len(tasks)
-	if orderCount >= pageSize {
+	if orderCount >= int(req.PageSize) {
 
-		// There could be more to load, use the last processed task's ID as our position marker
+		// There could be more to load, use the last processed task's submission time and ID as our position marker
 		lastTask := tasks[orderCount-1]
-		nextPageToken = fmt.Sprintf("%x,%s", lastTask.SubmissionTime.UnixNano(), tasks[orderCount-1].Id)
+		var err error
+		nextPageToken, err = paging.NewToken(req, &paging.Cursor{SubmissionTime: lastTask.SubmissionTime, Id: lastTask.Id})
+		if err != nil {
+			return nil, "", err
+		}
 	}
 
 	// All is well if we reach this point
@@ -333,14 +529,14 @@ func (fs *FulfillmentService) buildTaskQuery(query firestore.Query, req *pbfulfi
 	// already been returned, i.e. start after that one.
 	if len(req.PageToken) > 0 {
 
-		// Split the page token into its two parts - there should be two parts, submission time and ID
-		afterTime, afterTaskId, err := splitPageToken(req.PageToken)
+		// Recover the submission time and ID from the page token, so long as it was issued for this same query
+		cursor, err := paging.ParseToken(req, req.PageToken)
 		if err != nil {
 			return query, err
 		}
 
 		// Add the start after factors to our query
-		query = query.StartAfter(afterTime, afterTaskId)
+		query = query.StartAfter(cursor.SubmissionTime, cursor.Id)
 	}
 
 	// Limit the size of the result set to the page size
@@ -350,26 +546,6 @@ func (fs *FulfillmentService) buildTaskQuery(query firestore.Query, req *pbfulfi
 	return query, nil
 }
 
-// splitPageToken breaks a page token string into its time and task ID components.
-func splitPageToken(token string) (time.Time, string, error) {
-
-	// Split the page token into its two parts - there should be two parts
-	parts := strings.Split(token, ",")
-	if len(parts) == 2 {
-
-		// Convert the first part from a Unix time value to a time.Time
-		unixNanoTime, err := strconv.ParseInt(parts[0], 16, 64)
-		if err == nil {
-
-			// All looks good (as shallowly as we are bothering to look), return what we have
-			return time.Unix(0, unixNanoTime), parts[1], nil
-		}
-	}
-
-	// Only arrive here if the page token is invalid
-	return time.Time{}, "", fmt.Errorf("invalid page token: %s", token)
-}
-
 // logQuery writes a log entry documenting the attributes that make up a FulfillmentService.GetTasks Firestore query.
 // PII fields, i.e. family name, will be one way encrypted so that they can be safely logged (hashed with
 // a salt, encoded as base65, and truncated to 12 characters).
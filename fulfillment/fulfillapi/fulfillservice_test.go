@@ -18,6 +18,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -173,6 +175,139 @@ func TestSaveTaskError(t *testing.T) {
 	assert.Contains(err.Error(), unitTestErrorMessage, "did not see the specific error that we expected")
 }
 
+// TestSaveTransactionalTasks confirms that tasks saved within a caller's transaction are only stored if the
+// transaction as a whole succeeds.
+func TestSaveTransactionalTasks(t *testing.T) {
+
+	// Do the common setup that most of our tests require
+	assert, ctx, service := commonTestSetup(t)
+
+	// Save a task in a transaction that then fails
+	task := &schema.Task{Id: uuid.NewString(), OrderId: uuid.NewString(), TaskCode: "ship", Status: schema.WAITING_SERVICE}
+	err := service.FsClient.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		err := service.SaveTransactionalTasks(tx, []*schema.Task{task})
+		if err != nil {
+			return err
+		}
+		return errors.New(unitTestErrorMessage)
+	})
+	assert.NotNil(err, "should have seen the forced transaction error")
+	_, err = service.GetTaskByID(ctx, &pbfulfillment.GetTaskByIDRequest{TaskId: task.Id})
+	assert.NotNil(err, "task should not have been stored by a failed transaction")
+	assert.Contains(err.Error(), "failed to retrieve task snapshot", "did not see the error we expected")
+
+	// Then in one that succeeds
+	err = service.FsClient.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		return service.SaveTransactionalTasks(tx, []*schema.Task{task})
+	})
+	assert.Nil(err, "failed to save task in transaction: %v", err)
+	response, err := service.GetTaskByID(ctx, &pbfulfillment.GetTaskByIDRequest{TaskId: task.Id})
+	assert.Nil(err, "failed to retrieve task saved in transaction: %v", err)
+	assert.Equal(task.TaskCode, response.Task.TaskCode, "task code did not match")
+	_, err = service.FsClient.Doc(task.StoreRefPath()).Delete(ctx)
+	assert.Nil(err, "failed to clean up task: %v", err)
+}
+
+// TestTasksHeldForPayment confirms that tasks saved while the payment for their order is awaited are held in
+// WAITING_PAYMENT, and that releasing them restores the statuses that they would otherwise have had.
+func TestTasksHeldForPayment(t *testing.T) {
+
+	// Do the common setup that most of our tests require
+	assert, ctx, service := commonTestSetup(t)
+
+	// Save a couple of tasks for an order of our own, one that has not been paid for yet
+	heldOrderId := uuid.NewString()
+	tasks := []*schema.Task{
+		{Id: uuid.NewString(), OrderId: heldOrderId, TaskCode: "manufacture", Status: schema.WAITING_SERVICE},
+		{Id: uuid.NewString(), OrderId: heldOrderId, TaskCode: "ship", Status: schema.WAITING_TASK, ReasonCode: "wait_for_manufacture"},
+	}
+	err := service.SaveTasksHeldForPayment(ctx, tasks, func(tx *firestore.Transaction) (bool, error) {
+		return true, nil
+	})
+	assert.Nil(err, "failed to save tasks held for payment: %v", err)
+	assert.Equal(schema.TaskStatus(schema.WAITING_SERVICE), tasks[0].Status, "the given task should not itself have been held")
+
+	// Both should be waiting for the money
+	for _, task := range tasks {
+		response, err := service.GetTaskByID(ctx, &pbfulfillment.GetTaskByIDRequest{TaskId: task.Id})
+		assert.Nil(err, "failed to retrieve held task: %v", err)
+		assert.Equal(pbfulfillment.TaskStatus_WAITING_PAYMENT, response.Task.Status, "task should have been held for payment")
+		assert.Equal(schema.ReasonPaymentNotCaptured, response.Task.ReasonCode, "held task reason code did not match")
+	}
+
+	// Let them go
+	var released int
+	err = service.FsClient.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		released, err = service.ReleaseTasksHeldForPayment(tx, heldOrderId)
+		return err
+	})
+	assert.Nil(err, "failed to release tasks held for payment: %v", err)
+	assert.Equal(2, released, "both tasks should have been released")
+
+	// And they should be back to how they would have been
+	for _, task := range tasks {
+		response, err := service.GetTaskByID(ctx, &pbfulfillment.GetTaskByIDRequest{TaskId: task.Id})
+		assert.Nil(err, "failed to retrieve released task: %v", err)
+		assert.Equal(pbfulfillment.TaskStatus(task.Status), response.Task.Status, "released task status did not match")
+		assert.Equal(task.ReasonCode, response.Task.ReasonCode, "released task reason code did not match")
+		_, err = service.FsClient.Doc(task.StoreRefPath()).Delete(ctx)
+		assert.Nil(err, "failed to clean up task: %v", err)
+	}
+}
+
+// TestCancelOrderTasks confirms that the outstanding tasks of an order can be cancelled, but that items that have
+// already been shipped are left alone, and the cancellation refused unless partial cancellation is allowed.
+func TestCancelOrderTasks(t *testing.T) {
+
+	// Do the common setup that most of our tests require
+	assert, ctx, service := commonTestSetup(t)
+
+	// Save some tasks for an order of our own: the first item has been shipped, and is on its way back, the second
+	// is still being made
+	cancelOrderId := uuid.NewString()
+	shippedItemId := uuid.NewString()
+	makingItemId := uuid.NewString()
+	tasks := []*schema.Task{
+		{Id: uuid.NewString(), OrderId: cancelOrderId, OrderItemId: shippedItemId, TaskCode: "manufacture", Status: schema.COMPLETED},
+		{Id: uuid.NewString(), OrderId: cancelOrderId, OrderItemId: shippedItemId, TaskCode: "ship", Status: schema.COMPLETED},
+		{Id: uuid.NewString(), OrderId: cancelOrderId, OrderItemId: makingItemId, TaskCode: "manufacture", Status: schema.COMPLETED},
+		{Id: uuid.NewString(), OrderId: cancelOrderId, OrderItemId: makingItemId, TaskCode: "ship", Status: schema.WAITING_TASK, ReasonCode: "wait_for_manufacture"},
+		{Id: uuid.NewString(), OrderId: cancelOrderId, OrderItemId: shippedItemId, TaskCode: "receive_return", Status: schema.WAITING_CUSTOMER, ReturnId: uuid.NewString()},
+	}
+	err := service.SaveTasks(ctx, tasks)
+	assert.Nil(err, "failed to save tasks: %v", err)
+
+	// Cancelling the whole order is refused since the first item has already gone
+	_, _, err = service.CancelOrderTasks(ctx, cancelOrderId, "changed_mind", false)
+	assert.Equal(codes.FailedPrecondition, status.Code(err), "cancellation of a shipped order should have been refused: %v", err)
+	assert.Contains(err.Error(), shippedItemId, "refusal should have named the shipped item")
+
+	// But what remains can be cancelled
+	cancelled, keptItemIds, err := service.CancelOrderTasks(ctx, cancelOrderId, "changed_mind", true)
+	assert.Nil(err, "partial cancellation should have succeeded: %v", err)
+	assert.Equal(1, cancelled, "only the outstanding ship task should have been cancelled")
+	assert.Equal([]string{shippedItemId}, keptItemIds, "the shipped item should have been left alone")
+
+	// Leaving the tasks as we would expect
+	expected := []pbfulfillment.TaskStatus{pbfulfillment.TaskStatus_COMPLETED, pbfulfillment.TaskStatus_COMPLETED,
+		pbfulfillment.TaskStatus_COMPLETED, pbfulfillment.TaskStatus_CANCELED, pbfulfillment.TaskStatus_WAITING_CUSTOMER}
+	for i, task := range tasks {
+		response, err := service.GetTaskByID(ctx, &pbfulfillment.GetTaskByIDRequest{TaskId: task.Id})
+		assert.Nil(err, "failed to retrieve task: %v", err)
+		assert.Equal(expected[i], response.Task.Status, "status of task %d did not match", i)
+	}
+
+	// Now there is nothing left to cancel, so even a partial cancellation is refused
+	_, _, err = service.CancelOrderTasks(ctx, cancelOrderId, "changed_mind", true)
+	assert.Equal(codes.FailedPrecondition, status.Code(err), "cancellation with nothing to cancel should have been refused: %v", err)
+
+	// Clean up after ourselves
+	for _, task := range tasks {
+		_, err = service.FsClient.Doc(task.StoreRefPath()).Delete(ctx)
+		assert.Nil(err, "failed to clean up task: %v", err)
+	}
+}
+
 // TestGetTaskByID retrieves one of the mock tasks that primeFirestore has stores in the Firestore emulator.
 func TestGetTaskByID(t *testing.T) {
 
@@ -365,6 +500,39 @@ func TestProductCodeQuery(t *testing.T) {
 	assert.Empty(response.NextPageToken, "next page token should NOT have been set after retrieving first page")
 }
 
+// TestPageTokenMismatch confirms that a page token cannot be used to continue a query with different filters or
+// a different page size to the one that it came from.
+func TestPageTokenMismatch(t *testing.T) {
+
+	// Do the common setup that most of our tests require
+	assert, ctx, service := commonTestSetup(t)
+
+	// Get a token for the second page of our mock order's tasks
+	request := &pbfulfillment.GetTasksRequest{
+		OrderId:  OrderID, // All 12 or our mock tasks have the same order ID
+		PageSize: 6,
+	}
+	response, err := service.GetTasks(ctx, request)
+	assert.Nil(err, "did not expect an error calling GetTasks for the first page: %v", err)
+	assert.NotEmpty(response.NextPageToken, "next page token should have been set after retrieving first page")
+
+	// Try to use it for another product
+	productRequest := &pbfulfillment.GetTasksRequest{
+		OrderId:     OrderID,
+		ProductCode: mockTasks[10].ProductCode,
+		PageSize:    6,
+		PageToken:   response.NextPageToken,
+	}
+	_, err = service.GetTasks(ctx, productRequest)
+	assert.Equal(codes.InvalidArgument, status.Code(err), "page token should have been rejected for different filters: %v", err)
+
+	// Or for bigger pages
+	request.PageToken = response.NextPageToken
+	request.PageSize = 7
+	_, err = service.GetTasks(ctx, request)
+	assert.Equal(codes.InvalidArgument, status.Code(err), "page token should have been rejected for a different page size: %v", err)
+}
+
 // TestLoggingAndBadPageToken kills two birds with one stone, verifying that queries are logged for diagnostic
 // purposes and looking at how the code handles an invalid "net page token."
 func TestLoggingAndBadPageToken(t *testing.T) {
@@ -391,6 +559,7 @@ func TestLoggingAndBadPageToken(t *testing.T) {
 	// We should have see a error complaining about the page token
 	assert.NotNil(err, "expected an error calling GetTasks")
 	assert.Contains(err.Error(), "invalid page token", "did not get the expected error")
+	assert.Equal(codes.InvalidArgument, status.Code(err), "bad page token should have been an invalid argument")
 	assert.Nil(response, "should not have received a response")
 
 	// Confirm that the log output described the query
@@ -6,6 +6,7 @@ import (
 	"os"
 
 	"github.com/mikebway/poc-gcp-ecomm/fulfillment/fulfillapi"
+	"github.com/mikebway/poc-gcp-ecomm/paging"
 
 	"google.golang.org/grpc"
 
@@ -59,6 +60,13 @@ func initializeService() (*grpc.Server, net.Listener, error) {
 	}
 	zap.L().Info("poc-fulfillment-service: starting server", zap.String("port", port))
 
+	// Refuse to start without the key with which the page tokens handed out to our callers are signed
+	err := paging.RequireKey()
+	if err != nil {
+		zap.L().Error("page token key error", zap.String("error", err.Error()))
+		return nil, nil, err
+	}
+
 	listener, err := net.Listen("tcp", ":"+port)
 	if err != nil {
 		zap.L().Error("net.Listen error", zap.String("error", err.Error()))
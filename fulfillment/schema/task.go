@@ -11,8 +11,19 @@ import (
 const (
 	// TaskCollection names the firestore collection under which all of our documents are stored
 	TaskCollection = "tasks"
+
+	// ReasonPaymentNotCaptured is the reason code given to tasks that are held in WAITING_PAYMENT until the payment
+	// for their order has been captured
+	ReasonPaymentNotCaptured = "payment_not_captured"
 )
 
+// IrrevocableTaskCodes lists the task codes of the tasks that, once completed, cannot be undone; an order item is
+// past the point at which it can be cancelled when one of its tasks with these codes has been completed. For
+// example, there is no cancelling an item that has already been shipped.
+var IrrevocableTaskCodes = map[string]bool{
+	"ship": true,
+}
+
 // Task defines a fulfilment task that is being tracked by the Fulfillment Orchestration Service. Fulfillment tasks
 // map to order items in a many-to-one relationship, i.e. a single item in a customer order may map to multiple
 // fulfillment tasks.
@@ -63,6 +74,19 @@ type Task struct {
 	// Parameters is a map of zero to many named value parameters that might be required to complete the task. For example,
 	// extending the custom sofa analogy, the parameters might define the model and cover fabric for the sofa.
 	Parameters []*Parameter `firestore:"parameters" json:"parameters"`
+
+	// HeldStatus is the status that a task held in WAITING_PAYMENT, see HeldForPayment, is to be given once the
+	// payment for its order has been captured.
+	HeldStatus TaskStatus `firestore:"heldStatus,omitempty" json:"heldStatus,omitempty"`
+
+	// HeldReasonCode is the reason code that a task held in WAITING_PAYMENT is to be given, along with its
+	// HeldStatus, once the payment for its order has been captured.
+	HeldReasonCode string `firestore:"heldReasonCode,omitempty" json:"heldReasonCode,omitempty"`
+
+	// ReturnId is set only for the tasks that handle the return of an order item, relating the task to the return
+	// request that it was created for. Return tasks are kept out of the status of the order item being returned and
+	// are not cancelled along with the order; see IsReturnTask.
+	ReturnId string `firestore:"returnId,omitempty" json:"returnId,omitempty"`
 }
 
 // StoreRefPath returns the string representation of the document reference path for this Task.
@@ -70,6 +94,34 @@ func (t *Task) StoreRefPath() string {
 	return TaskCollection + "/" + t.Id
 }
 
+// IsIrrevocable returns true if this task has been completed and, according to IrrevocableTaskCodes, cannot be
+// undone.
+func (t *Task) IsIrrevocable() bool {
+	return t.Status == COMPLETED && IrrevocableTaskCodes[t.TaskCode]
+}
+
+// IsFinished returns true if this task has been either completed or canceled, i.e. there is nothing more to be done
+// about it.
+func (t *Task) IsFinished() bool {
+	return t.Status == COMPLETED || t.Status == CANCELED
+}
+
+// IsReturnTask returns true if this task handles the return of an order item rather than its original fulfillment.
+func (t *Task) IsReturnTask() bool {
+	return t.ReturnId != ""
+}
+
+// HeldForPayment returns a copy of this task held in WAITING_PAYMENT, with a reason code of ReasonPaymentNotCaptured,
+// remembering the status and reason code that the task is to be given when the payment for its order is captured.
+func (t *Task) HeldForPayment() *Task {
+	held := *t
+	held.HeldStatus = t.Status
+	held.HeldReasonCode = t.ReasonCode
+	held.Status = WAITING_PAYMENT
+	held.ReasonCode = ReasonPaymentNotCaptured
+	return &held
+}
+
 // TaskStatus is an integer enumeration of the possible task states
 type TaskStatus int32
 
@@ -144,6 +196,7 @@ func (t *Task) AsPBTask() *pbfulfillment.Task {
 		Status:         pbfulfillment.TaskStatus(t.Status),
 		ReasonCode:     t.ReasonCode,
 		Parameters:     t.asPBParameters(),
+		ReturnId:       t.ReturnId,
 	}
 }
 
@@ -110,6 +110,56 @@ func TestAsPBTaskNoParameters(t *testing.T) {
 	req.Equal(0, len(pbTask.Parameters), "unexpected count of converted task parameters")
 }
 
+// TestHeldForPayment confirms that a task held for payment remembers the status and reason code that it is to be
+// given once it is paid for, leaving the original task alone.
+func TestHeldForPayment(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	task := buildMockTask()
+	held := task.HeldForPayment()
+	req.Equal(TaskStatus(WAITING_PAYMENT), held.Status, "held task status did not match")
+	req.Equal(ReasonPaymentNotCaptured, held.ReasonCode, "held task reason code did not match")
+	req.Equal(task.Status, held.HeldStatus, "held task should have remembered its status")
+	req.Equal(task.ReasonCode, held.HeldReasonCode, "held task should have remembered its reason code")
+	req.Equal(task.Id, held.Id, "held task should otherwise have matched the original")
+	req.Equal(TaskStatus(WAITING_SERVICE), task.Status, "original task should not have been held")
+}
+
+// TestIsIrrevocable confirms that only completed tasks with irrevocable task codes are considered past undoing, and
+// that both completed and canceled tasks are considered finished.
+func TestIsIrrevocable(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	task := &Task{TaskCode: "ship", Status: WAITING_TASK}
+	req.False(task.IsIrrevocable(), "a ship task that has yet to be completed can still be undone")
+	req.False(task.IsFinished(), "a ship task that has yet to be completed is not finished")
+	task.Status = COMPLETED
+	req.True(task.IsIrrevocable(), "a completed ship task cannot be undone")
+	req.True(task.IsFinished(), "a completed ship task is finished")
+	task.TaskCode = "manufacture"
+	req.False(task.IsIrrevocable(), "a completed manufacture task can still be undone")
+	task.Status = CANCELED
+	req.True(task.IsFinished(), "a canceled task is finished")
+}
+
+// TestIsReturnTask confirms that only tasks created for a return request are considered return tasks, and that
+// the return request ID makes it into the protocol buffer form of the task.
+func TestIsReturnTask(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	task := &Task{TaskCode: "ship", Status: COMPLETED}
+	req.False(task.IsReturnTask(), "a ship task is not a return task")
+	task = &Task{TaskCode: "receive_return", Status: WAITING_CUSTOMER, ReturnId: "e2f2a3c9-6b1d-4d3c-9a3e-0f5e8a4b7c21"}
+	req.True(task.IsReturnTask(), "a task with a return ID is a return task")
+	req.Equal(task.ReturnId, task.AsPBTask().ReturnId, "PB task return ID did not match")
+}
+
 // TestStoreRefPath checks out how well Task.StoreRefPath does its job.
 func TestStoreRefPath(t *testing.T) {
 
@@ -0,0 +1,274 @@
+package inventory
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultReservationTTL is how long a reservation lasts, unless it is renewed, before it lapses
+	DefaultReservationTTL = 30 * time.Minute
+)
+
+var (
+	// ErrInsufficientStock is returned, wrapped, when there are not enough units of a product available to reserve
+	// or commit the quantity asked for
+	ErrInsufficientStock = errors.New("insufficient stock")
+)
+
+// Inventory reserves, releases, and commits stock on behalf of shopping carts. Every operation works within a
+// Firestore transaction supplied by the caller so that stock moves in step with the cart.
+//
+// Firestore requires that all the reads in a transaction come before any of the writes. Reserve and Commit read
+// then write, so they must be called after the caller has made all of its own reads and before it makes any writes.
+// Release only writes.
+type Inventory struct {
+
+	// FsClient is the GCP Firestore client - it is thread safe and can be reused concurrently
+	FsClient *firestore.Client
+
+	// ReservationTTL is how long a reservation lasts, from the time that it is made or last renewed
+	ReservationTTL time.Duration
+}
+
+// position is a snapshot of the stock of one product, as read within a transaction, from the point of view of the
+// shopping cart, or carts, that it was read for.
+type position struct {
+
+	// level is the stock level document as read, or nil if the product is not stock controlled
+	level *StockLevel
+
+	// reservedByOthers is the number of units held by the active reservations of all the other carts
+	reservedByOthers int64
+}
+
+// available returns the number of units that the cart, or carts, could have.
+func (p *position) available() int64 {
+	return p.level.OnHand - p.reservedByOthers
+}
+
+// NewInventory is a factory method returning an Inventory that uses the given Firestore client and the default
+// reservation TTL.
+func NewInventory(client *firestore.Client) *Inventory {
+	return &Inventory{
+		FsClient:       client,
+		ReservationTTL: DefaultReservationTTL,
+	}
+}
+
+// Reserve sets the number of units of each product that the given cart holds to the quantity given for the product
+// in the quantities map, renewing the reservations for another ReservationTTL. A quantity of zero or less releases
+// the cart's reservation for the product.
+//
+// An error wrapping ErrInsufficientStock is returned, and nothing is reserved, if any product does not have enough
+// units available that are not already reserved by other carts.
+func (inv *Inventory) Reserve(tx *firestore.Transaction, cartId string, quantities map[string]int64) error {
+
+	// Read everything that we need first
+	codes := sortedCodes(quantities)
+	positions, err := inv.getTransactionalPositions(tx, []string{cartId}, codes)
+	if err != nil {
+		return err
+	}
+
+	// Then, provided that there is enough to go round, write the reservations
+	err = checkAvailability(codes, quantities, positions)
+	if err != nil {
+		return err
+	}
+	return inv.setReservations(tx, cartId, codes, quantities, positions)
+}
+
+// Release gives up the reservations that the given cart holds for the products with the given codes. It is not an
+// error for the cart not to hold a reservation for any of the products.
+func (inv *Inventory) Release(tx *firestore.Transaction, cartId string, productCodes []string) error {
+	for _, code := range productCodes {
+		reservation := &Reservation{CartId: cartId, ProductCode: code}
+		err := tx.Delete(inv.FsClient.Doc(reservation.StoreRefPath()))
+		if err != nil {
+			return fmt.Errorf("failed deleting stock reservation from firestore: cart ID=%s, product code=%s: %w", cartId, code, err)
+		}
+	}
+	return nil
+}
+
+// Transfer moves the reservations that one cart holds for the products in the quantities map to another cart, as
+// when the first cart is merged into the second, setting the number of units of each product that the receiving
+// cart holds to the quantity given for the product. The units held by the giving cart count as available to the
+// receiving cart, and the giving cart's reservations for the products are released.
+//
+// An error wrapping ErrInsufficientStock is returned, and nothing is moved, if any product does not have enough
+// units available that are not already reserved by carts other than the two.
+func (inv *Inventory) Transfer(tx *firestore.Transaction, fromCartId string, toCartId string, quantities map[string]int64) error {
+
+	// Read everything that we need first
+	codes := sortedCodes(quantities)
+	positions, err := inv.getTransactionalPositions(tx, []string{fromCartId, toCartId}, codes)
+	if err != nil {
+		return err
+	}
+
+	// Then, provided that there is enough to go round, hand the reservations over
+	err = checkAvailability(codes, quantities, positions)
+	if err != nil {
+		return err
+	}
+	err = inv.setReservations(tx, toCartId, codes, quantities, positions)
+	if err != nil {
+		return err
+	}
+	return inv.Release(tx, fromCartId, codes)
+}
+
+// Commit takes the quantity of each product given in the quantities map out of stock for good, as the given cart is
+// checked out, and drops the cart's reservations for those products.
+//
+// The cart's own reservations are expected to cover the quantities but, should they have lapsed, stock that no other
+// cart has reserved in the meantime will do just as well. An error wrapping ErrInsufficientStock is returned, and
+// nothing is committed, if there is not enough of any product.
+func (inv *Inventory) Commit(tx *firestore.Transaction, cartId string, quantities map[string]int64) error {
+
+	// Read everything that we need first
+	codes := sortedCodes(quantities)
+	positions, err := inv.getTransactionalPositions(tx, []string{cartId}, codes)
+	if err != nil {
+		return err
+	}
+
+	// Then, provided that there is enough to go round, deduct the stock and drop the reservations
+	err = checkAvailability(codes, quantities, positions)
+	if err != nil {
+		return err
+	}
+	for _, code := range codes {
+		level := positions[code].level
+		if level == nil {
+			continue
+		}
+		err = tx.Update(inv.FsClient.Doc(level.StoreRefPath()), []firestore.Update{{Path: "onHand", Value: level.OnHand - quantities[code]}})
+		if err != nil {
+			return fmt.Errorf("failed updating stock level in firestore: product code=%s: %w", code, err)
+		}
+	}
+	return inv.Release(tx, cartId, codes)
+}
+
+// setReservations writes the reservations that the given cart holds for the products with the given codes, as
+// stock controlled according to the given positions, renewing them for another ReservationTTL. A quantity of zero
+// or less deletes the cart's reservation for the product.
+func (inv *Inventory) setReservations(tx *firestore.Transaction, cartId string, productCodes []string, quantities map[string]int64, positions map[string]*position) error {
+	expiryTime := time.Now().Add(inv.ReservationTTL)
+	for _, code := range productCodes {
+		if positions[code].level == nil {
+			continue
+		}
+		reservation := &Reservation{CartId: cartId, ProductCode: code, Quantity: quantities[code], ExpiryTime: expiryTime}
+		ref := inv.FsClient.Doc(reservation.StoreRefPath())
+		var err error
+		if reservation.Quantity <= 0 {
+			err = tx.Delete(ref)
+		} else {
+			err = tx.Set(ref, reservation)
+		}
+		if err != nil {
+			return fmt.Errorf("failed setting stock reservation to firestore: cart ID=%s, product code=%s: %w", cartId, code, err)
+		}
+	}
+	return nil
+}
+
+// getTransactionalPositions reads the stock level of each of the products with the given codes, and the active
+// reservations that carts other than the given ones hold against them, within the given Firestore transaction.
+//
+// Only the reservations that have yet to expire are read. Lapsed reservations are not deleted until their cart is
+// checked out or closed, if ever, so reading them all would make every transaction that touches a popular product
+// read, and lock, more and more documents as time goes by.
+func (inv *Inventory) getTransactionalPositions(tx *firestore.Transaction, cartIds []string, productCodes []string) (map[string]*position, error) {
+	now := time.Now()
+	positions := make(map[string]*position, len(productCodes))
+	for _, code := range productCodes {
+
+		// Products that do not have a stock level are not stock controlled
+		level := &StockLevel{ProductCode: code}
+		snap, err := tx.Get(inv.FsClient.Doc(level.StoreRefPath()))
+		if status.Code(err) == codes.NotFound {
+			positions[code] = &position{}
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve stock level: product code=%s: %w", code, err)
+		}
+		err = snap.DataTo(level)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal stock level: product code=%s: %w", code, err)
+		}
+
+		// Add up what everybody else is holding
+		pos := &position{level: level}
+		docs := tx.Documents(inv.FsClient.Collection(level.ReservationCollectionPath()).Where("expiryTime", ">", now))
+		for {
+			reservation := &Reservation{}
+			snap, err := docs.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err == nil {
+				err = snap.DataTo(reservation)
+			}
+			if err != nil {
+				docs.Stop()
+				return nil, fmt.Errorf("failed to retrieve stock reservations: product code=%s: %w", code, err)
+			}
+			if !containsString(cartIds, reservation.CartId) && reservation.IsActive(now) {
+				pos.reservedByOthers += reservation.Quantity
+			}
+		}
+		docs.Stop()
+		positions[code] = pos
+	}
+	return positions, nil
+}
+
+// checkAvailability returns an error wrapping ErrInsufficientStock for the first of the products with the given
+// codes that does not have enough units available to satisfy the quantity given for it.
+func checkAvailability(productCodes []string, quantities map[string]int64, positions map[string]*position) error {
+	for _, code := range productCodes {
+		pos := positions[code]
+		if pos.level != nil && quantities[code] > pos.available() {
+			available := pos.available()
+			if available < 0 {
+				available = 0
+			}
+			return fmt.Errorf("%w: product code=%s, requested=%d, available=%d", ErrInsufficientStock, code, quantities[code], available)
+		}
+	}
+	return nil
+}
+
+// sortedCodes returns the product codes of the given quantities map in order, so that the products are always
+// worked through, and any shortage reported, in a predictable order.
+func sortedCodes(quantities map[string]int64) []string {
+	codes := make([]string, 0, len(quantities))
+	for code := range quantities {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// containsString returns true if the given slice contains the given string.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,224 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// EnvFirestoreEmulator defines the environment variable name that is used to convey that the Firestore emulator
+	// is running, should be used, and how to connect to it
+	EnvFirestoreEmulator = "FIRESTORE_EMULATOR_HOST"
+
+	// FirestoreEmulatorHost defines the server name and port (in TCP6 terms) of the Firestore emulator
+	FirestoreEmulatorHost = "[::1]:8219"
+
+	// projectId is the demo project that the tests use, so that they cannot reach a live project by mistake
+	projectId = "demo-poc-gcp-ecomm"
+)
+
+// TestMain, if defined (it's optional), allows setup code to be run before and after the suite of unit tests
+// for this package.
+func TestMain(m *testing.M) {
+
+	// Configure the environment variable that informs the Firestore client that it should connect to the
+	// emulator and how to reach it.
+	_ = os.Setenv(EnvFirestoreEmulator, FirestoreEmulatorHost)
+
+	// Run all the unit tests
+	m.Run()
+}
+
+// commonTestSetup performs the basic foundation stuff that most of this package's tests require, returning an
+// Inventory and the code of a brand new product that has the given number of units on hand.
+func commonTestSetup(t *testing.T, onHand int64) (*require.Assertions, context.Context, *Inventory, string) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Obtain a Firestore client connected to the emulator
+	ctx := context.Background()
+	client, err := firestore.NewClient(ctx, projectId)
+	req.Nil(err, "failed to obtain firestore client: %v", err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	// Stock a product of our very own so that other tests cannot interfere with it
+	level := &StockLevel{ProductCode: "ut_yoyo_" + uuid.NewString(), OnHand: onHand}
+	_, err = client.Doc(level.StoreRefPath()).Set(ctx, level)
+	req.Nil(err, "failed to store stock level: %v", err)
+	return req, ctx, NewInventory(client), level.ProductCode
+}
+
+// inTransaction runs the given function in a Firestore transaction.
+func inTransaction(ctx context.Context, inv *Inventory, f func(tx *firestore.Transaction) error) error {
+	return inv.FsClient.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		return f(tx)
+	})
+}
+
+// onHand returns the number of units of the given product that are on hand.
+func onHand(req *require.Assertions, ctx context.Context, inv *Inventory, code string) int64 {
+	level := &StockLevel{ProductCode: code}
+	snap, err := inv.FsClient.Doc(level.StoreRefPath()).Get(ctx)
+	req.Nil(err, "failed to retrieve stock level: %v", err)
+	req.Nil(snap.DataTo(level), "failed to unmarshal stock level")
+	return level.OnHand
+}
+
+// TestReserve confirms that carts can reserve, and change their reservations for, stock that other carts have not
+// already reserved, but no more.
+func TestReserve(t *testing.T) {
+
+	// Five units to share between two carts
+	req, ctx, inv, code := commonTestSetup(t, 5)
+	cart1, cart2 := uuid.NewString(), uuid.NewString()
+
+	// The first cart takes three, then changes its mind and takes four
+	err := inTransaction(ctx, inv, func(tx *firestore.Transaction) error { return inv.Reserve(tx, cart1, map[string]int64{code: 3}) })
+	req.Nil(err, "should not have seen an error reserving three units: %v", err)
+	err = inTransaction(ctx, inv, func(tx *firestore.Transaction) error { return inv.Reserve(tx, cart1, map[string]int64{code: 4}) })
+	req.Nil(err, "should not have seen an error raising the reservation to four units: %v", err)
+
+	// The second cart can only have the one that is left
+	err = inTransaction(ctx, inv, func(tx *firestore.Transaction) error { return inv.Reserve(tx, cart2, map[string]int64{code: 2}) })
+	req.True(errors.Is(err, ErrInsufficientStock), "reserving two units should have run out of stock: %v", err)
+	req.Contains(err.Error(), "available=1", "error should have said how many units were available")
+	err = inTransaction(ctx, inv, func(tx *firestore.Transaction) error { return inv.Reserve(tx, cart2, map[string]int64{code: 1}) })
+	req.Nil(err, "should not have seen an error reserving the last unit: %v", err)
+
+	// Once the first cart drops to zero, the second can have the lot
+	err = inTransaction(ctx, inv, func(tx *firestore.Transaction) error { return inv.Reserve(tx, cart1, map[string]int64{code: 0}) })
+	req.Nil(err, "should not have seen an error dropping the reservation to zero: %v", err)
+	err = inTransaction(ctx, inv, func(tx *firestore.Transaction) error { return inv.Reserve(tx, cart2, map[string]int64{code: 5}) })
+	req.Nil(err, "should not have seen an error reserving all five units: %v", err)
+
+	// Reserving does not touch the units on hand
+	req.Equal(int64(5), onHand(req, ctx, inv, code), "units on hand should not have changed")
+}
+
+// TestReservationExpiry confirms that lapsed reservations do not hold stock from other carts.
+func TestReservationExpiry(t *testing.T) {
+
+	// One unit, reserved by a cart whose reservations lapse as soon as they are made
+	req, ctx, inv, code := commonTestSetup(t, 1)
+	inv.ReservationTTL = -time.Minute
+	err := inTransaction(ctx, inv, func(tx *firestore.Transaction) error {
+		return inv.Reserve(tx, uuid.NewString(), map[string]int64{code: 1})
+	})
+	req.Nil(err, "should not have seen an error reserving the unit: %v", err)
+
+	// Another cart can have it
+	inv.ReservationTTL = DefaultReservationTTL
+	err = inTransaction(ctx, inv, func(tx *firestore.Transaction) error {
+		return inv.Reserve(tx, uuid.NewString(), map[string]int64{code: 1})
+	})
+	req.Nil(err, "should not have seen an error reserving the lapsed unit: %v", err)
+}
+
+// TestRelease confirms that released stock can be reserved by other carts, and that releasing a reservation that
+// does not exist is harmless.
+func TestRelease(t *testing.T) {
+
+	// Two units, both reserved by one cart
+	req, ctx, inv, code := commonTestSetup(t, 2)
+	cart1, cart2 := uuid.NewString(), uuid.NewString()
+	err := inTransaction(ctx, inv, func(tx *firestore.Transaction) error { return inv.Reserve(tx, cart1, map[string]int64{code: 2}) })
+	req.Nil(err, "should not have seen an error reserving two units: %v", err)
+
+	// Release them, twice over, and the other cart can have them
+	for i := 0; i < 2; i++ {
+		err = inTransaction(ctx, inv, func(tx *firestore.Transaction) error { return inv.Release(tx, cart1, []string{code, "no_such_yoyo"}) })
+		req.Nil(err, "should not have seen an error releasing the reservation: %v", err)
+	}
+	err = inTransaction(ctx, inv, func(tx *firestore.Transaction) error { return inv.Reserve(tx, cart2, map[string]int64{code: 2}) })
+	req.Nil(err, "should not have seen an error reserving the released units: %v", err)
+}
+
+// TestCommit confirms that committing a reservation takes the units out of stock and drops the reservation, and
+// that a cart cannot commit stock that other carts have reserved.
+func TestCommit(t *testing.T) {
+
+	// Four units, three reserved by the first cart and one by the second
+	req, ctx, inv, code := commonTestSetup(t, 4)
+	cart1, cart2 := uuid.NewString(), uuid.NewString()
+	err := inTransaction(ctx, inv, func(tx *firestore.Transaction) error { return inv.Reserve(tx, cart1, map[string]int64{code: 3}) })
+	req.Nil(err, "should not have seen an error reserving three units: %v", err)
+	err = inTransaction(ctx, inv, func(tx *firestore.Transaction) error { return inv.Reserve(tx, cart2, map[string]int64{code: 1}) })
+	req.Nil(err, "should not have seen an error reserving one unit: %v", err)
+
+	// The second cart cannot check out with more than it reserved, and nothing changes when it tries
+	err = inTransaction(ctx, inv, func(tx *firestore.Transaction) error { return inv.Commit(tx, cart2, map[string]int64{code: 2}) })
+	req.True(errors.Is(err, ErrInsufficientStock), "committing two units should have run out of stock: %v", err)
+	req.Equal(int64(4), onHand(req, ctx, inv, code), "units on hand should not have changed")
+
+	// The first cart checks out with what it reserved
+	err = inTransaction(ctx, inv, func(tx *firestore.Transaction) error { return inv.Commit(tx, cart1, map[string]int64{code: 3}) })
+	req.Nil(err, "should not have seen an error committing three units: %v", err)
+	req.Equal(int64(1), onHand(req, ctx, inv, code), "committed units should have been taken out of stock")
+	reservation := &Reservation{CartId: cart1, ProductCode: code}
+	_, err = inv.FsClient.Doc(reservation.StoreRefPath()).Get(ctx)
+	req.Equal(codes.NotFound, status.Code(err), "committed reservation should have been dropped: %v", err)
+
+	// The second cart's reservation is still good
+	err = inTransaction(ctx, inv, func(tx *firestore.Transaction) error { return inv.Commit(tx, cart2, map[string]int64{code: 1}) })
+	req.Nil(err, "should not have seen an error committing the last unit: %v", err)
+	req.Equal(int64(0), onHand(req, ctx, inv, code), "all units should have been taken out of stock")
+}
+
+// TestUntrackedProduct confirms that products without a stock level can be reserved and committed in any quantity.
+func TestUntrackedProduct(t *testing.T) {
+
+	// Do the common setup, though we do not need the stocked product
+	req, ctx, inv, _ := commonTestSetup(t, 0)
+	code := "ut_untracked_" + uuid.NewString()
+	cartId := uuid.NewString()
+
+	// Anything goes, and nothing is written
+	err := inTransaction(ctx, inv, func(tx *firestore.Transaction) error { return inv.Reserve(tx, cartId, map[string]int64{code: 1000}) })
+	req.Nil(err, "should not have seen an error reserving an untracked product: %v", err)
+	err = inTransaction(ctx, inv, func(tx *firestore.Transaction) error { return inv.Commit(tx, cartId, map[string]int64{code: 1000}) })
+	req.Nil(err, "should not have seen an error committing an untracked product: %v", err)
+	_, err = inv.FsClient.Doc((&StockLevel{ProductCode: code}).StoreRefPath()).Get(ctx)
+	req.Equal(codes.NotFound, status.Code(err), "untracked product should not have gained a stock level: %v", err)
+}
+
+// TestTransfer confirms that one cart can hand its reservations over to another, the receiving cart being able to
+// use the units that the giving cart held.
+func TestTransfer(t *testing.T) {
+
+	// Three units, two held by the guest cart and one by the shopper's cart
+	req, ctx, inv, code := commonTestSetup(t, 3)
+	guestCart, shopperCart, otherCart := uuid.NewString(), uuid.NewString(), uuid.NewString()
+	err := inTransaction(ctx, inv, func(tx *firestore.Transaction) error { return inv.Reserve(tx, guestCart, map[string]int64{code: 2}) })
+	req.Nil(err, "should not have seen an error reserving two units: %v", err)
+	err = inTransaction(ctx, inv, func(tx *firestore.Transaction) error { return inv.Reserve(tx, shopperCart, map[string]int64{code: 1}) })
+	req.Nil(err, "should not have seen an error reserving one unit: %v", err)
+
+	// The shopper's cart cannot take more than the two carts hold between them
+	err = inTransaction(ctx, inv, func(tx *firestore.Transaction) error {
+		return inv.Transfer(tx, guestCart, shopperCart, map[string]int64{code: 4})
+	})
+	req.True(errors.Is(err, ErrInsufficientStock), "transferring four units should have run out of stock: %v", err)
+
+	// But it can have all three
+	err = inTransaction(ctx, inv, func(tx *firestore.Transaction) error {
+		return inv.Transfer(tx, guestCart, shopperCart, map[string]int64{code: 3})
+	})
+	req.Nil(err, "should not have seen an error transferring three units: %v", err)
+	reservation := &Reservation{CartId: guestCart, ProductCode: code}
+	_, err = inv.FsClient.Doc(reservation.StoreRefPath()).Get(ctx)
+	req.Equal(codes.NotFound, status.Code(err), "guest cart reservation should have been released: %v", err)
+
+	// Leaving nothing for anybody else
+	err = inTransaction(ctx, inv, func(tx *firestore.Transaction) error { return inv.Reserve(tx, otherCart, map[string]int64{code: 1}) })
+	req.True(errors.Is(err, ErrInsufficientStock), "reserving a unit should have run out of stock: %v", err)
+}
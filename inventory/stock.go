@@ -0,0 +1,71 @@
+// Package inventory is the bounded context that keeps track of how many units of each product we have to sell.
+//
+// A StockLevel document, keyed by product code, records the units on hand. Shopping carts hold short-lived
+// Reservation documents against the stock level, one per cart, so that two shoppers cannot both put the last
+// unit in their carts. Reservations are released when items leave a cart, or the cart is abandoned, and committed,
+// i.e. deducted from the units on hand, when the cart is checked out. A reservation that is not renewed lapses
+// after a while so that a shopper who wanders off does not keep stock from others for long.
+//
+// Products without a StockLevel document are not stock controlled; they can be reserved and committed in any
+// quantity. There is no API to maintain stock levels; they are managed directly in Firestore.
+package inventory
+
+import (
+	"time"
+)
+
+const (
+	// StockCollection names the firestore collection under which stock level documents are stored, keyed by their
+	// product codes
+	StockCollection = "inventory"
+
+	// ReservationCollection names the firestore sub-collection, beneath each stock level document, under which the
+	// reservations held against the stock level are stored, keyed by the IDs of the carts that hold them
+	ReservationCollection = "reservations"
+)
+
+// StockLevel records how many units of a product we have. It is persisted in the inventory firestore collection.
+type StockLevel struct {
+	// ProductCode identifies the product, doubling as the ID of the stock level document
+	ProductCode string `firestore:"productCode" json:"productCode"`
+
+	// OnHand is the number of units that we have, including those reserved by open carts but not those already
+	// committed to orders
+	OnHand int64 `firestore:"onHand" json:"onHand"`
+}
+
+// StoreRefPath returns the string representation of the document reference path for this StockLevel.
+func (s *StockLevel) StoreRefPath() string {
+	return StockCollection + "/" + s.ProductCode
+}
+
+// ReservationCollectionPath returns the string representation of the collection reference path under which
+// reservations against this StockLevel are stored.
+func (s *StockLevel) ReservationCollectionPath() string {
+	return s.StoreRefPath() + "/" + ReservationCollection
+}
+
+// Reservation holds a number of units of a product for a shopping cart until the reservation expires.
+type Reservation struct {
+	// CartId is the UUID ID of the cart holding the reservation, doubling as the ID of the reservation document
+	CartId string `firestore:"cartId" json:"cartId"`
+
+	// ProductCode identifies the product that is reserved
+	ProductCode string `firestore:"productCode" json:"productCode"`
+
+	// Quantity is the number of units reserved
+	Quantity int64 `firestore:"quantity" json:"quantity"`
+
+	// ExpiryTime is the time at which the reservation lapses, if it has not been renewed, committed, or released
+	ExpiryTime time.Time `firestore:"expiryTime" json:"expiryTime"`
+}
+
+// StoreRefPath returns the string representation of the document reference path for this Reservation.
+func (r *Reservation) StoreRefPath() string {
+	return (&StockLevel{ProductCode: r.ProductCode}).ReservationCollectionPath() + "/" + r.CartId
+}
+
+// IsActive returns true if the reservation has not expired at the given time.
+func (r *Reservation) IsActive(at time.Time) bool {
+	return at.Before(r.ExpiryTime)
+}
@@ -0,0 +1,33 @@
+package inventory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestStoreRefPaths confirms that reservations are stored beneath the stock levels that they are held against.
+func TestStoreRefPaths(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	level := &StockLevel{ProductCode: "gold_yoyo"}
+	req.Equal("inventory/gold_yoyo", level.StoreRefPath(), "stock level path did not match")
+	req.Equal("inventory/gold_yoyo/reservations", level.ReservationCollectionPath(), "reservation collection path did not match")
+	reservation := &Reservation{CartId: "cart-1", ProductCode: "gold_yoyo"}
+	req.Equal("inventory/gold_yoyo/reservations/cart-1", reservation.StoreRefPath(), "reservation path did not match")
+}
+
+// TestReservationIsActive confirms that reservations lapse at their expiry time.
+func TestReservationIsActive(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	now := time.Now()
+	reservation := &Reservation{ExpiryTime: now}
+	req.True(reservation.IsActive(now.Add(-time.Second)), "reservation should have been active before its expiry time")
+	req.False(reservation.IsActive(now), "reservation should have lapsed at its expiry time")
+}
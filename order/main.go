@@ -5,7 +5,9 @@ import (
 	"net"
 	"os"
 
+	"github.com/mikebway/poc-gcp-ecomm/fulfillment/fulfillapi"
 	"github.com/mikebway/poc-gcp-ecomm/order/orderapi"
+	"github.com/mikebway/poc-gcp-ecomm/paging"
 
 	"google.golang.org/grpc"
 
@@ -59,6 +61,13 @@ func initializeService() (*grpc.Server, net.Listener, error) {
 	}
 	zap.L().Info("poc-order-service: starting server", zap.String("port", port))
 
+	// Refuse to start without the key with which the page tokens handed out to our callers are signed
+	err := paging.RequireKey()
+	if err != nil {
+		zap.L().Error("page token key error", zap.String("error", err.Error()))
+		return nil, nil, err
+	}
+
 	listener, err := net.Listen("tcp", ":"+port)
 	if err != nil {
 		zap.L().Error("net.Listen error", zap.String("error", err.Error()))
@@ -79,6 +88,14 @@ func initializeService() (*grpc.Server, net.Listener, error) {
 		return nil, listener, fmt.Errorf("failed to initialize the OrderService: %v", err)
 	}
 
+	// Give the order service access to the fulfillment service so that orders can be cancelled
+	svc.Tasks, err = fulfillapi.NewFulfillmentService()
+	if err != nil {
+		zap.L().Error("NewFulfillmentService error", zap.String("error", err.Error()))
+		_ = listener.Close()
+		return nil, listener, fmt.Errorf("failed to initialize the FulfillmentService: %v", err)
+	}
+
 	// Initialize the gRPC server
 	grpcServer := grpc.NewServer()
 	pb.RegisterOrderAPIServer(grpcServer, svc)
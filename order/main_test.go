@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/mikebway/poc-gcp-ecomm/order/orderapi"
+	"github.com/mikebway/poc-gcp-ecomm/paging"
 	"github.com/mikebway/poc-gcp-ecomm/testutil"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
@@ -19,6 +20,9 @@ func resetEnvironment() {
 	// Clear the gRPC port number environment variable
 	_ = os.Setenv(EnvGRPCPort, "")
 
+	// Configure a page token key that is good enough for unit tests
+	_ = os.Setenv(paging.EnvPageTokenKey, "unit-test-page-token-key")
+
 	// Clear the request for the NewOrderService to return a mock error
 	orderapi.UnitTestNewOrderServiceError = nil
 }
@@ -205,3 +209,33 @@ func TestNoOrderServiceInitialization(t *testing.T) {
 	req.NotNil(listener, "listener should have been returned")
 	req.Nil(svc, "no gRPC service should have been returned")
 }
+
+// TestNoPageTokenKeyInitialization confirms that the service refuses to start if no page token key has been
+// configured.
+func TestNoPageTokenKeyInitialization(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Start with a clean slate and leave it that way too
+	resetEnvironment()
+	defer resetEnvironment()
+
+	// Remove the page token key
+	_ = os.Setenv(paging.EnvPageTokenKey, "")
+
+	// Initialize the service while capturing its log output
+	var svc *grpc.Server
+	var listener net.Listener
+	var err error
+	logged := testutil.CaptureLogging(func() {
+		svc, listener, err = initializeService()
+	})
+
+	// Now, see whether we like what happened
+	req.NotNil(err, "should have failed initialized the gRPC service")
+	req.Contains(err.Error(), paging.EnvPageTokenKey, "error should have named the page token key environment variable")
+	req.Contains(logged, "page token key error", "should have seen an error reported about the page token key in log")
+	req.Nil(listener, "no listener should have been returned")
+	req.Nil(svc, "no gRPC service should have been returned")
+}
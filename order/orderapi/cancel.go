@@ -0,0 +1,205 @@
+package orderapi
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/mikebway/poc-gcp-ecomm/order/schema"
+	"github.com/mikebway/poc-gcp-ecomm/payments"
+	pborder "github.com/mikebway/poc-gcp-ecomm/pb/order"
+	"github.com/mikebway/poc-gcp-ecomm/types"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// cancellationRefundId is the refund ID with which the payment for a cancelled order is refunded. An order can only
+// be cancelled once, so a refund retried after something went wrong part way through is never given twice.
+const cancellationRefundId = "cancellation"
+
+// TaskCanceller is the part of the fulfillment service that the order service needs in order to cancel the
+// fulfillment tasks of an order. It is implemented by fulfillapi.FulfillmentService.
+type TaskCanceller interface {
+
+	// CancelOrderTasks moves every task of the order with the given ID that has not already been completed or
+	// canceled to CANCELED, with the given reason code, returning the number of tasks cancelled and the IDs of any
+	// order items that were past the point at which they could be cancelled.
+	CancelOrderTasks(ctx context.Context, orderId string, reasonCode string, allowPartial bool) (int, []string, error)
+}
+
+// CancelOrder cancels the order identified in the pborder.CancelOrderRequest, recording the reason given on the
+// order and cancelling every fulfillment task of the order that has not already been completed or canceled with
+// the same reason code. The payment for the order is given up: voided if it has yet to be captured, otherwise the
+// cancelled items are refunded, see settleCancelledPayment.
+//
+// If any of the order items are past the point at which they can be cancelled, e.g. because they have already been
+// shipped, the cancellation is refused with a codes.FailedPrecondition status error unless the request allows for
+// partial cancellation, in which case the other items are cancelled. The order status is not changed here; it is
+// rolled up from the cancelled tasks by the task trigger in the usual way.
+//
+// The cancellation is recorded in a transaction that confirms that the order has not been cancelled already, so
+// that of two concurrent cancellations only one succeeds. Once it has been recorded, the order's tasks are swept
+// once more to catch any that the order to fulfillment consumer created while the first pass was under way; it
+// will create no more for a cancelled order.
+func (os *OrderService) CancelOrder(ctx context.Context, req *pborder.CancelOrderRequest) (*pborder.CancelOrderResponse, error) {
+
+	// Obtain a shortcut handle on our globally configured logger then log what we are about to do
+	l := zap.L()
+	l.Info("cancelling order", zap.String("orderId", req.OrderId), zap.String("reason", req.ReasonCode), zap.Bool("allowPartial", req.AllowPartial))
+
+	// TODO: Access control - only customer service should be able to cancel orders
+
+	// We need to know which order to cancel, why, and have some way to cancel its tasks
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order ID must be specified")
+	}
+	if req.ReasonCode == "" {
+		return nil, status.Error(codes.InvalidArgument, "cancellation reason code must be specified")
+	}
+	if os.Tasks == nil {
+		return nil, status.Error(codes.Unimplemented, "no fulfillment service is available to cancel tasks with")
+	}
+
+	// Fetch the order that is to be cancelled, it must not have been cancelled already. This is only a first look
+	// to save cancelling tasks in vain; the check is made again when the cancellation is recorded.
+	order, err := os.getCancellableOrder(ctx, nil, req.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cancel the tasks first; if that is refused, the order is left as it was
+	cancelledCount, keptItemIds, err := os.Tasks.CancelOrderTasks(ctx, req.OrderId, req.ReasonCode, req.AllowPartial)
+	if err != nil {
+		l.Error(err.Error(), zap.String("orderId", req.OrderId))
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to cancel order tasks: order ID=%s: %v", req.OrderId, err)
+	}
+
+	// Then record the cancellation on the order, so long as nobody else has beaten us to it
+	cancellation := &schema.Cancellation{
+		ReasonCode:       req.ReasonCode,
+		CancellationTime: time.Now(),
+		KeptItemIds:      keptItemIds,
+	}
+	ref := os.FsClient.Doc(order.StoreRefPath())
+	err = os.FsClient.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		var err error
+		order, err = os.getCancellableOrder(ctx, tx, req.OrderId)
+		if err != nil {
+			return err
+		}
+		return os.drProxy.TransactionalUpdate(ref, tx, []firestore.Update{{Path: "cancellation", Value: cancellation}})
+	})
+	if err != nil {
+		l.Error("failed recording order cancellation", zap.String("orderId", req.OrderId), zap.Error(err))
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed recording order cancellation: order ID=%s: %v", req.OrderId, err)
+	}
+	order.Cancellation = cancellation
+
+	// Sweep up any tasks that were created while we were about it. Everything is cancellable this time around;
+	// whatever was not on the first pass has been kept already.
+	sweptCount, _, err := os.Tasks.CancelOrderTasks(ctx, req.OrderId, req.ReasonCode, true)
+	if err != nil {
+		l.Error("failed sweeping up tasks of cancelled order", zap.String("orderId", req.OrderId), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "order cancelled but its tasks could not all be cancelled, try again: order ID=%s: %v", req.OrderId, err)
+	}
+	cancelledCount += sweptCount
+
+	// And give the money back
+	err = os.settleCancelledPayment(ctx, order)
+	if err != nil {
+		l.Error("failed settling payment of cancelled order", zap.String("orderId", req.OrderId), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "order cancelled but its payment could not be voided or refunded: order ID=%s: %v", req.OrderId, err)
+	}
+
+	// All done
+	l.Info("order cancelled", zap.String("orderId", req.OrderId), zap.Int("cancelledTasks", cancelledCount))
+	return &pborder.CancelOrderResponse{
+		Order:              order.AsPBOrder(),
+		CancelledTaskCount: int32(cancelledCount),
+	}, nil
+}
+
+// getCancellableOrder loads the order with the given ID, within the given transaction if it is not nil, returning a
+// status error if the order cannot be found or read, or has already been cancelled.
+func (os *OrderService) getCancellableOrder(ctx context.Context, tx *firestore.Transaction, orderId string) (*schema.Order, error) {
+
+	// Fetch the order
+	order := &schema.Order{Id: orderId}
+	ref := os.FsClient.Doc(order.StoreRefPath())
+	var snap *firestore.DocumentSnapshot
+	var err error
+	if tx == nil {
+		snap, err = os.drProxy.Get(ref, ctx)
+	} else {
+		snap, err = os.drProxy.TransactionalGet(ref, tx)
+	}
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Errorf(codes.NotFound, "order not found: order ID=%s", orderId)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to retrieve order snapshot with ID %s: %v", orderId, err)
+	}
+	err = os.dsProxy.DataTo(snap, order)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to unmarshal order snapshot with ID %s: %v", orderId, err)
+	}
+
+	// It must not have been cancelled already
+	if order.Cancellation != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "order has already been cancelled: order ID=%s", orderId)
+	}
+	return order, nil
+}
+
+// settleCancelledPayment gives up the payment for the given, cancelled, order. A payment that has yet to be captured
+// is voided; nothing can have been shipped before the payment was captured, so nothing can have been kept. Once the
+// payment has been captured, what is left of it is refunded if the whole order was cancelled, otherwise the share
+// of it that was paid for the items that were cancelled is, including their share of any discounts, delivery cost,
+// and tax. Orders placed before we started taking payments have nothing to settle.
+//
+// A payment that the payment capture consumer has started to capture cannot be voided, since the provider may already
+// have taken the money. We finish capturing it ourselves, which does no harm if the consumer gets there first, then
+// refund it like any other captured payment.
+func (os *OrderService) settleCancelledPayment(ctx context.Context, order *schema.Order) error {
+
+	// Void the payment if we can, which tells us where it has got to either way
+	payment, err := os.Payments.VoidOrderPayment(ctx, order.Id)
+	if err == nil && payment != nil && payment.Status == payments.PsCapturing {
+		payment, err = os.Payments.Capture(ctx, order.Id, nil, nil)
+	}
+	if err != nil || payment == nil || payment.Status != payments.PsCaptured {
+		return err
+	}
+
+	// Work out how much to give back
+	var refund *types.Money
+	if len(order.Cancellation.KeptItemIds) == 0 {
+		refund = payment.Amount
+		if payment.RefundedAmount != nil {
+			refund, err = payment.Amount.Subtract(payment.RefundedAmount)
+		}
+	} else {
+		refund, err = order.CancelledShare(payment.Amount)
+	}
+	if err != nil {
+		return err
+	}
+	if refund == nil || refund.IsZero() {
+		return nil
+	}
+
+	// And give it back
+	_, err = os.Payments.Refund(ctx, order.Id, cancellationRefundId, refund)
+	if err != nil {
+		return err
+	}
+	zap.L().Info("refunded cancelled order", zap.String("orderId", order.Id), zap.String("amount", refund.String()))
+	return nil
+}
@@ -0,0 +1,239 @@
+package orderapi
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/google/uuid"
+	"github.com/mikebway/poc-gcp-ecomm/order/schema"
+	"github.com/mikebway/poc-gcp-ecomm/payments"
+	pborder "github.com/mikebway/poc-gcp-ecomm/pb/order"
+	"github.com/mikebway/poc-gcp-ecomm/types"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UTTaskCanceller is a stand-in for the fulfillment service that remembers what it was asked to cancel and gives
+// whatever answer a unit test tells it to the first time it is asked, and the swept count thereafter.
+type UTTaskCanceller struct {
+	orderId      string
+	reasonCode   string
+	allowPartial bool
+	calls        int
+
+	cancelled   int
+	keptItemIds []string
+	err         error
+	swept       int
+
+	// during, if not nil, is called the first time that tasks are cancelled, to let a test get up to mischief
+	during func()
+}
+
+// CancelOrderTasks records the request and returns the programmed response.
+func (c *UTTaskCanceller) CancelOrderTasks(ctx context.Context, orderId string, reasonCode string, allowPartial bool) (int, []string, error) {
+	c.calls++
+	if c.calls > 1 {
+		return c.swept, nil, nil
+	}
+	c.orderId, c.reasonCode, c.allowPartial = orderId, reasonCode, allowPartial
+	if c.during != nil {
+		c.during()
+	}
+	return c.cancelled, c.keptItemIds, c.err
+}
+
+// TestCancelOrder confirms that cancelling an order cancels its tasks and records the cancellation on the order,
+// and that an order cannot be cancelled twice.
+func TestCancelOrder(t *testing.T) {
+
+	// Do the common setup that most of our tests require, with an order of our own to cancel that has one item
+	// already shipped
+	assert, ctx, service := commonTestSetup(t)
+	order := storeCancellableOrder(ctx, assert, service)
+	canceller := &UTTaskCanceller{cancelled: 2, keptItemIds: []string{order.OrderItems[0].Id}, swept: 1}
+	service.Tasks = canceller
+
+	// Cancel what can be cancelled
+	response, err := service.CancelOrder(ctx, &pborder.CancelOrderRequest{OrderId: order.Id, ReasonCode: "customer_request", AllowPartial: true})
+	assert.Nil(err, "should not have seen an error cancelling the order: %v", err)
+	assert.Equal(order.Id, canceller.orderId, "tasks of the wrong order were cancelled")
+	assert.Equal("customer_request", canceller.reasonCode, "tasks were cancelled with the wrong reason")
+	assert.True(canceller.allowPartial, "partial cancellation should have been allowed")
+	assert.Equal(2, canceller.calls, "tasks created during the cancellation should have been swept up")
+	assert.Equal(int32(3), response.CancelledTaskCount, "cancelled task count should have included the swept task")
+	assert.NotNil(response.Order.Cancellation, "response order should have been cancelled")
+	assert.Equal("customer_request", response.Order.Cancellation.ReasonCode, "response cancellation reason did not match")
+	assert.Equal([]string{order.OrderItems[0].Id}, response.Order.Cancellation.KeptItemIds, "response kept item IDs did not match")
+
+	// The cancellation should have been stored
+	byIdResponse, err := service.GetOrderByID(ctx, &pborder.GetOrderByIDRequest{OrderId: order.Id})
+	assert.Nil(err, "failed retrieving the cancelled order: %v", err)
+	cancellation := byIdResponse.Order.Cancellation
+	assert.NotNil(cancellation, "stored order should have been cancelled")
+	assert.Equal("customer_request", cancellation.ReasonCode, "stored cancellation reason did not match")
+	assert.WithinDuration(time.Now(), cancellation.CancellationTime.AsTime(), time.Minute, "stored cancellation time should have been about now")
+
+	// Once is enough
+	_, err = service.CancelOrder(ctx, &pborder.CancelOrderRequest{OrderId: order.Id, ReasonCode: "customer_request"})
+	assert.Equal(codes.FailedPrecondition, status.Code(err), "second cancellation should have been refused: %v", err)
+}
+
+// TestConcurrentCancelOrder confirms that, of two cancellations of the same order that overlap, only one is
+// recorded and the other is refused.
+func TestConcurrentCancelOrder(t *testing.T) {
+
+	// Do the common setup that most of our tests require, with an order of our own that someone else cancels while
+	// we are cancelling its tasks
+	assert, ctx, service := commonTestSetup(t)
+	order := storeCancellableOrder(ctx, assert, service)
+	service.Tasks = &UTTaskCanceller{during: func() {
+		_, err := service.FsClient.Doc(order.StoreRefPath()).Update(ctx, []firestore.Update{
+			{Path: "cancellation", Value: &schema.Cancellation{ReasonCode: "fraud", CancellationTime: time.Now()}},
+		})
+		assert.Nil(err, "failed to cancel the order behind the service's back: %v", err)
+	}}
+
+	// We should lose
+	_, err := service.CancelOrder(ctx, &pborder.CancelOrderRequest{OrderId: order.Id, ReasonCode: "customer_request"})
+	assert.Equal(codes.FailedPrecondition, status.Code(err), "overlapping cancellation should have been refused: %v", err)
+
+	// And the winner's record should stand
+	byIdResponse, err := service.GetOrderByID(ctx, &pborder.GetOrderByIDRequest{OrderId: order.Id})
+	assert.Nil(err, "failed retrieving the cancelled order: %v", err)
+	assert.Equal("fraud", byIdResponse.Order.Cancellation.ReasonCode, "the first cancellation should not have been overwritten")
+}
+
+// TestCancelOrderSettlesPayment confirms that cancelling an order voids a payment that has yet to be captured,
+// refunds the share paid for the cancelled items of one that has, and refunds one that was being captured once the capture is done.
+func TestCancelOrderSettlesPayment(t *testing.T) {
+
+	// Do the common setup that most of our tests require, with an order whose payment is only authorized
+	assert, ctx, service := commonTestSetup(t)
+	order := storeCancellableOrder(ctx, assert, service)
+	authorizeOrderPayment(ctx, assert, service, order.Id, types.NewMoney("USD", 30, 0))
+	service.Tasks = &UTTaskCanceller{cancelled: 2}
+
+	// Cancelling it voids the payment
+	_, err := service.CancelOrder(ctx, &pborder.CancelOrderRequest{OrderId: order.Id, ReasonCode: "customer_request"})
+	assert.Nil(err, "should not have seen an error cancelling the order: %v", err)
+	payment, err := service.Payments.GetPayment(ctx, order.Id)
+	assert.Nil(err, "failed to retrieve payment: %v", err)
+	assert.Equal(payments.PsVoided, payment.Status, "authorized payment should have been voided")
+
+	// Another order, paid for after a tenth was taken off it by a discount, with its first item shipped
+	order = storeCancellableOrder(ctx, assert, service)
+	authorizeOrderPayment(ctx, assert, service, order.Id, types.NewMoney("USD", 27, 0))
+	_, err = service.Payments.Capture(ctx, order.Id, nil, nil)
+	assert.Nil(err, "failed to capture payment: %v", err)
+	service.Tasks = &UTTaskCanceller{cancelled: 1, keptItemIds: []string{order.OrderItems[0].Id}}
+
+	// Cancelling the rest of it refunds what was paid for the second item
+	_, err = service.CancelOrder(ctx, &pborder.CancelOrderRequest{OrderId: order.Id, ReasonCode: "customer_request", AllowPartial: true})
+	assert.Nil(err, "should not have seen an error cancelling the order: %v", err)
+	payment, err = service.Payments.GetPayment(ctx, order.Id)
+	assert.Nil(err, "failed to retrieve payment: %v", err)
+	assert.Equal(payments.PsCaptured, payment.Status, "partly refunded payment should still have been captured")
+	assert.Equal("USD 11.25", payment.RefundedAmount.String(), "the discounted price of the cancelled item should have been refunded")
+
+	// And one whose payment was caught part way through being captured
+	order = storeCancellableOrder(ctx, assert, service)
+	authorizeOrderPayment(ctx, assert, service, order.Id, types.NewMoney("USD", 30, 0))
+	_, err = service.FsClient.Doc((&payments.Payment{OrderId: order.Id}).StoreRefPath()).Update(ctx, []firestore.Update{{Path: "status", Value: payments.PsCapturing}})
+	assert.Nil(err, "failed to mark payment as being captured: %v", err)
+	service.Tasks = &UTTaskCanceller{cancelled: 2}
+
+	// Cancelling it finishes the capture, then refunds the lot
+	_, err = service.CancelOrder(ctx, &pborder.CancelOrderRequest{OrderId: order.Id, ReasonCode: "customer_request"})
+	assert.Nil(err, "should not have seen an error cancelling the order: %v", err)
+	payment, err = service.Payments.GetPayment(ctx, order.Id)
+	assert.Nil(err, "failed to retrieve payment: %v", err)
+	assert.Equal(payments.PsRefunded, payment.Status, "payment being captured should have been captured and refunded")
+	assert.Equal("USD 30.00", payment.RefundedAmount.String(), "the whole payment should have been refunded")
+}
+
+// TestCancelOrderRefused confirms that the order is left alone if the fulfillment service refuses to cancel its
+// tasks.
+func TestCancelOrderRefused(t *testing.T) {
+
+	// Do the common setup that most of our tests require, with an order that the fulfillment service will not cancel
+	assert, ctx, service := commonTestSetup(t)
+	order := storeCancellableOrder(ctx, assert, service)
+	service.Tasks = &UTTaskCanceller{err: status.Error(codes.FailedPrecondition, "order items are past the point of cancellation")}
+
+	// Our request should be refused in the same terms
+	_, err := service.CancelOrder(ctx, &pborder.CancelOrderRequest{OrderId: order.Id, ReasonCode: "customer_request"})
+	assert.Equal(codes.FailedPrecondition, status.Code(err), "cancellation should have been refused: %v", err)
+	assert.Contains(err.Error(), "past the point of cancellation", "did not see the refusal that we expected")
+
+	// Other failures are internal errors
+	service.Tasks = &UTTaskCanceller{err: errors.New(unitTestErrorMessage)}
+	_, err = service.CancelOrder(ctx, &pborder.CancelOrderRequest{OrderId: order.Id, ReasonCode: "customer_request"})
+	assert.Equal(codes.Internal, status.Code(err), "cancellation should have failed: %v", err)
+	assert.Contains(err.Error(), unitTestErrorMessage, "did not see the error that we expected")
+
+	// And the order should not have been marked as cancelled
+	byIdResponse, err := service.GetOrderByID(ctx, &pborder.GetOrderByIDRequest{OrderId: order.Id})
+	assert.Nil(err, "failed retrieving the order: %v", err)
+	assert.Nil(byIdResponse.Order.Cancellation, "refused order should not have been cancelled")
+}
+
+// TestCancelOrderFailures confirms that requests that cannot be met are rejected with suitable status codes.
+func TestCancelOrderFailures(t *testing.T) {
+
+	// Do the common setup that most of our tests require, without a fulfillment service to start with
+	assert, ctx, service := commonTestSetup(t)
+	orderId := uuid.NewString()
+
+	// We must be told which order to cancel, and why
+	_, err := service.CancelOrder(ctx, &pborder.CancelOrderRequest{ReasonCode: "customer_request"})
+	assert.Equal(codes.InvalidArgument, status.Code(err), "cancellation without an order ID should have been rejected: %v", err)
+	_, err = service.CancelOrder(ctx, &pborder.CancelOrderRequest{OrderId: orderId})
+	assert.Equal(codes.InvalidArgument, status.Code(err), "cancellation without a reason should have been rejected: %v", err)
+
+	// And have some way to cancel its tasks
+	_, err = service.CancelOrder(ctx, &pborder.CancelOrderRequest{OrderId: orderId, ReasonCode: "customer_request"})
+	assert.Equal(codes.Unimplemented, status.Code(err), "cancellation without a fulfillment service should have been rejected: %v", err)
+
+	// Orders that do not exist cannot be cancelled
+	service.Tasks = &UTTaskCanceller{}
+	_, err = service.CancelOrder(ctx, &pborder.CancelOrderRequest{OrderId: orderId, ReasonCode: "customer_request"})
+	assert.Equal(codes.NotFound, status.Code(err), "cancellation of a missing order should have been rejected: %v", err)
+
+	// Nor can those that cannot be read
+	order := storeCancellableOrder(ctx, assert, service)
+	service.dsProxy = &UTDocSnapProxy{}
+	_, err = service.CancelOrder(ctx, &pborder.CancelOrderRequest{OrderId: order.Id, ReasonCode: "customer_request"})
+	assert.Equal(codes.Internal, status.Code(err), "cancellation of a corrupt order should have failed: %v", err)
+}
+
+// authorizeOrderPayment records an authorized payment of the given amount for the order with the given ID.
+func authorizeOrderPayment(ctx context.Context, assert *require.Assertions, service *OrderService, orderId string, amount *types.Money) {
+	err := service.FsClient.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		_, err := service.Payments.Authorize(ctx, tx, orderId, amount)
+		return err
+	})
+	assert.Nil(err, "failed to authorize payment: %v", err)
+}
+
+// storeCancellableOrder saves a two item order of our own to cancel. It does not have the UnitTestGivenName so as not
+// to upset the counts of the query tests.
+func storeCancellableOrder(ctx context.Context, assert *require.Assertions, service *OrderService) *schema.Order {
+	order := &schema.Order{
+		Id:             uuid.NewString(),
+		SubmissionTime: time.Now(),
+		OrderedBy:      &types.Person{Id: uuid.NewString(), FamilyName: "Cancel", GivenName: "Cancel~Test"},
+		OrderItems: []*schema.OrderItem{
+			{Id: uuid.NewString(), ProductCode: "cancel_1", Quantity: 1, UnitPrice: types.NewMoney("USD", 17, 500_000_000), Status: schema.OisInProgress},
+			{Id: uuid.NewString(), ProductCode: "cancel_2", Quantity: 1, UnitPrice: types.NewMoney("USD", 12, 500_000_000), Status: schema.OisPending},
+		},
+		Status: schema.OsInProgress,
+	}
+	err := service.SaveOrder(ctx, order)
+	assert.Nil(err, "did not expect an error storing the order: %v", err)
+	return order
+}
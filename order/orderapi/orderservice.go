@@ -7,13 +7,14 @@ import (
 	"encoding/base64"
 	"fmt"
 	"hash"
-	"strconv"
-	"strings"
+	"sort"
 	"time"
 
 	"cloud.google.com/go/firestore"
 	cartapi "github.com/mikebway/poc-gcp-ecomm/cart/cartapi"
 	"github.com/mikebway/poc-gcp-ecomm/order/schema"
+	"github.com/mikebway/poc-gcp-ecomm/paging"
+	"github.com/mikebway/poc-gcp-ecomm/payments"
 	pborder "github.com/mikebway/poc-gcp-ecomm/pb/order"
 	"go.uber.org/zap"
 	"google.golang.org/api/iterator"
@@ -72,6 +73,15 @@ type OrderService struct {
 	// queryProxy is used to allow unit tests to intercept firestore.Query function calls
 	// and insert errors etc. into the responses of the document iterator that the query returns.
 	queryProxy cartapi.QueryExecutionProxy
+
+	// Tasks gives the order service the means to cancel the fulfillment tasks of an order. It is set by the
+	// service main, not by NewOrderService, so that order service clients that have no need of it do not have
+	// to depend on the fulfillment service. CancelOrder is refused if it has not been set.
+	Tasks TaskCanceller
+
+	// Payments gives the order service the means to void or refund the payment for an order that is cancelled.
+	// Unit tests may substitute a payment provider that behaves as they need.
+	Payments *payments.Payments
 }
 
 // NewOrderService is a factory method returning an instance of our shopping cart service.
@@ -102,6 +112,9 @@ func NewOrderService() (*OrderService, error) {
 		return nil, fmt.Errorf("could not obtain firestore client: %w", err)
 	}
 
+	// Payments are given up through the same pretend payment provider that the cart service takes them with
+	svc.Payments = payments.NewPayments(svc.FsClient, payments.NewFakeProvider())
+
 	// All done - return the populated service instance
 	return svc, nil
 }
@@ -109,6 +122,8 @@ func NewOrderService() (*OrderService, error) {
 // SaveOrder stores the given order in the Firestore document collection. This is for internal domain use only and
 // so does not accept or return protobuf structures.
 //
+// The normalized names by which the order can be searched for, see schema.Order.SetSearchNames, are set here.
+//
 // An error will be returned if the order is already present in Firestore. Orders are immutable!
 func (os *OrderService) SaveOrder(ctx context.Context, order *schema.Order) error {
 
@@ -118,7 +133,8 @@ func (os *OrderService) SaveOrder(ctx context.Context, order *schema.Order) erro
 	l := zap.L()
 	l.Info("storing order", zap.String("orderId", order.Id))
 
-	// Store the order in firestore
+	// Store the order in firestore, searchable by name
+	order.SetSearchNames()
 	ref := os.FsClient.Doc(order.StoreRefPath())
 	_, err := os.drProxy.Create(ref, ctx, order)
 	if err != nil {
@@ -132,6 +148,77 @@ func (os *OrderService) SaveOrder(ctx context.Context, order *schema.Order) erro
 	return nil
 }
 
+// UpdateOrderStatus records the given order item statuses, keyed by order item ID, on the order with the given
+// ID and rolls them up into a new status for the order as a whole. Items that are not mentioned in the map keep the
+// status that they had. The new order status is returned. This is for internal domain use only and so does not
+// accept or return protobuf structures.
+//
+// The item statuses are taken to have been worked out from fulfillment tasks read at the given asOf time. The
+// order is read and updated within a transaction that only records them if no rollup of tasks read since then has
+// been recorded already; a stale rollup is ignored and the status of the order as it stands is returned instead.
+// That way, concurrent task changes for the same order cannot overwrite one another's item status, and a rollup
+// that is slow to finish cannot overwrite one that saw more recent task changes.
+func (os *OrderService) UpdateOrderStatus(ctx context.Context, orderId string, itemStatuses map[string]schema.OrderItemStatus, asOf time.Time) (schema.OrderStatus, error) {
+
+	// Obtain a shortcut handle on our globally configured logger and log some context
+	l := zap.L()
+	l.Info("updating order status", zap.String("orderId", orderId), zap.Time("asOf", asOf))
+
+	// Do the read and the write in the one transaction
+	var orderStatus schema.OrderStatus
+	var stale bool
+	ref := os.FsClient.Doc((&schema.Order{Id: orderId}).StoreRefPath())
+	err := os.FsClient.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+
+		// Load the order as it stands, into a fresh structure each time in case the transaction is retried
+		order := &schema.Order{Id: orderId}
+		snap, err := os.drProxy.TransactionalGet(ref, tx)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve order snapshot with ID %s: %w", orderId, err)
+		}
+		err = os.dsProxy.DataTo(snap, order)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal order snapshot with ID %s: %w", orderId, err)
+		}
+
+		// Leave the order alone if it already has the rollup of a more recent set of tasks than ours
+		stale = asOf.Before(order.StatusAsOf)
+		if stale {
+			orderStatus = order.Status
+			return nil
+		}
+
+		// Apply the item statuses that we have been given and roll them up
+		for _, item := range order.OrderItems {
+			if itemStatus, ok := itemStatuses[item.Id]; ok {
+				item.Status = itemStatus
+			}
+		}
+		orderStatus = order.RollupStatus()
+
+		// The items are stored as an array within the order document so have to be written back as a whole
+		return os.drProxy.TransactionalUpdate(ref, tx, []firestore.Update{
+			{Path: "orderItems", Value: order.OrderItems},
+			{Path: "status", Value: orderStatus},
+			{Path: "statusAsOf", Value: asOf},
+		})
+	})
+	if err != nil {
+		err = fmt.Errorf("failed updating order status in Firestore: %w", err)
+		l.Error(err.Error(), zap.String("orderId", orderId))
+		return schema.OsUnspecified, err
+	}
+
+	// All good, log our joy and return
+	if stale {
+		l.Info("order status not updated, a more recent rollup has been recorded", zap.String("orderId", orderId),
+			zap.Int32("status", int32(orderStatus)))
+		return orderStatus, nil
+	}
+	l.Info("order status updated", zap.String("orderId", orderId), zap.Int32("status", int32(orderStatus)))
+	return orderStatus, nil
+}
+
 // GetOrderByID retrieves an order matching the specified UUID ID in the pborder.GetOrderByIDRequest.
 func (os *OrderService) GetOrderByID(ctx context.Context, req *pborder.GetOrderByIDRequest) (*pborder.GetOrderByIDResponse, error) {
 
@@ -182,17 +269,45 @@ func (os *OrderService) GetOrders(ctx context.Context, req *pborder.GetOrdersReq
 	}
 
 	// Start with the whole collection and build up the query from there
-	query, err := os.buildOrderQuery(os.FsClient.Collection(schema.OrderCollection).Query, req)
+	query, err := os.buildOrderQuery(os.FsClient.Collection(schema.OrderCollection).Query, req, false)
 	if err != nil {
 		return nil, err
 	}
 
 	// Run the query to the set of matching orders. Casting the page size is safe - we just checked that it lies between 1 and 100
-	orders, nextPageToken, err := os.executeQuery(ctx, query, int(req.PageSize))
+	orders, err := os.executeQuery(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 
+	// Orders saved before the normalized search names were introduced do not have them, so cannot be found by them.
+	// Look for those by the exact names that they were recorded with, the way that they always used to be found, and
+	// merge them in. Both queries are sorted and paged the same way so the merged page follows on from the last.
+	if len(req.FamilyName) > 0 || len(req.GivenName) > 0 {
+		query, err = os.buildOrderQuery(os.FsClient.Collection(schema.OrderCollection).Query, req, true)
+		if err != nil {
+			return nil, err
+		}
+		legacyOrders, err := os.executeQuery(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		orders = mergeOrders(orders, legacyOrders, req.SortDirection == pborder.SortDirection_SD_DESCENDING, int(req.PageSize))
+	}
+
+	// Could there be another page? The orders slice should never be longer than pageSize, but we test for >= defensively
+	nextPageToken := ""
+	orderCount := len(orders)
+	if orderCount >= int(req.PageSize) {
+
+		// There could be more to load, use the last processed order's submission time and ID as our position marker
+		lastOrder := orders[orderCount-1]
+		nextPageToken, err = paging.NewToken(req, &paging.Cursor{SubmissionTime: lastOrder.SubmissionTime, Id: lastOrder.Id})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Loop, converting the slice of internal format orders to their protobuf equivalents
 	pbOrders := make([]*pborder.Order, len(orders))
 	for i, order := range orders {
@@ -208,7 +323,7 @@ func (os *OrderService) GetOrders(ctx context.Context, req *pborder.GetOrdersReq
 
 // executeQuery uses the supplied query to obtain an order document iterator, then build a slice of
 // results from that.
-func (os *OrderService) executeQuery(ctx context.Context, query firestore.Query, pageSize int) ([]*schema.Order, string, error) {
+func (os *OrderService) executeQuery(ctx context.Context, query firestore.Query) ([]*schema.Order, error) {
 
 	// Run the query to obtain an iterator over the matching documents
 	docs := os.queryProxy.Documents(ctx, query)
@@ -226,7 +341,7 @@ func (os *OrderService) executeQuery(ctx context.Context, query firestore.Query,
 
 			// We are either out of documents or have a real error
 			if err != iterator.Done {
-				return nil, "", fmt.Errorf("failed to retrieve order matching query: %w", err)
+				return nil, fmt.Errorf("failed to retrieve order matching query: %w", err)
 			}
 
 			//  For better or worse, we are done with this collection
@@ -237,23 +352,50 @@ func (os *OrderService) executeQuery(ctx context.Context, query firestore.Query,
 		orders = append(orders, item)
 	}
 
-	// Could there be another page? The orders slice should never be longer than pageSize, but we test for >= defensively
-	nextPageToken := ""
-	orderCount := len(orders)
-	if orderCount >= pageSize {
+	// All is well if we reach this point
+	return orders, nil
+}
 
-		// There could be more to load, use the last processed order's ID as our position marker
-		lastOrder := orders[orderCount-1]
-		nextPageToken = fmt.Sprintf("%x,%s", lastOrder.SubmissionTime.UnixNano(), orders[orderCount-1].Id)
+// mergeOrders merges two pages of orders, each sorted by submission time then ID, into a single page sorted the
+// same way, in descending order if asked, with no more than pageSize orders. Orders that appear in both pages
+// appear only once in the result.
+func mergeOrders(orders []*schema.Order, moreOrders []*schema.Order, descending bool, pageSize int) []*schema.Order {
+
+	// Gather the orders of both pages up, skipping any that we have seen already
+	seen := make(map[string]bool, len(orders)+len(moreOrders))
+	var merged []*schema.Order
+	for _, page := range [][]*schema.Order{orders, moreOrders} {
+		for _, order := range page {
+			if !seen[order.Id] {
+				seen[order.Id] = true
+				merged = append(merged, order)
+			}
+		}
 	}
 
-	// All is well if we reach this point
-	return orders, nextPageToken, nil
+	// Sort them the way that the queries did
+	before := func(a, b *schema.Order) bool {
+		return a.SubmissionTime.Before(b.SubmissionTime) || (a.SubmissionTime.Equal(b.SubmissionTime) && a.Id < b.Id)
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		if descending {
+			return before(merged[j], merged[i])
+		}
+		return before(merged[i], merged[j])
+	})
+
+	// Return no more than a page full
+	if len(merged) > pageSize {
+		merged = merged[:pageSize]
+	}
+	return merged
 }
 
 // buildOrderQuery translates the pborder.GetOrdersRequest parameters into filters on the given firestore.Query.
-// Always check the error return value - a query is returned whether an error occurred or not.
-func (os *OrderService) buildOrderQuery(query firestore.Query, req *pborder.GetOrdersRequest) (firestore.Query, error) {
+// Names are matched against the normalized search names of the orders unless legacyNames is true, in which case
+// they are matched exactly against the names of the person who submitted the order, as recorded before search
+// names were introduced. Always check the error return value - a query is returned whether an error occurred or not.
+func (os *OrderService) buildOrderQuery(query firestore.Query, req *pborder.GetOrdersRequest, legacyNames bool) (firestore.Query, error) {
 
 	// Ignore documents that fall outside the time window first then narrow the result set down from there
 	// if the caller specified that mach in their request ...
@@ -263,28 +405,49 @@ func (os *OrderService) buildOrderQuery(query firestore.Query, req *pborder.GetO
 	if req.EndTime != nil {
 		query = query.Where("submissionTime", "<", req.GetEndTime().AsTime())
 	}
-	if len(req.FamilyName) > 0 {
-		query = query.Where("orderedBy.familyName", "==", req.FamilyName)
+	if legacyNames {
+		if len(req.FamilyName) > 0 {
+			query = query.Where("orderedBy.familyName", "==", req.FamilyName)
+		}
+		if len(req.GivenName) > 0 {
+			query = query.Where("orderedBy.givenName", "==", req.GivenName)
+		}
+	} else {
+		if len(req.FamilyName) > 0 {
+			query = query.Where("searchFamilyName", "==", schema.SearchName(req.FamilyName))
+		}
+		if len(req.GivenName) > 0 {
+			query = query.Where("searchGivenName", "==", schema.SearchName(req.GivenName))
+		}
 	}
-	if len(req.GivenName) > 0 {
-		query = query.Where("orderedBy.givenName", "==", req.GivenName)
+	if req.Status != pborder.OrderStatus_OS_UNSPECIFIED {
+		query = query.Where("status", "==", schema.OrderStatus(req.Status))
+	}
+	if len(req.ShopperId) > 0 {
+		query = query.Where("orderedBy.id", "==", req.ShopperId)
 	}
 
-	// Order the results by submission time first, then by order ID (necessary for us to have a unique cursor position for paging)
-	query = query.OrderBy("submissionTime", firestore.Asc).OrderBy("id", firestore.Asc)
+	// Order the results by submission time first, then by order ID (necessary for us to have a unique cursor position for paging),
+	// newest first if that is what the caller wants
+	direction := firestore.Asc
+	if req.SortDirection == pborder.SortDirection_SD_DESCENDING {
+		direction = firestore.Desc
+	}
+	query = query.OrderBy("submissionTime", direction).OrderBy("id", direction)
 
 	// If a page token was specified, use that as the marker for the last document that has
-	// already been returned, i.e. start after that one.
+	// already been returned, i.e. start after that one. Since the cursor follows the direction of the ordering,
+	// this works just as well for newest first as for oldest first.
 	if len(req.PageToken) > 0 {
 
-		// Split the page token into its two parts - there should be two parts, submission time and ID
-		afterTime, afterOrderId, err := splitPageToken(req.PageToken)
+		// Recover the submission time and ID from the page token, so long as it was issued for this same query
+		cursor, err := paging.ParseToken(req, req.PageToken)
 		if err != nil {
 			return query, err
 		}
 
 		// Add the start after factors to our query
-		query = query.StartAfter(afterTime, afterOrderId)
+		query = query.StartAfter(cursor.SubmissionTime, cursor.Id)
 	}
 
 	// Limit the size of the result set to the page size
@@ -294,26 +457,6 @@ func (os *OrderService) buildOrderQuery(query firestore.Query, req *pborder.GetO
 	return query, nil
 }
 
-// splitPageToken breaks a page token string into its time and order ID components.
-func splitPageToken(token string) (time.Time, string, error) {
-
-	// Split the page token into its two parts - there should be two parts
-	parts := strings.Split(token, ",")
-	if len(parts) == 2 {
-
-		// Convert the first part from a Unix time value to a time.Time
-		unixNanoTime, err := strconv.ParseInt(parts[0], 16, 64)
-		if err == nil {
-
-			// All looks good (as shallowly as we are bothering to look), return what we have
-			return time.Unix(0, unixNanoTime), parts[1], nil
-		}
-	}
-
-	// Only arrive here if the page token is invalid
-	return time.Time{}, "", fmt.Errorf("invalid page token: %s", token)
-}
-
 // logQuery writes a log entry documenting the attributes that make up a OrderService.GetOrders Firestore query.
 // PII fields, i.e. family name, will be one way encrypted so that they can be safely logged (hashed with
 // a salt, encoded as base65, and truncated to 12 characters).
@@ -333,6 +476,15 @@ func (os *OrderService) logQuery(req *pborder.GetOrdersRequest) {
 	if len(req.GivenName) > 0 {
 		fields = append(fields, zap.String("givenName", PiiHashString(req.GivenName)))
 	}
+	if req.Status != pborder.OrderStatus_OS_UNSPECIFIED {
+		fields = append(fields, zap.String("status", req.Status.String()))
+	}
+	if len(req.ShopperId) > 0 {
+		fields = append(fields, zap.String("shopperId", req.ShopperId))
+	}
+	if req.SortDirection != pborder.SortDirection_SD_UNSPECIFIED {
+		fields = append(fields, zap.String("sortDirection", req.SortDirection.String()))
+	}
 	if len(req.PageToken) > 0 {
 		fields = append(fields, zap.String("pageToken", req.PageToken))
 	}
@@ -3,6 +3,7 @@ package orderapi
 import (
 	"errors"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -16,6 +17,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -239,6 +242,73 @@ func TestGetOrderByIDCorrupt(t *testing.T) {
 	assert.Nil(response, "should not have received a response")
 }
 
+// TestUpdateOrderStatus follows an order through its lifecycle as the status of its items is updated, confirming that
+// the rolled up order status is both returned by GetOrderByID and usable as a GetOrders filter.
+func TestUpdateOrderStatus(t *testing.T) {
+
+	// Do the common setup that most of our tests require
+	assert, ctx, service := commonTestSetup(t)
+
+	// Save an order of our own to work with, with a family name that no other order, from this or prior runs,
+	// will have. It does not have the UnitTestGivenName so as not to upset the counts of the other tests.
+	order := &schema.Order{
+		Id:             uuid.NewString(),
+		SubmissionTime: time.Now(),
+		OrderedBy:      &types.Person{Id: uuid.NewString(), FamilyName: uuid.NewString(), GivenName: "Status~Test"},
+		OrderItems: []*schema.OrderItem{
+			{Id: uuid.NewString(), ProductCode: "status_1", Quantity: 1, Status: schema.OisPending},
+			{Id: uuid.NewString(), ProductCode: "status_2", Quantity: 1, Status: schema.OisPending},
+		},
+		Status: schema.OsSubmitted,
+	}
+	err := service.SaveOrder(ctx, order)
+	assert.Nil(err, "did not expect an error storing the order: %v", err)
+
+	// Start work on the first item
+	status, err := service.UpdateOrderStatus(ctx, order.Id, map[string]schema.OrderItemStatus{order.OrderItems[0].Id: schema.OisInProgress}, time.Now())
+	assert.Nil(err, "did not expect an error starting the first item: %v", err)
+	assert.Equal(schema.OsInProgress, status, "order should have been in progress")
+
+	// The order should be found by its status, and not by any other
+	request := &pborder.GetOrdersRequest{FamilyName: order.OrderedBy.FamilyName, Status: pborder.OrderStatus_OS_IN_PROGRESS}
+	response, err := service.GetOrders(ctx, request)
+	assert.Nil(err, "did not expect an error querying in progress orders: %v", err)
+	assert.Equal(1, len(response.Orders), "expected to find the order in progress")
+	assert.Equal(order.Id, response.Orders[0].Id, "found the wrong order in progress")
+	request.Status = pborder.OrderStatus_OS_COMPLETE
+	response, err = service.GetOrders(ctx, request)
+	assert.Nil(err, "did not expect an error querying complete orders: %v", err)
+	assert.Equal(0, len(response.Orders), "did not expect to find a complete order")
+
+	// Finish the first item
+	staleAsOf := time.Now()
+	status, err = service.UpdateOrderStatus(ctx, order.Id, map[string]schema.OrderItemStatus{order.OrderItems[0].Id: schema.OisComplete}, time.Now())
+	assert.Nil(err, "did not expect an error completing the first item: %v", err)
+	assert.Equal(schema.OsPartiallyFulfilled, status, "order should have been partially fulfilled")
+
+	// A rollup of tasks read before that one was must not undo it
+	status, err = service.UpdateOrderStatus(ctx, order.Id, map[string]schema.OrderItemStatus{order.OrderItems[0].Id: schema.OisInProgress}, staleAsOf)
+	assert.Nil(err, "did not expect an error recording a stale rollup: %v", err)
+	assert.Equal(schema.OsPartiallyFulfilled, status, "stale rollup should have left the order partially fulfilled")
+
+	// Cancel the second, leaving nothing more to be done
+	status, err = service.UpdateOrderStatus(ctx, order.Id, map[string]schema.OrderItemStatus{order.OrderItems[1].Id: schema.OisCancelled}, time.Now())
+	assert.Nil(err, "did not expect an error cancelling the second item: %v", err)
+	assert.Equal(schema.OsComplete, status, "order should have been complete")
+
+	// All of which should be seen when the order is retrieved
+	byIdResponse, err := service.GetOrderByID(ctx, &pborder.GetOrderByIDRequest{OrderId: order.Id})
+	assert.Nil(err, "did not expect an error retrieving the order: %v", err)
+	assert.Equal(pborder.OrderStatus_OS_COMPLETE, byIdResponse.Order.Status, "retrieved order status did not match")
+	assert.Equal(pborder.OrderItemStatus_OIS_COMPLETE, byIdResponse.Order.OrderItems[0].Status, "retrieved first item status did not match")
+	assert.Equal(pborder.OrderItemStatus_OIS_CANCELLED, byIdResponse.Order.OrderItems[1].Status, "retrieved second item status did not match")
+
+	// Orders that do not exist cannot be updated
+	_, err = service.UpdateOrderStatus(ctx, "no-way-this-exists", nil, time.Now())
+	assert.NotNil(err, "should have failed updating a non-existent order")
+	assert.Contains(err.Error(), "failed to retrieve order snapshot with ID no-way-this-exists", "did not see the error we expected")
+}
+
 // TestSubmissionTimeQuery tries out finding multiple orders that fall within a given time span and paging to boot.
 func TestSubmissionTimeQuery(t *testing.T) {
 
@@ -346,6 +416,106 @@ func TestGivenNameQuery(t *testing.T) {
 	assert.Empty(response.NextPageToken, "next page token should NOT have been set after retrieving third page")
 }
 
+// TestShopperNewestFirstQuery tries out finding the orders of a single shopper, newest first, by their ID and by
+// their name typed without regard for case or accents, paging to boot.
+func TestShopperNewestFirstQuery(t *testing.T) {
+
+	// Do the common setup that most of our tests require
+	assert, ctx, service := commonTestSetup(t)
+
+	// Store three orders of our own for the same shopper, an hour apart and a year before the mock orders. They do
+	// not have the UnitTestGivenName so as not to upset the counts of the other tests.
+	shopperId := uuid.NewString()
+	var orders []*schema.Order
+	for i := 0; i < 3; i++ {
+		order := &schema.Order{
+			Id:             uuid.NewString(),
+			SubmissionTime: mockOrders[0].SubmissionTime.AddDate(-1, 0, 0).Add(time.Duration(i) * time.Hour),
+			OrderedBy:      &types.Person{Id: shopperId, FamilyName: "Brontë", GivenName: "Émilie"},
+		}
+		err := service.SaveOrder(ctx, order)
+		assert.Nil(err, "did not expect an error storing a shopper order: %v", err)
+		orders = append(orders, order)
+	}
+	assert.Equal("bronte", orders[0].SearchFamilyName, "search family name should have been set when the order was saved")
+
+	// The newest two come first
+	request := &pborder.GetOrdersRequest{
+		ShopperId:     shopperId,
+		SortDirection: pborder.SortDirection_SD_DESCENDING,
+		PageSize:      2,
+	}
+	response, err := service.GetOrders(ctx, request)
+	assert.Nil(err, "did not expect an error calling GetOrders for the first page: %v", err)
+	assert.Equal(2, len(response.Orders), "expect 2 orders in the first full page")
+	assert.Equal(orders[2].Id, response.Orders[0].Id, "newest order should have come first")
+	assert.Equal(orders[1].Id, response.Orders[1].Id, "second newest order should have come second")
+	assert.NotEmpty(response.NextPageToken, "next page token should have been set after retrieving first page")
+
+	// Then the oldest
+	request.PageToken = response.NextPageToken
+	response, err = service.GetOrders(ctx, request)
+	assert.Nil(err, "did not expect an error calling GetOrders for the second page: %v", err)
+	assert.Equal(1, len(response.Orders), "expect 1 order in the second partial page")
+	assert.Equal(orders[0].Id, response.Orders[0].Id, "oldest order should have come last")
+	assert.Empty(response.NextPageToken, "next page token should NOT have been set after retrieving second page")
+
+	// The shopper's name can be typed any which way
+	response, err = service.GetOrders(ctx, &pborder.GetOrdersRequest{ShopperId: shopperId, FamilyName: "BRONTE", GivenName: "emilie", PageSize: 5})
+	assert.Nil(err, "did not expect an error calling GetOrders by name: %v", err)
+	assert.Equal(3, len(response.Orders), "expect all 3 orders to be found by name")
+	assert.Equal(orders[0].Id, response.Orders[0].Id, "oldest order should have come first by default")
+	assert.Equal("Brontë", response.Orders[0].OrderedBy.FamilyName, "family name should have been returned as it was given")
+
+	// But only the shopper's own orders are found
+	response, err = service.GetOrders(ctx, &pborder.GetOrdersRequest{ShopperId: uuid.NewString(), FamilyName: "Brontë", PageSize: 5})
+	assert.Nil(err, "did not expect an error calling GetOrders for another shopper: %v", err)
+	assert.Empty(response.Orders, "another shopper should have had no orders")
+
+	// Clean up after ourselves
+	for _, order := range orders {
+		_, err = service.FsClient.Doc(order.StoreRefPath()).Delete(ctx)
+		assert.Nil(err, "failed to clean up order: %v", err)
+	}
+}
+
+// TestPageTokenMismatch confirms that a page token cannot be used to continue a query with different filters,
+// page size, or sort direction to the one that it came from.
+func TestPageTokenMismatch(t *testing.T) {
+
+	// Do the common setup that most of our tests require
+	assert, ctx, service := commonTestSetup(t)
+
+	// Get a token for the second page of our mock orders
+	request := &pborder.GetOrdersRequest{
+		GivenName: UnitTestGivenName, // All 10 or our mock orders have the same given name
+		PageSize:  5,
+	}
+	response, err := service.GetOrders(ctx, request)
+	assert.Nil(err, "did not expect an error calling GetOrders for the first page: %v", err)
+	assert.NotEmpty(response.NextPageToken, "next page token should have been set after retrieving first page")
+
+	// Try to use it for another family
+	familyRequest := &pborder.GetOrdersRequest{
+		GivenName:  UnitTestGivenName,
+		FamilyName: "RepeatedName",
+		PageSize:   5,
+		PageToken:  response.NextPageToken,
+	}
+	_, err = service.GetOrders(ctx, familyRequest)
+	assert.Equal(codes.InvalidArgument, status.Code(err), "page token should have been rejected for different filters: %v", err)
+
+	// Or for bigger pages, or the other way around
+	request.PageToken = response.NextPageToken
+	request.PageSize = 6
+	_, err = service.GetOrders(ctx, request)
+	assert.Equal(codes.InvalidArgument, status.Code(err), "page token should have been rejected for a different page size: %v", err)
+	request.PageSize = 5
+	request.SortDirection = pborder.SortDirection_SD_DESCENDING
+	_, err = service.GetOrders(ctx, request)
+	assert.Equal(codes.InvalidArgument, status.Code(err), "page token should have been rejected for a different sort direction: %v", err)
+}
+
 // TestLoggingAndBadPageToken kills two birds with one stone, verifying that queries are logged for diagnostic
 // purposes and looking at how the code handles an invalid "net page token."
 func TestLoggingAndBadPageToken(t *testing.T) {
@@ -370,6 +540,7 @@ func TestLoggingAndBadPageToken(t *testing.T) {
 	// We should have see a error complaining about the page token
 	assert.NotNil(err, "expected an error calling GetOrders")
 	assert.Contains(err.Error(), "invalid page token", "did not get the expected error")
+	assert.Equal(codes.InvalidArgument, status.Code(err), "bad page token should have been an invalid argument")
 	assert.Nil(response, "should not have received a response")
 
 	// Confirm that the log output described the query
@@ -381,6 +552,65 @@ func TestLoggingAndBadPageToken(t *testing.T) {
 	assert.Contains(logged, request.PageToken, "log output should contain the duff page token")
 }
 
+// TestLegacyNameQuery confirms that orders saved before the normalized search names were introduced can still be
+// found, along with newer orders, by the exact names that they were recorded with, paging to boot.
+func TestLegacyNameQuery(t *testing.T) {
+
+	// Do the common setup that most of our tests require
+	assert, ctx, service := commonTestSetup(t)
+
+	// Store two orders as they would have been stored before they had search names, and a third the way that they
+	// are now, all with a family name that no other order will have. They do not have the UnitTestGivenName so as
+	// not to upset the counts of the other tests.
+	familyName := "Legacy-" + uuid.NewString()
+	var orders []*schema.Order
+	for i := 0; i < 3; i++ {
+		order := &schema.Order{
+			Id:             uuid.NewString(),
+			SubmissionTime: mockOrders[0].SubmissionTime.AddDate(-1, 0, 0).Add(time.Duration(i) * time.Hour),
+			OrderedBy:      &types.Person{Id: uuid.NewString(), FamilyName: familyName, GivenName: "Legacy~Test"},
+		}
+		var err error
+		if i < 2 {
+			_, err = service.FsClient.Doc(order.StoreRefPath()).Set(ctx, order)
+		} else {
+			err = service.SaveOrder(ctx, order)
+		}
+		assert.Nil(err, "did not expect an error storing an order: %v", err)
+		orders = append(orders, order)
+	}
+	assert.Empty(orders[0].SearchFamilyName, "legacy order should not have had a search family name")
+
+	// The two oldest, legacy, orders come first
+	request := &pborder.GetOrdersRequest{FamilyName: familyName, PageSize: 2}
+	response, err := service.GetOrders(ctx, request)
+	assert.Nil(err, "did not expect an error calling GetOrders for the first page: %v", err)
+	assert.Equal(2, len(response.Orders), "expect 2 orders in the first full page")
+	assert.Equal(orders[0].Id, response.Orders[0].Id, "oldest order should have come first")
+	assert.Equal(orders[1].Id, response.Orders[1].Id, "second oldest order should have come second")
+	assert.NotEmpty(response.NextPageToken, "next page token should have been set after retrieving first page")
+
+	// Then the newer one, just the once even though it matches both ways
+	request.PageToken = response.NextPageToken
+	response, err = service.GetOrders(ctx, request)
+	assert.Nil(err, "did not expect an error calling GetOrders for the second page: %v", err)
+	assert.Equal(1, len(response.Orders), "expect 1 order in the second partial page")
+	assert.Equal(orders[2].Id, response.Orders[0].Id, "newest order should have come last")
+	assert.Empty(response.NextPageToken, "next page token should NOT have been set after retrieving second page")
+
+	// Typed any other way, only the newer order can be found
+	response, err = service.GetOrders(ctx, &pborder.GetOrdersRequest{FamilyName: strings.ToUpper(familyName), PageSize: 5})
+	assert.Nil(err, "did not expect an error calling GetOrders by upper case name: %v", err)
+	assert.Equal(1, len(response.Orders), "expect only the newer order to be found by upper case name")
+	assert.Equal(orders[2].Id, response.Orders[0].Id, "found the wrong order by upper case name")
+
+	// Clean up after ourselves
+	for _, order := range orders {
+		_, err = service.FsClient.Doc(order.StoreRefPath()).Delete(ctx)
+		assert.Nil(err, "failed to clean up order: %v", err)
+	}
+}
+
 // TestQueryError looks at how the code handles an error while executing a Firestore query
 func TestQueryError(t *testing.T) {
 
@@ -3,11 +3,17 @@
 package schema
 
 import (
+	"strings"
+	"time"
+	"unicode"
+
 	pborder "github.com/mikebway/poc-gcp-ecomm/pb/order"
 	pbtypes "github.com/mikebway/poc-gcp-ecomm/pb/types"
 	"github.com/mikebway/poc-gcp-ecomm/types"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
 	"google.golang.org/protobuf/types/known/timestamppb"
-	"time"
 )
 
 const (
@@ -39,6 +45,131 @@ type Order struct {
 
 	// OrderItems is the list of one to many items that make up the potential order
 	OrderItems []*OrderItem `firestore:"orderItems" json:"orderItems"`
+
+	// Subtotal is the sum of the subtotals of all the order items, recorded when the order is submitted
+	Subtotal *types.Money `firestore:"subtotal,omitempty" json:"subtotal,omitempty"`
+
+	// Discounts (Optional) lists the discounts earned by the promotion codes applied to the shopping cart that the
+	// order was derived from
+	Discounts []*Discount `firestore:"discounts,omitempty" json:"discounts,omitempty"`
+
+	// Total is the subtotal less all the discounts, plus the delivery cost and the tax, i.e. what the customer is to
+	// pay, recorded when the order is submitted
+	Total *types.Money `firestore:"total,omitempty" json:"total,omitempty"`
+
+	// Tax (Optional) is the tax due on the order, calculated when the order is submitted. It is not set if the tax
+	// could not be calculated, e.g. because the order has no delivery address.
+	Tax *types.Money `firestore:"tax,omitempty" json:"tax,omitempty"`
+
+	// DeliveryOption (Optional) is the delivery option chosen for the order, and its cost, as fixed when the
+	// shopping cart that the order was derived from was checked out
+	DeliveryOption *DeliveryOption `firestore:"deliveryOption,omitempty" json:"deliveryOption,omitempty"`
+
+	// Status describes the progress of the order as a whole. It is rolled up from the status of the order items
+	// whenever the status of one of their fulfillment tasks changes; see RollupStatus.
+	Status OrderStatus `firestore:"status" json:"status"`
+
+	// StatusAsOf is the time at which the fulfillment tasks that Status and the order item statuses were rolled up
+	// from were read. A rollup of tasks read before this time is stale and is not recorded; see
+	// orderapi.OrderService.UpdateOrderStatus.
+	StatusAsOf time.Time `firestore:"statusAsOf,omitempty" json:"-"`
+
+	// Cancellation (Optional) is set if the order has been cancelled
+	Cancellation *Cancellation `firestore:"cancellation,omitempty" json:"cancellation,omitempty"`
+
+	// SearchFamilyName is the family name of the person who submitted the order, normalized by SearchName so that
+	// orders can be found by family name regardless of case and accents. It is set by SetSearchNames when the order
+	// is saved and is not part of the order as far as anyone outside the order service is concerned.
+	SearchFamilyName string `firestore:"searchFamilyName,omitempty" json:"-"`
+
+	// SearchGivenName is the given name of the person who submitted the order, normalized in the same way as
+	// SearchFamilyName.
+	SearchGivenName string `firestore:"searchGivenName,omitempty" json:"-"`
+}
+
+// Cancellation records an order having been cancelled.
+type Cancellation struct {
+
+	// ReasonCode is the reason that the order was cancelled; it is also given to the cancelled fulfillment tasks
+	ReasonCode string `firestore:"reasonCode" json:"reasonCode"`
+
+	// CancellationTime is the time at which the order was cancelled
+	CancellationTime time.Time `firestore:"cancellationTime" json:"cancellationTime"`
+
+	// KeptItemIds lists the IDs of the order items that were past the point at which they could be cancelled
+	KeptItemIds []string `firestore:"keptItemIds,omitempty" json:"keptItemIds,omitempty"`
+}
+
+// CancelledItemsValue returns the total price of those of the given order items that were cancelled, i.e. are not
+// listed in KeptItemIds, or nil if there are none, or none of them were priced.
+func (c *Cancellation) CancelledItemsValue(items []*OrderItem) (*types.Money, error) {
+	kept := make(map[string]bool, len(c.KeptItemIds))
+	for _, id := range c.KeptItemIds {
+		kept[id] = true
+	}
+	var values []*types.Money
+	for _, item := range items {
+		if !kept[item.Id] && item.UnitPrice != nil {
+			values = append(values, item.UnitPrice.Multiply(item.Quantity))
+		}
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+	return types.SumMoney(values...)
+}
+
+// OrderStatus is an enumeration type defining the overall status of an order
+type OrderStatus int32
+
+const (
+	OsUnspecified        OrderStatus = 0
+	OsSubmitted          OrderStatus = 1
+	OsInProgress         OrderStatus = 2
+	OsPartiallyFulfilled OrderStatus = 3
+	OsComplete           OrderStatus = 4
+	OsCancelled          OrderStatus = 5
+)
+
+// OrderItemStatus is an enumeration type defining the status of a single order item
+type OrderItemStatus int32
+
+const (
+	OisUnspecified OrderItemStatus = 0
+	OisPending     OrderItemStatus = 1
+	OisInProgress  OrderItemStatus = 2
+	OisComplete    OrderItemStatus = 3
+	OisCancelled   OrderItemStatus = 4
+)
+
+// Discount records a discount earned by applying a promotion code to the shopping cart that an order was derived
+// from.
+type Discount struct {
+
+	// PromotionCode is the code of the promotion that earned the discount
+	PromotionCode string `firestore:"promotionCode" json:"promotionCode"`
+
+	// Description is the description of the promotion that earned the discount
+	Description string `firestore:"description" json:"description"`
+
+	// ProductCode (Optional) is the product that the discount applies to, if not the order as a whole
+	ProductCode string `firestore:"productCode,omitempty" json:"productCode,omitempty"`
+
+	// Amount is the amount taken off the order subtotal, expressed as a positive value
+	Amount *types.Money `firestore:"amount" json:"amount"`
+}
+
+// DeliveryOption records the way in which the physical items of an order are to be delivered, and what it costs.
+type DeliveryOption struct {
+
+	// Code identifies the delivery option, e.g. "STANDARD" or "EXPRESS"
+	Code string `firestore:"code" json:"code"`
+
+	// Description is a human readable description of the delivery option
+	Description string `firestore:"description" json:"description"`
+
+	// Cost is the price of delivering the order items by this option
+	Cost *types.Money `firestore:"cost" json:"cost"`
 }
 
 // OrderItem represents a single entry in an order. An order will contain one
@@ -63,6 +194,14 @@ type OrderItem struct {
 	// UnitPrice is the price that the customer was shown for a single item
 	// when they selected the item for their cart.
 	UnitPrice *types.Money `firestore:"unitPrice" json:"unitPrice"`
+
+	// Attributes describe how the item is to be customized, e.g. the text to be engraved on it, as chosen by the
+	// shopper in their cart.
+	Attributes []*types.Attribute `firestore:"attributes,omitempty" json:"attributes,omitempty"`
+
+	// Status describes the progress of the item towards fulfillment, rolled up from the status of the fulfillment
+	// tasks recorded for it.
+	Status OrderItemStatus `firestore:"status" json:"status"`
 }
 
 // StoreRefPath returns the string representation of the document reference path for this Order.
@@ -70,6 +209,27 @@ func (o *Order) StoreRefPath() string {
 	return OrderCollection + "/" + o.Id
 }
 
+// SetSearchNames sets the SearchFamilyName and SearchGivenName of this order from the names of the person who
+// submitted it.
+func (o *Order) SetSearchNames() {
+	if o.OrderedBy == nil {
+		return
+	}
+	o.SearchFamilyName = SearchName(o.OrderedBy.FamilyName)
+	o.SearchGivenName = SearchName(o.OrderedBy.GivenName)
+}
+
+// SearchName returns the given name in the normalized form in which names are stored for searching: lower case,
+// with accents and other combining marks removed, e.g. "Brontë" becomes "bronte".
+func SearchName(name string) string {
+	folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), name)
+	if err != nil {
+		// The transformation cannot fail on a string but, just in case, lower case is better than nothing
+		folded = name
+	}
+	return strings.ToLower(folded)
+}
+
 // AsPBOrder returns the protocol buffer representation of this order.
 func (o *Order) AsPBOrder() *pborder.Order {
 
@@ -98,6 +258,22 @@ func (o *Order) AsPBOrder() *pborder.Order {
 		pbItems[i] = item.AsPBOrderItem()
 	}
 
+	// As are the discounts
+	var pbDiscounts []*pborder.Discount
+	for _, discount := range o.Discounts {
+		pbDiscounts = append(pbDiscounts, discount.AsPBDiscount())
+	}
+
+	// Orders stored before subtotals and totals were recorded will need to have theirs calculated
+	subtotal := o.Subtotal
+	if subtotal == nil {
+		subtotal, _ = o.CalculateSubtotal()
+	}
+	total := o.Total
+	if total == nil {
+		total, _ = o.CalculateTotal()
+	}
+
 	// Return a populated protocol buffer version of the order
 	return &pborder.Order{
 		Id:              o.Id,
@@ -105,9 +281,118 @@ func (o *Order) AsPBOrder() *pborder.Order {
 		OrderedBy:       pbOrderedBy,
 		DeliveryAddress: pbAddress,
 		OrderItems:      pbItems,
+		Subtotal:        subtotal.AsPBMoney(),
+		Discounts:       pbDiscounts,
+		Total:           total.AsPBMoney(),
+		Tax:             o.Tax.AsPBMoney(),
+		DeliveryOption:  o.DeliveryOption.AsPBDeliveryOption(),
+		Status:          pborder.OrderStatus(o.Status),
+		Cancellation:    o.Cancellation.AsPBOrderCancellation(),
 	}
 }
 
+// RollupStatus returns the status of the order as a whole given the status of each of its items:
+//
+//   - cancelled if every item has been cancelled
+//   - complete if every item has been either completed or cancelled
+//   - partially fulfilled if some, but not all, of the items have been completed
+//   - in progress if work has started on any item
+//   - submitted if no work has started on any item yet, or the order has no items at all
+func (o *Order) RollupStatus() OrderStatus {
+
+	// Count the items in each of the states that we care about
+	var complete, cancelled, inProgress int
+	for _, item := range o.OrderItems {
+		switch item.Status {
+		case OisComplete:
+			complete++
+		case OisCancelled:
+			cancelled++
+		case OisInProgress:
+			inProgress++
+		}
+	}
+
+	// Work from the most finished states down to the least
+	itemCount := len(o.OrderItems)
+	switch {
+	case itemCount == 0:
+		return OsSubmitted
+	case cancelled == itemCount:
+		return OsCancelled
+	case complete+cancelled == itemCount:
+		return OsComplete
+	case complete > 0:
+		return OsPartiallyFulfilled
+	case inProgress > 0:
+		return OsInProgress
+	default:
+		return OsSubmitted
+	}
+}
+
+// CalculateSubtotal returns the sum of the subtotals of all the items in the order. An error is returned if the
+// order has no items, if any item has no unit price, or if the items are not all priced in the same currency.
+func (o *Order) CalculateSubtotal() (*types.Money, error) {
+
+	// Gather up the item subtotals and add them up
+	subtotals := make([]*types.Money, len(o.OrderItems))
+	for i, item := range o.OrderItems {
+		subtotals[i] = item.Subtotal()
+	}
+	return types.SumMoney(subtotals...)
+}
+
+// CancelledShare returns the share of the given amount paid for the order that is due back for its cancelled items,
+// or nil if the order has not been cancelled or there is nothing due back. The share is the amount paid prorated by
+// the value of the cancelled items against the subtotal of the order, so that the discounts, delivery cost, and tax
+// that were paid are given back in proportion to the items that were cancelled along with them.
+func (o *Order) CancelledShare(paid *types.Money) (*types.Money, error) {
+	if o.Cancellation == nil || paid == nil {
+		return nil, nil
+	}
+	value, err := o.Cancellation.CancelledItemsValue(o.OrderItems)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	subtotal := o.Subtotal
+	if subtotal == nil {
+		subtotal, err = o.CalculateSubtotal()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return paid.Prorate(value, subtotal)
+}
+
+// CalculateTotal returns the subtotal of the order less all of its discounts, plus any delivery cost and tax. An error
+// is returned if the subtotal cannot be calculated or if any discount, the delivery cost, or the tax is in a different
+// currency.
+func (o *Order) CalculateTotal() (*types.Money, error) {
+	total, err := o.CalculateSubtotal()
+	for _, discount := range o.Discounts {
+		if err != nil {
+			break
+		}
+		total, err = total.Subtract(discount.Amount)
+	}
+	if err == nil && o.DeliveryOption != nil && o.DeliveryOption.Cost != nil {
+		total, err = total.Add(o.DeliveryOption.Cost)
+	}
+	if err == nil && o.Tax != nil {
+		total, err = total.Add(o.Tax)
+	}
+	return total, err
+}
+
+// Subtotal returns the unit price of this order item multiplied by its quantity, or nil if the item has no unit price.
+func (item *OrderItem) Subtotal() *types.Money {
+	if item.UnitPrice == nil {
+		return nil
+	}
+	return item.UnitPrice.Multiply(item.Quantity)
+}
+
 // AsPBOrderItem returns the protocol buffer representation of this cart item.
 func (item *OrderItem) AsPBOrderItem() *pborder.OrderItem {
 	return &pborder.OrderItem{
@@ -115,5 +400,44 @@ func (item *OrderItem) AsPBOrderItem() *pborder.OrderItem {
 		ProductCode: item.ProductCode,
 		Quantity:    item.Quantity,
 		UnitPrice:   item.UnitPrice.AsPBMoney(),
+		Subtotal:    item.Subtotal().AsPBMoney(),
+		Attributes:  types.AttributesAsPB(item.Attributes),
+		Status:      pborder.OrderItemStatus(item.Status),
+	}
+}
+
+// AsPBDiscount returns the protocol buffer representation of this discount.
+func (d *Discount) AsPBDiscount() *pborder.Discount {
+	return &pborder.Discount{
+		PromotionCode: d.PromotionCode,
+		Description:   d.Description,
+		ProductCode:   d.ProductCode,
+		Amount:        d.Amount.AsPBMoney(),
+	}
+}
+
+// AsPBDeliveryOption returns the protocol buffer representation of this delivery option. Nil is returned for a nil
+// delivery option, saving callers from having to check whether one was chosen.
+func (d *DeliveryOption) AsPBDeliveryOption() *pborder.DeliveryOption {
+	if d == nil {
+		return nil
+	}
+	return &pborder.DeliveryOption{
+		Code:        d.Code,
+		Description: d.Description,
+		Cost:        d.Cost.AsPBMoney(),
+	}
+}
+
+// AsPBOrderCancellation returns the protocol buffer representation of this cancellation. Nil is returned for a nil
+// cancellation, saving callers from having to check whether the order was cancelled.
+func (c *Cancellation) AsPBOrderCancellation() *pborder.OrderCancellation {
+	if c == nil {
+		return nil
+	}
+	return &pborder.OrderCancellation{
+		ReasonCode:       c.ReasonCode,
+		CancellationTime: timestamppb.New(c.CancellationTime),
+		KeptItemIds:      c.KeptItemIds,
 	}
 }
@@ -110,6 +110,8 @@ func TestAsPBOrder(t *testing.T) {
 	req.Equal(itemPriceCurrencyCode, pbOrder.OrderItems[0].UnitPrice.CurrencyCode, "order item 1 price currency does not match")
 	req.Equal(itemPriceUnits1, pbOrder.OrderItems[0].UnitPrice.Units, "order item 1 price units does not match")
 	req.Equal(itemPriceNanos1, pbOrder.OrderItems[0].UnitPrice.Nanos, "order item 1 price nanos does not match")
+	req.Equal(1, len(pbOrder.OrderItems[0].Attributes), "order item 1 attribute count does not match")
+	req.Equal("engraving", pbOrder.OrderItems[0].Attributes[0].Name, "order item 1 attribute name does not match")
 
 	req.NotNil(pbOrder.OrderItems[1], "order item 2 missing")
 	req.Equal(itemId2, pbOrder.OrderItems[1].Id, "order item 2 ID does not match")
@@ -119,6 +121,64 @@ func TestAsPBOrder(t *testing.T) {
 	req.Equal(itemPriceCurrencyCode, pbOrder.OrderItems[1].UnitPrice.CurrencyCode, "order item 2 price currency does not match")
 	req.Equal(itemPriceUnits2, pbOrder.OrderItems[1].UnitPrice.Units, "order item 2 price units does not match")
 	req.Equal(itemPriceNanos2, pbOrder.OrderItems[1].UnitPrice.Nanos, "order item 2 price nanos does not match")
+
+	req.Equal("USD 1899.55", types.MoneyFromPB(pbOrder.OrderItems[0].Subtotal).String(), "order item 1 subtotal does not match")
+	req.Equal("USD 3197.92", types.MoneyFromPB(pbOrder.OrderItems[1].Subtotal).String(), "order item 2 subtotal does not match")
+	req.Equal("USD 5097.47", types.MoneyFromPB(pbOrder.Subtotal).String(), "order subtotal does not match")
+}
+
+// TestRecordedSubtotal confirms that the subtotal recorded when an order was submitted is the one that is reported
+// for the order, rather than one recalculated from the order items.
+func TestRecordedSubtotal(t *testing.T) {
+
+	// Create an order with a subtotal that does not match its items
+	order := buildMockOrder()
+	order.Subtotal = types.NewMoney(itemPriceCurrencyCode, 42, 0)
+
+	// Convert that to the Protocol Buffer form and check that the recorded subtotal has survived
+	pbOrder := order.AsPBOrder()
+	require.Equal(t, "USD 42.00", types.MoneyFromPB(pbOrder.Subtotal).String(), "order subtotal does not match")
+}
+
+// TestOrderDiscounts confirms that discounts, tax, and delivery are carried into the Protocol Buffer form of an order
+// and that the order total is the subtotal less those discounts plus the tax and delivery cost, whether or not the
+// total was recorded.
+func TestOrderDiscounts(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Discount an order with a subtotal of USD 5097.47
+	order := buildMockOrder()
+	order.Discounts = []*Discount{
+		{PromotionCode: "SAVE100", Description: "$100 off", Amount: types.NewMoney(itemPriceCurrencyCode, 100, 0)},
+		{PromotionCode: "HALFSTUDIO", Description: "Half price studios", ProductCode: itemProdCode1, Amount: types.NewMoney(itemPriceCurrencyCode, 949, 775_000_000)},
+	}
+
+	// Without a recorded total, the total is calculated
+	pbOrder := order.AsPBOrder()
+	req.Equal(2, len(pbOrder.Discounts), "order discount count does not match")
+	req.Equal("SAVE100", pbOrder.Discounts[0].PromotionCode, "order discount 1 promotion code does not match")
+	req.Equal(itemProdCode1, pbOrder.Discounts[1].ProductCode, "order discount 2 product code does not match")
+	req.Equal("USD 949.775", types.MoneyFromPB(pbOrder.Discounts[1].Amount).String(), "order discount 2 amount does not match")
+	req.Equal("USD 4047.695", types.MoneyFromPB(pbOrder.Total).String(), "calculated order total does not match")
+
+	// Tax is added to the calculated total
+	order.Tax = types.NewMoney(itemPriceCurrencyCode, 404, 770_000_000)
+	pbOrder = order.AsPBOrder()
+	req.Equal("USD 404.77", types.MoneyFromPB(pbOrder.Tax).String(), "order tax does not match")
+	req.Equal("USD 4452.465", types.MoneyFromPB(pbOrder.Total).String(), "calculated order total with tax does not match")
+
+	// As is the cost of delivery
+	order.DeliveryOption = &DeliveryOption{Code: "EXPRESS", Description: "Express delivery", Cost: types.NewMoney(itemPriceCurrencyCode, 19, 990_000_000)}
+	pbOrder = order.AsPBOrder()
+	req.Equal("EXPRESS", pbOrder.DeliveryOption.Code, "order delivery option code does not match")
+	req.Equal("USD 19.99", types.MoneyFromPB(pbOrder.DeliveryOption.Cost).String(), "order delivery cost does not match")
+	req.Equal("USD 4472.455", types.MoneyFromPB(pbOrder.Total).String(), "calculated order total with delivery does not match")
+
+	// With a recorded total, that is what is reported
+	order.Total = types.NewMoney(itemPriceCurrencyCode, 42, 0)
+	req.Equal("USD 42.00", types.MoneyFromPB(order.AsPBOrder().Total).String(), "recorded order total does not match")
 }
 
 // TestEmptyOrderAsPBOrder examines the behavior of Order.AsPBOrder for a completely unpopulated order. This is
@@ -139,6 +199,101 @@ func TestEmptyOrderAsPBOrder(t *testing.T) {
 	req.Nil(pbOrder.OrderedBy, "ordered by person is defined and should not be")
 	req.Nil(pbOrder.DeliveryAddress, "delivery address is defined and should not be")
 	req.Equal(0, len(pbOrder.OrderItems), "order item count is non-zero is defined and should not be")
+	req.Nil(pbOrder.Subtotal, "subtotal is defined and should not be")
+	req.Nil(pbOrder.Total, "total is defined and should not be")
+	req.Nil(pbOrder.Tax, "tax is defined and should not be")
+	req.Nil(pbOrder.DeliveryOption, "delivery option is defined and should not be")
+	req.Empty(pbOrder.Discounts, "discounts are defined and should not be")
+	req.Nil(pbOrder.Cancellation, "cancellation is defined and should not be")
+}
+
+// TestCancelledOrderAsPBOrder confirms that the cancellation of an order makes it through to its protocol buffer form.
+func TestCancelledOrderAsPBOrder(t *testing.T) {
+	req := require.New(t)
+	order := buildMockOrder()
+	order.Cancellation = &Cancellation{ReasonCode: "customer_request", CancellationTime: submissionTime, KeptItemIds: []string{itemId1}}
+	pbCancellation := order.AsPBOrder().Cancellation
+	req.NotNil(pbCancellation, "PB order cancellation missing")
+	req.Equal("customer_request", pbCancellation.ReasonCode, "PB order cancellation reason code did not match")
+	req.Equal(submissionTime, pbCancellation.CancellationTime.AsTime(), "PB order cancellation time did not match")
+	req.Equal([]string{itemId1}, pbCancellation.KeptItemIds, "PB order cancellation kept item IDs did not match")
+}
+
+// TestCancelledItemsValue confirms that the value of the cancelled items of an order leaves out those that were kept.
+func TestCancelledItemsValue(t *testing.T) {
+	req := require.New(t)
+	items := buildMockOrderItems()
+	value, err := (&Cancellation{KeptItemIds: []string{itemId1}}).CancelledItemsValue(items)
+	req.Nil(err, "did not expect an error valuing the cancelled items: %v", err)
+	req.Equal("USD 3197.92", value.String(), "only the second item should have been valued, twice over")
+	value, err = (&Cancellation{}).CancelledItemsValue(items)
+	req.Nil(err, "did not expect an error valuing all the items: %v", err)
+	req.Equal("USD 5097.47", value.String(), "all the items should have been valued")
+	value, err = (&Cancellation{KeptItemIds: []string{itemId1, itemId2}}).CancelledItemsValue(items)
+	req.Nil(err, "did not expect an error valuing no items: %v", err)
+	req.Nil(value, "there should have been nothing to value")
+}
+
+// TestCancelledShare confirms that the share of a payment that is due back for the cancelled items of an order is in
+// proportion to their value.
+func TestCancelledShare(t *testing.T) {
+	req := require.New(t)
+	order := &Order{OrderItems: buildMockOrderItems()}
+	share, err := order.CancelledShare(types.NewMoney("USD", 4_000, 0))
+	req.Nil(err, "did not expect an error from an order that has not been cancelled: %v", err)
+	req.Nil(share, "nothing should be due back for an order that has not been cancelled")
+
+	// Only the second item cancelled, against the calculated subtotal
+	order.Cancellation = &Cancellation{KeptItemIds: []string{itemId1}}
+	share, err = order.CancelledShare(types.NewMoney("USD", 4_000, 0))
+	req.Nil(err, "did not expect an error sharing the payment: %v", err)
+	req.Equal("USD 2509.41", share.String(), "the share of the payment did not match")
+
+	// Everything cancelled, against a recorded subtotal
+	order.Cancellation = &Cancellation{}
+	order.Subtotal = types.NewMoney("USD", 5_097, 470_000_000)
+	share, err = order.CancelledShare(types.NewMoney("USD", 4_000, 0))
+	req.Nil(err, "did not expect an error sharing the payment: %v", err)
+	req.Equal("USD 4000.00", share.String(), "all of the payment should have been due back")
+}
+
+// TestRollupStatus confirms that the status of an order is rolled up from the status of its items as we would expect.
+func TestRollupStatus(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Each case gives the status of our two mock items and the order status that should follow from them
+	cases := []struct {
+		first    OrderItemStatus
+		second   OrderItemStatus
+		expected OrderStatus
+	}{
+		{OisUnspecified, OisUnspecified, OsSubmitted},
+		{OisPending, OisPending, OsSubmitted},
+		{OisInProgress, OisPending, OsInProgress},
+		{OisComplete, OisInProgress, OsPartiallyFulfilled},
+		{OisComplete, OisPending, OsPartiallyFulfilled},
+		{OisCancelled, OisInProgress, OsInProgress},
+		{OisComplete, OisComplete, OsComplete},
+		{OisComplete, OisCancelled, OsComplete},
+		{OisCancelled, OisCancelled, OsCancelled},
+	}
+	order := buildMockOrder()
+	for _, c := range cases {
+		order.OrderItems[0].Status = c.first
+		order.OrderItems[1].Status = c.second
+		req.Equal(c.expected, order.RollupStatus(), "unexpected order status for item statuses %d and %d", c.first, c.second)
+	}
+
+	// An order with no items has not been started, whatever it says about itself
+	req.Equal(OsSubmitted, (&Order{Status: OsComplete}).RollupStatus(), "an order without items should have been submitted")
+
+	// The statuses make it through to the protocol buffer form
+	order.Status = order.RollupStatus()
+	pbOrder := order.AsPBOrder()
+	req.Equal(int32(OsCancelled), int32(pbOrder.Status), "PB order status did not match")
+	req.Equal(int32(OisCancelled), int32(pbOrder.OrderItems[0].Status), "PB order item status did not match")
 }
 
 // buildMockOrder returns a Order structure populated with a person that can be used to
@@ -185,6 +340,7 @@ func buildMockOrderItems() []*OrderItem {
 			ProductCode: itemProdCode1,
 			Quantity:    itemQuantity1,
 			UnitPrice:   &itemPrice1,
+			Attributes:  []*types.Attribute{{Name: "engraving", Value: "Happy Birthday Rupert!"}},
 		},
 		&OrderItem{
 			Id:          itemId2,
@@ -194,3 +350,22 @@ func buildMockOrderItems() []*OrderItem {
 		},
 	}
 }
+
+// TestSearchNames confirms that the search name fields are set to the lowercase, unaccented, form of the orderer's
+// names, and that an order without an orderer is left alone.
+func TestSearchNames(t *testing.T) {
+	req := require.New(t)
+	req.Equal("bronte", SearchName("Brontë"), "accent should have been dropped")
+	req.Equal("emilie", SearchName("ÉMILIE"), "name should have been lowercased")
+	req.Equal("o'brien-nunez", SearchName("O'Brien-Núñez"), "punctuation should have been left alone")
+
+	order := &Order{OrderedBy: &types.Person{FamilyName: "Brontë", GivenName: "Émilie"}}
+	order.SetSearchNames()
+	req.Equal("bronte", order.SearchFamilyName, "search family name did not match")
+	req.Equal("emilie", order.SearchGivenName, "search given name did not match")
+
+	order = &Order{}
+	order.SetSearchNames()
+	req.Empty(order.SearchFamilyName, "search family name should not have been set without an orderer")
+	req.Empty(order.SearchGivenName, "search given name should not have been set without an orderer")
+}
@@ -144,7 +144,8 @@ func unmarshalShoppingCart(message []byte) (*pb.ShoppingCart, error) {
 	return cart, nil
 }
 
-// ConvertCartToOrder clones the data of a shopping cart into an order structure
+// ConvertCartToOrder clones the data of a shopping cart into an order structure. The subtotal, tax, and total of the
+// order are those of the cart as it was checked out, i.e. the figures that the shopper's payment was authorized for.
 func ConvertCartToOrder(cart *pb.ShoppingCart) (*orders.Order, error) {
 
 	// TODO: Validate the order before recording it??? At least that it has ID values so that it can be found,
@@ -164,6 +165,7 @@ func ConvertCartToOrder(cart *pb.ShoppingCart) (*orders.Order, error) {
 	order := &orders.Order{
 		Id:             cart.Id,
 		SubmissionTime: cart.ClosedTime.AsTime(),
+		Status:         orders.OsSubmitted,
 	}
 
 	// Only convert the delivery address if that has been defined in the cart
@@ -187,6 +189,26 @@ func ConvertCartToOrder(cart *pb.ShoppingCart) (*orders.Order, error) {
 		order.OrderItems[i] = OrderItemItemFromShoppingCartPB(pbItem)
 	}
 
+	// Carry across the discounts that the cart earned at checkout, and the delivery option chosen for it
+	for _, pbDiscount := range cart.Discounts {
+		order.Discounts = append(order.Discounts, OrderDiscountFromShoppingCartPB(pbDiscount))
+	}
+	order.DeliveryOption = OrderDeliveryOptionFromShoppingCartPB(cart.DeliveryOption)
+
+	// The tax was worked out, and frozen into the cart, as the cart was checked out; it is the tax that the
+	// shopper has paid for, so we copy it rather than work it out again. A cart without any, e.g. because it had
+	// no delivery address, makes an order without any.
+	order.Tax = types.MoneyFromPB(cart.EstimatedTax)
+	order.Subtotal = types.MoneyFromPB(cart.Subtotal)
+	order.Total = types.MoneyFromPB(cart.Total)
+
+	// The cart subtotal and total are not set if the items could not be totalled, e.g. because they were priced in
+	// different currencies, in which case we still record the order as a faithful account of whatever came out of
+	// the cart, but make some noise about it
+	if order.Subtotal == nil || order.Total == nil {
+		zap.L().Warn("checked out cart has no subtotal or total", zap.String("cartId", cart.Id))
+	}
+
 	// All done, return the fruit of our labor
 	return order, nil
 }
@@ -199,5 +221,32 @@ func OrderItemItemFromShoppingCartPB(pbItem *pb.CartItem) *orders.OrderItem {
 		ProductCode: pbItem.ProductCode,
 		Quantity:    pbItem.Quantity,
 		UnitPrice:   types.MoneyFromPB(pbItem.UnitPrice),
+		Attributes:  types.AttributesFromPB(pbItem.Attributes),
+		Status:      orders.OisPending,
+	}
+}
+
+// OrderDiscountFromShoppingCartPB is a factory method that returns an order Discount representation derived from
+// its shopping cart protocol buffer equivalent.
+func OrderDiscountFromShoppingCartPB(pbDiscount *pb.Discount) *orders.Discount {
+	return &orders.Discount{
+		PromotionCode: pbDiscount.PromotionCode,
+		Description:   pbDiscount.Description,
+		ProductCode:   pbDiscount.ProductCode,
+		Amount:        types.MoneyFromPB(pbDiscount.Amount),
+	}
+}
+
+// OrderDeliveryOptionFromShoppingCartPB is a factory method that returns an order DeliveryOption representation
+// derived from its shopping cart protocol buffer equivalent. Nil is returned for nil, i.e. if no delivery option was
+// chosen for the cart.
+func OrderDeliveryOptionFromShoppingCartPB(pbOption *pb.DeliveryOption) *orders.DeliveryOption {
+	if pbOption == nil {
+		return nil
+	}
+	return &orders.DeliveryOption{
+		Code:        pbOption.Code,
+		Description: pbOption.Description,
+		Cost:        types.MoneyFromPB(pbOption.Cost),
 	}
 }
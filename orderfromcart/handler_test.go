@@ -67,6 +67,12 @@ const (
 	itemPriceNanos1       = 550_000_000
 	itemPriceUnits2       = 1598
 	itemPriceNanos2       = 960_000_000
+
+	// The promotion code that earned the discount applied to our mock cart
+	promotionCode = "SAVE100"
+
+	// The delivery option chosen for our mock cart
+	deliveryOptionCode = "STANDARD"
 )
 
 var (
@@ -131,6 +137,7 @@ func TestOrderFromCartHappyPath(t *testing.T) {
 	// Confirm all the order values are present as expected
 	req.Equal(shoppingCartId, order.Id, "order ID did not match")
 	req.Equal(shoppingCartClosedTime.Unix(), order.SubmissionTime.AsTime().Unix(), "submission time does not match")
+	req.Equal(pborder.OrderStatus_OS_SUBMITTED, order.Status, "new order should have been submitted")
 	req.NotNil(order.OrderedBy, "ordered by person missing")
 	req.Equal(shopperId, order.OrderedBy.Id, "ordered by person ID does not match")
 	req.Equal(shopperFamilyName, order.OrderedBy.FamilyName, "ordered by person family name does not match")
@@ -156,6 +163,9 @@ func TestOrderFromCartHappyPath(t *testing.T) {
 	req.Equal(itemPrice1.CurrencyCode, order.OrderItems[0].UnitPrice.CurrencyCode, "order item 1 price currency does not match")
 	req.Equal(itemPrice1.Units, order.OrderItems[0].UnitPrice.Units, "order item 1 price units does not match")
 	req.Equal(itemPrice1.Nanos, order.OrderItems[0].UnitPrice.Nanos, "order item 1 price nanos does not match")
+	req.Equal(1, len(order.OrderItems[0].Attributes), "order item 1 attribute count does not match")
+	req.Equal("engraving", order.OrderItems[0].Attributes[0].Name, "order item 1 attribute name does not match")
+	req.Equal("Happy Birthday Rupert!", order.OrderItems[0].Attributes[0].Value, "order item 1 attribute value does not match")
 
 	req.NotNil(order.OrderItems[1], "order item 2 missing")
 	req.Equal(itemId2, order.OrderItems[1].Id, "order item 2 ID does not match")
@@ -165,6 +175,71 @@ func TestOrderFromCartHappyPath(t *testing.T) {
 	req.Equal(itemPrice2.CurrencyCode, order.OrderItems[1].UnitPrice.CurrencyCode, "order item 2 price currency does not match")
 	req.Equal(itemPrice2.Units, order.OrderItems[1].UnitPrice.Units, "order item 2 price units does not match")
 	req.Equal(itemPrice2.Nanos, order.OrderItems[1].UnitPrice.Nanos, "order item 2 price nanos does not match")
+	req.Empty(order.OrderItems[1].Attributes, "order item 2 should not have had any attributes")
+	req.Equal(pborder.OrderItemStatus_OIS_PENDING, order.OrderItems[1].Status, "new order item should have been pending")
+
+	// The subtotal should have been recorded when the order was submitted
+	req.NotNil(order.Subtotal, "order subtotal missing")
+	req.Equal(itemPriceCurrencyCode, order.Subtotal.CurrencyCode, "order subtotal currency does not match")
+	req.Equal(int64(5097), order.Subtotal.Units, "order subtotal units does not match")
+	req.Equal(int32(470_000_000), order.Subtotal.Nanos, "order subtotal nanos does not match")
+
+	// As should the discounts, the delivery option, the tax that was fixed when the cart was checked out, and the
+	// total once the discounts had been taken off and the delivery cost and tax added
+	req.Equal(1, len(order.Discounts), "order discount count does not match")
+	req.Equal(promotionCode, order.Discounts[0].PromotionCode, "order discount promotion code does not match")
+	req.Equal(int64(100), order.Discounts[0].Amount.Units, "order discount amount does not match")
+	req.NotNil(order.DeliveryOption, "order delivery option missing")
+	req.Equal(deliveryOptionCode, order.DeliveryOption.Code, "order delivery option code does not match")
+	req.Equal("USD 9.99", types.MoneyFromPB(order.DeliveryOption.Cost).String(), "order delivery cost does not match")
+	req.NotNil(order.Tax, "order tax missing")
+	req.Equal("USD 1019.49", types.MoneyFromPB(order.Tax).String(), "order tax does not match")
+	req.NotNil(order.Total, "order total missing")
+	req.Equal("USD 6026.95", types.MoneyFromPB(order.Total).String(), "order total does not match")
+}
+
+// TestConvertCartToOrderWithoutTax confirms that orders are recorded without any tax if their carts were checked
+// out without any, e.g. because they had no delivery address.
+func TestConvertCartToOrderWithoutTax(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// A cart without a delivery address has no tax but is quietly converted anyway
+	cart := buildMockCart()
+	cart.DeliveryAddress = nil
+	cart.EstimatedTax = nil
+	var order *schema.Order
+	var err error
+	logged := testutil.CaptureLogging(func() {
+		order, err = ConvertCartToOrder(cart.AsPBShoppingCart())
+	})
+	req.Nil(err, "should not have seen an error converting a cart without an address: %v", err)
+	req.Nil(order.Tax, "order without an address should not have had any tax")
+	req.Equal("USD 5007.46", order.Total.String(), "order total without tax does not match")
+	req.Empty(logged, "should not have logged anything for a cart without tax")
+}
+
+// TestConvertCartToOrderWithoutTotal confirms that orders are still recorded, but with a warning, if their carts
+// could not be totalled.
+func TestConvertCartToOrderWithoutTotal(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// A cart with items in two currencies cannot be totalled
+	cart := buildMockCart()
+	cart.CartItems[1].UnitPrice = types.NewMoney("GBP", 1, 0)
+	var order *schema.Order
+	var err error
+	logged := testutil.CaptureLogging(func() {
+		order, err = ConvertCartToOrder(cart.AsPBShoppingCart())
+	})
+	req.Nil(err, "should not have seen an error converting a cart that cannot be totalled: %v", err)
+	req.Equal(2, len(order.OrderItems), "order should still have had its items")
+	req.Nil(order.Subtotal, "order should not have had a subtotal")
+	req.Nil(order.Total, "order should not have had a total")
+	req.Contains(logged, "checked out cart has no subtotal or total", "should have logged a warning for a cart that cannot be totalled")
 }
 
 // TestInvalidPushRequest exercises the main handler function with an invalid request that does not
@@ -448,6 +523,18 @@ func buildMockCart() *carts.ShoppingCart {
 		Shopper:         buildMockShopper(),
 		DeliveryAddress: buildMockDeliveryAddress(),
 		CartItems:       buildMockCartItems(),
+		PromotionCodes:  []string{promotionCode},
+		Discounts: []*carts.Discount{{
+			PromotionCode: promotionCode,
+			Description:   "$100 off",
+			Amount:        types.NewMoney(itemPriceCurrencyCode, 100, 0),
+		}},
+		DeliveryOption: &carts.DeliveryOption{
+			Code:        deliveryOptionCode,
+			Description: "Standard international delivery",
+			Cost:        types.NewMoney(itemPriceCurrencyCode, 9, 990_000_000),
+		},
+		EstimatedTax: types.NewMoney(itemPriceCurrencyCode, 1019, 490_000_000),
 	}
 }
 
@@ -484,6 +571,7 @@ func buildMockCartItems() []*carts.ShoppingCartItem {
 			ProductCode: itemProdCode1,
 			Quantity:    itemQuantity1,
 			UnitPrice:   &itemPrice1,
+			Attributes:  []*types.Attribute{{Name: "engraving", Value: "Happy Birthday Rupert!"}},
 		},
 		&carts.ShoppingCartItem{
 			Id:          itemId2,
@@ -7,20 +7,40 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 
+	"cloud.google.com/go/firestore"
 	"github.com/golang/protobuf/proto"
 	"github.com/google/uuid"
 	"github.com/mikebway/poc-gcp-ecomm/fulfillment/fulfillapi"
 	"github.com/mikebway/poc-gcp-ecomm/fulfillment/schema"
+	orderschema "github.com/mikebway/poc-gcp-ecomm/order/schema"
+	"github.com/mikebway/poc-gcp-ecomm/payments"
 	pb "github.com/mikebway/poc-gcp-ecomm/pb/order"
 	"go.uber.org/zap"
 	"google.golang.org/api/pubsub/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// ParamOrderQuantity names the task parameter that carries the quantity of the order item that the task is for
+	ParamOrderQuantity = "order_quantity"
+
+	// ParamOrderDeliveryRegion names the task parameter that carries the CLDR region code of the order's delivery
+	// address, if it has one
+	ParamOrderDeliveryRegion = "order_delivery_region"
 )
 
 var (
+	// errOrderCancelled is returned from within the task save transaction to abandon it when the order turns out to
+	// have been cancelled
+	errOrderCancelled = errors.New("order has been cancelled")
+
 	// productTasks is a map of product codes to potentially one or more skeleton fulfillment tasks
 	//
 	// In a production implementation, this mapp would be loaded from Firestore when the function is
@@ -110,8 +130,24 @@ func doOrderToFulfill(ctx context.Context, reader io.Reader) (int, error) {
 	// Convert the order structure to a set of fulfillment tasks
 	tasks := convertOrderToTasks(order)
 
-	// Save all the tasks in a single transaction - all or nothing service.
-	err = svc.SaveTasks(ctx, tasks)
+	// Save all the tasks in a single transaction - all or nothing service. Unless the payment for the order has
+	// already been captured, the tasks are held until it is. Nothing is saved if the order has been cancelled; the
+	// order is read in the same transaction so that a cancellation cannot slip in between the check and the save.
+	pmts := payments.NewPayments(svc.FsClient, nil)
+	err = svc.SaveTasksHeldForPayment(ctx, tasks, func(tx *firestore.Transaction) (bool, error) {
+		cancelled, err := orderCancelled(tx, svc.FsClient, order.Id)
+		if err != nil {
+			return false, err
+		}
+		if cancelled {
+			return false, errOrderCancelled
+		}
+		return awaitingPayment(tx, pmts, order.Id)
+	})
+	if errors.Is(err, errOrderCancelled) {
+		zap.L().Info("no tasks established for cancelled order", zap.String("orderId", order.Id))
+		return http.StatusOK, nil
+	}
 	if err != nil {
 		zap.L().Error("failed to save tasks for order", zap.String("orderId", order.Id), zap.Error(err))
 		return http.StatusInternalServerError, err
@@ -136,6 +172,34 @@ func getFulfillemntService() (*fulfillapi.FulfillmentService, error) {
 	return lazyFulfillmentService, err
 }
 
+// orderCancelled returns true if the order with the given ID has been cancelled, reading the order within the given
+// transaction. Orders that cannot be found, e.g. in unit tests, are taken not to have been cancelled.
+func orderCancelled(tx *firestore.Transaction, client *firestore.Client, orderId string) (bool, error) {
+	order := &orderschema.Order{Id: orderId}
+	snap, err := tx.Get(client.Doc(order.StoreRefPath()))
+	if status.Code(err) == codes.NotFound {
+		return false, nil
+	}
+	if err == nil {
+		err = snap.DataTo(order)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to retrieve order: order ID=%s: %w", orderId, err)
+	}
+	return order.Cancellation != nil, nil
+}
+
+// awaitingPayment returns true if the payment for the order with the given ID has been authorized but its capture
+// has not yet been recorded, reading the payment within the given transaction. Orders without payments, e.g. those
+// placed before we started taking payments, have nothing to wait for.
+func awaitingPayment(tx *firestore.Transaction, pmts *payments.Payments, orderId string) (bool, error) {
+	payment, err := pmts.GetTransactionalPayment(tx, orderId)
+	if err != nil || payment == nil {
+		return false, err
+	}
+	return payment.Status == payments.PsAuthorized || payment.Status == payments.PsCapturing, nil
+}
+
 // unmarshalOrder unpacks the provided binary protobuf message into an order structure.
 func unmarshalOrder(message []byte) (*pb.Order, error) {
 
@@ -152,7 +216,8 @@ func unmarshalOrder(message []byte) (*pb.Order, error) {
 	return order, nil
 }
 
-// convertOrderToTasks translates the order items into fulfillment task structures/
+// convertOrderToTasks translates the order items into fulfillment task structures. Each task is given the parameters
+// of its template followed by those describing the order item, see itemParameters.
 func convertOrderToTasks(pbOrder *pb.Order) []*schema.Task {
 
 	// TODO: Validate the order before converting it???
@@ -165,6 +230,7 @@ func convertOrderToTasks(pbOrder *pb.Order) []*schema.Task {
 
 		// Lookup the fulfillment tasks that match the item product type
 		itemTasks := productTasks[pbItem.ProductCode]
+		params := itemParameters(pbOrder, pbItem)
 
 		// If we found any tasks for this product, add a copy of the template tasks to our task set
 		for _, template := range itemTasks {
@@ -176,6 +242,7 @@ func convertOrderToTasks(pbOrder *pb.Order) []*schema.Task {
 			task.Id = uuid.NewString()
 			task.OrderId = pbOrder.Id
 			task.OrderItemId = pbItem.Id
+			task.Parameters = append(append([]*schema.Parameter(nil), template.Parameters...), params...)
 
 			// Add the task to our total set
 			tasks = append(tasks, &task)
@@ -186,6 +253,24 @@ func convertOrderToTasks(pbOrder *pb.Order) []*schema.Task {
 	return tasks
 }
 
+// itemParameters returns the task parameters that describe the given order item: the facts that tasks commonly need
+// to know about the order, i.e. the item quantity and the delivery region, followed by the attributes that the
+// shopper chose to customize the item with, e.g. the text to be engraved on it.
+func itemParameters(pbOrder *pb.Order, pbItem *pb.OrderItem) []*schema.Parameter {
+
+	// Start with the facts
+	params := []*schema.Parameter{{Name: ParamOrderQuantity, Value: strconv.Itoa(int(pbItem.Quantity))}}
+	if regionCode := pbOrder.GetDeliveryAddress().GetRegionCode(); regionCode != "" {
+		params = append(params, &schema.Parameter{Name: ParamOrderDeliveryRegion, Value: regionCode})
+	}
+
+	// Then the customizations
+	for _, attribute := range pbItem.Attributes {
+		params = append(params, &schema.Parameter{Name: attribute.Name, Value: attribute.Value})
+	}
+	return params
+}
+
 // addProductTaskMapping adds a single product to task mapping to our global map
 func addProductTaskMapping(task *schema.Task) {
 
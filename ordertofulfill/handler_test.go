@@ -23,6 +23,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/mikebway/poc-gcp-ecomm/fulfillment/fulfillapi"
 	ord "github.com/mikebway/poc-gcp-ecomm/order/schema"
+	"github.com/mikebway/poc-gcp-ecomm/payments"
 
 	"github.com/mikebway/poc-gcp-ecomm/types"
 	"github.com/stretchr/testify/require"
@@ -69,6 +70,10 @@ const (
 	itemPriceNanos1       = 550_000_000
 	itemPriceUnits2       = 2
 	itemPriceNanos2       = 990_000_000
+
+	// Define a customization attribute that the shopper chose for the first order item
+	itemAttrName1  = "engraving"
+	itemAttrValue1 = "To Ron, love Hermione"
 )
 
 var (
@@ -140,6 +145,70 @@ func TestOrderToFulfillHappyPath(t *testing.T) {
 	validateTask(req, findTask(req, tasks, "manufacture"), testStartTime, orderId, itemId1, itemProdCode1, pbfulfillment.TaskStatus_WAITING_SERVICE, "")
 	validateTask(req, findTask(req, tasks, "ship"), testStartTime, orderId, itemId1, itemProdCode1, pbfulfillment.TaskStatus_WAITING_TASK, "wait_for_manufacture")
 	validateTask(req, findTask(req, tasks, "upsell_to_gold"), testStartTime, orderId, itemId2, itemProdCode2, pbfulfillment.TaskStatus_WAITING_CS, "no_stock")
+
+	// The tasks for the first item should have been told about the customization that the shopper chose
+	validateParameters(req, findTask(req, tasks, "manufacture"), "1", itemAttrName1, itemAttrValue1)
+	validateParameters(req, findTask(req, tasks, "ship"), "1", itemAttrName1, itemAttrValue1)
+	validateParameters(req, findTask(req, tasks, "upsell_to_gold"), "2")
+}
+
+// TestOrderToFulfillAwaitingPayment confirms that the tasks for an order whose payment has yet to be captured are
+// held in WAITING_PAYMENT.
+func TestOrderToFulfillAwaitingPayment(t *testing.T) {
+
+	// Do the common setup that we share with some other tests, this includes deleting all tasks
+	req, ctx, svc := commonTestSetup(t)
+
+	// Record an authorized payment for the order, cleaning it up when we are done
+	payment := &payments.Payment{OrderId: orderId, Status: payments.PsAuthorized, Amount: &itemPrice1, AuthorizationId: payments.FakeAuthorizationPrefix + orderId}
+	ref := svc.FsClient.Doc(payment.StoreRefPath())
+	_, err := ref.Set(ctx, payment)
+	req.Nil(err, "failed to store payment: %v", err)
+	t.Cleanup(func() { _, _ = ref.Delete(ctx) })
+
+	// Assemble a mock HTTP request and a means to record the response, then handle it
+	httpRequest := httptest.NewRequest("POST", "/", buildPushRequest(mockOrderPB()))
+	responseRecorder := httptest.NewRecorder()
+	OrderToFulfill(responseRecorder, httpRequest)
+	req.Equal(http.StatusCreated, responseRecorder.Code, "should have a 201 Created response code")
+
+	// Every task should be waiting for the money
+	response, err := svc.GetTasks(ctx, &pbfulfillment.GetTasksRequest{OrderId: orderId, PageSize: 10})
+	req.Nil(err, "did not expect an error calling GetTasks: %v", err)
+	req.Equal(3, len(response.Tasks), "expected number of tasks was not created")
+	for _, task := range response.Tasks {
+		req.Equal(pbfulfillment.TaskStatus_WAITING_PAYMENT, task.Status, "%s task should have been held for payment", task.TaskCode)
+		req.Equal(schema.ReasonPaymentNotCaptured, task.ReasonCode, "%s task reason code is wrong", task.TaskCode)
+	}
+}
+
+// TestOrderToFulfillCancelledOrder confirms that no tasks are created for an order that was cancelled before they
+// could be.
+func TestOrderToFulfillCancelledOrder(t *testing.T) {
+
+	// Do the common setup that we share with some other tests, this includes deleting all tasks
+	req, ctx, svc := commonTestSetup(t)
+
+	// Record the order as having been cancelled, cleaning it up when we are done
+	order := &ord.Order{Id: orderId, Cancellation: &ord.Cancellation{ReasonCode: "customer_request", CancellationTime: time.Now()}}
+	ref := svc.FsClient.Doc(order.StoreRefPath())
+	_, err := ref.Set(ctx, order)
+	req.Nil(err, "failed to store order: %v", err)
+	t.Cleanup(func() { _, _ = ref.Delete(ctx) })
+
+	// Assemble a mock HTTP request and a means to record the response, then handle it
+	httpRequest := httptest.NewRequest("POST", "/", buildPushRequest(mockOrderPB()))
+	responseRecorder := httptest.NewRecorder()
+	logged := testutil.CaptureLogging(func() {
+		OrderToFulfill(responseRecorder, httpRequest)
+	})
+	req.Equal(http.StatusOK, responseRecorder.Code, "should have a 200 OK response code")
+	req.Contains(logged, "no tasks established for cancelled order", "should have been told that the order was cancelled")
+
+	// And there should be nothing to do
+	response, err := svc.GetTasks(ctx, &pbfulfillment.GetTasksRequest{OrderId: orderId, PageSize: 10})
+	req.Nil(err, "did not expect an error calling GetTasks: %v", err)
+	req.Empty(response.Tasks, "no tasks should have been created for a cancelled order")
 }
 
 // validateTask confirms that the supplied task matches the field values supplied, failing the test if it does not.
@@ -151,7 +220,23 @@ func validateTask(req *require.Assertions, task *pbfulfillment.Task, earliestTim
 	req.Equal(product, task.ProductCode, "%s task product code is wrong", status.String())
 	req.Equal(status, task.Status, "%s task status is wrong", status.String())
 	req.Equal(reason, task.ReasonCode, "%s task reason code is wrong", status.String())
-	req.Nil(task.Parameters, "%s task should not have had any parameters", status.String())
+}
+
+// validateParameters confirms that the supplied task was given the order quantity and delivery region parameters,
+// followed by the given name / value pairs of item attributes, failing the test if it was not.
+func validateParameters(req *require.Assertions, task *pbfulfillment.Task, quantity string, attributes ...string) {
+	expected := []*pbfulfillment.Parameter{
+		{Name: ParamOrderQuantity, Value: quantity},
+		{Name: ParamOrderDeliveryRegion, Value: addrRegionCode},
+	}
+	for i := 0; i+1 < len(attributes); i += 2 {
+		expected = append(expected, &pbfulfillment.Parameter{Name: attributes[i], Value: attributes[i+1]})
+	}
+	req.Equal(len(expected), len(task.Parameters), "%s task parameter count is wrong", task.TaskCode)
+	for i, param := range expected {
+		req.Equal(param.Name, task.Parameters[i].Name, "%s task parameter %d name is wrong", task.TaskCode, i)
+		req.Equal(param.Value, task.Parameters[i].Value, "%s task parameter %d value is wrong", task.TaskCode, i)
+	}
 }
 
 // findTask looks through the supplied slice of tasks for one that matches the given taskCode and returns that.
@@ -478,6 +563,7 @@ func buildMockOrderItems() []*ord.OrderItem {
 			ProductCode: itemProdCode1,
 			Quantity:    itemQuantity1,
 			UnitPrice:   &itemPrice1,
+			Attributes:  []*types.Attribute{{Name: itemAttrName1, Value: itemAttrValue1}},
 		},
 		&ord.OrderItem{
 			Id:          itemId2,
@@ -4,6 +4,7 @@ package ordertrigger
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"cloud.google.com/go/pubsub"
@@ -28,6 +29,16 @@ var (
 	// pubSubClient is an instance of a wrapper interface for our Pub/Sub client that allows us to inject errors
 	// when unit testing
 	pubSubClient PubSubClient
+
+	// unpublishedFields names the top level order document fields that are updated after an order has been
+	// published: the status fields written by orderapi.OrderService.UpdateOrderStatus and the cancellation written
+	// by orderapi.OrderService.CancelOrder. Updates that touch nothing else do not get the order published again.
+	unpublishedFields = map[string]bool{
+		"status":       true,
+		"statusAsOf":   true,
+		"orderItems":   true,
+		"cancellation": true,
+	}
 )
 
 // FirestoreEvent is the payload of a Firestore event.
@@ -130,6 +141,14 @@ func doCartTrigger(ctx context.Context, e FirestoreEvent) error {
 	newFields := e.Value.Fields
 	orderId := newFields.Id.StringValue
 
+	// Orders are written again every time that their status is rolled up from their fulfillment tasks, and when
+	// they are cancelled. Those updates change nothing that the subscribers to our topic care about and must not
+	// cause the order to be fulfilled, or paid for, all over again.
+	if isUnpublishedUpdate(e) {
+		logger.Info("ignoring order status update", zap.String("orderId", orderId))
+		return nil
+	}
+
 	// At this point we know that we have a order that needs to be published
 	logger.Info("processing order", zap.String("orderId", orderId))
 
@@ -150,6 +169,21 @@ func doCartTrigger(ctx context.Context, e FirestoreEvent) error {
 	return nil
 }
 
+// isUnpublishedUpdate returns true if the event describes an update to an existing order that only touched the
+// fields listed in unpublishedFields.
+func isUnpublishedUpdate(e FirestoreEvent) bool {
+	fieldPaths := e.UpdateMask.FieldPaths
+	if len(e.OldValue.Name) == 0 || len(fieldPaths) == 0 {
+		return false
+	}
+	for _, fieldPath := range fieldPaths {
+		if !unpublishedFields[strings.Split(fieldPath, ".")[0]] {
+			return false
+		}
+	}
+	return true
+}
+
 // OrderServiceClientImpl is the default implementation of the OrderServiceClient interface.
 // error generation.
 type OrderServiceClientImpl struct {
@@ -175,6 +175,52 @@ func TestHandlerHappyPath(t *testing.T) {
 	req.Contains(logged, storedOrderId, "did not see order ID in log message on second run")
 }
 
+// TestStatusRollupIgnored confirms that updates to an order that only record its rolled up status or its cancellation
+// are not published, while other updates, such as a forced republish, still are.
+func TestStatusRollupIgnored(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Dress our event up as an update of the order status and its items
+	event := mockFirestoreEvent(storedOrderId)
+	event.OldValue = *mockNewValue(storedOrderId)
+	event.UpdateMask.FieldPaths = []string{"orderItems", "status"}
+	ctx := context.Background()
+	var err error
+	logged := testutil.CaptureLogging(func() {
+		err = OrderTrigger(ctx, *event)
+	})
+	req.Nil(err, "no error was expected: %v", err)
+	req.Contains(logged, "ignoring order status update", "did not see status update log message")
+	req.NotContains(logged, "published order", "status update should not have been published")
+
+	// Including when the update records the time as of which the status was rolled up, as UpdateOrderStatus does
+	event.UpdateMask.FieldPaths = []string{"orderItems", "status", "statusAsOf"}
+	logged = testutil.CaptureLogging(func() {
+		err = OrderTrigger(ctx, *event)
+	})
+	req.Nil(err, "no error was expected: %v", err)
+	req.Contains(logged, "ignoring order status update", "did not see status update log message")
+	req.NotContains(logged, "published order", "rolled up status update should not have been published")
+
+	// As should the cancellation of the order
+	event.UpdateMask.FieldPaths = []string{"cancellation.reasonCode", "cancellation.cancellationTime"}
+	logged = testutil.CaptureLogging(func() {
+		err = OrderTrigger(ctx, *event)
+	})
+	req.Nil(err, "no error was expected: %v", err)
+	req.NotContains(logged, "published order", "cancellation should not have been published")
+
+	// Any other update still gets published
+	event.UpdateMask.FieldPaths = []string{"deliveryAddress", "status"}
+	logged = testutil.CaptureLogging(func() {
+		err = OrderTrigger(ctx, *event)
+	})
+	req.Nil(err, "no error was expected: %v", err)
+	req.Contains(logged, "published order", "other update should have been published")
+}
+
 // TestOrderNotExist looks at what happens when a order update triggers the handler but the order in
 // question does not exists - can't see how that could happen but it has the side benefit of testing
 // onm of the error paths trying to load a order from Firestore without having to mock an error.
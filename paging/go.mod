@@ -0,0 +1,21 @@
+module github.com/mikebway/poc-gcp-ecomm/paging
+
+go 1.19
+
+require (
+	github.com/mikebway/poc-gcp-ecomm/pb v0.0.0-20230106151957-dedb32a889cf
+	github.com/stretchr/testify v1.8.1
+	google.golang.org/grpc v1.51.0
+	google.golang.org/protobuf v1.28.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/golang/protobuf v1.5.2 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/net v0.5.0 // indirect
+	golang.org/x/sys v0.4.0 // indirect
+	golang.org/x/text v0.6.0 // indirect
+	google.golang.org/genproto v0.0.0-20221227171554-f9683d7f8bef // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,165 @@
+// Package paging issues and checks the opaque page tokens that the poc-gcp-ecomm list APIs, e.g. OrderAPI.GetOrders,
+// FulfillmentAPI.GetTasks and CartAPI.ListShoppingCarts, hand back to their callers so that they can ask for the next
+// page of results.
+//
+// A page token records the submission (or creation) time and ID of the last document returned, the cursor position
+// after which the next page starts, signed with an HMAC over both the cursor and the request that it was issued for,
+// page size included. A token can therefore only be used to continue the query that it came from; one that has been tampered
+// with, or that is presented with different filters or a different page size, is rejected with
+// codes.InvalidArgument.
+package paging
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	// EnvPageTokenKey names the environment variable with which the secret key that page tokens are signed with
+	// must be configured for any service that hands page tokens out to its callers; see RequireKey. All the
+	// instances of a service must share the same key if the tokens issued by one are to be accepted by the others.
+	EnvPageTokenKey = "PAGE_TOKEN_KEY"
+
+	// pageTokenField is the name of the field that carries the page token in a list request. It is left out of
+	// the request when the token is signed, everything else has to match.
+	pageTokenField = protoreflect.Name("page_token")
+
+	// ephemeralKeySize is the size, in bytes, of the key that is generated if EnvPageTokenKey has not been set
+	ephemeralKeySize = 32
+)
+
+var (
+	// key is the key actually used to sign page tokens
+	key []byte
+)
+
+// init is the static initializer used to configure our static variables.
+func init() {
+	key = keyFromEnv()
+}
+
+// keyFromEnv returns the page token signing key configured with the EnvPageTokenKey environment variable or, if it
+// has not been set, a random key that will be forgotten when the process ends. Tokens signed with the latter are
+// only any good for paging through results within the same process, e.g. in unit tests or in Cloud Functions that
+// use a service directly rather than through its API, which is all that they are needed for if RequireKey has not
+// been satisfied.
+func keyFromEnv() []byte {
+	if value := os.Getenv(EnvPageTokenKey); value != "" {
+		return []byte(value)
+	}
+	ephemeralKey := make([]byte, ephemeralKeySize)
+	if _, err := rand.Read(ephemeralKey); err != nil {
+		panic(fmt.Sprintf("unable to generate a page token key: %v", err))
+	}
+	return ephemeralKey
+}
+
+// RequireKey returns an error if the EnvPageTokenKey environment variable has not been set. Services that hand page
+// tokens out to their callers must call this before they start and refuse to start if an error is returned; without
+// a shared key, each instance of the service would reject the tokens issued by the others.
+func RequireKey() error {
+	if os.Getenv(EnvPageTokenKey) == "" {
+		return fmt.Errorf("the %s environment variable must be set to the secret key with which page tokens are signed", EnvPageTokenKey)
+	}
+	return nil
+}
+
+// Cursor marks the position of the last document returned in a page of results, by the submission time and ID on
+// which the results are sorted. Results that are sorted on some other time, e.g. the creation time of shopping
+// carts, carry that time as the SubmissionTime.
+type Cursor struct {
+	// SubmissionTime is the submission time of the last document returned, or whichever time the results are sorted on
+	SubmissionTime time.Time
+
+	// Id is the ID of the last document returned, which breaks ties between documents submitted at the same time
+	Id string
+}
+
+// NewToken returns the page token with which the caller can ask for the page of results that follows the given
+// cursor position, bound to the given list request.
+func NewToken(req proto.Message, cursor *Cursor) (string, error) {
+
+	// Render the cursor as the hex Unix nanosecond time and ID that page tokens always used to be
+	payload := fmt.Sprintf("%x,%s", cursor.SubmissionTime.UnixNano(), cursor.Id)
+
+	// Sign it along with the request and send the two together, in a form that is safe to put in a URL
+	signature, err := sign(req, payload)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(append([]byte(payload), signature...)), nil
+}
+
+// ParseToken returns the cursor position recorded in the page token of the given list request. A codes.InvalidArgument
+// status error is returned if the token was not issued by NewToken for the same request.
+func ParseToken(req proto.Message, token string) (*Cursor, error) {
+
+	// Decode the token and split the signature off of the end of it
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(raw) <= sha256.Size {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid page token: %s", token)
+	}
+	payload := string(raw[:len(raw)-sha256.Size])
+	signature := raw[len(raw)-sha256.Size:]
+
+	// Confirm that the token was issued for this very request and has not been altered since
+	expected, err := sign(req, payload)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal(signature, expected) {
+		return nil, status.Errorf(codes.InvalidArgument, "page token does not match the request, or has been altered: %s", token)
+	}
+
+	// Split the cursor into its two parts - there should be two parts, submission time and ID
+	parts := strings.SplitN(payload, ",", 2)
+	if len(parts) == 2 {
+
+		// Convert the first part from a Unix time value to a time.Time
+		unixNanoTime, err := strconv.ParseInt(parts[0], 16, 64)
+		if err == nil {
+			return &Cursor{SubmissionTime: time.Unix(0, unixNanoTime), Id: parts[1]}, nil
+		}
+	}
+
+	// Only arrive here if we signed something that was not a cursor, which should be impossible
+	return nil, status.Errorf(codes.InvalidArgument, "invalid page token: %s", token)
+}
+
+// sign returns the HMAC signature of the given cursor payload combined with every field of the given request
+// other than its page token.
+func sign(req proto.Message, payload string) ([]byte, error) {
+
+	// Take a copy of the request without its page token; the tokens for the first and subsequent pages must all
+	// agree, and a token cannot sign itself in any case
+	unsigned := proto.Clone(req)
+	message := unsigned.ProtoReflect()
+	if field := message.Descriptor().Fields().ByName(pageTokenField); field != nil {
+		message.Clear(field)
+	}
+
+	// Marshal what is left deterministically so that the same request always yields the same bytes
+	filters, err := proto.MarshalOptions{Deterministic: true}.Marshal(unsigned)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal request to sign page token: %v", err)
+	}
+
+	// Sign the two together
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(payload))
+	mac.Write([]byte{0})
+	mac.Write(filters)
+	return mac.Sum(nil), nil
+}
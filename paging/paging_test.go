@@ -0,0 +1,102 @@
+package paging
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	pborder "github.com/mikebway/poc-gcp-ecomm/pb/order"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// A UUID string value that we can use as a document ID in our tests
+	documentId = "d1cecab3-5bc0-43d4-aef1-99ad69794313"
+)
+
+// TestTokenRoundTrip confirms that a page token yields the cursor that it was made from when it is presented with
+// the request that it was issued for, whatever that request's own page token is.
+func TestTokenRoundTrip(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Issue a token for the first page of a query
+	cursor := &Cursor{SubmissionTime: time.Unix(0, 1667082199123456789), Id: documentId}
+	request := &pborder.GetOrdersRequest{FamilyName: "Grint", PageSize: 5}
+	token, err := NewToken(request, cursor)
+	req.Nil(err, "did not expect an error making a page token: %v", err)
+	req.NotContains(token, documentId, "page token should have been opaque")
+
+	// Present it for the second page
+	request.PageToken = token
+	parsed, err := ParseToken(request, request.PageToken)
+	req.Nil(err, "did not expect an error parsing a page token: %v", err)
+	req.True(cursor.SubmissionTime.Equal(parsed.SubmissionTime), "cursor submission time did not survive the round trip")
+	req.Equal(documentId, parsed.Id, "cursor ID did not survive the round trip")
+
+	// The token for the third page is bound to the same request, even though the page token has changed
+	token, err = NewToken(request, cursor)
+	req.Nil(err, "did not expect an error making a second page token: %v", err)
+	request.PageToken = token
+	_, err = ParseToken(request, request.PageToken)
+	req.Nil(err, "did not expect an error parsing a second page token: %v", err)
+}
+
+// TestTokenMismatch confirms that page tokens are rejected if they are presented with a different query, or have
+// been tampered with, or were never page tokens at all.
+func TestTokenMismatch(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Issue a token for the first page of a query
+	cursor := &Cursor{SubmissionTime: time.Unix(0, 1667082199123456789), Id: documentId}
+	token, err := NewToken(&pborder.GetOrdersRequest{FamilyName: "Grint", PageSize: 5}, cursor)
+	req.Nil(err, "did not expect an error making a page token: %v", err)
+
+	// Changing the filters or the page size between pages is not allowed
+	_, err = ParseToken(&pborder.GetOrdersRequest{FamilyName: "Watson", PageSize: 5}, token)
+	req.Equal(codes.InvalidArgument, status.Code(err), "token should have been rejected for different filters: %v", err)
+	_, err = ParseToken(&pborder.GetOrdersRequest{FamilyName: "Grint", PageSize: 10}, token)
+	req.Equal(codes.InvalidArgument, status.Code(err), "token should have been rejected for a different page size: %v", err)
+	_, err = ParseToken(&pborder.GetOrdersRequest{FamilyName: "Grint", PageSize: 5, SortDirection: pborder.SortDirection_SD_DESCENDING}, token)
+	req.Equal(codes.InvalidArgument, status.Code(err), "token should have been rejected for a different sort direction: %v", err)
+
+	// Nor is moving the cursor
+	raw, _ := base64.RawURLEncoding.DecodeString(token)
+	raw[0]++
+	_, err = ParseToken(&pborder.GetOrdersRequest{FamilyName: "Grint", PageSize: 5}, base64.RawURLEncoding.EncodeToString(raw))
+	req.Equal(codes.InvalidArgument, status.Code(err), "tampered token should have been rejected: %v", err)
+
+	// And plain text cursors, or garbage, are right out
+	for _, bad := range []string{"", "17230b8c1d4a1b15,d1cecab3-5bc0-43d4-aef1-99ad69794313", "not_a_number,not_a_uuid", "c2hvcnQ"} {
+		_, err = ParseToken(&pborder.GetOrdersRequest{FamilyName: "Grint", PageSize: 5}, bad)
+		req.Equal(codes.InvalidArgument, status.Code(err), "token should have been rejected: %s: %v", bad, err)
+	}
+}
+
+// TestKeyFromEnv confirms that the signing key can be configured from the environment, and that a random key is
+// generated when it is not.
+func TestKeyFromEnv(t *testing.T) {
+	req := require.New(t)
+	t.Setenv(EnvPageTokenKey, "")
+	first, second := keyFromEnv(), keyFromEnv()
+	req.Len(first, ephemeralKeySize, "random key should have been generated when none is configured")
+	req.NotEqual(first, second, "each random key should have been different")
+	t.Setenv(EnvPageTokenKey, "not-very-secret")
+	req.Equal([]byte("not-very-secret"), keyFromEnv(), "configured key should have been used")
+}
+
+// TestRequireKey confirms that services can tell whether a signing key has been configured.
+func TestRequireKey(t *testing.T) {
+	req := require.New(t)
+	t.Setenv(EnvPageTokenKey, "")
+	err := RequireKey()
+	req.NotNil(err, "should have failed with no key configured")
+	req.Contains(err.Error(), EnvPageTokenKey, "error should have named the environment variable")
+	t.Setenv(EnvPageTokenKey, "not-very-secret")
+	req.Nil(RequireKey(), "should have been happy with a key configured")
+}
@@ -0,0 +1,46 @@
+module github.com/mikebway/poc-gcp-ecomm/paymentcapture
+
+go 1.19
+
+require (
+	cloud.google.com/go/firestore v1.9.0
+	github.com/golang/protobuf v1.5.2
+	github.com/google/uuid v1.3.0
+	github.com/mikebway/poc-gcp-ecomm/fulfillment v0.0.0-20230111143213-6779b96c5a2e
+	github.com/mikebway/poc-gcp-ecomm/order v0.0.0-20230111143213-6779b96c5a2e
+	github.com/mikebway/poc-gcp-ecomm/pb v0.0.0-20230111143213-6779b96c5a2e
+	github.com/mikebway/poc-gcp-ecomm/testutil v0.0.0-20230106151957-dedb32a889cf
+	github.com/mikebway/poc-gcp-ecomm/types v0.0.0-20230111143213-6779b96c5a2e
+	github.com/stretchr/testify v1.8.1
+	go.uber.org/zap v1.24.0
+	google.golang.org/api v0.106.0
+	google.golang.org/grpc v1.51.0
+)
+
+require (
+	cloud.google.com/go v0.105.0 // indirect
+	cloud.google.com/go/compute v1.14.0 // indirect
+	cloud.google.com/go/compute/metadata v0.2.3 // indirect
+	cloud.google.com/go/longrunning v0.3.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/golang/groupcache v0.0.0-20200121045136-8c9f03a8e57e // indirect
+	github.com/google/go-cmp v0.5.9 // indirect
+	github.com/googleapis/enterprise-certificate-proxy v0.2.1 // indirect
+	github.com/googleapis/gax-go/v2 v2.7.0 // indirect
+	github.com/mikebway/poc-gcp-ecomm/cart v0.0.0-20230111143213-6779b96c5a2e // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	go.opencensus.io v0.24.0 // indirect
+	go.uber.org/atomic v1.7.0 // indirect
+	go.uber.org/multierr v1.6.0 // indirect
+	golang.org/x/net v0.5.0 // indirect
+	golang.org/x/oauth2 v0.0.0-20221014153046-6fdb5e3db783 // indirect
+	golang.org/x/sync v0.1.0 // indirect
+	golang.org/x/sys v0.4.0 // indirect
+	golang.org/x/text v0.6.0 // indirect
+	golang.org/x/time v0.1.0 // indirect
+	golang.org/x/xerrors v0.0.0-20220907171357-04be3eba64a2 // indirect
+	google.golang.org/appengine v1.6.7 // indirect
+	google.golang.org/genproto v0.0.0-20221227171554-f9683d7f8bef // indirect
+	google.golang.org/protobuf v1.28.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,190 @@
+// Package paymentcapture implements a Google Cloud Function to receive an order description via a Pub/Sub topic.
+// The handler captures the payment that was authorized for the order when its shopping cart was checked out and,
+// once the capture has been confirmed, releases the order's fulfillment tasks that were held waiting for it.
+package paymentcapture
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"cloud.google.com/go/firestore"
+	"github.com/golang/protobuf/proto"
+	"github.com/mikebway/poc-gcp-ecomm/fulfillment/fulfillapi"
+	orderschema "github.com/mikebway/poc-gcp-ecomm/order/schema"
+	"github.com/mikebway/poc-gcp-ecomm/payments"
+	pb "github.com/mikebway/poc-gcp-ecomm/pb/order"
+	"go.uber.org/zap"
+	"google.golang.org/api/pubsub/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// lazyFulfillmentService is the lazy-loaded fulfillment service implementation that we use to release tasks
+	lazyFulfillmentService *fulfillapi.FulfillmentService
+
+	// paymentProvider is the payment gateway that we capture payments through
+	paymentProvider payments.PaymentProvider
+)
+
+// init is the static initializer used to configure our local and global static variables.
+func init() {
+	// Initialize our Zap logger
+	serviceLogger, _ := zap.NewProduction()
+	zap.ReplaceGlobals(serviceLogger)
+
+	// We only have a pretend payment gateway for now
+	paymentProvider = payments.NewFakeProvider()
+}
+
+// pushRequest represents the payload of a Pub/Sub push message.
+type pushRequest struct {
+	Message      pubsub.PubsubMessage `json:"message"`
+	Subscription string               `json:"subscription,omitempty"`
+}
+
+// PaymentCapture is the Cloud Function entry point. The payload of the HTTP request is an order
+// expressed as a base64 encoded Protocol Buffer message wrapped in a JSON envelop.
+//
+// See https://cloud.google.com/pubsub/docs/push for documentation of the request body JSON content.
+func PaymentCapture(w http.ResponseWriter, r *http.Request) {
+
+	// Flush the logs before exiting each invocation of this Cloud Function
+	//goland:noinspection GoUnhandledErrorResult
+	defer zap.L().Sync()
+
+	// Have our big brother sibling do all the real work while we just handle the HTTP interfacing here
+	status, err := doPaymentCapture(r.Context(), r.Body)
+	if err != nil {
+
+		// Dang - log the error and return it to the caller as well
+		zap.L().Error("failed to capture payment", zap.Error(err))
+		http.Error(w, err.Error(), status)
+	}
+
+	// Return the successful status code
+	w.WriteHeader(status)
+}
+
+// doPaymentCapture does all the heavy lifting for PaymentCapture. It is implemented as a separate
+// function to isolate the message processing from the transport interface.
+//
+// An HTTP status code is always returned, this should be set in the response regardless of whether
+// an error is also returned. Failures to capture are reported as internal server errors so that Pub/Sub
+// will retry the push; capturing a payment that has already been captured does no harm.
+//
+// See https://cloud.google.com/pubsub/docs/push for documentation of the reader JSON content.
+func doPaymentCapture(ctx context.Context, reader io.Reader) (int, error) {
+
+	// Lazy load the fulfillment service that we wil use to release tasks
+	svc, err := getFulfillmentService()
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	// Unpack the JSON push request message from the request body
+	var pushReq pushRequest
+	if err := json.NewDecoder(reader).Decode(&pushReq); err != nil {
+		return http.StatusBadRequest, fmt.Errorf("could not decode push request json body: %v", err)
+	}
+
+	// Translate the base64 encoded body of the request as a binary byte slice
+	pbBytes, err := base64.StdEncoding.DecodeString(pushReq.Message.Data)
+	if err != nil {
+		return http.StatusBadRequest, fmt.Errorf("unable to decode base64 data: %w", err)
+	}
+
+	// Unmarshall the protobuf binary message into an order structure
+	order, err := unmarshalOrder(pbBytes)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	// Capture the payment and, in the same transaction that records the capture, let the tasks loose. Cancelled
+	// orders are not to be paid for; the order service voids or refunds their payments. The order published to us
+	// may have been cancelled since, so look at it as it stands, in the transaction that marks the payment as being
+	// captured, so that the order service cannot void the payment while the provider is taking the money.
+	pmts := payments.NewPayments(svc.FsClient, paymentProvider)
+	payment, err := pmts.Capture(ctx, order.Id, func(tx *firestore.Transaction) (bool, error) {
+		cancelled, err := orderCancelled(tx, svc.FsClient, order)
+		return !cancelled, err
+	}, func(tx *firestore.Transaction, payment *payments.Payment) error {
+		released, err := svc.ReleaseTasksHeldForPayment(tx, order.Id)
+		if err == nil {
+			zap.L().Info("released tasks held for payment", zap.String("orderId", order.Id), zap.Int("count", released))
+		}
+		return err
+	})
+	if err != nil {
+		zap.L().Error("failed to capture payment for order", zap.String("orderId", order.Id), zap.Error(err))
+		return http.StatusInternalServerError, err
+	}
+
+	// Orders placed before we started taking payments have nothing to capture
+	if payment == nil {
+		zap.L().Info("no payment to capture for order", zap.String("orderId", order.Id))
+		return http.StatusOK, nil
+	}
+
+	// A payment that is still only authorized was left alone because the order has been cancelled, and one that
+	// has been voided was given up by the order service when the order was cancelled
+	if payment.Status == payments.PsAuthorized || payment.Status == payments.PsVoided {
+		zap.L().Info("order has been cancelled, payment not captured", zap.String("orderId", order.Id))
+		return http.StatusOK, nil
+	}
+
+	// Everything is good
+	zap.L().Info("captured payment for order", zap.String("orderId", order.Id), zap.String("amount", payment.Amount.String()))
+	return http.StatusOK, nil
+}
+
+// orderCancelled returns true if the given order, or the order as it is now stored in Firestore, read within the
+// given transaction, has been cancelled. Orders that cannot be found, e.g. in unit tests, are taken not to have been
+// cancelled.
+func orderCancelled(tx *firestore.Transaction, client *firestore.Client, pbOrder *pb.Order) (bool, error) {
+	if pbOrder.Cancellation != nil {
+		return true, nil
+	}
+	order := &orderschema.Order{Id: pbOrder.Id}
+	snap, err := tx.Get(client.Doc(order.StoreRefPath()))
+	if status.Code(err) == codes.NotFound {
+		return false, nil
+	}
+	if err == nil {
+		err = snap.DataTo(order)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to retrieve order: order ID=%s: %w", pbOrder.Id, err)
+	}
+	return order.Cancellation != nil, nil
+}
+
+// getFulfillmentService lazy loads the fulfillment service that we use to release tasks
+func getFulfillmentService() (*fulfillapi.FulfillmentService, error) {
+
+	// if we already have the service in hand, return it fast
+	if lazyFulfillmentService != nil {
+		return lazyFulfillmentService, nil
+	}
+
+	// Try to load the service and cache it for posterity
+	var err error
+	lazyFulfillmentService, err = fulfillapi.NewFulfillmentService()
+	return lazyFulfillmentService, err
+}
+
+// unmarshalOrder unpacks the provided binary protobuf message into an order structure.
+func unmarshalOrder(message []byte) (*pb.Order, error) {
+
+	// Unmarshal the protobuf message bytes if we can
+	order := &pb.Order{}
+	err := proto.Unmarshal(message, order)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal order protobuf message: %w", err)
+	}
+	return order, nil
+}
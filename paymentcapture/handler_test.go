@@ -0,0 +1,275 @@
+package paymentcapture
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/uuid"
+	"github.com/mikebway/poc-gcp-ecomm/fulfillment/fulfillapi"
+	"github.com/mikebway/poc-gcp-ecomm/fulfillment/schema"
+	orderschema "github.com/mikebway/poc-gcp-ecomm/order/schema"
+	"github.com/mikebway/poc-gcp-ecomm/payments"
+	pbfulfillment "github.com/mikebway/poc-gcp-ecomm/pb/fulfillment"
+	pb "github.com/mikebway/poc-gcp-ecomm/pb/order"
+	"github.com/mikebway/poc-gcp-ecomm/testutil"
+	"github.com/mikebway/poc-gcp-ecomm/types"
+	"github.com/stretchr/testify/require"
+	pubsubapi "google.golang.org/api/pubsub/v1"
+)
+
+const (
+	// EnvFirestoreEmulator defines the environment variable name that is used to convey that the Firestore emulator
+	// is running, should be used, and how to connect to it
+	EnvFirestoreEmulator = "FIRESTORE_EMULATOR_HOST"
+
+	// FirestoreEmulatorHost defines the server name and port (in TCP6 terms) of the Firestore emulator
+	FirestoreEmulatorHost = "[::1]:8219"
+)
+
+// TestMain, if defined (it's optional), allows setup code to be run before and after the suite of unit tests
+// for this package.
+func TestMain(m *testing.M) {
+
+	// Ensure that our Firestore requests do not get routed to the live project by mistake
+	fulfillapi.ProjectId = "demo-" + fulfillapi.ProjectId
+
+	// Configure the environment variable that informs the Firestore client that it should connect to the
+	// emulator and how to reach it.
+	_ = os.Setenv(EnvFirestoreEmulator, FirestoreEmulatorHost)
+
+	// Run all the unit tests
+	m.Run()
+}
+
+// TestPaymentCaptureHappyPath confirms that the payment for an order is captured and the order's tasks that were
+// held waiting for it are released.
+func TestPaymentCaptureHappyPath(t *testing.T) {
+
+	// Do the common setup that we share with the other tests: an order with an authorized payment and a couple of
+	// tasks waiting for it
+	req, ctx, svc, orderId := commonTestSetup(t, payments.FakeAuthorizationPrefix)
+
+	// Assemble a mock HTTP request and a means to record the response
+	httpRequest := httptest.NewRequest("POST", "/", buildPushRequest(mockOrderPB(orderId)))
+	responseRecorder := httptest.NewRecorder()
+
+	// Wrap a call to the target function so that we can capture its log output
+	logged := testutil.CaptureLogging(func() {
+		PaymentCapture(responseRecorder, httpRequest)
+	})
+
+	// Confirm the result was a happy one
+	req.Equal(http.StatusOK, responseRecorder.Code, "should have a 200 OK response code")
+	req.Contains(logged, "captured payment for order", "should have seen the expected completion message in the logs")
+
+	// The payment should have been captured ...
+	payment, err := payments.NewPayments(svc.FsClient, nil).GetPayment(ctx, orderId)
+	req.Nil(err, "failed to retrieve payment: %v", err)
+	req.Equal(payments.PsCaptured, payment.Status, "payment should have been captured")
+
+	// ... and the tasks let go
+	response, err := svc.GetTasks(ctx, &pbfulfillment.GetTasksRequest{OrderId: orderId, PageSize: 10})
+	req.Nil(err, "did not expect an error calling GetTasks: %v", err)
+	req.Equal(2, len(response.Tasks), "unexpected number of tasks")
+	for _, task := range response.Tasks {
+		req.NotEqual(pbfulfillment.TaskStatus_WAITING_PAYMENT, task.Status, "%s task should have been released", task.TaskCode)
+	}
+}
+
+// TestPaymentCaptureNoPayment confirms that orders without payments are passed over without complaint.
+func TestPaymentCaptureNoPayment(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Assemble a mock HTTP request and a means to record the response
+	httpRequest := httptest.NewRequest("POST", "/", buildPushRequest(mockOrderPB(uuid.NewString())))
+	responseRecorder := httptest.NewRecorder()
+
+	// Wrap a call to the target function so that we can capture its log output
+	logged := testutil.CaptureLogging(func() {
+		PaymentCapture(responseRecorder, httpRequest)
+	})
+
+	// Confirm the result was a happy one
+	req.Equal(http.StatusOK, responseRecorder.Code, "should have a 200 OK response code")
+	req.Contains(logged, "no payment to capture for order", "should have seen the expected message in the logs")
+}
+
+// TestPaymentCaptureFailure confirms that a payment that the provider will not capture is reported as a server error,
+// so that Pub/Sub tries again later, and that the tasks remain held.
+func TestPaymentCaptureFailure(t *testing.T) {
+
+	// Do the common setup, with an authorization that the fake provider does not recognize
+	req, ctx, svc, orderId := commonTestSetup(t, "not_a_fake_auth_")
+
+	// Assemble a mock HTTP request and a means to record the response
+	httpRequest := httptest.NewRequest("POST", "/", buildPushRequest(mockOrderPB(orderId)))
+	responseRecorder := httptest.NewRecorder()
+
+	// Wrap a call to the target function so that we can capture its log output
+	logged := testutil.CaptureLogging(func() {
+		PaymentCapture(responseRecorder, httpRequest)
+	})
+
+	// Confirm the result was the sad one that we expected
+	req.Equal(http.StatusInternalServerError, responseRecorder.Code, "should have a 500 internal server error code")
+	req.Contains(logged, "unknown payment authorization", "should have seen the expected provider error in the logs")
+	response, err := svc.GetTasks(ctx, &pbfulfillment.GetTasksRequest{OrderId: orderId, PageSize: 10})
+	req.Nil(err, "did not expect an error calling GetTasks: %v", err)
+	for _, task := range response.Tasks {
+		req.Equal(pbfulfillment.TaskStatus_WAITING_PAYMENT, task.Status, "%s task should still have been held", task.TaskCode)
+	}
+}
+
+// TestPaymentCaptureCancelledOrder confirms that the payment for an order that has been cancelled is not captured,
+// nor are its tasks let loose.
+func TestPaymentCaptureCancelledOrder(t *testing.T) {
+
+	// Do the common setup that we share with the other tests, then cancel the order
+	req, ctx, svc, orderId := commonTestSetup(t, payments.FakeAuthorizationPrefix)
+	order := &orderschema.Order{Id: orderId, Cancellation: &orderschema.Cancellation{ReasonCode: "customer_request", CancellationTime: time.Now()}}
+	ref := svc.FsClient.Doc(order.StoreRefPath())
+	_, err := ref.Set(ctx, order)
+	req.Nil(err, "failed to store order: %v", err)
+	t.Cleanup(func() { _, _ = ref.Delete(ctx) })
+
+	// Assemble a mock HTTP request and a means to record the response, then handle it
+	httpRequest := httptest.NewRequest("POST", "/", buildPushRequest(mockOrderPB(orderId)))
+	responseRecorder := httptest.NewRecorder()
+	logged := testutil.CaptureLogging(func() {
+		PaymentCapture(responseRecorder, httpRequest)
+	})
+	req.Equal(http.StatusOK, responseRecorder.Code, "should have a 200 OK response code")
+	req.Contains(logged, "order has been cancelled, payment not captured", "should have been told that the order was cancelled")
+
+	// The payment should have been left alone, and the tasks held
+	payment, err := payments.NewPayments(svc.FsClient, nil).GetPayment(ctx, orderId)
+	req.Nil(err, "failed to retrieve payment: %v", err)
+	req.Equal(payments.PsAuthorized, payment.Status, "payment should not have been captured")
+	response, err := svc.GetTasks(ctx, &pbfulfillment.GetTasksRequest{OrderId: orderId, PageSize: 10})
+	req.Nil(err, "did not expect an error calling GetTasks: %v", err)
+	for _, task := range response.Tasks {
+		req.Equal(pbfulfillment.TaskStatus_WAITING_PAYMENT, task.Status, "%s task should still have been held", task.TaskCode)
+	}
+}
+
+// TestInvalidPushRequest exercises the main handler function with an invalid request that does not
+// match a Pub/Sub push.
+func TestInvalidPushRequest(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Assemble a mock HTTP request and a means to record the response
+	httpRequest := httptest.NewRequest("POST", "/", strings.NewReader("this is not a valid push request"))
+	responseRecorder := httptest.NewRecorder()
+
+	// Wrap a call to the target function so that we can capture its log output
+	logged := testutil.CaptureLogging(func() {
+		PaymentCapture(responseRecorder, httpRequest)
+	})
+
+	// Confirm the result was the sad one that we expected
+	req.Equal(http.StatusBadRequest, responseRecorder.Code, "should have a 400 Bad Request response code")
+	req.Contains(logged, "could not decode push request json body", "should have seen the expected invalid push request message in the logs")
+}
+
+// TestWrongBinary looks at what happens when a valid base64 string is passed to the handler but is not an order
+// message.
+func TestWrongBinary(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Assemble a mock HTTP request and a means to record the response
+	httpRequest := httptest.NewRequest("POST", "/", buildPushRequest([]byte("this is not a valid protobuf order")))
+	responseRecorder := httptest.NewRecorder()
+
+	// Wrap a call to the target function so that we can capture its log output
+	logged := testutil.CaptureLogging(func() {
+		PaymentCapture(responseRecorder, httpRequest)
+	})
+
+	// Confirm the result was the sad one that we expected
+	req.Equal(http.StatusBadRequest, responseRecorder.Code, "should have a 400 Bad Request response code")
+	req.Contains(logged, "failed to unmarshal order protobuf message", "should have seen the expected protobuf unmarshal error in the logs")
+}
+
+// commonTestSetup helps us to be a little DRY (Don't Repeat Yourself) in this file. It records an authorized payment,
+// with an authorization ID formed from the given prefix, for a brand new order, along with two tasks held waiting
+// for the payment, returning the ID of the order. The tasks and payment are cleaned up when the test is done.
+func commonTestSetup(t *testing.T, authorizationPrefix string) (*require.Assertions, context.Context, *fulfillapi.FulfillmentService, string) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Obtain a clean instance of the fulfillment service
+	lazyFulfillmentService = nil
+	ctx := context.Background()
+	svc, err := fulfillapi.NewFulfillmentService()
+	req.Nil(err, "did not expect an error obtaining a new FulfillmentService: %v", err)
+
+	// Record the payment
+	orderId := uuid.NewString()
+	payment := &payments.Payment{
+		OrderId:         orderId,
+		Status:          payments.PsAuthorized,
+		Amount:          types.NewMoney("USD", 18, 990_000_000),
+		AuthorizationId: authorizationPrefix + orderId,
+	}
+	paymentRef := svc.FsClient.Doc(payment.StoreRefPath())
+	_, err = paymentRef.Set(ctx, payment)
+	req.Nil(err, "failed to store payment: %v", err)
+
+	// And the tasks waiting on it
+	tasks := []*schema.Task{
+		{Id: uuid.NewString(), OrderId: orderId, ProductCode: "gold_yoyo", TaskCode: "manufacture", Status: schema.WAITING_SERVICE},
+		{Id: uuid.NewString(), OrderId: orderId, ProductCode: "gold_yoyo", TaskCode: "ship", Status: schema.WAITING_TASK, ReasonCode: "wait_for_manufacture"},
+	}
+	err = svc.SaveTasksHeldForPayment(ctx, tasks, func(tx *firestore.Transaction) (bool, error) {
+		return true, nil
+	})
+	req.Nil(err, "failed to store tasks: %v", err)
+
+	// Tidy up after ourselves
+	t.Cleanup(func() {
+		_, _ = paymentRef.Delete(ctx)
+		for _, task := range tasks {
+			_, _ = svc.FsClient.Doc(task.StoreRefPath()).Delete(ctx)
+		}
+	})
+	return req, ctx, svc, orderId
+}
+
+// mockOrderPB returns a protocol buffer order, with the given ID, marshalled into its binary form.
+func mockOrderPB(orderId string) []byte {
+	pbBytes, _ := proto.Marshal(&pb.Order{Id: orderId})
+	return pbBytes
+}
+
+// buildPushRequest wraps the provided data bytes as the data payload of a push request, returning that as byte reader.
+func buildPushRequest(data []byte) io.Reader {
+
+	// Wrap the base64 encoded data in a push request message structure
+	pushReq := &pushRequest{
+		Message: pubsubapi.PubsubMessage{
+			Data: base64.StdEncoding.EncodeToString(data),
+		},
+	}
+
+	// Marshal the push request structure to JSON and return that as a byte reader
+	pushReqBytes, _ := json.Marshal(pushReq)
+	return bytes.NewReader(pushReqBytes)
+}
@@ -0,0 +1,75 @@
+// Package payments is the bounded context that takes our customers' money, through a PaymentProvider, and keeps a
+// record of it.
+//
+// A payment is authorized, i.e. the funds are set aside, as a shopping cart is checked out and a Payment document,
+// keyed by the ID of the order that the cart becomes, is written in the same Firestore transaction. The payment is
+// captured, i.e. the money is actually taken, once the order has been recorded, and only then are the fulfillment
+// tasks for the order let loose. Captured payments can be refunded.
+package payments
+
+import (
+	"time"
+
+	"github.com/mikebway/poc-gcp-ecomm/types"
+)
+
+const (
+	// PaymentCollection names the firestore collection under which payment documents are stored, keyed by the IDs
+	// of the orders that they pay for
+	PaymentCollection = "payments"
+)
+
+// PaymentStatus is an enumeration type defining where a payment has got to
+type PaymentStatus int32
+
+const (
+	PsUnspecified PaymentStatus = 0
+	PsAuthorized  PaymentStatus = 1
+	PsCaptured    PaymentStatus = 2
+	PsVoided      PaymentStatus = 3
+	PsRefunded    PaymentStatus = 4
+	PsCapturing   PaymentStatus = 5
+)
+
+// Payment records the money taken, or to be taken, for an order. It is persisted in the payments firestore collection.
+type Payment struct {
+	// OrderId is the UUID ID of the order that the payment is for, doubling as the ID of the payment document. Since
+	// orders take the IDs of the carts that they are made from, this is also the ID of the cart that was checked out.
+	OrderId string `firestore:"orderId" json:"orderId"`
+
+	// Status is where the payment has got to
+	Status PaymentStatus `firestore:"status" json:"status"`
+
+	// Amount is the amount authorized and, once the payment is captured, taken
+	Amount *types.Money `firestore:"amount" json:"amount"`
+
+	// RefundedAmount is the total of any refunds given
+	RefundedAmount *types.Money `firestore:"refundedAmount,omitempty" json:"refundedAmount,omitempty"`
+
+	// RefundIds lists the IDs of the refunds given, so that none is given twice
+	RefundIds []string `firestore:"refundIds,omitempty" json:"refundIds,omitempty"`
+
+	// AuthorizationId is the ID that the payment provider issued for the authorization
+	AuthorizationId string `firestore:"authorizationId" json:"authorizationId"`
+
+	// AuthorizationTime is the time at which the payment was authorized
+	AuthorizationTime time.Time `firestore:"authorizationTime" json:"authorizationTime"`
+
+	// CaptureTime is the time at which the payment was captured
+	CaptureTime time.Time `firestore:"captureTime,omitempty" json:"captureTime,omitempty"`
+}
+
+// StoreRefPath returns the string representation of the document reference path for this Payment.
+func (p *Payment) StoreRefPath() string {
+	return PaymentCollection + "/" + p.OrderId
+}
+
+// HasRefund returns true if the refund with the given ID has been given.
+func (p *Payment) HasRefund(refundId string) bool {
+	for _, id := range p.RefundIds {
+		if id == refundId {
+			return true
+		}
+	}
+	return false
+}
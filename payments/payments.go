@@ -0,0 +1,317 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/mikebway/poc-gcp-ecomm/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrNotCaptured is returned, wrapped, when asked to refund a payment that has not been captured
+	ErrNotCaptured = errors.New("payment not captured")
+
+	// ErrRefundExceedsPayment is returned, wrapped, when asked to refund more than is left of a payment
+	ErrRefundExceedsPayment = errors.New("refund exceeds payment")
+)
+
+// Payments takes payments for orders through a PaymentProvider, recording them as Payment documents in Firestore.
+//
+// Authorize works within a Firestore transaction supplied by the caller, so that the payment record is written in
+// step with the checkout that it pays for. It only writes, so it must be called after all of the caller's reads.
+type Payments struct {
+
+	// FsClient is the GCP Firestore client - it is thread safe and can be reused concurrently
+	FsClient *firestore.Client
+
+	// Provider is the payment gateway that actually moves the money
+	Provider PaymentProvider
+}
+
+// NewPayments is a factory method returning a Payments that uses the given Firestore client and payment provider.
+func NewPayments(client *firestore.Client, provider PaymentProvider) *Payments {
+	return &Payments{
+		FsClient: client,
+		Provider: provider,
+	}
+}
+
+// Authorize has the payment provider set aside the given amount for the order with the given ID and records the
+// authorized payment within the given transaction. An error wrapping ErrPaymentDeclined is returned, and nothing
+// is recorded, if the provider refuses.
+//
+// Should the transaction fail, the authorization that was made must be given up by passing the returned payment to
+// Void.
+func (p *Payments) Authorize(ctx context.Context, tx *firestore.Transaction, orderId string, amount *types.Money) (*Payment, error) {
+
+	// Ask the provider first; there is nothing to record if they say no
+	authorizationId, err := p.Provider.Authorize(ctx, orderId, amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize payment: order ID=%s: %w", orderId, err)
+	}
+
+	// Record the authorization
+	payment := &Payment{
+		OrderId:           orderId,
+		Status:            PsAuthorized,
+		Amount:            amount,
+		AuthorizationId:   authorizationId,
+		AuthorizationTime: time.Now(),
+	}
+	err = tx.Set(p.FsClient.Doc(payment.StoreRefPath()), payment)
+	if err != nil {
+		return nil, fmt.Errorf("failed setting payment to firestore: order ID=%s: %w", orderId, err)
+	}
+	return payment, nil
+}
+
+// Void gives up an authorization returned by Authorize that never made it into Firestore because the transaction
+// that it was recorded in failed.
+func (p *Payments) Void(ctx context.Context, payment *Payment) error {
+	err := p.Provider.Void(ctx, payment.AuthorizationId)
+	if err != nil {
+		return fmt.Errorf("failed to void payment authorization: order ID=%s: %w", payment.OrderId, err)
+	}
+	return nil
+}
+
+// VoidOrderPayment gives up the authorized payment for the order with the given ID, recording that it has been
+// voided, e.g. because the order has been cancelled. The payment as it then stands is returned, or nil if the order
+// has no payment. A payment that has already been captured, or is being captured, cannot be voided; it is returned
+// as it is, for the caller to finish capturing, see Capture, and refund if need be.
+//
+// The payment is recorded as voided before the provider is asked to void it, so that a concurrent Capture will
+// not record it as captured, nor let loose whatever was waiting on it. Voiding a payment that has already been
+// voided does no harm.
+func (p *Payments) VoidOrderPayment(ctx context.Context, orderId string) (*Payment, error) {
+
+	// Record the payment as voided if it has not got any further than being authorized
+	var payment *Payment
+	err := p.FsClient.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		var err error
+		payment, err = p.GetTransactionalPayment(tx, orderId)
+		if err != nil || payment == nil || payment.Status != PsAuthorized {
+			return err
+		}
+		payment.Status = PsVoided
+		return tx.Update(p.FsClient.Doc(payment.StoreRefPath()), []firestore.Update{{Path: "status", Value: payment.Status}})
+	})
+	if err != nil || payment == nil || payment.Status != PsVoided {
+		return payment, err
+	}
+
+	// Then have the provider release the funds
+	err = p.Void(ctx, payment)
+	if err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+// Capture has the payment provider take the money authorized for the order with the given ID, then, within a
+// Firestore transaction, records that the payment has been captured. The payment as it then stands is returned, or
+// nil if the order has no payment, e.g. because it was placed before we started taking payments.
+//
+// Before the provider is asked for the money, the payment is marked PsCapturing in a transaction of its own, so that
+// a concurrent VoidOrderPayment cannot record as voided a payment that the provider is about to take. If capturable
+// is not nil, it is called within that same transaction, after the payment has been read, and the payment is left
+// as it is, and returned still authorized, if it returns false; e.g. because the order has been cancelled. It may
+// read but must not write. A payment that was left PsCapturing, because the provider failed or we were interrupted,
+// is captured without asking again.
+//
+// If captured is not nil, it is called within the transaction that records the capture, after the payment has been
+// read but before it is written, for every payment that has been captured, now or in the past, so that whatever was
+// waiting on the payment can be let loose in step with it being recorded. It may make reads of its own, followed by
+// writes.
+//
+// Capturing a payment that has already been captured does no harm; the provider is not asked to capture it again.
+func (p *Payments) Capture(ctx context.Context, orderId string, capturable func(tx *firestore.Transaction) (bool, error), captured func(tx *firestore.Transaction, payment *Payment) error) (*Payment, error) {
+
+	// Mark the payment as being captured, if it has not got that far already and may be
+	var payment *Payment
+	err := p.FsClient.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		var err error
+		payment, err = p.GetTransactionalPayment(tx, orderId)
+		if err != nil || payment == nil || payment.Status != PsAuthorized {
+			return err
+		}
+		if capturable != nil {
+			ok, err := capturable(tx)
+			if err != nil || !ok {
+				return err
+			}
+		}
+		payment.Status = PsCapturing
+		return tx.Update(p.FsClient.Doc(payment.StoreRefPath()), []firestore.Update{{Path: "status", Value: payment.Status}})
+	})
+	if err != nil || payment == nil || payment.Status == PsAuthorized {
+		return payment, err
+	}
+
+	// Have the provider take the money, if they have not already
+	if payment.Status == PsCapturing {
+		err = p.Provider.Capture(ctx, payment.AuthorizationId, payment.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("failed to capture payment: order ID=%s: %w", orderId, err)
+		}
+	}
+
+	// Record the capture, letting the caller in on it
+	err = p.FsClient.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		payment, err = p.GetTransactionalPayment(tx, orderId)
+		if err != nil {
+			return err
+		}
+		if payment == nil {
+			return fmt.Errorf("payment disappeared while being captured: order ID=%s", orderId)
+		}
+		recording := payment.Status == PsCapturing
+		if recording {
+			payment.Status = PsCaptured
+			payment.CaptureTime = time.Now()
+		}
+		if captured != nil && payment.Status != PsVoided {
+			err = captured(tx, payment)
+			if err != nil {
+				return err
+			}
+		}
+		if !recording {
+			return nil
+		}
+		return tx.Update(p.FsClient.Doc(payment.StoreRefPath()), []firestore.Update{
+			{Path: "status", Value: payment.Status},
+			{Path: "captureTime", Value: payment.CaptureTime},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+// Refund has the payment provider give back the given amount of the captured payment for the order with the given
+// ID, then records the refund. Once the whole of the payment has been given back, its status becomes PsRefunded.
+//
+// The refund is identified by the given refund ID, e.g. the ID of the task that called for it, which is unique to
+// the payment. A refund is only given once however many times it is asked for, so the caller can safely try again
+// if something goes wrong; asking again for a refund that has been given simply returns the payment.
+//
+// An error wrapping ErrNotCaptured is returned if the payment has not been captured, or has already been refunded
+// in full, and one wrapping ErrRefundExceedsPayment if the amount is more than is left of the payment. Either way,
+// nothing is refunded.
+func (p *Payments) Refund(ctx context.Context, orderId string, refundId string, amount *types.Money) (*Payment, error) {
+
+	// Make sure that the refund has not already been given, and that there is enough left to give back, before
+	// asking the provider to give it
+	payment, err := p.GetPayment(ctx, orderId)
+	if err == nil && payment == nil {
+		err = status.Errorf(codes.NotFound, "no payment found for order: order ID=%s", orderId)
+	}
+	if err == nil && payment.HasRefund(refundId) {
+		return payment, nil
+	}
+	if err == nil {
+		_, err = payment.refunded(amount)
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = p.Provider.Refund(ctx, payment.AuthorizationId, refundId, amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refund payment: order ID=%s: %w", orderId, err)
+	}
+
+	// Add the refund to those already recorded for the payment, unless a concurrent call beat us to it
+	err = p.FsClient.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		payment, err = p.GetTransactionalPayment(tx, orderId)
+		if err != nil {
+			return err
+		}
+		if payment == nil {
+			return fmt.Errorf("payment disappeared while being refunded: order ID=%s", orderId)
+		}
+		if payment.HasRefund(refundId) {
+			return nil
+		}
+		refundedAmount, err := payment.refunded(amount)
+		if err != nil {
+			return err
+		}
+		payment.RefundedAmount = refundedAmount
+		payment.RefundIds = append(payment.RefundIds, refundId)
+		if comparison, _ := refundedAmount.Compare(payment.Amount); comparison == 0 {
+			payment.Status = PsRefunded
+		}
+		return tx.Update(p.FsClient.Doc(payment.StoreRefPath()), []firestore.Update{
+			{Path: "status", Value: payment.Status},
+			{Path: "refundedAmount", Value: payment.RefundedAmount},
+			{Path: "refundIds", Value: payment.RefundIds},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+// GetPayment reads the payment for the order with the given ID, returning nil if there is no such payment.
+func (p *Payments) GetPayment(ctx context.Context, orderId string) (*Payment, error) {
+	payment := &Payment{OrderId: orderId}
+	snap, err := p.FsClient.Doc(payment.StoreRefPath()).Get(ctx)
+	return paymentFromSnapshot(payment, snap, err)
+}
+
+// GetTransactionalPayment reads the payment for the order with the given ID within the given Firestore transaction,
+// returning nil if there is no such payment.
+func (p *Payments) GetTransactionalPayment(tx *firestore.Transaction, orderId string) (*Payment, error) {
+	payment := &Payment{OrderId: orderId}
+	snap, err := tx.Get(p.FsClient.Doc(payment.StoreRefPath()))
+	return paymentFromSnapshot(payment, snap, err)
+}
+
+// paymentFromSnapshot unmarshals the given snapshot, and the error returned in reading it, into the given payment.
+func paymentFromSnapshot(payment *Payment, snap *firestore.DocumentSnapshot, err error) (*Payment, error) {
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err == nil {
+		err = snap.DataTo(payment)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve payment: order ID=%s: %w", payment.OrderId, err)
+	}
+	return payment, nil
+}
+
+// refunded returns the total that would have been refunded of the payment were the given amount to be refunded as
+// well, or an error if that is not possible.
+func (p *Payment) refunded(amount *types.Money) (*types.Money, error) {
+	if p.Status != PsCaptured {
+		return nil, fmt.Errorf("%w: order ID=%s", ErrNotCaptured, p.OrderId)
+	}
+	if amount == nil || amount.IsZero() || amount.Units < 0 || amount.Nanos < 0 {
+		return nil, fmt.Errorf("refund amount must be greater than zero: order ID=%s, refund=%s", p.OrderId, amount)
+	}
+	total := amount
+	if p.RefundedAmount != nil {
+		var err error
+		total, err = p.RefundedAmount.Add(amount)
+		if err != nil {
+			return nil, err
+		}
+	}
+	exceeds, err := total.Compare(p.Amount)
+	if err != nil {
+		return nil, err
+	}
+	if exceeds > 0 {
+		return nil, fmt.Errorf("%w: order ID=%s, refund=%s, already refunded=%s, payment=%s", ErrRefundExceedsPayment, p.OrderId, amount, p.RefundedAmount, p.Amount)
+	}
+	return total, nil
+}
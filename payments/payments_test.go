@@ -0,0 +1,234 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"cloud.google.com/go/firestore"
+	"github.com/google/uuid"
+	"github.com/mikebway/poc-gcp-ecomm/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// EnvFirestoreEmulator defines the environment variable name that is used to convey that the Firestore emulator
+	// is running, should be used, and how to connect to it
+	EnvFirestoreEmulator = "FIRESTORE_EMULATOR_HOST"
+
+	// FirestoreEmulatorHost defines the server name and port (in TCP6 terms) of the Firestore emulator
+	FirestoreEmulatorHost = "[::1]:8219"
+
+	// projectId is the demo project that the tests use, so that they cannot reach a live project by mistake
+	projectId = "demo-poc-gcp-ecomm"
+)
+
+// TestMain, if defined (it's optional), allows setup code to be run before and after the suite of unit tests
+// for this package.
+func TestMain(m *testing.M) {
+
+	// Configure the environment variable that informs the Firestore client that it should connect to the
+	// emulator and how to reach it.
+	_ = os.Setenv(EnvFirestoreEmulator, FirestoreEmulatorHost)
+
+	// Run all the unit tests
+	m.Run()
+}
+
+// commonTestSetup performs the basic foundation stuff that most of this package's tests require, returning a
+// Payments backed by the fake provider and a brand new order ID.
+func commonTestSetup(t *testing.T) (*require.Assertions, context.Context, *Payments, string) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Obtain a Firestore client connected to the emulator
+	ctx := context.Background()
+	client, err := firestore.NewClient(ctx, projectId)
+	req.Nil(err, "failed to obtain firestore client: %v", err)
+	t.Cleanup(func() { _ = client.Close() })
+	return req, ctx, NewPayments(client, NewFakeProvider()), uuid.NewString()
+}
+
+// authorize authorizes a payment of the given amount for the given order, failing the test if that cannot be done.
+func authorize(req *require.Assertions, ctx context.Context, pmts *Payments, orderId string, amount *types.Money) {
+	err := pmts.FsClient.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		_, err := pmts.Authorize(ctx, tx, orderId, amount)
+		return err
+	})
+	req.Nil(err, "failed to authorize payment: %v", err)
+}
+
+// TestAuthorize confirms that authorized payments are recorded and declined ones are not.
+func TestAuthorize(t *testing.T) {
+
+	// Do the common setup that we share with the other tests
+	req, ctx, pmts, orderId := commonTestSetup(t)
+
+	// An affordable payment is recorded
+	authorize(req, ctx, pmts, orderId, types.NewMoney("USD", 21, 500_000_000))
+	payment, err := pmts.GetPayment(ctx, orderId)
+	req.Nil(err, "failed to retrieve payment: %v", err)
+	req.NotNil(payment, "payment should have been recorded")
+	req.Equal(PsAuthorized, payment.Status, "payment should have been authorized")
+	req.Equal("USD 21.50", payment.Amount.String(), "authorized amount did not match")
+	req.Equal(FakeAuthorizationPrefix+orderId+"_USD_21_500000000", payment.AuthorizationId, "authorization ID did not match")
+
+	// An extravagant one is not
+	declinedId := uuid.NewString()
+	err = pmts.FsClient.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		_, err := pmts.Authorize(ctx, tx, declinedId, types.NewMoney("USD", DefaultFakeCreditLimit, 0))
+		return err
+	})
+	req.True(errors.Is(err, ErrPaymentDeclined), "payment should have been declined: %v", err)
+	payment, err = pmts.GetPayment(ctx, declinedId)
+	req.Nil(err, "failed to look for payment: %v", err)
+	req.Nil(payment, "declined payment should not have been recorded")
+}
+
+// TestCapture confirms that capturing a payment records the capture, letting the caller in on it, and that
+// capturing it again does no harm.
+func TestCapture(t *testing.T) {
+
+	// Do the common setup that we share with the other tests
+	req, ctx, pmts, orderId := commonTestSetup(t)
+	authorize(req, ctx, pmts, orderId, types.NewMoney("USD", 21, 500_000_000))
+
+	// Count the number of times that we are told about the capture
+	confirmations := 0
+	confirm := func(tx *firestore.Transaction, payment *Payment) error {
+		req.Equal(PsCaptured, payment.Status, "confirmed payment should have been captured")
+		confirmations++
+		return nil
+	}
+
+	// Capture the payment, twice
+	payment, err := pmts.Capture(ctx, orderId, nil, confirm)
+	req.Nil(err, "failed to capture payment: %v", err)
+	req.Equal(PsCaptured, payment.Status, "payment should have been captured")
+	req.False(payment.CaptureTime.IsZero(), "capture time should have been set")
+	payment, err = pmts.Capture(ctx, orderId, nil, confirm)
+	req.Nil(err, "failed to capture payment a second time: %v", err)
+	req.Equal(PsCaptured, payment.Status, "payment should still have been captured")
+	req.Equal(2, confirmations, "each capture should have been confirmed")
+
+	// Orders without payments have nothing to capture
+	payment, err = pmts.Capture(ctx, uuid.NewString(), nil, confirm)
+	req.Nil(err, "capturing a missing payment should not have failed: %v", err)
+	req.Nil(payment, "there should not have been a payment to capture")
+	req.Equal(2, confirmations, "missing payment should not have been confirmed")
+}
+
+// TestRefund confirms that captured payments can be refunded in parts, but not beyond what was paid, and that each
+// refund is only given once.
+func TestRefund(t *testing.T) {
+
+	// Do the common setup that we share with the other tests
+	req, ctx, pmts, orderId := commonTestSetup(t)
+	authorize(req, ctx, pmts, orderId, types.NewMoney("USD", 20, 0))
+
+	// Nothing can be refunded until the payment has been captured
+	_, err := pmts.Refund(ctx, orderId, "refund-1", types.NewMoney("USD", 5, 0))
+	req.True(errors.Is(err, ErrNotCaptured), "uncaptured payment should not have been refunded: %v", err)
+	_, err = pmts.Capture(ctx, orderId, nil, nil)
+	req.Nil(err, "failed to capture payment: %v", err)
+
+	// Give some back, then try to give back more than is left
+	payment, err := pmts.Refund(ctx, orderId, "refund-1", types.NewMoney("USD", 5, 0))
+	req.Nil(err, "failed to refund part of the payment: %v", err)
+	req.Equal(PsCaptured, payment.Status, "partly refunded payment should still have been captured")
+	req.Equal("USD 5.00", payment.RefundedAmount.String(), "refunded amount did not match")
+	_, err = pmts.Refund(ctx, orderId, "refund-2", types.NewMoney("USD", 15, 10_000_000))
+	req.True(errors.Is(err, ErrRefundExceedsPayment), "refund of more than was left should have failed: %v", err)
+
+	// Asking for the same refund again gives nothing more back
+	payment, err = pmts.Refund(ctx, orderId, "refund-1", types.NewMoney("USD", 5, 0))
+	req.Nil(err, "repeated refund should not have failed: %v", err)
+	req.Equal("USD 5.00", payment.RefundedAmount.String(), "repeated refund should not have been given again")
+
+	// Then give back the rest
+	payment, err = pmts.Refund(ctx, orderId, "refund-2", types.NewMoney("USD", 15, 0))
+	req.Nil(err, "failed to refund the rest of the payment: %v", err)
+	req.Equal(PsRefunded, payment.Status, "fully refunded payment should have been refunded")
+	req.Equal("USD 20.00", payment.RefundedAmount.String(), "refunded amount did not match")
+	req.Equal([]string{"refund-1", "refund-2"}, payment.RefundIds, "refund IDs did not match")
+
+	// There is nothing more to give
+	_, err = pmts.Refund(ctx, orderId, "refund-3", types.NewMoney("USD", 0, 10_000_000))
+	req.True(errors.Is(err, ErrNotCaptured), "fully refunded payment should not have been refunded again: %v", err)
+}
+
+// TestVoidOrderPayment confirms that an authorized payment is recorded as voided, after which it cannot be captured,
+// and that a captured payment is left for the caller to refund.
+func TestVoidOrderPayment(t *testing.T) {
+
+	// Do the common setup that we share with the other tests
+	req, ctx, pmts, orderId := commonTestSetup(t)
+
+	// Orders without payments have nothing to void
+	payment, err := pmts.VoidOrderPayment(ctx, orderId)
+	req.Nil(err, "did not expect an error voiding a missing payment: %v", err)
+	req.Nil(payment, "there should have been no payment to void")
+
+	// Authorized payments are voided, as often as we like
+	authorize(req, ctx, pmts, orderId, types.NewMoney("USD", 21, 500_000_000))
+	for i := 0; i < 2; i++ {
+		payment, err = pmts.VoidOrderPayment(ctx, orderId)
+		req.Nil(err, "failed to void payment: %v", err)
+		req.Equal(PsVoided, payment.Status, "payment should have been voided")
+	}
+
+	// And are not captured, nor is anything waiting on them let loose
+	payment, err = pmts.Capture(ctx, orderId, nil, func(tx *firestore.Transaction, payment *Payment) error {
+		return errors.New("should not have been called for a voided payment")
+	})
+	req.Nil(err, "did not expect an error capturing a voided payment: %v", err)
+	req.Equal(PsVoided, payment.Status, "voided payment should not have been captured")
+
+	// Captured payments are left as they are
+	capturedId := uuid.NewString()
+	authorize(req, ctx, pmts, capturedId, types.NewMoney("USD", 21, 500_000_000))
+	_, err = pmts.Capture(ctx, capturedId, nil, nil)
+	req.Nil(err, "failed to capture payment: %v", err)
+	payment, err = pmts.VoidOrderPayment(ctx, capturedId)
+	req.Nil(err, "did not expect an error voiding a captured payment: %v", err)
+	req.Equal(PsCaptured, payment.Status, "captured payment should not have been voided")
+
+	// As are those that are being captured; the provider may be taking the money as we speak
+	capturingId := uuid.NewString()
+	authorize(req, ctx, pmts, capturingId, types.NewMoney("USD", 21, 500_000_000))
+	_, err = pmts.FsClient.Doc((&Payment{OrderId: capturingId}).StoreRefPath()).Update(ctx, []firestore.Update{{Path: "status", Value: PsCapturing}})
+	req.Nil(err, "failed to mark payment as being captured: %v", err)
+	payment, err = pmts.VoidOrderPayment(ctx, capturingId)
+	req.Nil(err, "did not expect an error voiding a payment being captured: %v", err)
+	req.Equal(PsCapturing, payment.Status, "payment being captured should not have been voided")
+
+	// For the caller to finish capturing, without the capturable check, which only applies to authorized payments
+	payment, err = pmts.Capture(ctx, capturingId, func(tx *firestore.Transaction) (bool, error) {
+		return false, nil
+	}, nil)
+	req.Nil(err, "failed to finish capturing payment: %v", err)
+	req.Equal(PsCaptured, payment.Status, "payment being captured should have been captured")
+}
+
+// TestCaptureNotCapturable confirms that a payment is left authorized, and nothing let loose, if the caller finds
+// that it should not be captured after all.
+func TestCaptureNotCapturable(t *testing.T) {
+
+	// Do the common setup that we share with the other tests
+	req, ctx, pmts, orderId := commonTestSetup(t)
+	authorize(req, ctx, pmts, orderId, types.NewMoney("USD", 21, 500_000_000))
+
+	// Have the capture turned down
+	payment, err := pmts.Capture(ctx, orderId, func(tx *firestore.Transaction) (bool, error) {
+		return false, nil
+	}, func(tx *firestore.Transaction, payment *Payment) error {
+		return errors.New("should not have been called for a payment that was not captured")
+	})
+	req.Nil(err, "did not expect an error declining to capture a payment: %v", err)
+	req.Equal(PsAuthorized, payment.Status, "payment should have been left authorized")
+	payment, err = pmts.GetPayment(ctx, orderId)
+	req.Nil(err, "failed to retrieve payment: %v", err)
+	req.Equal(PsAuthorized, payment.Status, "payment should have been recorded as authorized still")
+}
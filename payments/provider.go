@@ -0,0 +1,104 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mikebway/poc-gcp-ecomm/types"
+)
+
+const (
+	// FakeAuthorizationPrefix is the prefix of the authorization IDs issued by the FakeProvider
+	FakeAuthorizationPrefix = "fake_auth_"
+
+	// DefaultFakeCreditLimit is the number of whole currency units, in any currency, at or above which the
+	// FakeProvider returned by NewFakeProvider declines to authorize a payment
+	DefaultFakeCreditLimit = 10_000
+)
+
+var (
+	// ErrPaymentDeclined is returned, wrapped, when a payment provider refuses to authorize a payment
+	ErrPaymentDeclined = errors.New("payment declined")
+
+	// ErrUnknownAuthorization is returned, wrapped, when a payment provider is asked to act on an authorization that
+	// it did not issue
+	ErrUnknownAuthorization = errors.New("unknown payment authorization")
+)
+
+// PaymentProvider is implemented by the payment gateways that take our customers' money. A payment is first
+// authorized, i.e. the funds are set aside with the customer's bank, then either captured, i.e. actually taken, or
+// voided if the order falls through before that. Captured payments may be refunded, in whole or in part.
+//
+// Implementations must treat the order ID and amount passed to Authorize together as an idempotency key, returning
+// the same authorization for the same order and amount, since authorizations are made from within Firestore
+// transactions that may be retried. A retry that asks for a different amount, e.g. because the cart being checked
+// out changed in between, must be given a different authorization, so that no payment is ever recorded against an
+// authorization for some other amount. Likewise, the refund ID passed to Refund is an idempotency key, giving back
+// only once for the same refund. Capturing or voiding an authorization more than once must do no harm.
+type PaymentProvider interface {
+
+	// Authorize sets aside the given amount for the order with the given ID, returning the ID of the authorization.
+	// An error wrapping ErrPaymentDeclined is returned if the customer's bank refuses.
+	Authorize(ctx context.Context, orderId string, amount *types.Money) (string, error)
+
+	// Capture takes the given amount, which may not exceed the amount authorized, against the authorization with the
+	// given ID.
+	Capture(ctx context.Context, authorizationId string, amount *types.Money) error
+
+	// Void cancels the authorization with the given ID, releasing the funds that it set aside, before it has been
+	// captured.
+	Void(ctx context.Context, authorizationId string) error
+
+	// Refund gives back the given amount of a payment captured against the authorization with the given ID, as the
+	// refund with the given ID.
+	Refund(ctx context.Context, authorizationId string, refundId string, amount *types.Money) error
+}
+
+// FakeProvider is a deterministic stand-in for a real payment gateway, good enough for development and unit tests.
+// It keeps no state: authorization IDs are derived from order IDs and amounts, any amount below the credit limit is
+// authorized, and any authorization ID that it could have issued can be captured, voided, or refunded.
+type FakeProvider struct {
+
+	// CreditLimit is the number of whole currency units at or above which payments are declined
+	CreditLimit int64
+}
+
+// NewFakeProvider is a factory method returning a FakeProvider with the default credit limit.
+func NewFakeProvider() *FakeProvider {
+	return &FakeProvider{CreditLimit: DefaultFakeCreditLimit}
+}
+
+// Authorize returns an authorization ID formed from the FakeAuthorizationPrefix, the given order ID, and the amount,
+// unless the amount is at or above the credit limit, in which case an error wrapping ErrPaymentDeclined is returned.
+func (p *FakeProvider) Authorize(ctx context.Context, orderId string, amount *types.Money) (string, error) {
+	if amount == nil || amount.Units >= p.CreditLimit {
+		return "", fmt.Errorf("%w: order ID=%s, amount=%s", ErrPaymentDeclined, orderId, amount)
+	}
+	return fmt.Sprintf("%s%s_%s_%d_%09d", FakeAuthorizationPrefix, orderId, amount.CurrencyCode, amount.Units, amount.Nanos), nil
+}
+
+// Capture succeeds for any authorization ID that the fake provider could have issued.
+func (p *FakeProvider) Capture(ctx context.Context, authorizationId string, amount *types.Money) error {
+	return p.checkAuthorization(authorizationId)
+}
+
+// Void succeeds for any authorization ID that the fake provider could have issued.
+func (p *FakeProvider) Void(ctx context.Context, authorizationId string) error {
+	return p.checkAuthorization(authorizationId)
+}
+
+// Refund succeeds for any authorization ID that the fake provider could have issued.
+func (p *FakeProvider) Refund(ctx context.Context, authorizationId string, refundId string, amount *types.Money) error {
+	return p.checkAuthorization(authorizationId)
+}
+
+// checkAuthorization returns an error wrapping ErrUnknownAuthorization if the given authorization ID is not one that
+// the fake provider could have issued.
+func (p *FakeProvider) checkAuthorization(authorizationId string) error {
+	if !strings.HasPrefix(authorizationId, FakeAuthorizationPrefix) {
+		return fmt.Errorf("%w: authorization ID=%s", ErrUnknownAuthorization, authorizationId)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mikebway/poc-gcp-ecomm/types"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFakeProvider confirms that the fake provider authorizes amounts below its credit limit, deterministically,
+// keyed by order and amount, and only acts on authorizations that it could have issued.
+func TestFakeProvider(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	ctx := context.Background()
+	provider := &FakeProvider{CreditLimit: 100}
+	authorizationId, err := provider.Authorize(ctx, "order-1", types.NewMoney("USD", 99, 990_000_000))
+	req.Nil(err, "amount below the credit limit should have been authorized: %v", err)
+	req.Equal(FakeAuthorizationPrefix+"order-1_USD_99_990000000", authorizationId, "authorization ID should have been derived from the order ID and amount")
+	again, _ := provider.Authorize(ctx, "order-1", types.NewMoney("USD", 99, 990_000_000))
+	req.Equal(authorizationId, again, "authorizing the same order and amount again should have given the same authorization ID")
+	different, _ := provider.Authorize(ctx, "order-1", types.NewMoney("USD", 98, 0))
+	req.NotEqual(authorizationId, different, "authorizing a different amount should have given a different authorization ID")
+
+	_, err = provider.Authorize(ctx, "order-2", types.NewMoney("USD", 100, 0))
+	req.True(errors.Is(err, ErrPaymentDeclined), "amount at the credit limit should have been declined: %v", err)
+
+	req.Nil(provider.Capture(ctx, authorizationId, types.NewMoney("USD", 1, 0)), "capture should have succeeded")
+	req.Nil(provider.Void(ctx, authorizationId), "void should have succeeded")
+	req.Nil(provider.Refund(ctx, authorizationId, "refund-1", types.NewMoney("USD", 1, 0)), "refund should have succeeded")
+	err = provider.Capture(ctx, "somebody_elses_auth", types.NewMoney("USD", 1, 0))
+	req.True(errors.Is(err, ErrUnknownAuthorization), "capture of a foreign authorization should have failed: %v", err)
+}
@@ -10,6 +10,8 @@ import (
 	types "github.com/mikebway/poc-gcp-ecomm/pb/types"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -21,6 +23,62 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// An enumeration of the reasons for which an order item may not be added to a new cart by the ReorderFromOrder API
+type SkippedItemReason int32
+
+const (
+	SkippedItemReason_SIR_UNSPECIFIED SkippedItemReason = 0
+	// The product is still in the catalog but the item cannot be added to a cart as it was ordered
+	SkippedItemReason_SIR_UNAVAILABLE SkippedItemReason = 1
+	// There is not enough stock of the product
+	SkippedItemReason_SIR_OUT_OF_STOCK SkippedItemReason = 2
+	// The product is no longer in the catalog
+	SkippedItemReason_SIR_UNKNOWN_PRODUCT SkippedItemReason = 3
+)
+
+// Enum value maps for SkippedItemReason.
+var (
+	SkippedItemReason_name = map[int32]string{
+		0: "SIR_UNSPECIFIED",
+		1: "SIR_UNAVAILABLE",
+		2: "SIR_OUT_OF_STOCK",
+		3: "SIR_UNKNOWN_PRODUCT",
+	}
+	SkippedItemReason_value = map[string]int32{
+		"SIR_UNSPECIFIED":     0,
+		"SIR_UNAVAILABLE":     1,
+		"SIR_OUT_OF_STOCK":    2,
+		"SIR_UNKNOWN_PRODUCT": 3,
+	}
+)
+
+func (x SkippedItemReason) Enum() *SkippedItemReason {
+	p := new(SkippedItemReason)
+	*p = x
+	return p
+}
+
+func (x SkippedItemReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SkippedItemReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_mikebway_cart_cart_api_proto_enumTypes[0].Descriptor()
+}
+
+func (SkippedItemReason) Type() protoreflect.EnumType {
+	return &file_mikebway_cart_cart_api_proto_enumTypes[0]
+}
+
+func (x SkippedItemReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SkippedItemReason.Descriptor instead.
+func (SkippedItemReason) EnumDescriptor() ([]byte, []int) {
+	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{0}
+}
+
 // Request parameters for the CreateShoppingCart API
 type CreateShoppingCartRequest struct {
 	state         protoimpl.MessageState
@@ -29,6 +87,10 @@ type CreateShoppingCartRequest struct {
 
 	// The person / user requesting to open a new shopping cart
 	Shopper *types.Person `protobuf:"bytes,1,opt,name=shopper,proto3" json:"shopper,omitempty"`
+	// Optional. A unique ID chosen by the caller for this request. If a cart has already been created
+	// for the same shopper with the same request ID, that cart is returned again, as it was when first
+	// created, rather than a second cart being created. Request IDs are remembered for 24 hours.
+	RequestId string `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
 }
 
 func (x *CreateShoppingCartRequest) Reset() {
@@ -70,6 +132,13 @@ func (x *CreateShoppingCartRequest) GetShopper() *types.Person {
 	return nil
 }
 
+func (x *CreateShoppingCartRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
 // Response parameters for the CreateShoppingCart API
 type CreateShoppingCartResponse struct {
 	state         protoimpl.MessageState
@@ -217,20 +286,18 @@ func (x *GetShoppingCartByIDResponse) GetCart() *ShoppingCart {
 	return nil
 }
 
-// Request parameters for the AddItemToShoppingCart API
-type AddItemToShoppingCartRequest struct {
+// Request parameters for the WatchShoppingCart API
+type WatchShoppingCartRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The ID of the cart that the item should be added too
+	// REQUIRED. The UUID ID of the cart to be watched
 	CartId string `protobuf:"bytes,1,opt,name=cart_id,json=cartId,proto3" json:"cart_id,omitempty"`
-	// The item to be added to the shopping cart
-	Item *CartItem `protobuf:"bytes,2,opt,name=item,proto3" json:"item,omitempty"`
 }
 
-func (x *AddItemToShoppingCartRequest) Reset() {
-	*x = AddItemToShoppingCartRequest{}
+func (x *WatchShoppingCartRequest) Reset() {
+	*x = WatchShoppingCartRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_mikebway_cart_cart_api_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -238,13 +305,13 @@ func (x *AddItemToShoppingCartRequest) Reset() {
 	}
 }
 
-func (x *AddItemToShoppingCartRequest) String() string {
+func (x *WatchShoppingCartRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddItemToShoppingCartRequest) ProtoMessage() {}
+func (*WatchShoppingCartRequest) ProtoMessage() {}
 
-func (x *AddItemToShoppingCartRequest) ProtoReflect() protoreflect.Message {
+func (x *WatchShoppingCartRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_mikebway_cart_cart_api_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -256,37 +323,34 @@ func (x *AddItemToShoppingCartRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddItemToShoppingCartRequest.ProtoReflect.Descriptor instead.
-func (*AddItemToShoppingCartRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use WatchShoppingCartRequest.ProtoReflect.Descriptor instead.
+func (*WatchShoppingCartRequest) Descriptor() ([]byte, []int) {
 	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *AddItemToShoppingCartRequest) GetCartId() string {
+func (x *WatchShoppingCartRequest) GetCartId() string {
 	if x != nil {
 		return x.CartId
 	}
 	return ""
 }
 
-func (x *AddItemToShoppingCartRequest) GetItem() *CartItem {
-	if x != nil {
-		return x.Item
-	}
-	return nil
-}
-
-// Response parameters for the AddItemToShoppingCart API
-type AddItemToShoppingCartResponse struct {
+// Response stream messages for the WatchShoppingCart API.
+//
+// The first message carries the cart as it stands when the watch begins. Another follows every time that the cart,
+// its items, or its delivery address change. The stream ends after the message that shows the cart to have left the
+// SCS_OPEN status.
+type WatchShoppingCartResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The populated cart requested
+	// The cart as it now stands
 	Cart *ShoppingCart `protobuf:"bytes,1,opt,name=cart,proto3" json:"cart,omitempty"`
 }
 
-func (x *AddItemToShoppingCartResponse) Reset() {
-	*x = AddItemToShoppingCartResponse{}
+func (x *WatchShoppingCartResponse) Reset() {
+	*x = WatchShoppingCartResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_mikebway_cart_cart_api_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -294,13 +358,13 @@ func (x *AddItemToShoppingCartResponse) Reset() {
 	}
 }
 
-func (x *AddItemToShoppingCartResponse) String() string {
+func (x *WatchShoppingCartResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddItemToShoppingCartResponse) ProtoMessage() {}
+func (*WatchShoppingCartResponse) ProtoMessage() {}
 
-func (x *AddItemToShoppingCartResponse) ProtoReflect() protoreflect.Message {
+func (x *WatchShoppingCartResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_mikebway_cart_cart_api_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -312,32 +376,54 @@ func (x *AddItemToShoppingCartResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddItemToShoppingCartResponse.ProtoReflect.Descriptor instead.
-func (*AddItemToShoppingCartResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use WatchShoppingCartResponse.ProtoReflect.Descriptor instead.
+func (*WatchShoppingCartResponse) Descriptor() ([]byte, []int) {
 	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *AddItemToShoppingCartResponse) GetCart() *ShoppingCart {
+func (x *WatchShoppingCartResponse) GetCart() *ShoppingCart {
 	if x != nil {
 		return x.Cart
 	}
 	return nil
 }
 
-// Request parameters for the RemoveItemFromShoppingCart API
-type RemoveItemFromShoppingCartRequest struct {
+// Request parameters for the ListShoppingCarts API
+//
+// See https://cloud.google.com/apis/design/design_patterns
+type ListShoppingCartsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The ID of the cart that the item should be removed from
-	CartId string `protobuf:"bytes,1,opt,name=cart_id,json=cartId,proto3" json:"cart_id,omitempty"`
-	// The item to be removed from the shopping cart
-	ItemId string `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
-}
-
-func (x *RemoveItemFromShoppingCartRequest) Reset() {
-	*x = RemoveItemFromShoppingCartRequest{}
+	// REQUIRED. The ID of the shopper (i.e. the Person.id) whose carts are to be listed.
+	ShopperId string `protobuf:"bytes,1,opt,name=shopper_id,json=shopperId,proto3" json:"shopper_id,omitempty"`
+	// OPTIONAL. The statuses of the carts to be returned, e.g. just SCS_OPEN to find a shopper's open carts.
+	// Carts of any status are returned if none are given.
+	Statuses []ShoppingCartStatus `protobuf:"varint,2,rep,packed,name=statuses,proto3,enum=mikebway.cart.ShoppingCartStatus" json:"statuses,omitempty"`
+	// OPTIONAL. The earliest cart creation time for which carts are to be returned.
+	// Creation times may be equal to or greater than this.
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// OPTIONAL. The cart creation time after which carts are not to be returned.
+	// Creation times must be less than this.
+	EndTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// REQUIRED. The maximum number of result to be returned in a single response.
+	//
+	// Must be between 1 and 100.
+	PageSize int32 `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// OPTIONAL. Required for second and subsequent requests, a marker token used to identify where the
+	// next page of results should begin within the overall result set.
+	//
+	// All other parameters should be the same as in previous requests otherwise the results shall
+	// be no deterministic.
+	PageToken string `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// OPTIONAL. If true, the carts are returned complete with their items and delivery address. Otherwise only
+	// the top level cart fields are populated.
+	IncludeItems bool `protobuf:"varint,7,opt,name=include_items,json=includeItems,proto3" json:"include_items,omitempty"`
+}
+
+func (x *ListShoppingCartsRequest) Reset() {
+	*x = ListShoppingCartsRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_mikebway_cart_cart_api_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -345,13 +431,13 @@ func (x *RemoveItemFromShoppingCartRequest) Reset() {
 	}
 }
 
-func (x *RemoveItemFromShoppingCartRequest) String() string {
+func (x *ListShoppingCartsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveItemFromShoppingCartRequest) ProtoMessage() {}
+func (*ListShoppingCartsRequest) ProtoMessage() {}
 
-func (x *RemoveItemFromShoppingCartRequest) ProtoReflect() protoreflect.Message {
+func (x *ListShoppingCartsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_mikebway_cart_cart_api_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -363,37 +449,78 @@ func (x *RemoveItemFromShoppingCartRequest) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveItemFromShoppingCartRequest.ProtoReflect.Descriptor instead.
-func (*RemoveItemFromShoppingCartRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListShoppingCartsRequest.ProtoReflect.Descriptor instead.
+func (*ListShoppingCartsRequest) Descriptor() ([]byte, []int) {
 	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *RemoveItemFromShoppingCartRequest) GetCartId() string {
+func (x *ListShoppingCartsRequest) GetShopperId() string {
 	if x != nil {
-		return x.CartId
+		return x.ShopperId
 	}
 	return ""
 }
 
-func (x *RemoveItemFromShoppingCartRequest) GetItemId() string {
+func (x *ListShoppingCartsRequest) GetStatuses() []ShoppingCartStatus {
 	if x != nil {
-		return x.ItemId
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListShoppingCartsRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *ListShoppingCartsRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *ListShoppingCartsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListShoppingCartsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
 	}
 	return ""
 }
 
-// Response parameters for the RemoveItemFromShoppingCart API
-type RemoveItemFromShoppingCartResponse struct {
+func (x *ListShoppingCartsRequest) GetIncludeItems() bool {
+	if x != nil {
+		return x.IncludeItems
+	}
+	return false
+}
+
+// Response parameters for the ListShoppingCarts API.
+type ListShoppingCartsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The (slightly) depopulated cart
-	Cart *ShoppingCart `protobuf:"bytes,1,opt,name=cart,proto3" json:"cart,omitempty"`
+	// OPTIONAL. May not be present if the result set was empty.
+	//
+	// The list of carts in the current page of results, oldest first.
+	Carts []*ShoppingCart `protobuf:"bytes,1,rep,name=carts,proto3" json:"carts,omitempty"`
+	// OPTIONAL. May not be present if the result set was empty
+	//
+	// The token to be included to request the next page of results.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 }
 
-func (x *RemoveItemFromShoppingCartResponse) Reset() {
-	*x = RemoveItemFromShoppingCartResponse{}
+func (x *ListShoppingCartsResponse) Reset() {
+	*x = ListShoppingCartsResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_mikebway_cart_cart_api_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -401,13 +528,13 @@ func (x *RemoveItemFromShoppingCartResponse) Reset() {
 	}
 }
 
-func (x *RemoveItemFromShoppingCartResponse) String() string {
+func (x *ListShoppingCartsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveItemFromShoppingCartResponse) ProtoMessage() {}
+func (*ListShoppingCartsResponse) ProtoMessage() {}
 
-func (x *RemoveItemFromShoppingCartResponse) ProtoReflect() protoreflect.Message {
+func (x *ListShoppingCartsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_mikebway_cart_cart_api_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -419,32 +546,46 @@ func (x *RemoveItemFromShoppingCartResponse) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveItemFromShoppingCartResponse.ProtoReflect.Descriptor instead.
-func (*RemoveItemFromShoppingCartResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListShoppingCartsResponse.ProtoReflect.Descriptor instead.
+func (*ListShoppingCartsResponse) Descriptor() ([]byte, []int) {
 	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *RemoveItemFromShoppingCartResponse) GetCart() *ShoppingCart {
+func (x *ListShoppingCartsResponse) GetCarts() []*ShoppingCart {
 	if x != nil {
-		return x.Cart
+		return x.Carts
 	}
 	return nil
 }
 
-// Request parameters for the SetDeliveryAddress API
-type SetDeliveryAddressRequest struct {
+func (x *ListShoppingCartsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Request parameters for the AddItemToShoppingCart API
+type AddItemToShoppingCartRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The ID of the cart that the address should be added too
+	// The ID of the cart that the item should be added too
 	CartId string `protobuf:"bytes,1,opt,name=cart_id,json=cartId,proto3" json:"cart_id,omitempty"`
-	// The address to be set into the order
-	DeliveryAddress *types.PostalAddress `protobuf:"bytes,2,opt,name=delivery_address,json=deliveryAddress,proto3" json:"delivery_address,omitempty"`
+	// The item to be added to the shopping cart
+	Item *CartItem `protobuf:"bytes,2,opt,name=item,proto3" json:"item,omitempty"`
+	// Optional. The etag of the cart as last seen by the caller. If provided, the request
+	// will be rejected with an ABORTED status if the cart has since been modified.
+	Etag string `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	// Optional. A unique ID chosen by the caller for this request. If a request with the same ID has
+	// already been applied to the cart, the response to that request is returned again rather than
+	// the change being applied twice. Request IDs are remembered for 24 hours.
+	RequestId string `protobuf:"bytes,4,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
 }
 
-func (x *SetDeliveryAddressRequest) Reset() {
-	*x = SetDeliveryAddressRequest{}
+func (x *AddItemToShoppingCartRequest) Reset() {
+	*x = AddItemToShoppingCartRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_mikebway_cart_cart_api_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -452,13 +593,13 @@ func (x *SetDeliveryAddressRequest) Reset() {
 	}
 }
 
-func (x *SetDeliveryAddressRequest) String() string {
+func (x *AddItemToShoppingCartRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetDeliveryAddressRequest) ProtoMessage() {}
+func (*AddItemToShoppingCartRequest) ProtoMessage() {}
 
-func (x *SetDeliveryAddressRequest) ProtoReflect() protoreflect.Message {
+func (x *AddItemToShoppingCartRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_mikebway_cart_cart_api_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -470,27 +611,41 @@ func (x *SetDeliveryAddressRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetDeliveryAddressRequest.ProtoReflect.Descriptor instead.
-func (*SetDeliveryAddressRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AddItemToShoppingCartRequest.ProtoReflect.Descriptor instead.
+func (*AddItemToShoppingCartRequest) Descriptor() ([]byte, []int) {
 	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *SetDeliveryAddressRequest) GetCartId() string {
+func (x *AddItemToShoppingCartRequest) GetCartId() string {
 	if x != nil {
 		return x.CartId
 	}
 	return ""
 }
 
-func (x *SetDeliveryAddressRequest) GetDeliveryAddress() *types.PostalAddress {
+func (x *AddItemToShoppingCartRequest) GetItem() *CartItem {
 	if x != nil {
-		return x.DeliveryAddress
+		return x.Item
 	}
 	return nil
 }
 
-// Response parameters for the SetDeliveryAddress API
-type SetDeliveryAddressResponse struct {
+func (x *AddItemToShoppingCartRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *AddItemToShoppingCartRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+// Response parameters for the AddItemToShoppingCart API
+type AddItemToShoppingCartResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -499,8 +654,8 @@ type SetDeliveryAddressResponse struct {
 	Cart *ShoppingCart `protobuf:"bytes,1,opt,name=cart,proto3" json:"cart,omitempty"`
 }
 
-func (x *SetDeliveryAddressResponse) Reset() {
-	*x = SetDeliveryAddressResponse{}
+func (x *AddItemToShoppingCartResponse) Reset() {
+	*x = AddItemToShoppingCartResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_mikebway_cart_cart_api_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -508,13 +663,13 @@ func (x *SetDeliveryAddressResponse) Reset() {
 	}
 }
 
-func (x *SetDeliveryAddressResponse) String() string {
+func (x *AddItemToShoppingCartResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetDeliveryAddressResponse) ProtoMessage() {}
+func (*AddItemToShoppingCartResponse) ProtoMessage() {}
 
-func (x *SetDeliveryAddressResponse) ProtoReflect() protoreflect.Message {
+func (x *AddItemToShoppingCartResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_mikebway_cart_cart_api_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -526,29 +681,39 @@ func (x *SetDeliveryAddressResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetDeliveryAddressResponse.ProtoReflect.Descriptor instead.
-func (*SetDeliveryAddressResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use AddItemToShoppingCartResponse.ProtoReflect.Descriptor instead.
+func (*AddItemToShoppingCartResponse) Descriptor() ([]byte, []int) {
 	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *SetDeliveryAddressResponse) GetCart() *ShoppingCart {
+func (x *AddItemToShoppingCartResponse) GetCart() *ShoppingCart {
 	if x != nil {
 		return x.Cart
 	}
 	return nil
 }
 
-// Request parameters for the CheckoutShoppingCart API
-type CheckoutShoppingCartRequest struct {
+// Request parameters for the RemoveItemFromShoppingCart API
+type RemoveItemFromShoppingCartRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
+	// The ID of the cart that the item should be removed from
 	CartId string `protobuf:"bytes,1,opt,name=cart_id,json=cartId,proto3" json:"cart_id,omitempty"`
+	// The item to be removed from the shopping cart
+	ItemId string `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	// Optional. The etag of the cart as last seen by the caller. If provided, the request
+	// will be rejected with an ABORTED status if the cart has since been modified.
+	Etag string `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	// Optional. A unique ID chosen by the caller for this request. If a request with the same ID has
+	// already been applied to the cart, the response to that request is returned again rather than
+	// the change being applied twice. Request IDs are remembered for 24 hours.
+	RequestId string `protobuf:"bytes,4,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
 }
 
-func (x *CheckoutShoppingCartRequest) Reset() {
-	*x = CheckoutShoppingCartRequest{}
+func (x *RemoveItemFromShoppingCartRequest) Reset() {
+	*x = RemoveItemFromShoppingCartRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_mikebway_cart_cart_api_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -556,13 +721,13 @@ func (x *CheckoutShoppingCartRequest) Reset() {
 	}
 }
 
-func (x *CheckoutShoppingCartRequest) String() string {
+func (x *RemoveItemFromShoppingCartRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckoutShoppingCartRequest) ProtoMessage() {}
+func (*RemoveItemFromShoppingCartRequest) ProtoMessage() {}
 
-func (x *CheckoutShoppingCartRequest) ProtoReflect() protoreflect.Message {
+func (x *RemoveItemFromShoppingCartRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_mikebway_cart_cart_api_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -574,29 +739,51 @@ func (x *CheckoutShoppingCartRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckoutShoppingCartRequest.ProtoReflect.Descriptor instead.
-func (*CheckoutShoppingCartRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RemoveItemFromShoppingCartRequest.ProtoReflect.Descriptor instead.
+func (*RemoveItemFromShoppingCartRequest) Descriptor() ([]byte, []int) {
 	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *CheckoutShoppingCartRequest) GetCartId() string {
+func (x *RemoveItemFromShoppingCartRequest) GetCartId() string {
 	if x != nil {
 		return x.CartId
 	}
 	return ""
 }
 
-// Response parameters for the CheckoutShoppingCart API.
-type CheckoutShoppingCartResponse struct {
+func (x *RemoveItemFromShoppingCartRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *RemoveItemFromShoppingCartRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *RemoveItemFromShoppingCartRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+// Response parameters for the RemoveItemFromShoppingCart API
+type RemoveItemFromShoppingCartResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
+	// The (slightly) depopulated cart
 	Cart *ShoppingCart `protobuf:"bytes,1,opt,name=cart,proto3" json:"cart,omitempty"`
 }
 
-func (x *CheckoutShoppingCartResponse) Reset() {
-	*x = CheckoutShoppingCartResponse{}
+func (x *RemoveItemFromShoppingCartResponse) Reset() {
+	*x = RemoveItemFromShoppingCartResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_mikebway_cart_cart_api_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -604,13 +791,13 @@ func (x *CheckoutShoppingCartResponse) Reset() {
 	}
 }
 
-func (x *CheckoutShoppingCartResponse) String() string {
+func (x *RemoveItemFromShoppingCartResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckoutShoppingCartResponse) ProtoMessage() {}
+func (*RemoveItemFromShoppingCartResponse) ProtoMessage() {}
 
-func (x *CheckoutShoppingCartResponse) ProtoReflect() protoreflect.Message {
+func (x *RemoveItemFromShoppingCartResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_mikebway_cart_cart_api_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -622,29 +809,43 @@ func (x *CheckoutShoppingCartResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckoutShoppingCartResponse.ProtoReflect.Descriptor instead.
-func (*CheckoutShoppingCartResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RemoveItemFromShoppingCartResponse.ProtoReflect.Descriptor instead.
+func (*RemoveItemFromShoppingCartResponse) Descriptor() ([]byte, []int) {
 	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *CheckoutShoppingCartResponse) GetCart() *ShoppingCart {
+func (x *RemoveItemFromShoppingCartResponse) GetCart() *ShoppingCart {
 	if x != nil {
 		return x.Cart
 	}
 	return nil
 }
 
-// Request parameters for the AbandonShoppingCart API
-type AbandonShoppingCartRequest struct {
+// Request parameters for the UpdateCartItem API
+type UpdateCartItemRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
+	// The ID of the cart that contains the item to be updated
 	CartId string `protobuf:"bytes,1,opt,name=cart_id,json=cartId,proto3" json:"cart_id,omitempty"`
-}
-
-func (x *AbandonShoppingCartRequest) Reset() {
-	*x = AbandonShoppingCartRequest{}
+	// The item values to be applied. The item ID identifies the cart item to be updated;
+	// only the fields named in the update mask are taken from this structure.
+	Item *CartItem `protobuf:"bytes,2,opt,name=item,proto3" json:"item,omitempty"`
+	// The item fields to be updated. At present, only "quantity" may be updated. If the mask
+	// is not provided, it is assumed to contain "quantity".
+	UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// Optional. The etag of the cart as last seen by the caller. If provided, the request
+	// will be rejected with an ABORTED status if the cart has since been modified.
+	Etag string `protobuf:"bytes,4,opt,name=etag,proto3" json:"etag,omitempty"`
+	// Optional. A unique ID chosen by the caller for this request. If a request with the same ID has
+	// already been applied to the cart, the response to that request is returned again rather than
+	// the change being applied twice. Request IDs are remembered for 24 hours.
+	RequestId string `protobuf:"bytes,5,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+}
+
+func (x *UpdateCartItemRequest) Reset() {
+	*x = UpdateCartItemRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_mikebway_cart_cart_api_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -652,13 +853,13 @@ func (x *AbandonShoppingCartRequest) Reset() {
 	}
 }
 
-func (x *AbandonShoppingCartRequest) String() string {
+func (x *UpdateCartItemRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AbandonShoppingCartRequest) ProtoMessage() {}
+func (*UpdateCartItemRequest) ProtoMessage() {}
 
-func (x *AbandonShoppingCartRequest) ProtoReflect() protoreflect.Message {
+func (x *UpdateCartItemRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_mikebway_cart_cart_api_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -670,29 +871,58 @@ func (x *AbandonShoppingCartRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AbandonShoppingCartRequest.ProtoReflect.Descriptor instead.
-func (*AbandonShoppingCartRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateCartItemRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCartItemRequest) Descriptor() ([]byte, []int) {
 	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *AbandonShoppingCartRequest) GetCartId() string {
+func (x *UpdateCartItemRequest) GetCartId() string {
 	if x != nil {
 		return x.CartId
 	}
 	return ""
 }
 
-// Response parameters for the AbandonShoppingCart API.
-type AbandonShoppingCartResponse struct {
+func (x *UpdateCartItemRequest) GetItem() *CartItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *UpdateCartItemRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateCartItemRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *UpdateCartItemRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+// Response parameters for the UpdateCartItem API
+type UpdateCartItemResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
+	// The cart including the updated item
 	Cart *ShoppingCart `protobuf:"bytes,1,opt,name=cart,proto3" json:"cart,omitempty"`
 }
 
-func (x *AbandonShoppingCartResponse) Reset() {
-	*x = AbandonShoppingCartResponse{}
+func (x *UpdateCartItemResponse) Reset() {
+	*x = UpdateCartItemResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_mikebway_cart_cart_api_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -700,251 +930,2759 @@ func (x *AbandonShoppingCartResponse) Reset() {
 	}
 }
 
-func (x *AbandonShoppingCartResponse) String() string {
+func (x *UpdateCartItemResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AbandonShoppingCartResponse) ProtoMessage() {}
+func (*UpdateCartItemResponse) ProtoMessage() {}
 
-func (x *AbandonShoppingCartResponse) ProtoReflect() protoreflect.Message {
+func (x *UpdateCartItemResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_mikebway_cart_cart_api_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
 		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use AbandonShoppingCartResponse.ProtoReflect.Descriptor instead.
-func (*AbandonShoppingCartResponse) Descriptor() ([]byte, []int) {
-	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{13}
-}
-
-func (x *AbandonShoppingCartResponse) GetCart() *ShoppingCart {
-	if x != nil {
-		return x.Cart
-	}
-	return nil
-}
-
-var File_mikebway_cart_cart_api_proto protoreflect.FileDescriptor
-
-var file_mikebway_cart_cart_api_proto_rawDesc = []byte{
-	0x0a, 0x1c, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2f, 0x63, 0x61, 0x72, 0x74, 0x2f,
-	0x63, 0x61, 0x72, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d,
-	0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x1a, 0x18, 0x6d,
-	0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2f, 0x63, 0x61, 0x72, 0x74, 0x2f, 0x63, 0x61, 0x72,
-	0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x18, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61,
-	0x79, 0x2f, 0x63, 0x61, 0x72, 0x74, 0x2f, 0x69, 0x74, 0x65, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x1a, 0x1c, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2f, 0x74, 0x79, 0x70, 0x65,
-	0x73, 0x2f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
-	0x1b, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f,
-	0x70, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x4d, 0x0a, 0x19,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61,
-	0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x30, 0x0a, 0x07, 0x73, 0x68, 0x6f,
-	0x70, 0x70, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x69, 0x6b,
-	0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x50, 0x65, 0x72, 0x73,
-	0x6f, 0x6e, 0x52, 0x07, 0x73, 0x68, 0x6f, 0x70, 0x70, 0x65, 0x72, 0x22, 0x4d, 0x0a, 0x1a, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72,
-	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x04, 0x63, 0x61, 0x72,
-	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77,
-	0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67,
-	0x43, 0x61, 0x72, 0x74, 0x52, 0x04, 0x63, 0x61, 0x72, 0x74, 0x22, 0x35, 0x0a, 0x1a, 0x47, 0x65,
-	0x74, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x42, 0x79, 0x49,
-	0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x61, 0x72, 0x74,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x61, 0x72, 0x74, 0x49,
-	0x64, 0x22, 0x4e, 0x0a, 0x1b, 0x47, 0x65, 0x74, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67,
-	0x43, 0x61, 0x72, 0x74, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x2f, 0x0a, 0x04, 0x63, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
-	0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x53,
-	0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x04, 0x63, 0x61, 0x72,
-	0x74, 0x22, 0x64, 0x0a, 0x1c, 0x41, 0x64, 0x64, 0x49, 0x74, 0x65, 0x6d, 0x54, 0x6f, 0x53, 0x68,
-	0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x61, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x63, 0x61, 0x72, 0x74, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x04, 0x69, 0x74,
-	0x65, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62,
-	0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x43, 0x61, 0x72, 0x74, 0x49, 0x74, 0x65,
-	0x6d, 0x52, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x22, 0x50, 0x0a, 0x1d, 0x41, 0x64, 0x64, 0x49, 0x74,
-	0x65, 0x6d, 0x54, 0x6f, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x04, 0x63, 0x61, 0x72, 0x74,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61,
-	0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43,
-	0x61, 0x72, 0x74, 0x52, 0x04, 0x63, 0x61, 0x72, 0x74, 0x22, 0x55, 0x0a, 0x21, 0x52, 0x65, 0x6d,
-	0x6f, 0x76, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x68, 0x6f, 0x70, 0x70,
-	0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17,
-	0x0a, 0x07, 0x63, 0x61, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x63, 0x61, 0x72, 0x74, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x69, 0x74, 0x65, 0x6d, 0x5f,
-	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x69, 0x74, 0x65, 0x6d, 0x49, 0x64,
-	0x22, 0x55, 0x0a, 0x22, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x46, 0x72,
-	0x6f, 0x6d, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x04, 0x63, 0x61, 0x72, 0x74, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e,
-	0x63, 0x61, 0x72, 0x74, 0x2e, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72,
-	0x74, 0x52, 0x04, 0x63, 0x61, 0x72, 0x74, 0x22, 0x7e, 0x0a, 0x19, 0x53, 0x65, 0x74, 0x44, 0x65,
-	0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x61, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x61, 0x72, 0x74, 0x49, 0x64, 0x12, 0x48, 0x0a,
-	0x10, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
-	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77,
-	0x61, 0x79, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x61, 0x6c, 0x41,
-	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x0f, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79,
-	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x4d, 0x0a, 0x1a, 0x53, 0x65, 0x74, 0x44, 0x65,
-	0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x04, 0x63, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63,
-	0x61, 0x72, 0x74, 0x2e, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74,
-	0x52, 0x04, 0x63, 0x61, 0x72, 0x74, 0x22, 0x36, 0x0a, 0x1b, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x6f,
-	0x75, 0x74, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x61, 0x72, 0x74, 0x5f, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x61, 0x72, 0x74, 0x49, 0x64, 0x22, 0x4f,
-	0x0a, 0x1c, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x6f, 0x75, 0x74, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69,
-	0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f,
-	0x0a, 0x04, 0x63, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6d,
-	0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x53, 0x68, 0x6f,
-	0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x04, 0x63, 0x61, 0x72, 0x74, 0x22,
-	0x35, 0x0a, 0x1a, 0x41, 0x62, 0x61, 0x6e, 0x64, 0x6f, 0x6e, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69,
-	0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a,
-	0x07, 0x63, 0x61, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x63, 0x61, 0x72, 0x74, 0x49, 0x64, 0x22, 0x4e, 0x0a, 0x1b, 0x41, 0x62, 0x61, 0x6e, 0x64, 0x6f,
-	0x6e, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x04, 0x63, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63,
-	0x61, 0x72, 0x74, 0x2e, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74,
-	0x52, 0x04, 0x63, 0x61, 0x72, 0x74, 0x32, 0xb2, 0x06, 0x0a, 0x07, 0x43, 0x61, 0x72, 0x74, 0x41,
-	0x50, 0x49, 0x12, 0x6b, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x68, 0x6f, 0x70,
-	0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x12, 0x28, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62,
-	0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53,
-	0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x29, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61,
-	0x72, 0x74, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e,
-	0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x6e, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61,
-	0x72, 0x74, 0x42, 0x79, 0x49, 0x44, 0x12, 0x29, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61,
-	0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69,
-	0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x2a, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72,
-	0x74, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72,
-	0x74, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x74, 0x0a, 0x15, 0x41, 0x64, 0x64, 0x49, 0x74, 0x65, 0x6d, 0x54, 0x6f, 0x53, 0x68, 0x6f, 0x70,
-	0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x12, 0x2b, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62,
-	0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x41, 0x64, 0x64, 0x49, 0x74, 0x65, 0x6d,
-	0x54, 0x6f, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79,
-	0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x41, 0x64, 0x64, 0x49, 0x74, 0x65, 0x6d, 0x54, 0x6f, 0x53,
-	0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x83, 0x01, 0x0a, 0x1a, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65,
-	0x49, 0x74, 0x65, 0x6d, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67,
-	0x43, 0x61, 0x72, 0x74, 0x12, 0x30, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e,
-	0x63, 0x61, 0x72, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x46,
-	0x72, 0x6f, 0x6d, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x31, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61,
-	0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x49, 0x74, 0x65,
-	0x6d, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72,
-	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x6b, 0x0a, 0x12, 0x53,
-	0x65, 0x74, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
-	0x73, 0x12, 0x28, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72,
-	0x74, 0x2e, 0x53, 0x65, 0x74, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x41, 0x64, 0x64,
-	0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x6d, 0x69,
-	0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x53, 0x65, 0x74, 0x44,
-	0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x71, 0x0a, 0x14, 0x43, 0x68, 0x65, 0x63,
-	0x6b, 0x6f, 0x75, 0x74, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74,
-	0x12, 0x2a, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74,
-	0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x6f, 0x75, 0x74, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e,
-	0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x6d,
-	0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x43, 0x68, 0x65,
-	0x63, 0x6b, 0x6f, 0x75, 0x74, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72,
-	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x6e, 0x0a, 0x13, 0x41,
-	0x62, 0x61, 0x6e, 0x64, 0x6f, 0x6e, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61,
-	0x72, 0x74, 0x12, 0x29, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61,
-	0x72, 0x74, 0x2e, 0x41, 0x62, 0x61, 0x6e, 0x64, 0x6f, 0x6e, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69,
-	0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e,
-	0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x41, 0x62,
-	0x61, 0x6e, 0x64, 0x6f, 0x6e, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72,
-	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x2b, 0x5a, 0x29, 0x67,
-	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77,
-	0x61, 0x79, 0x2f, 0x70, 0x6f, 0x63, 0x2d, 0x67, 0x63, 0x70, 0x2d, 0x65, 0x63, 0x6f, 0x6d, 0x6d,
-	0x2f, 0x70, 0x62, 0x2f, 0x63, 0x61, 0x72, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-}
-
-var (
-	file_mikebway_cart_cart_api_proto_rawDescOnce sync.Once
-	file_mikebway_cart_cart_api_proto_rawDescData = file_mikebway_cart_cart_api_proto_rawDesc
-)
-
-func file_mikebway_cart_cart_api_proto_rawDescGZIP() []byte {
-	file_mikebway_cart_cart_api_proto_rawDescOnce.Do(func() {
-		file_mikebway_cart_cart_api_proto_rawDescData = protoimpl.X.CompressGZIP(file_mikebway_cart_cart_api_proto_rawDescData)
-	})
-	return file_mikebway_cart_cart_api_proto_rawDescData
-}
-
-var file_mikebway_cart_cart_api_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
-var file_mikebway_cart_cart_api_proto_goTypes = []interface{}{
-	(*CreateShoppingCartRequest)(nil),          // 0: mikebway.cart.CreateShoppingCartRequest
-	(*CreateShoppingCartResponse)(nil),         // 1: mikebway.cart.CreateShoppingCartResponse
-	(*GetShoppingCartByIDRequest)(nil),         // 2: mikebway.cart.GetShoppingCartByIDRequest
-	(*GetShoppingCartByIDResponse)(nil),        // 3: mikebway.cart.GetShoppingCartByIDResponse
-	(*AddItemToShoppingCartRequest)(nil),       // 4: mikebway.cart.AddItemToShoppingCartRequest
-	(*AddItemToShoppingCartResponse)(nil),      // 5: mikebway.cart.AddItemToShoppingCartResponse
-	(*RemoveItemFromShoppingCartRequest)(nil),  // 6: mikebway.cart.RemoveItemFromShoppingCartRequest
-	(*RemoveItemFromShoppingCartResponse)(nil), // 7: mikebway.cart.RemoveItemFromShoppingCartResponse
-	(*SetDeliveryAddressRequest)(nil),          // 8: mikebway.cart.SetDeliveryAddressRequest
-	(*SetDeliveryAddressResponse)(nil),         // 9: mikebway.cart.SetDeliveryAddressResponse
-	(*CheckoutShoppingCartRequest)(nil),        // 10: mikebway.cart.CheckoutShoppingCartRequest
-	(*CheckoutShoppingCartResponse)(nil),       // 11: mikebway.cart.CheckoutShoppingCartResponse
-	(*AbandonShoppingCartRequest)(nil),         // 12: mikebway.cart.AbandonShoppingCartRequest
-	(*AbandonShoppingCartResponse)(nil),        // 13: mikebway.cart.AbandonShoppingCartResponse
-	(*types.Person)(nil),                       // 14: mikebway.types.Person
-	(*ShoppingCart)(nil),                       // 15: mikebway.cart.ShoppingCart
-	(*CartItem)(nil),                           // 16: mikebway.cart.CartItem
-	(*types.PostalAddress)(nil),                // 17: mikebway.types.PostalAddress
-}
-var file_mikebway_cart_cart_api_proto_depIdxs = []int32{
-	14, // 0: mikebway.cart.CreateShoppingCartRequest.shopper:type_name -> mikebway.types.Person
-	15, // 1: mikebway.cart.CreateShoppingCartResponse.cart:type_name -> mikebway.cart.ShoppingCart
-	15, // 2: mikebway.cart.GetShoppingCartByIDResponse.cart:type_name -> mikebway.cart.ShoppingCart
-	16, // 3: mikebway.cart.AddItemToShoppingCartRequest.item:type_name -> mikebway.cart.CartItem
-	15, // 4: mikebway.cart.AddItemToShoppingCartResponse.cart:type_name -> mikebway.cart.ShoppingCart
-	15, // 5: mikebway.cart.RemoveItemFromShoppingCartResponse.cart:type_name -> mikebway.cart.ShoppingCart
-	17, // 6: mikebway.cart.SetDeliveryAddressRequest.delivery_address:type_name -> mikebway.types.PostalAddress
-	15, // 7: mikebway.cart.SetDeliveryAddressResponse.cart:type_name -> mikebway.cart.ShoppingCart
-	15, // 8: mikebway.cart.CheckoutShoppingCartResponse.cart:type_name -> mikebway.cart.ShoppingCart
-	15, // 9: mikebway.cart.AbandonShoppingCartResponse.cart:type_name -> mikebway.cart.ShoppingCart
-	0,  // 10: mikebway.cart.CartAPI.CreateShoppingCart:input_type -> mikebway.cart.CreateShoppingCartRequest
-	2,  // 11: mikebway.cart.CartAPI.GetShoppingCartByID:input_type -> mikebway.cart.GetShoppingCartByIDRequest
-	4,  // 12: mikebway.cart.CartAPI.AddItemToShoppingCart:input_type -> mikebway.cart.AddItemToShoppingCartRequest
-	6,  // 13: mikebway.cart.CartAPI.RemoveItemFromShoppingCart:input_type -> mikebway.cart.RemoveItemFromShoppingCartRequest
-	8,  // 14: mikebway.cart.CartAPI.SetDeliveryAddress:input_type -> mikebway.cart.SetDeliveryAddressRequest
-	10, // 15: mikebway.cart.CartAPI.CheckoutShoppingCart:input_type -> mikebway.cart.CheckoutShoppingCartRequest
-	12, // 16: mikebway.cart.CartAPI.AbandonShoppingCart:input_type -> mikebway.cart.AbandonShoppingCartRequest
-	1,  // 17: mikebway.cart.CartAPI.CreateShoppingCart:output_type -> mikebway.cart.CreateShoppingCartResponse
-	3,  // 18: mikebway.cart.CartAPI.GetShoppingCartByID:output_type -> mikebway.cart.GetShoppingCartByIDResponse
-	5,  // 19: mikebway.cart.CartAPI.AddItemToShoppingCart:output_type -> mikebway.cart.AddItemToShoppingCartResponse
-	7,  // 20: mikebway.cart.CartAPI.RemoveItemFromShoppingCart:output_type -> mikebway.cart.RemoveItemFromShoppingCartResponse
-	9,  // 21: mikebway.cart.CartAPI.SetDeliveryAddress:output_type -> mikebway.cart.SetDeliveryAddressResponse
-	11, // 22: mikebway.cart.CartAPI.CheckoutShoppingCart:output_type -> mikebway.cart.CheckoutShoppingCartResponse
-	13, // 23: mikebway.cart.CartAPI.AbandonShoppingCart:output_type -> mikebway.cart.AbandonShoppingCartResponse
-	17, // [17:24] is the sub-list for method output_type
-	10, // [10:17] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
-}
-
-func init() { file_mikebway_cart_cart_api_proto_init() }
-func file_mikebway_cart_cart_api_proto_init() {
-	if File_mikebway_cart_cart_api_proto != nil {
-		return
-	}
-	file_mikebway_cart_cart_proto_init()
-	file_mikebway_cart_item_proto_init()
-	if !protoimpl.UnsafeEnabled {
-		file_mikebway_cart_cart_api_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateShoppingCartRequest); i {
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCartItemResponse.ProtoReflect.Descriptor instead.
+func (*UpdateCartItemResponse) Descriptor() ([]byte, []int) {
+	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdateCartItemResponse) GetCart() *ShoppingCart {
+	if x != nil {
+		return x.Cart
+	}
+	return nil
+}
+
+// Request parameters for the SetDeliveryAddress API
+type SetDeliveryAddressRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The ID of the cart that the address should be added too
+	CartId string `protobuf:"bytes,1,opt,name=cart_id,json=cartId,proto3" json:"cart_id,omitempty"`
+	// The address to be set into the order
+	DeliveryAddress *types.PostalAddress `protobuf:"bytes,2,opt,name=delivery_address,json=deliveryAddress,proto3" json:"delivery_address,omitempty"`
+	// Optional. The etag of the cart as last seen by the caller. If provided, the request
+	// will be rejected with an ABORTED status if the cart has since been modified.
+	Etag string `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	// Optional. A unique ID chosen by the caller for this request. If a request with the same ID has
+	// already been applied to the cart, the response to that request is returned again rather than
+	// the change being applied twice. Request IDs are remembered for 24 hours.
+	RequestId string `protobuf:"bytes,4,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+}
+
+func (x *SetDeliveryAddressRequest) Reset() {
+	*x = SetDeliveryAddressRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_cart_cart_api_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetDeliveryAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDeliveryAddressRequest) ProtoMessage() {}
+
+func (x *SetDeliveryAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_cart_cart_api_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDeliveryAddressRequest.ProtoReflect.Descriptor instead.
+func (*SetDeliveryAddressRequest) Descriptor() ([]byte, []int) {
+	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SetDeliveryAddressRequest) GetCartId() string {
+	if x != nil {
+		return x.CartId
+	}
+	return ""
+}
+
+func (x *SetDeliveryAddressRequest) GetDeliveryAddress() *types.PostalAddress {
+	if x != nil {
+		return x.DeliveryAddress
+	}
+	return nil
+}
+
+func (x *SetDeliveryAddressRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *SetDeliveryAddressRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+// Response parameters for the SetDeliveryAddress API
+type SetDeliveryAddressResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The populated cart requested
+	Cart *ShoppingCart `protobuf:"bytes,1,opt,name=cart,proto3" json:"cart,omitempty"`
+}
+
+func (x *SetDeliveryAddressResponse) Reset() {
+	*x = SetDeliveryAddressResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_cart_cart_api_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetDeliveryAddressResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDeliveryAddressResponse) ProtoMessage() {}
+
+func (x *SetDeliveryAddressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_cart_cart_api_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDeliveryAddressResponse.ProtoReflect.Descriptor instead.
+func (*SetDeliveryAddressResponse) Descriptor() ([]byte, []int) {
+	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SetDeliveryAddressResponse) GetCart() *ShoppingCart {
+	if x != nil {
+		return x.Cart
+	}
+	return nil
+}
+
+// Request parameters for the ListDeliveryOptions API
+type ListDeliveryOptionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The ID of the cart for which delivery options are to be listed. The cart must be open and
+	// have both items and a delivery address.
+	CartId string `protobuf:"bytes,1,opt,name=cart_id,json=cartId,proto3" json:"cart_id,omitempty"`
+}
+
+func (x *ListDeliveryOptionsRequest) Reset() {
+	*x = ListDeliveryOptionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_cart_cart_api_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDeliveryOptionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeliveryOptionsRequest) ProtoMessage() {}
+
+func (x *ListDeliveryOptionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_cart_cart_api_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeliveryOptionsRequest.ProtoReflect.Descriptor instead.
+func (*ListDeliveryOptionsRequest) Descriptor() ([]byte, []int) {
+	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListDeliveryOptionsRequest) GetCartId() string {
+	if x != nil {
+		return x.CartId
+	}
+	return ""
+}
+
+// Response parameters for the ListDeliveryOptions API
+type ListDeliveryOptionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The delivery options available for the cart, priced for its current contents and delivery
+	// address. Empty if the cart cannot be delivered to its address.
+	DeliveryOptions []*DeliveryOption `protobuf:"bytes,1,rep,name=delivery_options,json=deliveryOptions,proto3" json:"delivery_options,omitempty"`
+}
+
+func (x *ListDeliveryOptionsResponse) Reset() {
+	*x = ListDeliveryOptionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_cart_cart_api_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDeliveryOptionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeliveryOptionsResponse) ProtoMessage() {}
+
+func (x *ListDeliveryOptionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_cart_cart_api_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeliveryOptionsResponse.ProtoReflect.Descriptor instead.
+func (*ListDeliveryOptionsResponse) Descriptor() ([]byte, []int) {
+	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListDeliveryOptionsResponse) GetDeliveryOptions() []*DeliveryOption {
+	if x != nil {
+		return x.DeliveryOptions
+	}
+	return nil
+}
+
+// Request parameters for the SetDeliveryOption API
+type SetDeliveryOptionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The ID of the cart for which the delivery option is to be chosen
+	CartId string `protobuf:"bytes,1,opt,name=cart_id,json=cartId,proto3" json:"cart_id,omitempty"`
+	// The code of the chosen delivery option, as returned by the ListDeliveryOptions API
+	DeliveryOptionCode string `protobuf:"bytes,2,opt,name=delivery_option_code,json=deliveryOptionCode,proto3" json:"delivery_option_code,omitempty"`
+	// Optional. The etag of the cart as last seen by the caller. If provided, the request
+	// will be rejected with an ABORTED status if the cart has since been modified.
+	Etag string `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	// Optional. A unique ID chosen by the caller for this request. If a request with the same ID has
+	// already been applied to the cart, the response to that request is returned again rather than
+	// the change being applied twice. Request IDs are remembered for 24 hours.
+	RequestId string `protobuf:"bytes,4,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+}
+
+func (x *SetDeliveryOptionRequest) Reset() {
+	*x = SetDeliveryOptionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_cart_cart_api_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetDeliveryOptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDeliveryOptionRequest) ProtoMessage() {}
+
+func (x *SetDeliveryOptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_cart_cart_api_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDeliveryOptionRequest.ProtoReflect.Descriptor instead.
+func (*SetDeliveryOptionRequest) Descriptor() ([]byte, []int) {
+	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SetDeliveryOptionRequest) GetCartId() string {
+	if x != nil {
+		return x.CartId
+	}
+	return ""
+}
+
+func (x *SetDeliveryOptionRequest) GetDeliveryOptionCode() string {
+	if x != nil {
+		return x.DeliveryOptionCode
+	}
+	return ""
+}
+
+func (x *SetDeliveryOptionRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *SetDeliveryOptionRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+// Response parameters for the SetDeliveryOption API
+type SetDeliveryOptionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The cart, including the chosen delivery option and its cost
+	Cart *ShoppingCart `protobuf:"bytes,1,opt,name=cart,proto3" json:"cart,omitempty"`
+}
+
+func (x *SetDeliveryOptionResponse) Reset() {
+	*x = SetDeliveryOptionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_cart_cart_api_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetDeliveryOptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDeliveryOptionResponse) ProtoMessage() {}
+
+func (x *SetDeliveryOptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_cart_cart_api_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDeliveryOptionResponse.ProtoReflect.Descriptor instead.
+func (*SetDeliveryOptionResponse) Descriptor() ([]byte, []int) {
+	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SetDeliveryOptionResponse) GetCart() *ShoppingCart {
+	if x != nil {
+		return x.Cart
+	}
+	return nil
+}
+
+// Request parameters for the ApplyPromotionCode API
+type ApplyPromotionCodeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The ID of the cart that the promotion code is to be applied to
+	CartId string `protobuf:"bytes,1,opt,name=cart_id,json=cartId,proto3" json:"cart_id,omitempty"`
+	// The promotion code, as given to the shopper. Promotion codes are not case sensitive.
+	PromotionCode string `protobuf:"bytes,2,opt,name=promotion_code,json=promotionCode,proto3" json:"promotion_code,omitempty"`
+	// Optional. The etag of the cart as last seen by the caller. If provided, the request
+	// will be rejected with an ABORTED status if the cart has since been modified.
+	Etag string `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	// Optional. A unique ID chosen by the caller for this request. If a request with the same ID has
+	// already been applied to the cart, the response to that request is returned again rather than
+	// the change being applied twice. Request IDs are remembered for 24 hours.
+	RequestId string `protobuf:"bytes,4,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+}
+
+func (x *ApplyPromotionCodeRequest) Reset() {
+	*x = ApplyPromotionCodeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_cart_cart_api_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApplyPromotionCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyPromotionCodeRequest) ProtoMessage() {}
+
+func (x *ApplyPromotionCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_cart_cart_api_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyPromotionCodeRequest.ProtoReflect.Descriptor instead.
+func (*ApplyPromotionCodeRequest) Descriptor() ([]byte, []int) {
+	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ApplyPromotionCodeRequest) GetCartId() string {
+	if x != nil {
+		return x.CartId
+	}
+	return ""
+}
+
+func (x *ApplyPromotionCodeRequest) GetPromotionCode() string {
+	if x != nil {
+		return x.PromotionCode
+	}
+	return ""
+}
+
+func (x *ApplyPromotionCodeRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *ApplyPromotionCodeRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+// Response parameters for the ApplyPromotionCode API
+type ApplyPromotionCodeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The cart, including any discount earned by the promotion code
+	Cart *ShoppingCart `protobuf:"bytes,1,opt,name=cart,proto3" json:"cart,omitempty"`
+}
+
+func (x *ApplyPromotionCodeResponse) Reset() {
+	*x = ApplyPromotionCodeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_cart_cart_api_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApplyPromotionCodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyPromotionCodeResponse) ProtoMessage() {}
+
+func (x *ApplyPromotionCodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_cart_cart_api_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyPromotionCodeResponse.ProtoReflect.Descriptor instead.
+func (*ApplyPromotionCodeResponse) Descriptor() ([]byte, []int) {
+	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ApplyPromotionCodeResponse) GetCart() *ShoppingCart {
+	if x != nil {
+		return x.Cart
+	}
+	return nil
+}
+
+// Request parameters for the RemovePromotionCode API
+type RemovePromotionCodeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The ID of the cart that the promotion code is to be removed from
+	CartId string `protobuf:"bytes,1,opt,name=cart_id,json=cartId,proto3" json:"cart_id,omitempty"`
+	// The promotion code to be removed
+	PromotionCode string `protobuf:"bytes,2,opt,name=promotion_code,json=promotionCode,proto3" json:"promotion_code,omitempty"`
+	// Optional. The etag of the cart as last seen by the caller. If provided, the request
+	// will be rejected with an ABORTED status if the cart has since been modified.
+	Etag string `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	// Optional. A unique ID chosen by the caller for this request. If a request with the same ID has
+	// already been applied to the cart, the response to that request is returned again rather than
+	// the change being applied twice. Request IDs are remembered for 24 hours.
+	RequestId string `protobuf:"bytes,4,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+}
+
+func (x *RemovePromotionCodeRequest) Reset() {
+	*x = RemovePromotionCodeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_cart_cart_api_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemovePromotionCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemovePromotionCodeRequest) ProtoMessage() {}
+
+func (x *RemovePromotionCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_cart_cart_api_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemovePromotionCodeRequest.ProtoReflect.Descriptor instead.
+func (*RemovePromotionCodeRequest) Descriptor() ([]byte, []int) {
+	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RemovePromotionCodeRequest) GetCartId() string {
+	if x != nil {
+		return x.CartId
+	}
+	return ""
+}
+
+func (x *RemovePromotionCodeRequest) GetPromotionCode() string {
+	if x != nil {
+		return x.PromotionCode
+	}
+	return ""
+}
+
+func (x *RemovePromotionCodeRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *RemovePromotionCodeRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+// Response parameters for the RemovePromotionCode API
+type RemovePromotionCodeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The cart, without the promotion code or its discount
+	Cart *ShoppingCart `protobuf:"bytes,1,opt,name=cart,proto3" json:"cart,omitempty"`
+}
+
+func (x *RemovePromotionCodeResponse) Reset() {
+	*x = RemovePromotionCodeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_cart_cart_api_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemovePromotionCodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemovePromotionCodeResponse) ProtoMessage() {}
+
+func (x *RemovePromotionCodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_cart_cart_api_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemovePromotionCodeResponse.ProtoReflect.Descriptor instead.
+func (*RemovePromotionCodeResponse) Descriptor() ([]byte, []int) {
+	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RemovePromotionCodeResponse) GetCart() *ShoppingCart {
+	if x != nil {
+		return x.Cart
+	}
+	return nil
+}
+
+// Request parameters for the MergeShoppingCarts API
+type MergeShoppingCartsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The ID of the target cart into which the source cart is to be merged
+	CartId string `protobuf:"bytes,1,opt,name=cart_id,json=cartId,proto3" json:"cart_id,omitempty"`
+	// The ID of the source cart, the items and delivery address of which are to be moved to the target cart.
+	// The source cart is abandoned by the merge.
+	SourceCartId string `protobuf:"bytes,2,opt,name=source_cart_id,json=sourceCartId,proto3" json:"source_cart_id,omitempty"`
+	// Optional. The etag of the target cart as last seen by the caller. If provided, the request
+	// will be rejected with an ABORTED status if the target cart has since been modified.
+	Etag string `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	// Optional. The etag of the source cart as last seen by the caller. If provided, the request
+	// will be rejected with an ABORTED status if the source cart has since been modified.
+	SourceEtag string `protobuf:"bytes,4,opt,name=source_etag,json=sourceEtag,proto3" json:"source_etag,omitempty"`
+	// Optional. A unique ID chosen by the caller for this request. If a request with the same ID has
+	// already been applied to the cart, the response to that request is returned again rather than
+	// the change being applied twice. Request IDs are remembered for 24 hours.
+	RequestId string `protobuf:"bytes,5,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+}
+
+func (x *MergeShoppingCartsRequest) Reset() {
+	*x = MergeShoppingCartsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_cart_cart_api_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MergeShoppingCartsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeShoppingCartsRequest) ProtoMessage() {}
+
+func (x *MergeShoppingCartsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_cart_cart_api_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeShoppingCartsRequest.ProtoReflect.Descriptor instead.
+func (*MergeShoppingCartsRequest) Descriptor() ([]byte, []int) {
+	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *MergeShoppingCartsRequest) GetCartId() string {
+	if x != nil {
+		return x.CartId
+	}
+	return ""
+}
+
+func (x *MergeShoppingCartsRequest) GetSourceCartId() string {
+	if x != nil {
+		return x.SourceCartId
+	}
+	return ""
+}
+
+func (x *MergeShoppingCartsRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *MergeShoppingCartsRequest) GetSourceEtag() string {
+	if x != nil {
+		return x.SourceEtag
+	}
+	return ""
+}
+
+func (x *MergeShoppingCartsRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+// Response parameters for the MergeShoppingCarts API
+type MergeShoppingCartsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The target cart, now including the items of the source cart
+	Cart *ShoppingCart `protobuf:"bytes,1,opt,name=cart,proto3" json:"cart,omitempty"`
+}
+
+func (x *MergeShoppingCartsResponse) Reset() {
+	*x = MergeShoppingCartsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_cart_cart_api_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MergeShoppingCartsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeShoppingCartsResponse) ProtoMessage() {}
+
+func (x *MergeShoppingCartsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_cart_cart_api_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeShoppingCartsResponse.ProtoReflect.Descriptor instead.
+func (*MergeShoppingCartsResponse) Descriptor() ([]byte, []int) {
+	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *MergeShoppingCartsResponse) GetCart() *ShoppingCart {
+	if x != nil {
+		return x.Cart
+	}
+	return nil
+}
+
+// Request parameters for the ReorderFromOrder API
+type ReorderFromOrderRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The ID of the previous order, the items and delivery address of which are to be copied to a new cart
+	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+}
+
+func (x *ReorderFromOrderRequest) Reset() {
+	*x = ReorderFromOrderRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_cart_cart_api_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReorderFromOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderFromOrderRequest) ProtoMessage() {}
+
+func (x *ReorderFromOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_cart_cart_api_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderFromOrderRequest.ProtoReflect.Descriptor instead.
+func (*ReorderFromOrderRequest) Descriptor() ([]byte, []int) {
+	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ReorderFromOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+// Response parameters for the ReorderFromOrder API
+type ReorderFromOrderResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The new cart, holding those of the order's items that could be added to it, priced from the catalog as it
+	// stands today rather than at the prices paid for the order
+	Cart *ShoppingCart `protobuf:"bytes,1,opt,name=cart,proto3" json:"cart,omitempty"`
+	// The items of the order that could not be added to the new cart, if any, and why
+	SkippedItems []*SkippedOrderItem `protobuf:"bytes,2,rep,name=skipped_items,json=skippedItems,proto3" json:"skipped_items,omitempty"`
+}
+
+func (x *ReorderFromOrderResponse) Reset() {
+	*x = ReorderFromOrderResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_cart_cart_api_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReorderFromOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderFromOrderResponse) ProtoMessage() {}
+
+func (x *ReorderFromOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_cart_cart_api_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderFromOrderResponse.ProtoReflect.Descriptor instead.
+func (*ReorderFromOrderResponse) Descriptor() ([]byte, []int) {
+	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ReorderFromOrderResponse) GetCart() *ShoppingCart {
+	if x != nil {
+		return x.Cart
+	}
+	return nil
+}
+
+func (x *ReorderFromOrderResponse) GetSkippedItems() []*SkippedOrderItem {
+	if x != nil {
+		return x.SkippedItems
+	}
+	return nil
+}
+
+// An item of a previous order that the ReorderFromOrder API could not add to the new cart
+type SkippedOrderItem struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The ID of the order item that was skipped
+	OrderItemId string `protobuf:"bytes,1,opt,name=order_item_id,json=orderItemId,proto3" json:"order_item_id,omitempty"`
+	// The product code of the order item
+	ProductCode string `protobuf:"bytes,2,opt,name=product_code,json=productCode,proto3" json:"product_code,omitempty"`
+	// The quantity of the product that was ordered
+	Quantity int32 `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// Why the item could not be added, as an enumerated value
+	Reason SkippedItemReason `protobuf:"varint,4,opt,name=reason,proto3,enum=mikebway.cart.SkippedItemReason" json:"reason,omitempty"`
+	// Why the item could not be added, in words
+	Detail string `protobuf:"bytes,5,opt,name=detail,proto3" json:"detail,omitempty"`
+}
+
+func (x *SkippedOrderItem) Reset() {
+	*x = SkippedOrderItem{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_cart_cart_api_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SkippedOrderItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SkippedOrderItem) ProtoMessage() {}
+
+func (x *SkippedOrderItem) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_cart_cart_api_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SkippedOrderItem.ProtoReflect.Descriptor instead.
+func (*SkippedOrderItem) Descriptor() ([]byte, []int) {
+	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SkippedOrderItem) GetOrderItemId() string {
+	if x != nil {
+		return x.OrderItemId
+	}
+	return ""
+}
+
+func (x *SkippedOrderItem) GetProductCode() string {
+	if x != nil {
+		return x.ProductCode
+	}
+	return ""
+}
+
+func (x *SkippedOrderItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *SkippedOrderItem) GetReason() SkippedItemReason {
+	if x != nil {
+		return x.Reason
+	}
+	return SkippedItemReason_SIR_UNSPECIFIED
+}
+
+func (x *SkippedOrderItem) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+// Request parameters for the SaveForLater API
+type SaveForLaterRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The ID of the cart that the item is to be moved out of
+	CartId string `protobuf:"bytes,1,opt,name=cart_id,json=cartId,proto3" json:"cart_id,omitempty"`
+	// The ID of the cart item to be moved to the wishlist of the shopper who owns the cart
+	ItemId string `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	// Optional. The etag of the cart as last seen by the caller. If provided, the request
+	// will be rejected with an ABORTED status if the cart has since been modified.
+	Etag string `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	// Optional. A unique ID chosen by the caller for this request. If a request with the same ID has
+	// already been applied to the cart, the response to that request is returned again rather than
+	// the change being applied twice. Request IDs are remembered for 24 hours.
+	RequestId string `protobuf:"bytes,4,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+}
+
+func (x *SaveForLaterRequest) Reset() {
+	*x = SaveForLaterRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_cart_cart_api_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SaveForLaterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveForLaterRequest) ProtoMessage() {}
+
+func (x *SaveForLaterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_cart_cart_api_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveForLaterRequest.ProtoReflect.Descriptor instead.
+func (*SaveForLaterRequest) Descriptor() ([]byte, []int) {
+	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SaveForLaterRequest) GetCartId() string {
+	if x != nil {
+		return x.CartId
+	}
+	return ""
+}
+
+func (x *SaveForLaterRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *SaveForLaterRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *SaveForLaterRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+// Response parameters for the SaveForLater API
+type SaveForLaterResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The cart, without the item
+	Cart *ShoppingCart `protobuf:"bytes,1,opt,name=cart,proto3" json:"cart,omitempty"`
+	// The shopper's wishlist, with the item
+	Wishlist *Wishlist `protobuf:"bytes,2,opt,name=wishlist,proto3" json:"wishlist,omitempty"`
+}
+
+func (x *SaveForLaterResponse) Reset() {
+	*x = SaveForLaterResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_cart_cart_api_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SaveForLaterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveForLaterResponse) ProtoMessage() {}
+
+func (x *SaveForLaterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_cart_cart_api_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveForLaterResponse.ProtoReflect.Descriptor instead.
+func (*SaveForLaterResponse) Descriptor() ([]byte, []int) {
+	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *SaveForLaterResponse) GetCart() *ShoppingCart {
+	if x != nil {
+		return x.Cart
+	}
+	return nil
+}
+
+func (x *SaveForLaterResponse) GetWishlist() *Wishlist {
+	if x != nil {
+		return x.Wishlist
+	}
+	return nil
+}
+
+// Request parameters for the MoveToCart API
+type MoveToCartRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The ID of the cart that the item is to be moved into
+	CartId string `protobuf:"bytes,1,opt,name=cart_id,json=cartId,proto3" json:"cart_id,omitempty"`
+	// The ID of the item on the wishlist of the shopper who owns the cart that is to be moved to the cart
+	WishlistItemId string `protobuf:"bytes,2,opt,name=wishlist_item_id,json=wishlistItemId,proto3" json:"wishlist_item_id,omitempty"`
+	// Optional. The etag of the cart as last seen by the caller. If provided, the request
+	// will be rejected with an ABORTED status if the cart has since been modified.
+	Etag string `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	// Optional. A unique ID chosen by the caller for this request. If a request with the same ID has
+	// already been applied to the cart, the response to that request is returned again rather than
+	// the change being applied twice. Request IDs are remembered for 24 hours.
+	RequestId string `protobuf:"bytes,4,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+}
+
+func (x *MoveToCartRequest) Reset() {
+	*x = MoveToCartRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_cart_cart_api_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MoveToCartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveToCartRequest) ProtoMessage() {}
+
+func (x *MoveToCartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_cart_cart_api_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveToCartRequest.ProtoReflect.Descriptor instead.
+func (*MoveToCartRequest) Descriptor() ([]byte, []int) {
+	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *MoveToCartRequest) GetCartId() string {
+	if x != nil {
+		return x.CartId
+	}
+	return ""
+}
+
+func (x *MoveToCartRequest) GetWishlistItemId() string {
+	if x != nil {
+		return x.WishlistItemId
+	}
+	return ""
+}
+
+func (x *MoveToCartRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *MoveToCartRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+// Response parameters for the MoveToCart API
+type MoveToCartResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The cart, with the item
+	Cart *ShoppingCart `protobuf:"bytes,1,opt,name=cart,proto3" json:"cart,omitempty"`
+	// The shopper's wishlist, without the item
+	Wishlist *Wishlist `protobuf:"bytes,2,opt,name=wishlist,proto3" json:"wishlist,omitempty"`
+}
+
+func (x *MoveToCartResponse) Reset() {
+	*x = MoveToCartResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_cart_cart_api_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MoveToCartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveToCartResponse) ProtoMessage() {}
+
+func (x *MoveToCartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_cart_cart_api_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveToCartResponse.ProtoReflect.Descriptor instead.
+func (*MoveToCartResponse) Descriptor() ([]byte, []int) {
+	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *MoveToCartResponse) GetCart() *ShoppingCart {
+	if x != nil {
+		return x.Cart
+	}
+	return nil
+}
+
+func (x *MoveToCartResponse) GetWishlist() *Wishlist {
+	if x != nil {
+		return x.Wishlist
+	}
+	return nil
+}
+
+// Request parameters for the GetWishlist API
+type GetWishlistRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The ID of the shopper whose wishlist is to be retrieved
+	ShopperId string `protobuf:"bytes,1,opt,name=shopper_id,json=shopperId,proto3" json:"shopper_id,omitempty"`
+}
+
+func (x *GetWishlistRequest) Reset() {
+	*x = GetWishlistRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_cart_cart_api_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetWishlistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWishlistRequest) ProtoMessage() {}
+
+func (x *GetWishlistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_cart_cart_api_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWishlistRequest.ProtoReflect.Descriptor instead.
+func (*GetWishlistRequest) Descriptor() ([]byte, []int) {
+	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetWishlistRequest) GetShopperId() string {
+	if x != nil {
+		return x.ShopperId
+	}
+	return ""
+}
+
+// Response parameters for the GetWishlist API
+type GetWishlistResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The shopper's wishlist, empty if they have never added anything to it
+	Wishlist *Wishlist `protobuf:"bytes,1,opt,name=wishlist,proto3" json:"wishlist,omitempty"`
+}
+
+func (x *GetWishlistResponse) Reset() {
+	*x = GetWishlistResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_cart_cart_api_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetWishlistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWishlistResponse) ProtoMessage() {}
+
+func (x *GetWishlistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_cart_cart_api_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWishlistResponse.ProtoReflect.Descriptor instead.
+func (*GetWishlistResponse) Descriptor() ([]byte, []int) {
+	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetWishlistResponse) GetWishlist() *Wishlist {
+	if x != nil {
+		return x.Wishlist
+	}
+	return nil
+}
+
+// Request parameters for the AddToWishlist API
+type AddToWishlistRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The ID of the shopper whose wishlist the item is to be added to
+	ShopperId string `protobuf:"bytes,1,opt,name=shopper_id,json=shopperId,proto3" json:"shopper_id,omitempty"`
+	// The item to be added. Only the product code, quantity, and attributes are used; a quantity of zero is
+	// taken to mean one.
+	Item *WishlistItem `protobuf:"bytes,2,opt,name=item,proto3" json:"item,omitempty"`
+}
+
+func (x *AddToWishlistRequest) Reset() {
+	*x = AddToWishlistRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_cart_cart_api_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddToWishlistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddToWishlistRequest) ProtoMessage() {}
+
+func (x *AddToWishlistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_cart_cart_api_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddToWishlistRequest.ProtoReflect.Descriptor instead.
+func (*AddToWishlistRequest) Descriptor() ([]byte, []int) {
+	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *AddToWishlistRequest) GetShopperId() string {
+	if x != nil {
+		return x.ShopperId
+	}
+	return ""
+}
+
+func (x *AddToWishlistRequest) GetItem() *WishlistItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+// Response parameters for the AddToWishlist API
+type AddToWishlistResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The shopper's wishlist, with the item
+	Wishlist *Wishlist `protobuf:"bytes,1,opt,name=wishlist,proto3" json:"wishlist,omitempty"`
+}
+
+func (x *AddToWishlistResponse) Reset() {
+	*x = AddToWishlistResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_cart_cart_api_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddToWishlistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddToWishlistResponse) ProtoMessage() {}
+
+func (x *AddToWishlistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_cart_cart_api_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddToWishlistResponse.ProtoReflect.Descriptor instead.
+func (*AddToWishlistResponse) Descriptor() ([]byte, []int) {
+	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *AddToWishlistResponse) GetWishlist() *Wishlist {
+	if x != nil {
+		return x.Wishlist
+	}
+	return nil
+}
+
+// Request parameters for the RemoveFromWishlist API
+type RemoveFromWishlistRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The ID of the shopper whose wishlist the item is to be removed from
+	ShopperId string `protobuf:"bytes,1,opt,name=shopper_id,json=shopperId,proto3" json:"shopper_id,omitempty"`
+	// The ID of the wishlist item to be removed
+	ItemId string `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+}
+
+func (x *RemoveFromWishlistRequest) Reset() {
+	*x = RemoveFromWishlistRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_cart_cart_api_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveFromWishlistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveFromWishlistRequest) ProtoMessage() {}
+
+func (x *RemoveFromWishlistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_cart_cart_api_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveFromWishlistRequest.ProtoReflect.Descriptor instead.
+func (*RemoveFromWishlistRequest) Descriptor() ([]byte, []int) {
+	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *RemoveFromWishlistRequest) GetShopperId() string {
+	if x != nil {
+		return x.ShopperId
+	}
+	return ""
+}
+
+func (x *RemoveFromWishlistRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+// Response parameters for the RemoveFromWishlist API
+type RemoveFromWishlistResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The shopper's wishlist, without the item
+	Wishlist *Wishlist `protobuf:"bytes,1,opt,name=wishlist,proto3" json:"wishlist,omitempty"`
+}
+
+func (x *RemoveFromWishlistResponse) Reset() {
+	*x = RemoveFromWishlistResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_cart_cart_api_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveFromWishlistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveFromWishlistResponse) ProtoMessage() {}
+
+func (x *RemoveFromWishlistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_cart_cart_api_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveFromWishlistResponse.ProtoReflect.Descriptor instead.
+func (*RemoveFromWishlistResponse) Descriptor() ([]byte, []int) {
+	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *RemoveFromWishlistResponse) GetWishlist() *Wishlist {
+	if x != nil {
+		return x.Wishlist
+	}
+	return nil
+}
+
+// Request parameters for the GetSharedWishlist API
+type GetSharedWishlistRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The share ID of the wishlist to be retrieved, as given out by the shopper whose wishlist it is
+	ShareId string `protobuf:"bytes,1,opt,name=share_id,json=shareId,proto3" json:"share_id,omitempty"`
+}
+
+func (x *GetSharedWishlistRequest) Reset() {
+	*x = GetSharedWishlistRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_cart_cart_api_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSharedWishlistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSharedWishlistRequest) ProtoMessage() {}
+
+func (x *GetSharedWishlistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_cart_cart_api_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSharedWishlistRequest.ProtoReflect.Descriptor instead.
+func (*GetSharedWishlistRequest) Descriptor() ([]byte, []int) {
+	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetSharedWishlistRequest) GetShareId() string {
+	if x != nil {
+		return x.ShareId
+	}
+	return ""
+}
+
+// Response parameters for the GetSharedWishlist API
+type GetSharedWishlistResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The shared wishlist, without the ID of the shopper whose wishlist it is
+	Wishlist *Wishlist `protobuf:"bytes,1,opt,name=wishlist,proto3" json:"wishlist,omitempty"`
+}
+
+func (x *GetSharedWishlistResponse) Reset() {
+	*x = GetSharedWishlistResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_cart_cart_api_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSharedWishlistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSharedWishlistResponse) ProtoMessage() {}
+
+func (x *GetSharedWishlistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_cart_cart_api_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSharedWishlistResponse.ProtoReflect.Descriptor instead.
+func (*GetSharedWishlistResponse) Descriptor() ([]byte, []int) {
+	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetSharedWishlistResponse) GetWishlist() *Wishlist {
+	if x != nil {
+		return x.Wishlist
+	}
+	return nil
+}
+
+// Request parameters for the CheckoutShoppingCart API
+type CheckoutShoppingCartRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CartId string `protobuf:"bytes,1,opt,name=cart_id,json=cartId,proto3" json:"cart_id,omitempty"`
+	// Optional. The etag of the cart as last seen by the caller. If provided, the request
+	// will be rejected with an ABORTED status if the cart has since been modified.
+	Etag string `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	// Optional. A unique ID chosen by the caller for this request. If a request with the same ID has
+	// already been applied to the cart, the response to that request is returned again rather than
+	// the change being applied twice. Request IDs are remembered for 24 hours.
+	RequestId string `protobuf:"bytes,3,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+}
+
+func (x *CheckoutShoppingCartRequest) Reset() {
+	*x = CheckoutShoppingCartRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_cart_cart_api_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckoutShoppingCartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckoutShoppingCartRequest) ProtoMessage() {}
+
+func (x *CheckoutShoppingCartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_cart_cart_api_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckoutShoppingCartRequest.ProtoReflect.Descriptor instead.
+func (*CheckoutShoppingCartRequest) Descriptor() ([]byte, []int) {
+	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *CheckoutShoppingCartRequest) GetCartId() string {
+	if x != nil {
+		return x.CartId
+	}
+	return ""
+}
+
+func (x *CheckoutShoppingCartRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *CheckoutShoppingCartRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+// Response parameters for the CheckoutShoppingCart API.
+type CheckoutShoppingCartResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cart *ShoppingCart `protobuf:"bytes,1,opt,name=cart,proto3" json:"cart,omitempty"`
+}
+
+func (x *CheckoutShoppingCartResponse) Reset() {
+	*x = CheckoutShoppingCartResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_cart_cart_api_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckoutShoppingCartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckoutShoppingCartResponse) ProtoMessage() {}
+
+func (x *CheckoutShoppingCartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_cart_cart_api_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckoutShoppingCartResponse.ProtoReflect.Descriptor instead.
+func (*CheckoutShoppingCartResponse) Descriptor() ([]byte, []int) {
+	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *CheckoutShoppingCartResponse) GetCart() *ShoppingCart {
+	if x != nil {
+		return x.Cart
+	}
+	return nil
+}
+
+// Request parameters for the AbandonShoppingCart API
+type AbandonShoppingCartRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CartId string `protobuf:"bytes,1,opt,name=cart_id,json=cartId,proto3" json:"cart_id,omitempty"`
+	// Optional. The etag of the cart as last seen by the caller. If provided, the request
+	// will be rejected with an ABORTED status if the cart has since been modified.
+	Etag string `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	// Optional. A unique ID chosen by the caller for this request. If a request with the same ID has
+	// already been applied to the cart, the response to that request is returned again rather than
+	// the change being applied twice. Request IDs are remembered for 24 hours.
+	RequestId string `protobuf:"bytes,3,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+}
+
+func (x *AbandonShoppingCartRequest) Reset() {
+	*x = AbandonShoppingCartRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_cart_cart_api_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AbandonShoppingCartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbandonShoppingCartRequest) ProtoMessage() {}
+
+func (x *AbandonShoppingCartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_cart_cart_api_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbandonShoppingCartRequest.ProtoReflect.Descriptor instead.
+func (*AbandonShoppingCartRequest) Descriptor() ([]byte, []int) {
+	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *AbandonShoppingCartRequest) GetCartId() string {
+	if x != nil {
+		return x.CartId
+	}
+	return ""
+}
+
+func (x *AbandonShoppingCartRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *AbandonShoppingCartRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+// Response parameters for the AbandonShoppingCart API.
+type AbandonShoppingCartResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cart *ShoppingCart `protobuf:"bytes,1,opt,name=cart,proto3" json:"cart,omitempty"`
+}
+
+func (x *AbandonShoppingCartResponse) Reset() {
+	*x = AbandonShoppingCartResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_cart_cart_api_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AbandonShoppingCartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbandonShoppingCartResponse) ProtoMessage() {}
+
+func (x *AbandonShoppingCartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_cart_cart_api_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbandonShoppingCartResponse.ProtoReflect.Descriptor instead.
+func (*AbandonShoppingCartResponse) Descriptor() ([]byte, []int) {
+	return file_mikebway_cart_cart_api_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *AbandonShoppingCartResponse) GetCart() *ShoppingCart {
+	if x != nil {
+		return x.Cart
+	}
+	return nil
+}
+
+var File_mikebway_cart_cart_api_proto protoreflect.FileDescriptor
+
+var file_mikebway_cart_cart_api_proto_rawDesc = []byte{
+	0x0a, 0x1c, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2f, 0x63, 0x61, 0x72, 0x74, 0x2f,
+	0x63, 0x61, 0x72, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d,
+	0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x1a, 0x20, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x18, 0x6d, 0x69,
+	0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2f, 0x63, 0x61, 0x72, 0x74, 0x2f, 0x63, 0x61, 0x72, 0x74,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x18, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79,
+	0x2f, 0x63, 0x61, 0x72, 0x74, 0x2f, 0x69, 0x74, 0x65, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x1a, 0x1c, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2f, 0x63, 0x61, 0x72, 0x74, 0x2f,
+	0x77, 0x69, 0x73, 0x68, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c,
+	0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x6d, 0x69,
+	0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f, 0x70, 0x65, 0x72,
+	0x73, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x6c, 0x0a, 0x19, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x30, 0x0a, 0x07, 0x73, 0x68, 0x6f, 0x70, 0x70, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77,
+	0x61, 0x79, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x50, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x52,
+	0x07, 0x73, 0x68, 0x6f, 0x70, 0x70, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x22, 0x4d, 0x0a, 0x1a, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x04, 0x63, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63,
+	0x61, 0x72, 0x74, 0x2e, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74,
+	0x52, 0x04, 0x63, 0x61, 0x72, 0x74, 0x22, 0x35, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x53, 0x68, 0x6f,
+	0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x61, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x61, 0x72, 0x74, 0x49, 0x64, 0x22, 0x4e, 0x0a,
+	0x1b, 0x47, 0x65, 0x74, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74,
+	0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x04,
+	0x63, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6d, 0x69, 0x6b,
+	0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x53, 0x68, 0x6f, 0x70, 0x70,
+	0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x04, 0x63, 0x61, 0x72, 0x74, 0x22, 0x33, 0x0a,
+	0x18, 0x57, 0x61, 0x74, 0x63, 0x68, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61,
+	0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x61, 0x72,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x61, 0x72, 0x74,
+	0x49, 0x64, 0x22, 0x4c, 0x0a, 0x19, 0x57, 0x61, 0x74, 0x63, 0x68, 0x53, 0x68, 0x6f, 0x70, 0x70,
+	0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x2f, 0x0a, 0x04, 0x63, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x53, 0x68,
+	0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x04, 0x63, 0x61, 0x72, 0x74,
+	0x22, 0xcb, 0x02, 0x0a, 0x18, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e,
+	0x67, 0x43, 0x61, 0x72, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a,
+	0x0a, 0x73, 0x68, 0x6f, 0x70, 0x70, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x73, 0x68, 0x6f, 0x70, 0x70, 0x65, 0x72, 0x49, 0x64, 0x12, 0x3d, 0x0a, 0x08,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x21,
+	0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x53,
+	0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x08, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x12, 0x39, 0x0a, 0x0a, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1b, 0x0a,
+	0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61,
+	0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x63,
+	0x6c, 0x75, 0x64, 0x65, 0x5f, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0c, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x22, 0x76,
+	0x0a, 0x19, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61,
+	0x72, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x05, 0x63,
+	0x61, 0x72, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6d, 0x69, 0x6b,
+	0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x53, 0x68, 0x6f, 0x70, 0x70,
+	0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x05, 0x63, 0x61, 0x72, 0x74, 0x73, 0x12, 0x26,
+	0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67,
+	0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x97, 0x01, 0x0a, 0x1c, 0x41, 0x64, 0x64, 0x49, 0x74,
+	0x65, 0x6d, 0x54, 0x6f, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x61, 0x72, 0x74, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x61, 0x72, 0x74, 0x49, 0x64,
+	0x12, 0x2b, 0x0a, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x43,
+	0x61, 0x72, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x12, 0x12, 0x0a,
+	0x04, 0x65, 0x74, 0x61, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x65, 0x74, 0x61,
+	0x67, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64,
+	0x22, 0x50, 0x0a, 0x1d, 0x41, 0x64, 0x64, 0x49, 0x74, 0x65, 0x6d, 0x54, 0x6f, 0x53, 0x68, 0x6f,
+	0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x2f, 0x0a, 0x04, 0x63, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e,
+	0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x04, 0x63, 0x61,
+	0x72, 0x74, 0x22, 0x88, 0x01, 0x0a, 0x21, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x49, 0x74, 0x65,
+	0x6d, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x61, 0x72, 0x74,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x61, 0x72, 0x74, 0x49,
+	0x64, 0x12, 0x17, 0x0a, 0x07, 0x69, 0x74, 0x65, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x69, 0x74, 0x65, 0x6d, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x65, 0x74,
+	0x61, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x65, 0x74, 0x61, 0x67, 0x12, 0x1d,
+	0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x22, 0x55, 0x0a,
+	0x22, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x46, 0x72, 0x6f, 0x6d, 0x53,
+	0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x04, 0x63, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72,
+	0x74, 0x2e, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x04,
+	0x63, 0x61, 0x72, 0x74, 0x22, 0xcd, 0x01, 0x0a, 0x15, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43,
+	0x61, 0x72, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17,
+	0x0a, 0x07, 0x63, 0x61, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x63, 0x61, 0x72, 0x74, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79,
+	0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x43, 0x61, 0x72, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x04,
+	0x69, 0x74, 0x65, 0x6d, 0x12, 0x3b, 0x0a, 0x0b, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x6d,
+	0x61, 0x73, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x73,
+	0x6b, 0x12, 0x12, 0x0a, 0x04, 0x65, 0x74, 0x61, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x65, 0x74, 0x61, 0x67, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x49, 0x64, 0x22, 0x49, 0x0a, 0x16, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x61,
+	0x72, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f,
+	0x0a, 0x04, 0x63, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6d,
+	0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x53, 0x68, 0x6f,
+	0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x04, 0x63, 0x61, 0x72, 0x74, 0x22,
+	0xb1, 0x01, 0x0a, 0x19, 0x53, 0x65, 0x74, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a,
+	0x07, 0x63, 0x61, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x63, 0x61, 0x72, 0x74, 0x49, 0x64, 0x12, 0x48, 0x0a, 0x10, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65,
+	0x72, 0x79, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x74, 0x79, 0x70, 0x65,
+	0x73, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x61, 0x6c, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52,
+	0x0f, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x65, 0x74, 0x61, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x65, 0x74, 0x61, 0x67, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f,
+	0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x49, 0x64, 0x22, 0x4d, 0x0a, 0x1a, 0x53, 0x65, 0x74, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65,
+	0x72, 0x79, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x2f, 0x0a, 0x04, 0x63, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e,
+	0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x04, 0x63, 0x61,
+	0x72, 0x74, 0x22, 0x35, 0x0a, 0x1a, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65,
+	0x72, 0x79, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x17, 0x0a, 0x07, 0x63, 0x61, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x63, 0x61, 0x72, 0x74, 0x49, 0x64, 0x22, 0x67, 0x0a, 0x1b, 0x4c, 0x69, 0x73,
+	0x74, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x10, 0x64, 0x65, 0x6c, 0x69,
+	0x76, 0x65, 0x72, 0x79, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61,
+	0x72, 0x74, 0x2e, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x0f, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x22, 0x98, 0x01, 0x0a, 0x18, 0x53, 0x65, 0x74, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65,
+	0x72, 0x79, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x17, 0x0a, 0x07, 0x63, 0x61, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x63, 0x61, 0x72, 0x74, 0x49, 0x64, 0x12, 0x30, 0x0a, 0x14, 0x64, 0x65, 0x6c, 0x69,
+	0x76, 0x65, 0x72, 0x79, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x65, 0x74,
+	0x61, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x65, 0x74, 0x61, 0x67, 0x12, 0x1d,
+	0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x22, 0x4c, 0x0a,
+	0x19, 0x53, 0x65, 0x74, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x04, 0x63, 0x61,
+	0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62,
+	0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e,
+	0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x04, 0x63, 0x61, 0x72, 0x74, 0x22, 0x8e, 0x01, 0x0a, 0x19,
+	0x41, 0x70, 0x70, 0x6c, 0x79, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f,
+	0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x61, 0x72,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x61, 0x72, 0x74,
+	0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x72, 0x6f, 0x6d,
+	0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x65, 0x74, 0x61,
+	0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x65, 0x74, 0x61, 0x67, 0x12, 0x1d, 0x0a,
+	0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x22, 0x4d, 0x0a, 0x1a,
+	0x41, 0x70, 0x70, 0x6c, 0x79, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f,
+	0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x04, 0x63, 0x61,
+	0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62,
+	0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e,
+	0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x04, 0x63, 0x61, 0x72, 0x74, 0x22, 0x8f, 0x01, 0x0a, 0x1a,
+	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x43,
+	0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x61,
+	0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x61, 0x72,
+	0x74, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x72, 0x6f,
+	0x6d, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x65, 0x74,
+	0x61, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x65, 0x74, 0x61, 0x67, 0x12, 0x1d,
+	0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x22, 0x4e, 0x0a,
+	0x1b, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x69, 0x6f, 0x6e,
+	0x43, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x04,
+	0x63, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6d, 0x69, 0x6b,
+	0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x53, 0x68, 0x6f, 0x70, 0x70,
+	0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x04, 0x63, 0x61, 0x72, 0x74, 0x22, 0xae, 0x01,
+	0x0a, 0x19, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43,
+	0x61, 0x72, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x63,
+	0x61, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x61,
+	0x72, 0x74, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x0e, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x63,
+	0x61, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x43, 0x61, 0x72, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x65, 0x74,
+	0x61, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x65, 0x74, 0x61, 0x67, 0x12, 0x1f,
+	0x0a, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x65, 0x74, 0x61, 0x67, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x45, 0x74, 0x61, 0x67, 0x12,
+	0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x22, 0x4d,
+	0x0a, 0x1a, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43,
+	0x61, 0x72, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x04,
+	0x63, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6d, 0x69, 0x6b,
+	0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x53, 0x68, 0x6f, 0x70, 0x70,
+	0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x04, 0x63, 0x61, 0x72, 0x74, 0x22, 0x34, 0x0a,
+	0x17, 0x52, 0x65, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x46, 0x72, 0x6f, 0x6d, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x49, 0x64, 0x22, 0x91, 0x01, 0x0a, 0x18, 0x52, 0x65, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x46,
+	0x72, 0x6f, 0x6d, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x2f, 0x0a, 0x04, 0x63, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x53,
+	0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x04, 0x63, 0x61, 0x72,
+	0x74, 0x12, 0x44, 0x0a, 0x0d, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x5f, 0x69, 0x74, 0x65,
+	0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62,
+	0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x53, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x0c, 0x73, 0x6b, 0x69, 0x70, 0x70,
+	0x65, 0x64, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x22, 0xc7, 0x01, 0x0a, 0x10, 0x53, 0x6b, 0x69, 0x70,
+	0x70, 0x65, 0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x22, 0x0a, 0x0d,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x74, 0x65, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x74, 0x65, 0x6d, 0x49, 0x64,
+	0x12, 0x21, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x43,
+	0x6f, 0x64, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12,
+	0x38, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x20, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e,
+	0x53, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x74,
+	0x61, 0x69, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x22, 0x7a, 0x0a, 0x13, 0x53, 0x61, 0x76, 0x65, 0x46, 0x6f, 0x72, 0x4c, 0x61, 0x74, 0x65,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x61, 0x72, 0x74,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x61, 0x72, 0x74, 0x49,
+	0x64, 0x12, 0x17, 0x0a, 0x07, 0x69, 0x74, 0x65, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x69, 0x74, 0x65, 0x6d, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x65, 0x74,
+	0x61, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x65, 0x74, 0x61, 0x67, 0x12, 0x1d,
+	0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x22, 0x7c, 0x0a,
+	0x14, 0x53, 0x61, 0x76, 0x65, 0x46, 0x6f, 0x72, 0x4c, 0x61, 0x74, 0x65, 0x72, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x04, 0x63, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63,
+	0x61, 0x72, 0x74, 0x2e, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74,
+	0x52, 0x04, 0x63, 0x61, 0x72, 0x74, 0x12, 0x33, 0x0a, 0x08, 0x77, 0x69, 0x73, 0x68, 0x6c, 0x69,
+	0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62,
+	0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x57, 0x69, 0x73, 0x68, 0x6c, 0x69, 0x73,
+	0x74, 0x52, 0x08, 0x77, 0x69, 0x73, 0x68, 0x6c, 0x69, 0x73, 0x74, 0x22, 0x89, 0x01, 0x0a, 0x11,
+	0x4d, 0x6f, 0x76, 0x65, 0x54, 0x6f, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x61, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x63, 0x61, 0x72, 0x74, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x10, 0x77, 0x69,
+	0x73, 0x68, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x69, 0x74, 0x65, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x77, 0x69, 0x73, 0x68, 0x6c, 0x69, 0x73, 0x74, 0x49, 0x74,
+	0x65, 0x6d, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x65, 0x74, 0x61, 0x67, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x65, 0x74, 0x61, 0x67, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x22, 0x7a, 0x0a, 0x12, 0x4d, 0x6f, 0x76, 0x65, 0x54,
+	0x6f, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a,
+	0x04, 0x63, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6d, 0x69,
+	0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x53, 0x68, 0x6f, 0x70,
+	0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x04, 0x63, 0x61, 0x72, 0x74, 0x12, 0x33,
+	0x0a, 0x08, 0x77, 0x69, 0x73, 0x68, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74,
+	0x2e, 0x57, 0x69, 0x73, 0x68, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x08, 0x77, 0x69, 0x73, 0x68, 0x6c,
+	0x69, 0x73, 0x74, 0x22, 0x33, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x57, 0x69, 0x73, 0x68, 0x6c, 0x69,
+	0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x68, 0x6f,
+	0x70, 0x70, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73,
+	0x68, 0x6f, 0x70, 0x70, 0x65, 0x72, 0x49, 0x64, 0x22, 0x4a, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x57,
+	0x69, 0x73, 0x68, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x33, 0x0a, 0x08, 0x77, 0x69, 0x73, 0x68, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72,
+	0x74, 0x2e, 0x57, 0x69, 0x73, 0x68, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x08, 0x77, 0x69, 0x73, 0x68,
+	0x6c, 0x69, 0x73, 0x74, 0x22, 0x66, 0x0a, 0x14, 0x41, 0x64, 0x64, 0x54, 0x6f, 0x57, 0x69, 0x73,
+	0x68, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x73, 0x68, 0x6f, 0x70, 0x70, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x73, 0x68, 0x6f, 0x70, 0x70, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2f, 0x0a, 0x04, 0x69,
+	0x74, 0x65, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6d, 0x69, 0x6b, 0x65,
+	0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x57, 0x69, 0x73, 0x68, 0x6c, 0x69,
+	0x73, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x22, 0x4c, 0x0a, 0x15,
+	0x41, 0x64, 0x64, 0x54, 0x6f, 0x57, 0x69, 0x73, 0x68, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x08, 0x77, 0x69, 0x73, 0x68, 0x6c, 0x69, 0x73,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77,
+	0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x57, 0x69, 0x73, 0x68, 0x6c, 0x69, 0x73, 0x74,
+	0x52, 0x08, 0x77, 0x69, 0x73, 0x68, 0x6c, 0x69, 0x73, 0x74, 0x22, 0x53, 0x0a, 0x19, 0x52, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x57, 0x69, 0x73, 0x68, 0x6c, 0x69, 0x73, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x68, 0x6f, 0x70, 0x70,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x68, 0x6f,
+	0x70, 0x70, 0x65, 0x72, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x69, 0x74, 0x65, 0x6d, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x69, 0x74, 0x65, 0x6d, 0x49, 0x64, 0x22,
+	0x51, 0x0a, 0x1a, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x57, 0x69, 0x73,
+	0x68, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a,
+	0x08, 0x77, 0x69, 0x73, 0x68, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e,
+	0x57, 0x69, 0x73, 0x68, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x08, 0x77, 0x69, 0x73, 0x68, 0x6c, 0x69,
+	0x73, 0x74, 0x22, 0x35, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x57,
+	0x69, 0x73, 0x68, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19,
+	0x0a, 0x08, 0x73, 0x68, 0x61, 0x72, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x73, 0x68, 0x61, 0x72, 0x65, 0x49, 0x64, 0x22, 0x50, 0x0a, 0x19, 0x47, 0x65, 0x74,
+	0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x57, 0x69, 0x73, 0x68, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x08, 0x77, 0x69, 0x73, 0x68, 0x6c, 0x69,
+	0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62,
+	0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x57, 0x69, 0x73, 0x68, 0x6c, 0x69, 0x73,
+	0x74, 0x52, 0x08, 0x77, 0x69, 0x73, 0x68, 0x6c, 0x69, 0x73, 0x74, 0x22, 0x69, 0x0a, 0x1b, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x6f, 0x75, 0x74, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43,
+	0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x61,
+	0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x61, 0x72,
+	0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x65, 0x74, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x65, 0x74, 0x61, 0x67, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x22, 0x4f, 0x0a, 0x1c, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x6f,
+	0x75, 0x74, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x04, 0x63, 0x61, 0x72, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e,
+	0x63, 0x61, 0x72, 0x74, 0x2e, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72,
+	0x74, 0x52, 0x04, 0x63, 0x61, 0x72, 0x74, 0x22, 0x68, 0x0a, 0x1a, 0x41, 0x62, 0x61, 0x6e, 0x64,
+	0x6f, 0x6e, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x61, 0x72, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x61, 0x72, 0x74, 0x49, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x65, 0x74, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x65, 0x74,
+	0x61, 0x67, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49,
+	0x64, 0x22, 0x4e, 0x0a, 0x1b, 0x41, 0x62, 0x61, 0x6e, 0x64, 0x6f, 0x6e, 0x53, 0x68, 0x6f, 0x70,
+	0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x2f, 0x0a, 0x04, 0x63, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x53,
+	0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x04, 0x63, 0x61, 0x72,
+	0x74, 0x2a, 0x6c, 0x0a, 0x11, 0x53, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x49, 0x74, 0x65, 0x6d,
+	0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x49, 0x52, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x53,
+	0x49, 0x52, 0x5f, 0x55, 0x4e, 0x41, 0x56, 0x41, 0x49, 0x4c, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x01,
+	0x12, 0x14, 0x0a, 0x10, 0x53, 0x49, 0x52, 0x5f, 0x4f, 0x55, 0x54, 0x5f, 0x4f, 0x46, 0x5f, 0x53,
+	0x54, 0x4f, 0x43, 0x4b, 0x10, 0x02, 0x12, 0x17, 0x0a, 0x13, 0x53, 0x49, 0x52, 0x5f, 0x55, 0x4e,
+	0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x50, 0x52, 0x4f, 0x44, 0x55, 0x43, 0x54, 0x10, 0x03, 0x32,
+	0xb1, 0x12, 0x0a, 0x07, 0x43, 0x61, 0x72, 0x74, 0x41, 0x50, 0x49, 0x12, 0x6b, 0x0a, 0x12, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72,
+	0x74, 0x12, 0x28, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72,
+	0x74, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67,
+	0x43, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x6d, 0x69,
+	0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x6e, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x53,
+	0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x42, 0x79, 0x49, 0x44, 0x12,
+	0x29, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e,
+	0x47, 0x65, 0x74, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x42,
+	0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x6d, 0x69, 0x6b,
+	0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x68,
+	0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x6a, 0x0a, 0x11, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x12, 0x27, 0x2e,
+	0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61,
+	0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x53, 0x68, 0x6f, 0x70,
+	0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x30, 0x01, 0x12, 0x68, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x68, 0x6f, 0x70,
+	0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x73, 0x12, 0x27, 0x2e, 0x6d, 0x69, 0x6b, 0x65,
+	0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x68,
+	0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x28, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61,
+	0x72, 0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43,
+	0x61, 0x72, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x74,
+	0x0a, 0x15, 0x41, 0x64, 0x64, 0x49, 0x74, 0x65, 0x6d, 0x54, 0x6f, 0x53, 0x68, 0x6f, 0x70, 0x70,
+	0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x12, 0x2b, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77,
+	0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x41, 0x64, 0x64, 0x49, 0x74, 0x65, 0x6d, 0x54,
+	0x6f, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e,
+	0x63, 0x61, 0x72, 0x74, 0x2e, 0x41, 0x64, 0x64, 0x49, 0x74, 0x65, 0x6d, 0x54, 0x6f, 0x53, 0x68,
+	0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x83, 0x01, 0x0a, 0x1a, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x49,
+	0x74, 0x65, 0x6d, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43,
+	0x61, 0x72, 0x74, 0x12, 0x30, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63,
+	0x61, 0x72, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x46, 0x72,
+	0x6f, 0x6d, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x31, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79,
+	0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x49, 0x74, 0x65, 0x6d,
+	0x46, 0x72, 0x6f, 0x6d, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5f, 0x0a, 0x0e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x43, 0x61, 0x72, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x24, 0x2e, 0x6d,
+	0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x43, 0x61, 0x72, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x25, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61,
+	0x72, 0x74, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x61, 0x72, 0x74, 0x49, 0x74, 0x65,
+	0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x6b, 0x0a, 0x12, 0x53,
+	0x65, 0x74, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x12, 0x28, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72,
+	0x74, 0x2e, 0x53, 0x65, 0x74, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x41, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x6d, 0x69,
+	0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x53, 0x65, 0x74, 0x44,
+	0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x6e, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74,
+	0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x29, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x6d, 0x69, 0x6b,
+	0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44,
+	0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x68, 0x0a, 0x11, 0x53, 0x65, 0x74, 0x44,
+	0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x27, 0x2e,
+	0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x53, 0x65,
+	0x74, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61,
+	0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x53, 0x65, 0x74, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65,
+	0x72, 0x79, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x6b, 0x0a, 0x12, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x50, 0x72, 0x6f, 0x6d, 0x6f,
+	0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x28, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62,
+	0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x50, 0x72,
+	0x6f, 0x6d, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x29, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61,
+	0x72, 0x74, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x69, 0x6f,
+	0x6e, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x6e, 0x0a, 0x13, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x69,
+	0x6f, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x29, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61,
+	0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x50, 0x72, 0x6f,
+	0x6d, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x2a, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72,
+	0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x69, 0x6f,
+	0x6e, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x6b, 0x0a, 0x12, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67,
+	0x43, 0x61, 0x72, 0x74, 0x73, 0x12, 0x28, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79,
+	0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x53, 0x68, 0x6f, 0x70, 0x70,
+	0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x29, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e,
+	0x4d, 0x65, 0x72, 0x67, 0x65, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x65, 0x0a, 0x10,
+	0x52, 0x65, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x46, 0x72, 0x6f, 0x6d, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x12, 0x26, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74,
+	0x2e, 0x52, 0x65, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x46, 0x72, 0x6f, 0x6d, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62,
+	0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x52, 0x65, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x46, 0x72, 0x6f, 0x6d, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x59, 0x0a, 0x0c, 0x53, 0x61, 0x76, 0x65, 0x46, 0x6f, 0x72, 0x4c, 0x61,
+	0x74, 0x65, 0x72, 0x12, 0x22, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63,
+	0x61, 0x72, 0x74, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x46, 0x6f, 0x72, 0x4c, 0x61, 0x74, 0x65, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77,
+	0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x46, 0x6f, 0x72, 0x4c,
+	0x61, 0x74, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x53,
+	0x0a, 0x0a, 0x4d, 0x6f, 0x76, 0x65, 0x54, 0x6f, 0x43, 0x61, 0x72, 0x74, 0x12, 0x20, 0x2e, 0x6d,
+	0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x4d, 0x6f, 0x76,
+	0x65, 0x54, 0x6f, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21,
+	0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x4d,
+	0x6f, 0x76, 0x65, 0x54, 0x6f, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x56, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x57, 0x69, 0x73, 0x68, 0x6c, 0x69,
+	0x73, 0x74, 0x12, 0x21, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61,
+	0x72, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x57, 0x69, 0x73, 0x68, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79,
+	0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x57, 0x69, 0x73, 0x68, 0x6c, 0x69, 0x73,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5c, 0x0a, 0x0d, 0x41,
+	0x64, 0x64, 0x54, 0x6f, 0x57, 0x69, 0x73, 0x68, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x23, 0x2e, 0x6d,
+	0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x41, 0x64, 0x64,
+	0x54, 0x6f, 0x57, 0x69, 0x73, 0x68, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x24, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72,
+	0x74, 0x2e, 0x41, 0x64, 0x64, 0x54, 0x6f, 0x57, 0x69, 0x73, 0x68, 0x6c, 0x69, 0x73, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x6b, 0x0a, 0x12, 0x52, 0x65, 0x6d,
+	0x6f, 0x76, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x57, 0x69, 0x73, 0x68, 0x6c, 0x69, 0x73, 0x74, 0x12,
+	0x28, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e,
+	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x57, 0x69, 0x73, 0x68, 0x6c, 0x69,
+	0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x6d, 0x69, 0x6b, 0x65,
+	0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65,
+	0x46, 0x72, 0x6f, 0x6d, 0x57, 0x69, 0x73, 0x68, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x68, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x53, 0x68, 0x61,
+	0x72, 0x65, 0x64, 0x57, 0x69, 0x73, 0x68, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x27, 0x2e, 0x6d, 0x69,
+	0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x53,
+	0x68, 0x61, 0x72, 0x65, 0x64, 0x57, 0x69, 0x73, 0x68, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e,
+	0x63, 0x61, 0x72, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x57, 0x69,
+	0x73, 0x68, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x71, 0x0a, 0x14, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x6f, 0x75, 0x74, 0x53, 0x68, 0x6f, 0x70,
+	0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x12, 0x2a, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62,
+	0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x6f, 0x75,
+	0x74, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e,
+	0x63, 0x61, 0x72, 0x74, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x6f, 0x75, 0x74, 0x53, 0x68, 0x6f,
+	0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x6e, 0x0a, 0x13, 0x41, 0x62, 0x61, 0x6e, 0x64, 0x6f, 0x6e, 0x53, 0x68,
+	0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x12, 0x29, 0x2e, 0x6d, 0x69, 0x6b,
+	0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x41, 0x62, 0x61, 0x6e, 0x64,
+	0x6f, 0x6e, 0x53, 0x68, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79,
+	0x2e, 0x63, 0x61, 0x72, 0x74, 0x2e, 0x41, 0x62, 0x61, 0x6e, 0x64, 0x6f, 0x6e, 0x53, 0x68, 0x6f,
+	0x70, 0x70, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x42, 0x2b, 0x5a, 0x29, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2f, 0x70, 0x6f, 0x63, 0x2d, 0x67,
+	0x63, 0x70, 0x2d, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x2f, 0x70, 0x62, 0x2f, 0x63, 0x61, 0x72, 0x74,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_mikebway_cart_cart_api_proto_rawDescOnce sync.Once
+	file_mikebway_cart_cart_api_proto_rawDescData = file_mikebway_cart_cart_api_proto_rawDesc
+)
+
+func file_mikebway_cart_cart_api_proto_rawDescGZIP() []byte {
+	file_mikebway_cart_cart_api_proto_rawDescOnce.Do(func() {
+		file_mikebway_cart_cart_api_proto_rawDescData = protoimpl.X.CompressGZIP(file_mikebway_cart_cart_api_proto_rawDescData)
+	})
+	return file_mikebway_cart_cart_api_proto_rawDescData
+}
+
+var file_mikebway_cart_cart_api_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_mikebway_cart_cart_api_proto_msgTypes = make([]protoimpl.MessageInfo, 45)
+var file_mikebway_cart_cart_api_proto_goTypes = []interface{}{
+	(SkippedItemReason)(0),                     // 0: mikebway.cart.SkippedItemReason
+	(*CreateShoppingCartRequest)(nil),          // 1: mikebway.cart.CreateShoppingCartRequest
+	(*CreateShoppingCartResponse)(nil),         // 2: mikebway.cart.CreateShoppingCartResponse
+	(*GetShoppingCartByIDRequest)(nil),         // 3: mikebway.cart.GetShoppingCartByIDRequest
+	(*GetShoppingCartByIDResponse)(nil),        // 4: mikebway.cart.GetShoppingCartByIDResponse
+	(*WatchShoppingCartRequest)(nil),           // 5: mikebway.cart.WatchShoppingCartRequest
+	(*WatchShoppingCartResponse)(nil),          // 6: mikebway.cart.WatchShoppingCartResponse
+	(*ListShoppingCartsRequest)(nil),           // 7: mikebway.cart.ListShoppingCartsRequest
+	(*ListShoppingCartsResponse)(nil),          // 8: mikebway.cart.ListShoppingCartsResponse
+	(*AddItemToShoppingCartRequest)(nil),       // 9: mikebway.cart.AddItemToShoppingCartRequest
+	(*AddItemToShoppingCartResponse)(nil),      // 10: mikebway.cart.AddItemToShoppingCartResponse
+	(*RemoveItemFromShoppingCartRequest)(nil),  // 11: mikebway.cart.RemoveItemFromShoppingCartRequest
+	(*RemoveItemFromShoppingCartResponse)(nil), // 12: mikebway.cart.RemoveItemFromShoppingCartResponse
+	(*UpdateCartItemRequest)(nil),              // 13: mikebway.cart.UpdateCartItemRequest
+	(*UpdateCartItemResponse)(nil),             // 14: mikebway.cart.UpdateCartItemResponse
+	(*SetDeliveryAddressRequest)(nil),          // 15: mikebway.cart.SetDeliveryAddressRequest
+	(*SetDeliveryAddressResponse)(nil),         // 16: mikebway.cart.SetDeliveryAddressResponse
+	(*ListDeliveryOptionsRequest)(nil),         // 17: mikebway.cart.ListDeliveryOptionsRequest
+	(*ListDeliveryOptionsResponse)(nil),        // 18: mikebway.cart.ListDeliveryOptionsResponse
+	(*SetDeliveryOptionRequest)(nil),           // 19: mikebway.cart.SetDeliveryOptionRequest
+	(*SetDeliveryOptionResponse)(nil),          // 20: mikebway.cart.SetDeliveryOptionResponse
+	(*ApplyPromotionCodeRequest)(nil),          // 21: mikebway.cart.ApplyPromotionCodeRequest
+	(*ApplyPromotionCodeResponse)(nil),         // 22: mikebway.cart.ApplyPromotionCodeResponse
+	(*RemovePromotionCodeRequest)(nil),         // 23: mikebway.cart.RemovePromotionCodeRequest
+	(*RemovePromotionCodeResponse)(nil),        // 24: mikebway.cart.RemovePromotionCodeResponse
+	(*MergeShoppingCartsRequest)(nil),          // 25: mikebway.cart.MergeShoppingCartsRequest
+	(*MergeShoppingCartsResponse)(nil),         // 26: mikebway.cart.MergeShoppingCartsResponse
+	(*ReorderFromOrderRequest)(nil),            // 27: mikebway.cart.ReorderFromOrderRequest
+	(*ReorderFromOrderResponse)(nil),           // 28: mikebway.cart.ReorderFromOrderResponse
+	(*SkippedOrderItem)(nil),                   // 29: mikebway.cart.SkippedOrderItem
+	(*SaveForLaterRequest)(nil),                // 30: mikebway.cart.SaveForLaterRequest
+	(*SaveForLaterResponse)(nil),               // 31: mikebway.cart.SaveForLaterResponse
+	(*MoveToCartRequest)(nil),                  // 32: mikebway.cart.MoveToCartRequest
+	(*MoveToCartResponse)(nil),                 // 33: mikebway.cart.MoveToCartResponse
+	(*GetWishlistRequest)(nil),                 // 34: mikebway.cart.GetWishlistRequest
+	(*GetWishlistResponse)(nil),                // 35: mikebway.cart.GetWishlistResponse
+	(*AddToWishlistRequest)(nil),               // 36: mikebway.cart.AddToWishlistRequest
+	(*AddToWishlistResponse)(nil),              // 37: mikebway.cart.AddToWishlistResponse
+	(*RemoveFromWishlistRequest)(nil),          // 38: mikebway.cart.RemoveFromWishlistRequest
+	(*RemoveFromWishlistResponse)(nil),         // 39: mikebway.cart.RemoveFromWishlistResponse
+	(*GetSharedWishlistRequest)(nil),           // 40: mikebway.cart.GetSharedWishlistRequest
+	(*GetSharedWishlistResponse)(nil),          // 41: mikebway.cart.GetSharedWishlistResponse
+	(*CheckoutShoppingCartRequest)(nil),        // 42: mikebway.cart.CheckoutShoppingCartRequest
+	(*CheckoutShoppingCartResponse)(nil),       // 43: mikebway.cart.CheckoutShoppingCartResponse
+	(*AbandonShoppingCartRequest)(nil),         // 44: mikebway.cart.AbandonShoppingCartRequest
+	(*AbandonShoppingCartResponse)(nil),        // 45: mikebway.cart.AbandonShoppingCartResponse
+	(*types.Person)(nil),                       // 46: mikebway.types.Person
+	(*ShoppingCart)(nil),                       // 47: mikebway.cart.ShoppingCart
+	(ShoppingCartStatus)(0),                    // 48: mikebway.cart.ShoppingCartStatus
+	(*timestamppb.Timestamp)(nil),              // 49: google.protobuf.Timestamp
+	(*CartItem)(nil),                           // 50: mikebway.cart.CartItem
+	(*fieldmaskpb.FieldMask)(nil),              // 51: google.protobuf.FieldMask
+	(*types.PostalAddress)(nil),                // 52: mikebway.types.PostalAddress
+	(*DeliveryOption)(nil),                     // 53: mikebway.cart.DeliveryOption
+	(*Wishlist)(nil),                           // 54: mikebway.cart.Wishlist
+	(*WishlistItem)(nil),                       // 55: mikebway.cart.WishlistItem
+}
+var file_mikebway_cart_cart_api_proto_depIdxs = []int32{
+	46, // 0: mikebway.cart.CreateShoppingCartRequest.shopper:type_name -> mikebway.types.Person
+	47, // 1: mikebway.cart.CreateShoppingCartResponse.cart:type_name -> mikebway.cart.ShoppingCart
+	47, // 2: mikebway.cart.GetShoppingCartByIDResponse.cart:type_name -> mikebway.cart.ShoppingCart
+	47, // 3: mikebway.cart.WatchShoppingCartResponse.cart:type_name -> mikebway.cart.ShoppingCart
+	48, // 4: mikebway.cart.ListShoppingCartsRequest.statuses:type_name -> mikebway.cart.ShoppingCartStatus
+	49, // 5: mikebway.cart.ListShoppingCartsRequest.start_time:type_name -> google.protobuf.Timestamp
+	49, // 6: mikebway.cart.ListShoppingCartsRequest.end_time:type_name -> google.protobuf.Timestamp
+	47, // 7: mikebway.cart.ListShoppingCartsResponse.carts:type_name -> mikebway.cart.ShoppingCart
+	50, // 8: mikebway.cart.AddItemToShoppingCartRequest.item:type_name -> mikebway.cart.CartItem
+	47, // 9: mikebway.cart.AddItemToShoppingCartResponse.cart:type_name -> mikebway.cart.ShoppingCart
+	47, // 10: mikebway.cart.RemoveItemFromShoppingCartResponse.cart:type_name -> mikebway.cart.ShoppingCart
+	50, // 11: mikebway.cart.UpdateCartItemRequest.item:type_name -> mikebway.cart.CartItem
+	51, // 12: mikebway.cart.UpdateCartItemRequest.update_mask:type_name -> google.protobuf.FieldMask
+	47, // 13: mikebway.cart.UpdateCartItemResponse.cart:type_name -> mikebway.cart.ShoppingCart
+	52, // 14: mikebway.cart.SetDeliveryAddressRequest.delivery_address:type_name -> mikebway.types.PostalAddress
+	47, // 15: mikebway.cart.SetDeliveryAddressResponse.cart:type_name -> mikebway.cart.ShoppingCart
+	53, // 16: mikebway.cart.ListDeliveryOptionsResponse.delivery_options:type_name -> mikebway.cart.DeliveryOption
+	47, // 17: mikebway.cart.SetDeliveryOptionResponse.cart:type_name -> mikebway.cart.ShoppingCart
+	47, // 18: mikebway.cart.ApplyPromotionCodeResponse.cart:type_name -> mikebway.cart.ShoppingCart
+	47, // 19: mikebway.cart.RemovePromotionCodeResponse.cart:type_name -> mikebway.cart.ShoppingCart
+	47, // 20: mikebway.cart.MergeShoppingCartsResponse.cart:type_name -> mikebway.cart.ShoppingCart
+	47, // 21: mikebway.cart.ReorderFromOrderResponse.cart:type_name -> mikebway.cart.ShoppingCart
+	29, // 22: mikebway.cart.ReorderFromOrderResponse.skipped_items:type_name -> mikebway.cart.SkippedOrderItem
+	0,  // 23: mikebway.cart.SkippedOrderItem.reason:type_name -> mikebway.cart.SkippedItemReason
+	47, // 24: mikebway.cart.SaveForLaterResponse.cart:type_name -> mikebway.cart.ShoppingCart
+	54, // 25: mikebway.cart.SaveForLaterResponse.wishlist:type_name -> mikebway.cart.Wishlist
+	47, // 26: mikebway.cart.MoveToCartResponse.cart:type_name -> mikebway.cart.ShoppingCart
+	54, // 27: mikebway.cart.MoveToCartResponse.wishlist:type_name -> mikebway.cart.Wishlist
+	54, // 28: mikebway.cart.GetWishlistResponse.wishlist:type_name -> mikebway.cart.Wishlist
+	55, // 29: mikebway.cart.AddToWishlistRequest.item:type_name -> mikebway.cart.WishlistItem
+	54, // 30: mikebway.cart.AddToWishlistResponse.wishlist:type_name -> mikebway.cart.Wishlist
+	54, // 31: mikebway.cart.RemoveFromWishlistResponse.wishlist:type_name -> mikebway.cart.Wishlist
+	54, // 32: mikebway.cart.GetSharedWishlistResponse.wishlist:type_name -> mikebway.cart.Wishlist
+	47, // 33: mikebway.cart.CheckoutShoppingCartResponse.cart:type_name -> mikebway.cart.ShoppingCart
+	47, // 34: mikebway.cart.AbandonShoppingCartResponse.cart:type_name -> mikebway.cart.ShoppingCart
+	1,  // 35: mikebway.cart.CartAPI.CreateShoppingCart:input_type -> mikebway.cart.CreateShoppingCartRequest
+	3,  // 36: mikebway.cart.CartAPI.GetShoppingCartByID:input_type -> mikebway.cart.GetShoppingCartByIDRequest
+	5,  // 37: mikebway.cart.CartAPI.WatchShoppingCart:input_type -> mikebway.cart.WatchShoppingCartRequest
+	7,  // 38: mikebway.cart.CartAPI.ListShoppingCarts:input_type -> mikebway.cart.ListShoppingCartsRequest
+	9,  // 39: mikebway.cart.CartAPI.AddItemToShoppingCart:input_type -> mikebway.cart.AddItemToShoppingCartRequest
+	11, // 40: mikebway.cart.CartAPI.RemoveItemFromShoppingCart:input_type -> mikebway.cart.RemoveItemFromShoppingCartRequest
+	13, // 41: mikebway.cart.CartAPI.UpdateCartItem:input_type -> mikebway.cart.UpdateCartItemRequest
+	15, // 42: mikebway.cart.CartAPI.SetDeliveryAddress:input_type -> mikebway.cart.SetDeliveryAddressRequest
+	17, // 43: mikebway.cart.CartAPI.ListDeliveryOptions:input_type -> mikebway.cart.ListDeliveryOptionsRequest
+	19, // 44: mikebway.cart.CartAPI.SetDeliveryOption:input_type -> mikebway.cart.SetDeliveryOptionRequest
+	21, // 45: mikebway.cart.CartAPI.ApplyPromotionCode:input_type -> mikebway.cart.ApplyPromotionCodeRequest
+	23, // 46: mikebway.cart.CartAPI.RemovePromotionCode:input_type -> mikebway.cart.RemovePromotionCodeRequest
+	25, // 47: mikebway.cart.CartAPI.MergeShoppingCarts:input_type -> mikebway.cart.MergeShoppingCartsRequest
+	27, // 48: mikebway.cart.CartAPI.ReorderFromOrder:input_type -> mikebway.cart.ReorderFromOrderRequest
+	30, // 49: mikebway.cart.CartAPI.SaveForLater:input_type -> mikebway.cart.SaveForLaterRequest
+	32, // 50: mikebway.cart.CartAPI.MoveToCart:input_type -> mikebway.cart.MoveToCartRequest
+	34, // 51: mikebway.cart.CartAPI.GetWishlist:input_type -> mikebway.cart.GetWishlistRequest
+	36, // 52: mikebway.cart.CartAPI.AddToWishlist:input_type -> mikebway.cart.AddToWishlistRequest
+	38, // 53: mikebway.cart.CartAPI.RemoveFromWishlist:input_type -> mikebway.cart.RemoveFromWishlistRequest
+	40, // 54: mikebway.cart.CartAPI.GetSharedWishlist:input_type -> mikebway.cart.GetSharedWishlistRequest
+	42, // 55: mikebway.cart.CartAPI.CheckoutShoppingCart:input_type -> mikebway.cart.CheckoutShoppingCartRequest
+	44, // 56: mikebway.cart.CartAPI.AbandonShoppingCart:input_type -> mikebway.cart.AbandonShoppingCartRequest
+	2,  // 57: mikebway.cart.CartAPI.CreateShoppingCart:output_type -> mikebway.cart.CreateShoppingCartResponse
+	4,  // 58: mikebway.cart.CartAPI.GetShoppingCartByID:output_type -> mikebway.cart.GetShoppingCartByIDResponse
+	6,  // 59: mikebway.cart.CartAPI.WatchShoppingCart:output_type -> mikebway.cart.WatchShoppingCartResponse
+	8,  // 60: mikebway.cart.CartAPI.ListShoppingCarts:output_type -> mikebway.cart.ListShoppingCartsResponse
+	10, // 61: mikebway.cart.CartAPI.AddItemToShoppingCart:output_type -> mikebway.cart.AddItemToShoppingCartResponse
+	12, // 62: mikebway.cart.CartAPI.RemoveItemFromShoppingCart:output_type -> mikebway.cart.RemoveItemFromShoppingCartResponse
+	14, // 63: mikebway.cart.CartAPI.UpdateCartItem:output_type -> mikebway.cart.UpdateCartItemResponse
+	16, // 64: mikebway.cart.CartAPI.SetDeliveryAddress:output_type -> mikebway.cart.SetDeliveryAddressResponse
+	18, // 65: mikebway.cart.CartAPI.ListDeliveryOptions:output_type -> mikebway.cart.ListDeliveryOptionsResponse
+	20, // 66: mikebway.cart.CartAPI.SetDeliveryOption:output_type -> mikebway.cart.SetDeliveryOptionResponse
+	22, // 67: mikebway.cart.CartAPI.ApplyPromotionCode:output_type -> mikebway.cart.ApplyPromotionCodeResponse
+	24, // 68: mikebway.cart.CartAPI.RemovePromotionCode:output_type -> mikebway.cart.RemovePromotionCodeResponse
+	26, // 69: mikebway.cart.CartAPI.MergeShoppingCarts:output_type -> mikebway.cart.MergeShoppingCartsResponse
+	28, // 70: mikebway.cart.CartAPI.ReorderFromOrder:output_type -> mikebway.cart.ReorderFromOrderResponse
+	31, // 71: mikebway.cart.CartAPI.SaveForLater:output_type -> mikebway.cart.SaveForLaterResponse
+	33, // 72: mikebway.cart.CartAPI.MoveToCart:output_type -> mikebway.cart.MoveToCartResponse
+	35, // 73: mikebway.cart.CartAPI.GetWishlist:output_type -> mikebway.cart.GetWishlistResponse
+	37, // 74: mikebway.cart.CartAPI.AddToWishlist:output_type -> mikebway.cart.AddToWishlistResponse
+	39, // 75: mikebway.cart.CartAPI.RemoveFromWishlist:output_type -> mikebway.cart.RemoveFromWishlistResponse
+	41, // 76: mikebway.cart.CartAPI.GetSharedWishlist:output_type -> mikebway.cart.GetSharedWishlistResponse
+	43, // 77: mikebway.cart.CartAPI.CheckoutShoppingCart:output_type -> mikebway.cart.CheckoutShoppingCartResponse
+	45, // 78: mikebway.cart.CartAPI.AbandonShoppingCart:output_type -> mikebway.cart.AbandonShoppingCartResponse
+	57, // [57:79] is the sub-list for method output_type
+	35, // [35:57] is the sub-list for method input_type
+	35, // [35:35] is the sub-list for extension type_name
+	35, // [35:35] is the sub-list for extension extendee
+	0,  // [0:35] is the sub-list for field type_name
+}
+
+func init() { file_mikebway_cart_cart_api_proto_init() }
+func file_mikebway_cart_cart_api_proto_init() {
+	if File_mikebway_cart_cart_api_proto != nil {
+		return
+	}
+	file_mikebway_cart_cart_proto_init()
+	file_mikebway_cart_item_proto_init()
+	file_mikebway_cart_wishlist_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_mikebway_cart_cart_api_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateShoppingCartRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_cart_cart_api_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateShoppingCartResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_cart_cart_api_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetShoppingCartByIDRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_cart_cart_api_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetShoppingCartByIDResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_cart_cart_api_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchShoppingCartRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_cart_cart_api_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchShoppingCartResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_cart_cart_api_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListShoppingCartsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_cart_cart_api_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListShoppingCartsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_cart_cart_api_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddItemToShoppingCartRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_cart_cart_api_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddItemToShoppingCartResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_cart_cart_api_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveItemFromShoppingCartRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_cart_cart_api_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveItemFromShoppingCartResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_cart_cart_api_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateCartItemRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_cart_cart_api_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateCartItemResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_cart_cart_api_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetDeliveryAddressRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_cart_cart_api_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetDeliveryAddressResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_cart_cart_api_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDeliveryOptionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_cart_cart_api_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDeliveryOptionsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -955,8 +3693,8 @@ func file_mikebway_cart_cart_api_proto_init() {
 				return nil
 			}
 		}
-		file_mikebway_cart_cart_api_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateShoppingCartResponse); i {
+		file_mikebway_cart_cart_api_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetDeliveryOptionRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -967,8 +3705,8 @@ func file_mikebway_cart_cart_api_proto_init() {
 				return nil
 			}
 		}
-		file_mikebway_cart_cart_api_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetShoppingCartByIDRequest); i {
+		file_mikebway_cart_cart_api_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetDeliveryOptionResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -979,8 +3717,8 @@ func file_mikebway_cart_cart_api_proto_init() {
 				return nil
 			}
 		}
-		file_mikebway_cart_cart_api_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetShoppingCartByIDResponse); i {
+		file_mikebway_cart_cart_api_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApplyPromotionCodeRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -991,8 +3729,8 @@ func file_mikebway_cart_cart_api_proto_init() {
 				return nil
 			}
 		}
-		file_mikebway_cart_cart_api_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddItemToShoppingCartRequest); i {
+		file_mikebway_cart_cart_api_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApplyPromotionCodeResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1003,8 +3741,8 @@ func file_mikebway_cart_cart_api_proto_init() {
 				return nil
 			}
 		}
-		file_mikebway_cart_cart_api_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddItemToShoppingCartResponse); i {
+		file_mikebway_cart_cart_api_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemovePromotionCodeRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1015,8 +3753,8 @@ func file_mikebway_cart_cart_api_proto_init() {
 				return nil
 			}
 		}
-		file_mikebway_cart_cart_api_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RemoveItemFromShoppingCartRequest); i {
+		file_mikebway_cart_cart_api_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemovePromotionCodeResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1027,8 +3765,8 @@ func file_mikebway_cart_cart_api_proto_init() {
 				return nil
 			}
 		}
-		file_mikebway_cart_cart_api_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RemoveItemFromShoppingCartResponse); i {
+		file_mikebway_cart_cart_api_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MergeShoppingCartsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1039,8 +3777,8 @@ func file_mikebway_cart_cart_api_proto_init() {
 				return nil
 			}
 		}
-		file_mikebway_cart_cart_api_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetDeliveryAddressRequest); i {
+		file_mikebway_cart_cart_api_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MergeShoppingCartsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1051,8 +3789,8 @@ func file_mikebway_cart_cart_api_proto_init() {
 				return nil
 			}
 		}
-		file_mikebway_cart_cart_api_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetDeliveryAddressResponse); i {
+		file_mikebway_cart_cart_api_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReorderFromOrderRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1063,7 +3801,175 @@ func file_mikebway_cart_cart_api_proto_init() {
 				return nil
 			}
 		}
-		file_mikebway_cart_cart_api_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+		file_mikebway_cart_cart_api_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReorderFromOrderResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_cart_cart_api_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SkippedOrderItem); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_cart_cart_api_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SaveForLaterRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_cart_cart_api_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SaveForLaterResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_cart_cart_api_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MoveToCartRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_cart_cart_api_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MoveToCartResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_cart_cart_api_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetWishlistRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_cart_cart_api_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetWishlistResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_cart_cart_api_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddToWishlistRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_cart_cart_api_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddToWishlistResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_cart_cart_api_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveFromWishlistRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_cart_cart_api_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveFromWishlistResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_cart_cart_api_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSharedWishlistRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_cart_cart_api_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSharedWishlistResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_cart_cart_api_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CheckoutShoppingCartRequest); i {
 			case 0:
 				return &v.state
@@ -1075,7 +3981,7 @@ func file_mikebway_cart_cart_api_proto_init() {
 				return nil
 			}
 		}
-		file_mikebway_cart_cart_api_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+		file_mikebway_cart_cart_api_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CheckoutShoppingCartResponse); i {
 			case 0:
 				return &v.state
@@ -1087,7 +3993,7 @@ func file_mikebway_cart_cart_api_proto_init() {
 				return nil
 			}
 		}
-		file_mikebway_cart_cart_api_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+		file_mikebway_cart_cart_api_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*AbandonShoppingCartRequest); i {
 			case 0:
 				return &v.state
@@ -1099,7 +4005,7 @@ func file_mikebway_cart_cart_api_proto_init() {
 				return nil
 			}
 		}
-		file_mikebway_cart_cart_api_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+		file_mikebway_cart_cart_api_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*AbandonShoppingCartResponse); i {
 			case 0:
 				return &v.state
@@ -1117,13 +4023,14 @@ func file_mikebway_cart_cart_api_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_mikebway_cart_cart_api_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   14,
+			NumEnums:      1,
+			NumMessages:   45,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_mikebway_cart_cart_api_proto_goTypes,
 		DependencyIndexes: file_mikebway_cart_cart_api_proto_depIdxs,
+		EnumInfos:         file_mikebway_cart_cart_api_proto_enumTypes,
 		MessageInfos:      file_mikebway_cart_cart_api_proto_msgTypes,
 	}.Build()
 	File_mikebway_cart_cart_api_proto = out.File
@@ -26,12 +26,43 @@ type CartAPIClient interface {
 	CreateShoppingCart(ctx context.Context, in *CreateShoppingCartRequest, opts ...grpc.CallOption) (*CreateShoppingCartResponse, error)
 	// Retrieve a cart by UUID ID
 	GetShoppingCartByID(ctx context.Context, in *GetShoppingCartByIDRequest, opts ...grpc.CallOption) (*GetShoppingCartByIDResponse, error)
+	// Watch a cart, receiving a fresh copy of it whenever it changes, until it is no longer open
+	WatchShoppingCart(ctx context.Context, in *WatchShoppingCartRequest, opts ...grpc.CallOption) (CartAPI_WatchShoppingCartClient, error)
+	// Get a list of a shopper's shopping carts matching some criteria
+	ListShoppingCarts(ctx context.Context, in *ListShoppingCartsRequest, opts ...grpc.CallOption) (*ListShoppingCartsResponse, error)
 	// Add an item to a cart
 	AddItemToShoppingCart(ctx context.Context, in *AddItemToShoppingCartRequest, opts ...grpc.CallOption) (*AddItemToShoppingCartResponse, error)
 	// Remove an item from the cart
 	RemoveItemFromShoppingCart(ctx context.Context, in *RemoveItemFromShoppingCartRequest, opts ...grpc.CallOption) (*RemoveItemFromShoppingCartResponse, error)
+	// Update selected fields of an item that is already in the cart
+	UpdateCartItem(ctx context.Context, in *UpdateCartItemRequest, opts ...grpc.CallOption) (*UpdateCartItemResponse, error)
 	// Set the delivery address for physical cart items
 	SetDeliveryAddress(ctx context.Context, in *SetDeliveryAddressRequest, opts ...grpc.CallOption) (*SetDeliveryAddressResponse, error)
+	// List the delivery options available for a cart, and what each would cost
+	ListDeliveryOptions(ctx context.Context, in *ListDeliveryOptionsRequest, opts ...grpc.CallOption) (*ListDeliveryOptionsResponse, error)
+	// Choose how the physical items of a cart are to be delivered
+	SetDeliveryOption(ctx context.Context, in *SetDeliveryOptionRequest, opts ...grpc.CallOption) (*SetDeliveryOptionResponse, error)
+	// Apply a promotion code to a cart, earning whatever discount the promotion offers
+	ApplyPromotionCode(ctx context.Context, in *ApplyPromotionCodeRequest, opts ...grpc.CallOption) (*ApplyPromotionCodeResponse, error)
+	// Remove a promotion code, and the discount that it earned, from a cart
+	RemovePromotionCode(ctx context.Context, in *RemovePromotionCodeRequest, opts ...grpc.CallOption) (*RemovePromotionCodeResponse, error)
+	// Move the items and delivery address of one open cart into another, abandoning the first. Typically used to
+	// consolidate the cart that a shopper built as a guest with their own cart when they sign in.
+	MergeShoppingCarts(ctx context.Context, in *MergeShoppingCartsRequest, opts ...grpc.CallOption) (*MergeShoppingCartsResponse, error)
+	// Start a new shopping cart for the shopper of a previous order, holding the same items and delivery address
+	ReorderFromOrder(ctx context.Context, in *ReorderFromOrderRequest, opts ...grpc.CallOption) (*ReorderFromOrderResponse, error)
+	// Move an item out of a shopping cart and onto the shopper's wishlist
+	SaveForLater(ctx context.Context, in *SaveForLaterRequest, opts ...grpc.CallOption) (*SaveForLaterResponse, error)
+	// Move an item off the shopper's wishlist and into a shopping cart
+	MoveToCart(ctx context.Context, in *MoveToCartRequest, opts ...grpc.CallOption) (*MoveToCartResponse, error)
+	// Retrieve a shopper's wishlist
+	GetWishlist(ctx context.Context, in *GetWishlistRequest, opts ...grpc.CallOption) (*GetWishlistResponse, error)
+	// Add an item to a shopper's wishlist
+	AddToWishlist(ctx context.Context, in *AddToWishlistRequest, opts ...grpc.CallOption) (*AddToWishlistResponse, error)
+	// Remove an item from a shopper's wishlist
+	RemoveFromWishlist(ctx context.Context, in *RemoveFromWishlistRequest, opts ...grpc.CallOption) (*RemoveFromWishlistResponse, error)
+	// Retrieve a wishlist that a shopper has shared, without revealing who the shopper is
+	GetSharedWishlist(ctx context.Context, in *GetSharedWishlistRequest, opts ...grpc.CallOption) (*GetSharedWishlistResponse, error)
 	// Submit the order / checkout the shopping cart
 	CheckoutShoppingCart(ctx context.Context, in *CheckoutShoppingCartRequest, opts ...grpc.CallOption) (*CheckoutShoppingCartResponse, error)
 	// Explicitly abandon a shopping cart in response to a user request.
@@ -64,6 +95,47 @@ func (c *cartAPIClient) GetShoppingCartByID(ctx context.Context, in *GetShopping
 	return out, nil
 }
 
+func (c *cartAPIClient) WatchShoppingCart(ctx context.Context, in *WatchShoppingCartRequest, opts ...grpc.CallOption) (CartAPI_WatchShoppingCartClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CartAPI_ServiceDesc.Streams[0], "/mikebway.cart.CartAPI/WatchShoppingCart", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &cartAPIWatchShoppingCartClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CartAPI_WatchShoppingCartClient interface {
+	Recv() (*WatchShoppingCartResponse, error)
+	grpc.ClientStream
+}
+
+type cartAPIWatchShoppingCartClient struct {
+	grpc.ClientStream
+}
+
+func (x *cartAPIWatchShoppingCartClient) Recv() (*WatchShoppingCartResponse, error) {
+	m := new(WatchShoppingCartResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *cartAPIClient) ListShoppingCarts(ctx context.Context, in *ListShoppingCartsRequest, opts ...grpc.CallOption) (*ListShoppingCartsResponse, error) {
+	out := new(ListShoppingCartsResponse)
+	err := c.cc.Invoke(ctx, "/mikebway.cart.CartAPI/ListShoppingCarts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *cartAPIClient) AddItemToShoppingCart(ctx context.Context, in *AddItemToShoppingCartRequest, opts ...grpc.CallOption) (*AddItemToShoppingCartResponse, error) {
 	out := new(AddItemToShoppingCartResponse)
 	err := c.cc.Invoke(ctx, "/mikebway.cart.CartAPI/AddItemToShoppingCart", in, out, opts...)
@@ -82,6 +154,15 @@ func (c *cartAPIClient) RemoveItemFromShoppingCart(ctx context.Context, in *Remo
 	return out, nil
 }
 
+func (c *cartAPIClient) UpdateCartItem(ctx context.Context, in *UpdateCartItemRequest, opts ...grpc.CallOption) (*UpdateCartItemResponse, error) {
+	out := new(UpdateCartItemResponse)
+	err := c.cc.Invoke(ctx, "/mikebway.cart.CartAPI/UpdateCartItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *cartAPIClient) SetDeliveryAddress(ctx context.Context, in *SetDeliveryAddressRequest, opts ...grpc.CallOption) (*SetDeliveryAddressResponse, error) {
 	out := new(SetDeliveryAddressResponse)
 	err := c.cc.Invoke(ctx, "/mikebway.cart.CartAPI/SetDeliveryAddress", in, out, opts...)
@@ -91,6 +172,114 @@ func (c *cartAPIClient) SetDeliveryAddress(ctx context.Context, in *SetDeliveryA
 	return out, nil
 }
 
+func (c *cartAPIClient) ListDeliveryOptions(ctx context.Context, in *ListDeliveryOptionsRequest, opts ...grpc.CallOption) (*ListDeliveryOptionsResponse, error) {
+	out := new(ListDeliveryOptionsResponse)
+	err := c.cc.Invoke(ctx, "/mikebway.cart.CartAPI/ListDeliveryOptions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartAPIClient) SetDeliveryOption(ctx context.Context, in *SetDeliveryOptionRequest, opts ...grpc.CallOption) (*SetDeliveryOptionResponse, error) {
+	out := new(SetDeliveryOptionResponse)
+	err := c.cc.Invoke(ctx, "/mikebway.cart.CartAPI/SetDeliveryOption", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartAPIClient) ApplyPromotionCode(ctx context.Context, in *ApplyPromotionCodeRequest, opts ...grpc.CallOption) (*ApplyPromotionCodeResponse, error) {
+	out := new(ApplyPromotionCodeResponse)
+	err := c.cc.Invoke(ctx, "/mikebway.cart.CartAPI/ApplyPromotionCode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartAPIClient) RemovePromotionCode(ctx context.Context, in *RemovePromotionCodeRequest, opts ...grpc.CallOption) (*RemovePromotionCodeResponse, error) {
+	out := new(RemovePromotionCodeResponse)
+	err := c.cc.Invoke(ctx, "/mikebway.cart.CartAPI/RemovePromotionCode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartAPIClient) MergeShoppingCarts(ctx context.Context, in *MergeShoppingCartsRequest, opts ...grpc.CallOption) (*MergeShoppingCartsResponse, error) {
+	out := new(MergeShoppingCartsResponse)
+	err := c.cc.Invoke(ctx, "/mikebway.cart.CartAPI/MergeShoppingCarts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartAPIClient) ReorderFromOrder(ctx context.Context, in *ReorderFromOrderRequest, opts ...grpc.CallOption) (*ReorderFromOrderResponse, error) {
+	out := new(ReorderFromOrderResponse)
+	err := c.cc.Invoke(ctx, "/mikebway.cart.CartAPI/ReorderFromOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartAPIClient) SaveForLater(ctx context.Context, in *SaveForLaterRequest, opts ...grpc.CallOption) (*SaveForLaterResponse, error) {
+	out := new(SaveForLaterResponse)
+	err := c.cc.Invoke(ctx, "/mikebway.cart.CartAPI/SaveForLater", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartAPIClient) MoveToCart(ctx context.Context, in *MoveToCartRequest, opts ...grpc.CallOption) (*MoveToCartResponse, error) {
+	out := new(MoveToCartResponse)
+	err := c.cc.Invoke(ctx, "/mikebway.cart.CartAPI/MoveToCart", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartAPIClient) GetWishlist(ctx context.Context, in *GetWishlistRequest, opts ...grpc.CallOption) (*GetWishlistResponse, error) {
+	out := new(GetWishlistResponse)
+	err := c.cc.Invoke(ctx, "/mikebway.cart.CartAPI/GetWishlist", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartAPIClient) AddToWishlist(ctx context.Context, in *AddToWishlistRequest, opts ...grpc.CallOption) (*AddToWishlistResponse, error) {
+	out := new(AddToWishlistResponse)
+	err := c.cc.Invoke(ctx, "/mikebway.cart.CartAPI/AddToWishlist", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartAPIClient) RemoveFromWishlist(ctx context.Context, in *RemoveFromWishlistRequest, opts ...grpc.CallOption) (*RemoveFromWishlistResponse, error) {
+	out := new(RemoveFromWishlistResponse)
+	err := c.cc.Invoke(ctx, "/mikebway.cart.CartAPI/RemoveFromWishlist", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartAPIClient) GetSharedWishlist(ctx context.Context, in *GetSharedWishlistRequest, opts ...grpc.CallOption) (*GetSharedWishlistResponse, error) {
+	out := new(GetSharedWishlistResponse)
+	err := c.cc.Invoke(ctx, "/mikebway.cart.CartAPI/GetSharedWishlist", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *cartAPIClient) CheckoutShoppingCart(ctx context.Context, in *CheckoutShoppingCartRequest, opts ...grpc.CallOption) (*CheckoutShoppingCartResponse, error) {
 	out := new(CheckoutShoppingCartResponse)
 	err := c.cc.Invoke(ctx, "/mikebway.cart.CartAPI/CheckoutShoppingCart", in, out, opts...)
@@ -117,12 +306,43 @@ type CartAPIServer interface {
 	CreateShoppingCart(context.Context, *CreateShoppingCartRequest) (*CreateShoppingCartResponse, error)
 	// Retrieve a cart by UUID ID
 	GetShoppingCartByID(context.Context, *GetShoppingCartByIDRequest) (*GetShoppingCartByIDResponse, error)
+	// Watch a cart, receiving a fresh copy of it whenever it changes, until it is no longer open
+	WatchShoppingCart(*WatchShoppingCartRequest, CartAPI_WatchShoppingCartServer) error
+	// Get a list of a shopper's shopping carts matching some criteria
+	ListShoppingCarts(context.Context, *ListShoppingCartsRequest) (*ListShoppingCartsResponse, error)
 	// Add an item to a cart
 	AddItemToShoppingCart(context.Context, *AddItemToShoppingCartRequest) (*AddItemToShoppingCartResponse, error)
 	// Remove an item from the cart
 	RemoveItemFromShoppingCart(context.Context, *RemoveItemFromShoppingCartRequest) (*RemoveItemFromShoppingCartResponse, error)
+	// Update selected fields of an item that is already in the cart
+	UpdateCartItem(context.Context, *UpdateCartItemRequest) (*UpdateCartItemResponse, error)
 	// Set the delivery address for physical cart items
 	SetDeliveryAddress(context.Context, *SetDeliveryAddressRequest) (*SetDeliveryAddressResponse, error)
+	// List the delivery options available for a cart, and what each would cost
+	ListDeliveryOptions(context.Context, *ListDeliveryOptionsRequest) (*ListDeliveryOptionsResponse, error)
+	// Choose how the physical items of a cart are to be delivered
+	SetDeliveryOption(context.Context, *SetDeliveryOptionRequest) (*SetDeliveryOptionResponse, error)
+	// Apply a promotion code to a cart, earning whatever discount the promotion offers
+	ApplyPromotionCode(context.Context, *ApplyPromotionCodeRequest) (*ApplyPromotionCodeResponse, error)
+	// Remove a promotion code, and the discount that it earned, from a cart
+	RemovePromotionCode(context.Context, *RemovePromotionCodeRequest) (*RemovePromotionCodeResponse, error)
+	// Move the items and delivery address of one open cart into another, abandoning the first. Typically used to
+	// consolidate the cart that a shopper built as a guest with their own cart when they sign in.
+	MergeShoppingCarts(context.Context, *MergeShoppingCartsRequest) (*MergeShoppingCartsResponse, error)
+	// Start a new shopping cart for the shopper of a previous order, holding the same items and delivery address
+	ReorderFromOrder(context.Context, *ReorderFromOrderRequest) (*ReorderFromOrderResponse, error)
+	// Move an item out of a shopping cart and onto the shopper's wishlist
+	SaveForLater(context.Context, *SaveForLaterRequest) (*SaveForLaterResponse, error)
+	// Move an item off the shopper's wishlist and into a shopping cart
+	MoveToCart(context.Context, *MoveToCartRequest) (*MoveToCartResponse, error)
+	// Retrieve a shopper's wishlist
+	GetWishlist(context.Context, *GetWishlistRequest) (*GetWishlistResponse, error)
+	// Add an item to a shopper's wishlist
+	AddToWishlist(context.Context, *AddToWishlistRequest) (*AddToWishlistResponse, error)
+	// Remove an item from a shopper's wishlist
+	RemoveFromWishlist(context.Context, *RemoveFromWishlistRequest) (*RemoveFromWishlistResponse, error)
+	// Retrieve a wishlist that a shopper has shared, without revealing who the shopper is
+	GetSharedWishlist(context.Context, *GetSharedWishlistRequest) (*GetSharedWishlistResponse, error)
 	// Submit the order / checkout the shopping cart
 	CheckoutShoppingCart(context.Context, *CheckoutShoppingCartRequest) (*CheckoutShoppingCartResponse, error)
 	// Explicitly abandon a shopping cart in response to a user request.
@@ -140,15 +360,60 @@ func (UnimplementedCartAPIServer) CreateShoppingCart(context.Context, *CreateSho
 func (UnimplementedCartAPIServer) GetShoppingCartByID(context.Context, *GetShoppingCartByIDRequest) (*GetShoppingCartByIDResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetShoppingCartByID not implemented")
 }
+func (UnimplementedCartAPIServer) WatchShoppingCart(*WatchShoppingCartRequest, CartAPI_WatchShoppingCartServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchShoppingCart not implemented")
+}
+func (UnimplementedCartAPIServer) ListShoppingCarts(context.Context, *ListShoppingCartsRequest) (*ListShoppingCartsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListShoppingCarts not implemented")
+}
 func (UnimplementedCartAPIServer) AddItemToShoppingCart(context.Context, *AddItemToShoppingCartRequest) (*AddItemToShoppingCartResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddItemToShoppingCart not implemented")
 }
 func (UnimplementedCartAPIServer) RemoveItemFromShoppingCart(context.Context, *RemoveItemFromShoppingCartRequest) (*RemoveItemFromShoppingCartResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveItemFromShoppingCart not implemented")
 }
+func (UnimplementedCartAPIServer) UpdateCartItem(context.Context, *UpdateCartItemRequest) (*UpdateCartItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCartItem not implemented")
+}
 func (UnimplementedCartAPIServer) SetDeliveryAddress(context.Context, *SetDeliveryAddressRequest) (*SetDeliveryAddressResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetDeliveryAddress not implemented")
 }
+func (UnimplementedCartAPIServer) ListDeliveryOptions(context.Context, *ListDeliveryOptionsRequest) (*ListDeliveryOptionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeliveryOptions not implemented")
+}
+func (UnimplementedCartAPIServer) SetDeliveryOption(context.Context, *SetDeliveryOptionRequest) (*SetDeliveryOptionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetDeliveryOption not implemented")
+}
+func (UnimplementedCartAPIServer) ApplyPromotionCode(context.Context, *ApplyPromotionCodeRequest) (*ApplyPromotionCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyPromotionCode not implemented")
+}
+func (UnimplementedCartAPIServer) RemovePromotionCode(context.Context, *RemovePromotionCodeRequest) (*RemovePromotionCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemovePromotionCode not implemented")
+}
+func (UnimplementedCartAPIServer) MergeShoppingCarts(context.Context, *MergeShoppingCartsRequest) (*MergeShoppingCartsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeShoppingCarts not implemented")
+}
+func (UnimplementedCartAPIServer) ReorderFromOrder(context.Context, *ReorderFromOrderRequest) (*ReorderFromOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReorderFromOrder not implemented")
+}
+func (UnimplementedCartAPIServer) SaveForLater(context.Context, *SaveForLaterRequest) (*SaveForLaterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveForLater not implemented")
+}
+func (UnimplementedCartAPIServer) MoveToCart(context.Context, *MoveToCartRequest) (*MoveToCartResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveToCart not implemented")
+}
+func (UnimplementedCartAPIServer) GetWishlist(context.Context, *GetWishlistRequest) (*GetWishlistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWishlist not implemented")
+}
+func (UnimplementedCartAPIServer) AddToWishlist(context.Context, *AddToWishlistRequest) (*AddToWishlistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddToWishlist not implemented")
+}
+func (UnimplementedCartAPIServer) RemoveFromWishlist(context.Context, *RemoveFromWishlistRequest) (*RemoveFromWishlistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveFromWishlist not implemented")
+}
+func (UnimplementedCartAPIServer) GetSharedWishlist(context.Context, *GetSharedWishlistRequest) (*GetSharedWishlistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSharedWishlist not implemented")
+}
 func (UnimplementedCartAPIServer) CheckoutShoppingCart(context.Context, *CheckoutShoppingCartRequest) (*CheckoutShoppingCartResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckoutShoppingCart not implemented")
 }
@@ -204,6 +469,45 @@ func _CartAPI_GetShoppingCartByID_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CartAPI_WatchShoppingCart_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchShoppingCartRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CartAPIServer).WatchShoppingCart(m, &cartAPIWatchShoppingCartServer{stream})
+}
+
+type CartAPI_WatchShoppingCartServer interface {
+	Send(*WatchShoppingCartResponse) error
+	grpc.ServerStream
+}
+
+type cartAPIWatchShoppingCartServer struct {
+	grpc.ServerStream
+}
+
+func (x *cartAPIWatchShoppingCartServer) Send(m *WatchShoppingCartResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CartAPI_ListShoppingCarts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListShoppingCartsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartAPIServer).ListShoppingCarts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mikebway.cart.CartAPI/ListShoppingCarts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartAPIServer).ListShoppingCarts(ctx, req.(*ListShoppingCartsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CartAPI_AddItemToShoppingCart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddItemToShoppingCartRequest)
 	if err := dec(in); err != nil {
@@ -240,6 +544,24 @@ func _CartAPI_RemoveItemFromShoppingCart_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CartAPI_UpdateCartItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCartItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartAPIServer).UpdateCartItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mikebway.cart.CartAPI/UpdateCartItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartAPIServer).UpdateCartItem(ctx, req.(*UpdateCartItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CartAPI_SetDeliveryAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SetDeliveryAddressRequest)
 	if err := dec(in); err != nil {
@@ -258,6 +580,222 @@ func _CartAPI_SetDeliveryAddress_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CartAPI_ListDeliveryOptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeliveryOptionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartAPIServer).ListDeliveryOptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mikebway.cart.CartAPI/ListDeliveryOptions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartAPIServer).ListDeliveryOptions(ctx, req.(*ListDeliveryOptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartAPI_SetDeliveryOption_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDeliveryOptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartAPIServer).SetDeliveryOption(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mikebway.cart.CartAPI/SetDeliveryOption",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartAPIServer).SetDeliveryOption(ctx, req.(*SetDeliveryOptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartAPI_ApplyPromotionCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyPromotionCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartAPIServer).ApplyPromotionCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mikebway.cart.CartAPI/ApplyPromotionCode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartAPIServer).ApplyPromotionCode(ctx, req.(*ApplyPromotionCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartAPI_RemovePromotionCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemovePromotionCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartAPIServer).RemovePromotionCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mikebway.cart.CartAPI/RemovePromotionCode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartAPIServer).RemovePromotionCode(ctx, req.(*RemovePromotionCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartAPI_MergeShoppingCarts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeShoppingCartsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartAPIServer).MergeShoppingCarts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mikebway.cart.CartAPI/MergeShoppingCarts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartAPIServer).MergeShoppingCarts(ctx, req.(*MergeShoppingCartsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartAPI_ReorderFromOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderFromOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartAPIServer).ReorderFromOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mikebway.cart.CartAPI/ReorderFromOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartAPIServer).ReorderFromOrder(ctx, req.(*ReorderFromOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartAPI_SaveForLater_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveForLaterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartAPIServer).SaveForLater(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mikebway.cart.CartAPI/SaveForLater",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartAPIServer).SaveForLater(ctx, req.(*SaveForLaterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartAPI_MoveToCart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveToCartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartAPIServer).MoveToCart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mikebway.cart.CartAPI/MoveToCart",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartAPIServer).MoveToCart(ctx, req.(*MoveToCartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartAPI_GetWishlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWishlistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartAPIServer).GetWishlist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mikebway.cart.CartAPI/GetWishlist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartAPIServer).GetWishlist(ctx, req.(*GetWishlistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartAPI_AddToWishlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddToWishlistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartAPIServer).AddToWishlist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mikebway.cart.CartAPI/AddToWishlist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartAPIServer).AddToWishlist(ctx, req.(*AddToWishlistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartAPI_RemoveFromWishlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveFromWishlistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartAPIServer).RemoveFromWishlist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mikebway.cart.CartAPI/RemoveFromWishlist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartAPIServer).RemoveFromWishlist(ctx, req.(*RemoveFromWishlistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CartAPI_GetSharedWishlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSharedWishlistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CartAPIServer).GetSharedWishlist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mikebway.cart.CartAPI/GetSharedWishlist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CartAPIServer).GetSharedWishlist(ctx, req.(*GetSharedWishlistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CartAPI_CheckoutShoppingCart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CheckoutShoppingCartRequest)
 	if err := dec(in); err != nil {
@@ -309,6 +847,10 @@ var CartAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetShoppingCartByID",
 			Handler:    _CartAPI_GetShoppingCartByID_Handler,
 		},
+		{
+			MethodName: "ListShoppingCarts",
+			Handler:    _CartAPI_ListShoppingCarts_Handler,
+		},
 		{
 			MethodName: "AddItemToShoppingCart",
 			Handler:    _CartAPI_AddItemToShoppingCart_Handler,
@@ -317,10 +859,62 @@ var CartAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveItemFromShoppingCart",
 			Handler:    _CartAPI_RemoveItemFromShoppingCart_Handler,
 		},
+		{
+			MethodName: "UpdateCartItem",
+			Handler:    _CartAPI_UpdateCartItem_Handler,
+		},
 		{
 			MethodName: "SetDeliveryAddress",
 			Handler:    _CartAPI_SetDeliveryAddress_Handler,
 		},
+		{
+			MethodName: "ListDeliveryOptions",
+			Handler:    _CartAPI_ListDeliveryOptions_Handler,
+		},
+		{
+			MethodName: "SetDeliveryOption",
+			Handler:    _CartAPI_SetDeliveryOption_Handler,
+		},
+		{
+			MethodName: "ApplyPromotionCode",
+			Handler:    _CartAPI_ApplyPromotionCode_Handler,
+		},
+		{
+			MethodName: "RemovePromotionCode",
+			Handler:    _CartAPI_RemovePromotionCode_Handler,
+		},
+		{
+			MethodName: "MergeShoppingCarts",
+			Handler:    _CartAPI_MergeShoppingCarts_Handler,
+		},
+		{
+			MethodName: "ReorderFromOrder",
+			Handler:    _CartAPI_ReorderFromOrder_Handler,
+		},
+		{
+			MethodName: "SaveForLater",
+			Handler:    _CartAPI_SaveForLater_Handler,
+		},
+		{
+			MethodName: "MoveToCart",
+			Handler:    _CartAPI_MoveToCart_Handler,
+		},
+		{
+			MethodName: "GetWishlist",
+			Handler:    _CartAPI_GetWishlist_Handler,
+		},
+		{
+			MethodName: "AddToWishlist",
+			Handler:    _CartAPI_AddToWishlist_Handler,
+		},
+		{
+			MethodName: "RemoveFromWishlist",
+			Handler:    _CartAPI_RemoveFromWishlist_Handler,
+		},
+		{
+			MethodName: "GetSharedWishlist",
+			Handler:    _CartAPI_GetSharedWishlist_Handler,
+		},
 		{
 			MethodName: "CheckoutShoppingCart",
 			Handler:    _CartAPI_CheckoutShoppingCart_Handler,
@@ -330,6 +924,12 @@ var CartAPI_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _CartAPI_AbandonShoppingCart_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchShoppingCart",
+			Handler:       _CartAPI_WatchShoppingCart_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "mikebway/cart/cart_api.proto",
 }
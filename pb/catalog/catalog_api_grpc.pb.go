@@ -0,0 +1,183 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.9
+// source: mikebway/catalog/catalog_api.proto
+
+package catalog
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// CatalogAPIClient is the client API for CatalogAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CatalogAPIClient interface {
+	// Retrieve a product by its product code
+	GetProduct(ctx context.Context, in *GetProductRequest, opts ...grpc.CallOption) (*GetProductResponse, error)
+	// Get a page of products, in product code order
+	ListProducts(ctx context.Context, in *ListProductsRequest, opts ...grpc.CallOption) (*ListProductsResponse, error)
+	// Add a product to the catalog or replace an existing product with the same code
+	UpsertProduct(ctx context.Context, in *UpsertProductRequest, opts ...grpc.CallOption) (*UpsertProductResponse, error)
+}
+
+type catalogAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCatalogAPIClient(cc grpc.ClientConnInterface) CatalogAPIClient {
+	return &catalogAPIClient{cc}
+}
+
+func (c *catalogAPIClient) GetProduct(ctx context.Context, in *GetProductRequest, opts ...grpc.CallOption) (*GetProductResponse, error) {
+	out := new(GetProductResponse)
+	err := c.cc.Invoke(ctx, "/mikebway.catalog.CatalogAPI/GetProduct", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogAPIClient) ListProducts(ctx context.Context, in *ListProductsRequest, opts ...grpc.CallOption) (*ListProductsResponse, error) {
+	out := new(ListProductsResponse)
+	err := c.cc.Invoke(ctx, "/mikebway.catalog.CatalogAPI/ListProducts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogAPIClient) UpsertProduct(ctx context.Context, in *UpsertProductRequest, opts ...grpc.CallOption) (*UpsertProductResponse, error) {
+	out := new(UpsertProductResponse)
+	err := c.cc.Invoke(ctx, "/mikebway.catalog.CatalogAPI/UpsertProduct", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CatalogAPIServer is the server API for CatalogAPI service.
+// All implementations must embed UnimplementedCatalogAPIServer
+// for forward compatibility
+type CatalogAPIServer interface {
+	// Retrieve a product by its product code
+	GetProduct(context.Context, *GetProductRequest) (*GetProductResponse, error)
+	// Get a page of products, in product code order
+	ListProducts(context.Context, *ListProductsRequest) (*ListProductsResponse, error)
+	// Add a product to the catalog or replace an existing product with the same code
+	UpsertProduct(context.Context, *UpsertProductRequest) (*UpsertProductResponse, error)
+	mustEmbedUnimplementedCatalogAPIServer()
+}
+
+// UnimplementedCatalogAPIServer must be embedded to have forward compatible implementations.
+type UnimplementedCatalogAPIServer struct {
+}
+
+func (UnimplementedCatalogAPIServer) GetProduct(context.Context, *GetProductRequest) (*GetProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProduct not implemented")
+}
+func (UnimplementedCatalogAPIServer) ListProducts(context.Context, *ListProductsRequest) (*ListProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProducts not implemented")
+}
+func (UnimplementedCatalogAPIServer) UpsertProduct(context.Context, *UpsertProductRequest) (*UpsertProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpsertProduct not implemented")
+}
+func (UnimplementedCatalogAPIServer) mustEmbedUnimplementedCatalogAPIServer() {}
+
+// UnsafeCatalogAPIServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CatalogAPIServer will
+// result in compilation errors.
+type UnsafeCatalogAPIServer interface {
+	mustEmbedUnimplementedCatalogAPIServer()
+}
+
+func RegisterCatalogAPIServer(s grpc.ServiceRegistrar, srv CatalogAPIServer) {
+	s.RegisterService(&CatalogAPI_ServiceDesc, srv)
+}
+
+func _CatalogAPI_GetProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogAPIServer).GetProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mikebway.catalog.CatalogAPI/GetProduct",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogAPIServer).GetProduct(ctx, req.(*GetProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogAPI_ListProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogAPIServer).ListProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mikebway.catalog.CatalogAPI/ListProducts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogAPIServer).ListProducts(ctx, req.(*ListProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogAPI_UpsertProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogAPIServer).UpsertProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mikebway.catalog.CatalogAPI/UpsertProduct",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogAPIServer).UpsertProduct(ctx, req.(*UpsertProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CatalogAPI_ServiceDesc is the grpc.ServiceDesc for CatalogAPI service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CatalogAPI_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "mikebway.catalog.CatalogAPI",
+	HandlerType: (*CatalogAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetProduct",
+			Handler:    _CatalogAPI_GetProduct_Handler,
+		},
+		{
+			MethodName: "ListProducts",
+			Handler:    _CatalogAPI_ListProducts_Handler,
+		},
+		{
+			MethodName: "UpsertProduct",
+			Handler:    _CatalogAPI_UpsertProduct_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "mikebway/catalog/catalog_api.proto",
+}
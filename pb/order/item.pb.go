@@ -7,6 +7,7 @@
 package order
 
 import (
+	types "github.com/mikebway/poc-gcp-ecomm/pb/types"
 	money "google.golang.org/genproto/googleapis/type/money"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
@@ -21,6 +22,63 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// An enumeration of the possible states of an order item, as rolled up from the status of the fulfillment tasks
+// recorded for it
+type OrderItemStatus int32
+
+const (
+	OrderItemStatus_OIS_UNSPECIFIED OrderItemStatus = 0 // The status has not been set, e.g. for orders recorded before statuses were introduced
+	OrderItemStatus_OIS_PENDING     OrderItemStatus = 1 // No work has started on the item, e.g. because payment for the order has yet to be captured
+	OrderItemStatus_OIS_IN_PROGRESS OrderItemStatus = 2 // Fulfillment of the item has started but not all of its tasks have been completed
+	OrderItemStatus_OIS_COMPLETE    OrderItemStatus = 3 // All the tasks for the item have been completed, or canceled as no longer needed
+	OrderItemStatus_OIS_CANCELLED   OrderItemStatus = 4 // All the tasks for the item have been canceled
+)
+
+// Enum value maps for OrderItemStatus.
+var (
+	OrderItemStatus_name = map[int32]string{
+		0: "OIS_UNSPECIFIED",
+		1: "OIS_PENDING",
+		2: "OIS_IN_PROGRESS",
+		3: "OIS_COMPLETE",
+		4: "OIS_CANCELLED",
+	}
+	OrderItemStatus_value = map[string]int32{
+		"OIS_UNSPECIFIED": 0,
+		"OIS_PENDING":     1,
+		"OIS_IN_PROGRESS": 2,
+		"OIS_COMPLETE":    3,
+		"OIS_CANCELLED":   4,
+	}
+)
+
+func (x OrderItemStatus) Enum() *OrderItemStatus {
+	p := new(OrderItemStatus)
+	*p = x
+	return p
+}
+
+func (x OrderItemStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderItemStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_mikebway_order_item_proto_enumTypes[0].Descriptor()
+}
+
+func (OrderItemStatus) Type() protoreflect.EnumType {
+	return &file_mikebway_order_item_proto_enumTypes[0]
+}
+
+func (x OrderItemStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderItemStatus.Descriptor instead.
+func (OrderItemStatus) EnumDescriptor() ([]byte, []int) {
+	return file_mikebway_order_item_proto_rawDescGZIP(), []int{0}
+}
+
 // OrderItem represents a single entry in an order. An order will contain one
 // to many order items.
 type OrderItem struct {
@@ -39,6 +97,13 @@ type OrderItem struct {
 	// The unit price is the price that the customer was shown for a single item
 	// when they selected the item for their cart.
 	UnitPrice *money.Money `protobuf:"bytes,4,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	// The unit price multiplied by the quantity
+	Subtotal *money.Money `protobuf:"bytes,5,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	// Attributes describe how the item is to be customized, e.g. the text to be engraved on it, as chosen by the
+	// shopper in their cart.
+	Attributes []*types.Attribute `protobuf:"bytes,6,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	// The progress of the item towards fulfillment, rolled up from the status of its fulfillment tasks
+	Status OrderItemStatus `protobuf:"varint,7,opt,name=status,proto3,enum=mikebway.order.OrderItemStatus" json:"status,omitempty"`
 }
 
 func (x *OrderItem) Reset() {
@@ -101,6 +166,27 @@ func (x *OrderItem) GetUnitPrice() *money.Money {
 	return nil
 }
 
+func (x *OrderItem) GetSubtotal() *money.Money {
+	if x != nil {
+		return x.Subtotal
+	}
+	return nil
+}
+
+func (x *OrderItem) GetAttributes() []*types.Attribute {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *OrderItem) GetStatus() OrderItemStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderItemStatus_OIS_UNSPECIFIED
+}
+
 var File_mikebway_order_item_proto protoreflect.FileDescriptor
 
 var file_mikebway_order_item_proto_rawDesc = []byte{
@@ -108,7 +194,9 @@ var file_mikebway_order_item_proto_rawDesc = []byte{
 	0x2f, 0x69, 0x74, 0x65, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0e, 0x6d, 0x69, 0x6b,
 	0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x1a, 0x17, 0x67, 0x6f, 0x6f,
 	0x67, 0x6c, 0x65, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x6d, 0x6f, 0x6e, 0x65, 0x79, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x8d, 0x01, 0x0a, 0x09, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x74,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2f, 0x74,
+	0x79, 0x70, 0x65, 0x73, 0x2f, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb1, 0x02, 0x0a, 0x09, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x74,
 	0x65, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
 	0x69, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x5f, 0x63, 0x6f,
 	0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63,
@@ -117,10 +205,28 @@ var file_mikebway_order_item_proto_rawDesc = []byte{
 	0x79, 0x12, 0x31, 0x0a, 0x0a, 0x75, 0x6e, 0x69, 0x74, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18,
 	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x74,
 	0x79, 0x70, 0x65, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x09, 0x75, 0x6e, 0x69, 0x74, 0x50,
-	0x72, 0x69, 0x63, 0x65, 0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
-	0x6f, 0x6d, 0x2f, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2f, 0x70, 0x6f, 0x63, 0x2d,
-	0x67, 0x63, 0x70, 0x2d, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x2f, 0x70, 0x62, 0x2f, 0x6f, 0x72, 0x64,
-	0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x72, 0x69, 0x63, 0x65, 0x12, 0x2e, 0x0a, 0x08, 0x73, 0x75, 0x62, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x74, 0x79, 0x70, 0x65, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x08, 0x73, 0x75, 0x62, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x12, 0x39, 0x0a, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74,
+	0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62,
+	0x77, 0x61, 0x79, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62,
+	0x75, 0x74, 0x65, 0x52, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x12,
+	0x37, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x1f, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2a, 0x71, 0x0a, 0x0f, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x49, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x13, 0x0a, 0x0f, 0x4f,
+	0x49, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x0f, 0x0a, 0x0b, 0x4f, 0x49, 0x53, 0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10,
+	0x01, 0x12, 0x13, 0x0a, 0x0f, 0x4f, 0x49, 0x53, 0x5f, 0x49, 0x4e, 0x5f, 0x50, 0x52, 0x4f, 0x47,
+	0x52, 0x45, 0x53, 0x53, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x4f, 0x49, 0x53, 0x5f, 0x43, 0x4f,
+	0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x03, 0x12, 0x11, 0x0a, 0x0d, 0x4f, 0x49, 0x53, 0x5f,
+	0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x4c, 0x45, 0x44, 0x10, 0x04, 0x42, 0x2c, 0x5a, 0x2a, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77,
+	0x61, 0x79, 0x2f, 0x70, 0x6f, 0x63, 0x2d, 0x67, 0x63, 0x70, 0x2d, 0x65, 0x63, 0x6f, 0x6d, 0x6d,
+	0x2f, 0x70, 0x62, 0x2f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -135,18 +241,24 @@ func file_mikebway_order_item_proto_rawDescGZIP() []byte {
 	return file_mikebway_order_item_proto_rawDescData
 }
 
+var file_mikebway_order_item_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
 var file_mikebway_order_item_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
 var file_mikebway_order_item_proto_goTypes = []interface{}{
-	(*OrderItem)(nil),   // 0: mikebway.order.OrderItem
-	(*money.Money)(nil), // 1: google.type.Money
+	(OrderItemStatus)(0),    // 0: mikebway.order.OrderItemStatus
+	(*OrderItem)(nil),       // 1: mikebway.order.OrderItem
+	(*money.Money)(nil),     // 2: google.type.Money
+	(*types.Attribute)(nil), // 3: mikebway.types.Attribute
 }
 var file_mikebway_order_item_proto_depIdxs = []int32{
-	1, // 0: mikebway.order.OrderItem.unit_price:type_name -> google.type.Money
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	2, // 0: mikebway.order.OrderItem.unit_price:type_name -> google.type.Money
+	2, // 1: mikebway.order.OrderItem.subtotal:type_name -> google.type.Money
+	3, // 2: mikebway.order.OrderItem.attributes:type_name -> mikebway.types.Attribute
+	0, // 3: mikebway.order.OrderItem.status:type_name -> mikebway.order.OrderItemStatus
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_mikebway_order_item_proto_init() }
@@ -173,13 +285,14 @@ func file_mikebway_order_item_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_mikebway_order_item_proto_rawDesc,
-			NumEnums:      0,
+			NumEnums:      1,
 			NumMessages:   1,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_mikebway_order_item_proto_goTypes,
 		DependencyIndexes: file_mikebway_order_item_proto_depIdxs,
+		EnumInfos:         file_mikebway_order_item_proto_enumTypes,
 		MessageInfos:      file_mikebway_order_item_proto_msgTypes,
 	}.Build()
 	File_mikebway_order_item_proto = out.File
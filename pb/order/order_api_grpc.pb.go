@@ -22,10 +22,12 @@ const _ = grpc.SupportPackageIsVersion7
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type OrderAPIClient interface {
-	// Get a specified order
+	// Get a specified order, including its status and that of each of its items
 	GetOrderByID(ctx context.Context, in *GetOrderByIDRequest, opts ...grpc.CallOption) (*GetOrderByIDResponse, error)
 	// Get a list of orders matching some criteria
 	GetOrders(ctx context.Context, in *GetOrdersRequest, opts ...grpc.CallOption) (*GetOrdersResponse, error)
+	// Cancel an order, cancelling all of its outstanding fulfillment tasks
+	CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error)
 }
 
 type orderAPIClient struct {
@@ -54,14 +56,25 @@ func (c *orderAPIClient) GetOrders(ctx context.Context, in *GetOrdersRequest, op
 	return out, nil
 }
 
+func (c *orderAPIClient) CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error) {
+	out := new(CancelOrderResponse)
+	err := c.cc.Invoke(ctx, "/mikebway.order.OrderAPI/CancelOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OrderAPIServer is the server API for OrderAPI service.
 // All implementations must embed UnimplementedOrderAPIServer
 // for forward compatibility
 type OrderAPIServer interface {
-	// Get a specified order
+	// Get a specified order, including its status and that of each of its items
 	GetOrderByID(context.Context, *GetOrderByIDRequest) (*GetOrderByIDResponse, error)
 	// Get a list of orders matching some criteria
 	GetOrders(context.Context, *GetOrdersRequest) (*GetOrdersResponse, error)
+	// Cancel an order, cancelling all of its outstanding fulfillment tasks
+	CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderResponse, error)
 	mustEmbedUnimplementedOrderAPIServer()
 }
 
@@ -75,6 +88,9 @@ func (UnimplementedOrderAPIServer) GetOrderByID(context.Context, *GetOrderByIDRe
 func (UnimplementedOrderAPIServer) GetOrders(context.Context, *GetOrdersRequest) (*GetOrdersResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetOrders not implemented")
 }
+func (UnimplementedOrderAPIServer) CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelOrder not implemented")
+}
 func (UnimplementedOrderAPIServer) mustEmbedUnimplementedOrderAPIServer() {}
 
 // UnsafeOrderAPIServer may be embedded to opt out of forward compatibility for this service.
@@ -124,6 +140,24 @@ func _OrderAPI_GetOrders_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrderAPI_CancelOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderAPIServer).CancelOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mikebway.order.OrderAPI/CancelOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderAPIServer).CancelOrder(ctx, req.(*CancelOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OrderAPI_ServiceDesc is the grpc.ServiceDesc for OrderAPI service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -139,6 +173,10 @@ var OrderAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetOrders",
 			Handler:    _OrderAPI_GetOrders_Handler,
 		},
+		{
+			MethodName: "CancelOrder",
+			Handler:    _OrderAPI_CancelOrder_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "mikebway/order/order_api.proto",
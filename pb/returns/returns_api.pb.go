@@ -0,0 +1,618 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.9
+// source: mikebway/returns/returns_api.proto
+
+package returns
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Request parameters for the OpenReturn API
+type OpenReturnRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// REQUIRED. The UUID ID of the order that the items to be returned were purchased in
+	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// REQUIRED. The order items, and how many of each, that are to be returned
+	Items []*ReturnItemRequest `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	// REQUIRED. The code identifying why the customer is returning the items, e.g. "damaged" or "unwanted"
+	ReasonCode string `protobuf:"bytes,3,opt,name=reason_code,json=reasonCode,proto3" json:"reason_code,omitempty"`
+}
+
+func (x *OpenReturnRequest) Reset() {
+	*x = OpenReturnRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_returns_returns_api_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OpenReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenReturnRequest) ProtoMessage() {}
+
+func (x *OpenReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_returns_returns_api_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenReturnRequest.ProtoReflect.Descriptor instead.
+func (*OpenReturnRequest) Descriptor() ([]byte, []int) {
+	return file_mikebway_returns_returns_api_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OpenReturnRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *OpenReturnRequest) GetItems() []*ReturnItemRequest {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *OpenReturnRequest) GetReasonCode() string {
+	if x != nil {
+		return x.ReasonCode
+	}
+	return ""
+}
+
+// A single order item, and how many of it, that is to be returned
+type ReturnItemRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// REQUIRED. The UUID ID of the order item to be returned
+	OrderItemId string `protobuf:"bytes,1,opt,name=order_item_id,json=orderItemId,proto3" json:"order_item_id,omitempty"`
+	// REQUIRED. The number of the order item to be returned. Must be at least one and no more than the quantity
+	// ordered less any that are already being, or have been, returned.
+	Quantity int32 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+}
+
+func (x *ReturnItemRequest) Reset() {
+	*x = ReturnItemRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_returns_returns_api_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReturnItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnItemRequest) ProtoMessage() {}
+
+func (x *ReturnItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_returns_returns_api_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnItemRequest.ProtoReflect.Descriptor instead.
+func (*ReturnItemRequest) Descriptor() ([]byte, []int) {
+	return file_mikebway_returns_returns_api_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ReturnItemRequest) GetOrderItemId() string {
+	if x != nil {
+		return x.OrderItemId
+	}
+	return ""
+}
+
+func (x *ReturnItemRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// Response parameters for the OpenReturn API
+type OpenReturnResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The return request that was opened
+	ReturnRequest *ReturnRequest `protobuf:"bytes,1,opt,name=return_request,json=returnRequest,proto3" json:"return_request,omitempty"`
+}
+
+func (x *OpenReturnResponse) Reset() {
+	*x = OpenReturnResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_returns_returns_api_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OpenReturnResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenReturnResponse) ProtoMessage() {}
+
+func (x *OpenReturnResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_returns_returns_api_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenReturnResponse.ProtoReflect.Descriptor instead.
+func (*OpenReturnResponse) Descriptor() ([]byte, []int) {
+	return file_mikebway_returns_returns_api_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OpenReturnResponse) GetReturnRequest() *ReturnRequest {
+	if x != nil {
+		return x.ReturnRequest
+	}
+	return nil
+}
+
+// Request parameters for the GetReturnByID API
+type GetReturnByIDRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// REQUIRED. The UUID ID of the return request to be retrieved
+	ReturnId string `protobuf:"bytes,1,opt,name=return_id,json=returnId,proto3" json:"return_id,omitempty"`
+}
+
+func (x *GetReturnByIDRequest) Reset() {
+	*x = GetReturnByIDRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_returns_returns_api_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetReturnByIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReturnByIDRequest) ProtoMessage() {}
+
+func (x *GetReturnByIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_returns_returns_api_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReturnByIDRequest.ProtoReflect.Descriptor instead.
+func (*GetReturnByIDRequest) Descriptor() ([]byte, []int) {
+	return file_mikebway_returns_returns_api_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetReturnByIDRequest) GetReturnId() string {
+	if x != nil {
+		return x.ReturnId
+	}
+	return ""
+}
+
+// Response parameters for the GetReturnByID API
+type GetReturnByIDResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The return request requested
+	ReturnRequest *ReturnRequest `protobuf:"bytes,1,opt,name=return_request,json=returnRequest,proto3" json:"return_request,omitempty"`
+}
+
+func (x *GetReturnByIDResponse) Reset() {
+	*x = GetReturnByIDResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_returns_returns_api_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetReturnByIDResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReturnByIDResponse) ProtoMessage() {}
+
+func (x *GetReturnByIDResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_returns_returns_api_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReturnByIDResponse.ProtoReflect.Descriptor instead.
+func (*GetReturnByIDResponse) Descriptor() ([]byte, []int) {
+	return file_mikebway_returns_returns_api_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetReturnByIDResponse) GetReturnRequest() *ReturnRequest {
+	if x != nil {
+		return x.ReturnRequest
+	}
+	return nil
+}
+
+// Request parameters for the GetOrderReturns API
+type GetOrderReturnsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// REQUIRED. The UUID ID of the order whose return requests are to be retrieved
+	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+}
+
+func (x *GetOrderReturnsRequest) Reset() {
+	*x = GetOrderReturnsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_returns_returns_api_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetOrderReturnsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderReturnsRequest) ProtoMessage() {}
+
+func (x *GetOrderReturnsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_returns_returns_api_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderReturnsRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderReturnsRequest) Descriptor() ([]byte, []int) {
+	return file_mikebway_returns_returns_api_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetOrderReturnsRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+// Response parameters for the GetOrderReturns API
+type GetOrderReturnsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The return requests opened for the order, oldest first. Empty if there have been none.
+	ReturnRequests []*ReturnRequest `protobuf:"bytes,1,rep,name=return_requests,json=returnRequests,proto3" json:"return_requests,omitempty"`
+}
+
+func (x *GetOrderReturnsResponse) Reset() {
+	*x = GetOrderReturnsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mikebway_returns_returns_api_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetOrderReturnsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderReturnsResponse) ProtoMessage() {}
+
+func (x *GetOrderReturnsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mikebway_returns_returns_api_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderReturnsResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderReturnsResponse) Descriptor() ([]byte, []int) {
+	return file_mikebway_returns_returns_api_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetOrderReturnsResponse) GetReturnRequests() []*ReturnRequest {
+	if x != nil {
+		return x.ReturnRequests
+	}
+	return nil
+}
+
+var File_mikebway_returns_returns_api_proto protoreflect.FileDescriptor
+
+var file_mikebway_returns_returns_api_proto_rawDesc = []byte{
+	0x0a, 0x22, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2f, 0x72, 0x65, 0x74, 0x75, 0x72,
+	0x6e, 0x73, 0x2f, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x73, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x10, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x72,
+	0x65, 0x74, 0x75, 0x72, 0x6e, 0x73, 0x1a, 0x1e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79,
+	0x2f, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x73, 0x2f, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x73,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x8a, 0x01, 0x0a, 0x11, 0x4f, 0x70, 0x65, 0x6e, 0x52,
+	0x65, 0x74, 0x75, 0x72, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61,
+	0x79, 0x2e, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x73, 0x2e, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e,
+	0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x05, 0x69, 0x74, 0x65,
+	0x6d, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x64,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x43,
+	0x6f, 0x64, 0x65, 0x22, 0x53, 0x0a, 0x11, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x49, 0x74, 0x65,
+	0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x5f, 0x69, 0x74, 0x65, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x74, 0x65, 0x6d, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08,
+	0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x22, 0x5c, 0x0a, 0x12, 0x4f, 0x70, 0x65, 0x6e,
+	0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46,
+	0x0a, 0x0e, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61,
+	0x79, 0x2e, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x73, 0x2e, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x0d, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x33, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x52, 0x65, 0x74,
+	0x75, 0x72, 0x6e, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b,
+	0x0a, 0x09, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x49, 0x64, 0x22, 0x5f, 0x0a, 0x15, 0x47,
+	0x65, 0x74, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x0e, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x5f, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6d,
+	0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x73, 0x2e,
+	0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x0d, 0x72,
+	0x65, 0x74, 0x75, 0x72, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x33, 0x0a, 0x16,
+	0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49,
+	0x64, 0x22, 0x63, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x74,
+	0x75, 0x72, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x0f,
+	0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79,
+	0x2e, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x73, 0x2e, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x0e, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x32, 0xb5, 0x02, 0x0a, 0x0a, 0x52, 0x65, 0x74, 0x75, 0x72,
+	0x6e, 0x73, 0x41, 0x50, 0x49, 0x12, 0x59, 0x0a, 0x0a, 0x4f, 0x70, 0x65, 0x6e, 0x52, 0x65, 0x74,
+	0x75, 0x72, 0x6e, 0x12, 0x23, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x72,
+	0x65, 0x74, 0x75, 0x72, 0x6e, 0x73, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x52, 0x65, 0x74, 0x75, 0x72,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62,
+	0x77, 0x61, 0x79, 0x2e, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x73, 0x2e, 0x4f, 0x70, 0x65, 0x6e,
+	0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x62, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x42, 0x79, 0x49,
+	0x44, 0x12, 0x26, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x72, 0x65, 0x74,
+	0x75, 0x72, 0x6e, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x42, 0x79,
+	0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x6d, 0x69, 0x6b, 0x65,
+	0x62, 0x77, 0x61, 0x79, 0x2e, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x73, 0x2e, 0x47, 0x65, 0x74,
+	0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x68, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x73, 0x12, 0x28, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77,
+	0x61, 0x79, 0x2e, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x72,
+	0x64, 0x65, 0x72, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x29, 0x2e, 0x6d, 0x69, 0x6b, 0x65, 0x62, 0x77, 0x61, 0x79, 0x2e, 0x72, 0x65, 0x74,
+	0x75, 0x72, 0x6e, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x74,
+	0x75, 0x72, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x2e,
+	0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x69, 0x6b,
+	0x65, 0x62, 0x77, 0x61, 0x79, 0x2f, 0x70, 0x6f, 0x63, 0x2d, 0x67, 0x63, 0x70, 0x2d, 0x65, 0x63,
+	0x6f, 0x6d, 0x6d, 0x2f, 0x70, 0x62, 0x2f, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x73, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_mikebway_returns_returns_api_proto_rawDescOnce sync.Once
+	file_mikebway_returns_returns_api_proto_rawDescData = file_mikebway_returns_returns_api_proto_rawDesc
+)
+
+func file_mikebway_returns_returns_api_proto_rawDescGZIP() []byte {
+	file_mikebway_returns_returns_api_proto_rawDescOnce.Do(func() {
+		file_mikebway_returns_returns_api_proto_rawDescData = protoimpl.X.CompressGZIP(file_mikebway_returns_returns_api_proto_rawDescData)
+	})
+	return file_mikebway_returns_returns_api_proto_rawDescData
+}
+
+var file_mikebway_returns_returns_api_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_mikebway_returns_returns_api_proto_goTypes = []interface{}{
+	(*OpenReturnRequest)(nil),       // 0: mikebway.returns.OpenReturnRequest
+	(*ReturnItemRequest)(nil),       // 1: mikebway.returns.ReturnItemRequest
+	(*OpenReturnResponse)(nil),      // 2: mikebway.returns.OpenReturnResponse
+	(*GetReturnByIDRequest)(nil),    // 3: mikebway.returns.GetReturnByIDRequest
+	(*GetReturnByIDResponse)(nil),   // 4: mikebway.returns.GetReturnByIDResponse
+	(*GetOrderReturnsRequest)(nil),  // 5: mikebway.returns.GetOrderReturnsRequest
+	(*GetOrderReturnsResponse)(nil), // 6: mikebway.returns.GetOrderReturnsResponse
+	(*ReturnRequest)(nil),           // 7: mikebway.returns.ReturnRequest
+}
+var file_mikebway_returns_returns_api_proto_depIdxs = []int32{
+	1, // 0: mikebway.returns.OpenReturnRequest.items:type_name -> mikebway.returns.ReturnItemRequest
+	7, // 1: mikebway.returns.OpenReturnResponse.return_request:type_name -> mikebway.returns.ReturnRequest
+	7, // 2: mikebway.returns.GetReturnByIDResponse.return_request:type_name -> mikebway.returns.ReturnRequest
+	7, // 3: mikebway.returns.GetOrderReturnsResponse.return_requests:type_name -> mikebway.returns.ReturnRequest
+	0, // 4: mikebway.returns.ReturnsAPI.OpenReturn:input_type -> mikebway.returns.OpenReturnRequest
+	3, // 5: mikebway.returns.ReturnsAPI.GetReturnByID:input_type -> mikebway.returns.GetReturnByIDRequest
+	5, // 6: mikebway.returns.ReturnsAPI.GetOrderReturns:input_type -> mikebway.returns.GetOrderReturnsRequest
+	2, // 7: mikebway.returns.ReturnsAPI.OpenReturn:output_type -> mikebway.returns.OpenReturnResponse
+	4, // 8: mikebway.returns.ReturnsAPI.GetReturnByID:output_type -> mikebway.returns.GetReturnByIDResponse
+	6, // 9: mikebway.returns.ReturnsAPI.GetOrderReturns:output_type -> mikebway.returns.GetOrderReturnsResponse
+	7, // [7:10] is the sub-list for method output_type
+	4, // [4:7] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_mikebway_returns_returns_api_proto_init() }
+func file_mikebway_returns_returns_api_proto_init() {
+	if File_mikebway_returns_returns_api_proto != nil {
+		return
+	}
+	file_mikebway_returns_returns_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_mikebway_returns_returns_api_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OpenReturnRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_returns_returns_api_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReturnItemRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_returns_returns_api_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OpenReturnResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_returns_returns_api_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetReturnByIDRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_returns_returns_api_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetReturnByIDResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_returns_returns_api_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetOrderReturnsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mikebway_returns_returns_api_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetOrderReturnsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_mikebway_returns_returns_api_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_mikebway_returns_returns_api_proto_goTypes,
+		DependencyIndexes: file_mikebway_returns_returns_api_proto_depIdxs,
+		MessageInfos:      file_mikebway_returns_returns_api_proto_msgTypes,
+	}.Build()
+	File_mikebway_returns_returns_api_proto = out.File
+	file_mikebway_returns_returns_api_proto_rawDesc = nil
+	file_mikebway_returns_returns_api_proto_goTypes = nil
+	file_mikebway_returns_returns_api_proto_depIdxs = nil
+}
@@ -0,0 +1,183 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.9
+// source: mikebway/returns/returns_api.proto
+
+package returns
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ReturnsAPIClient is the client API for ReturnsAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ReturnsAPIClient interface {
+	// Open a return request (RMA) for some or all of the delivered items of an order
+	OpenReturn(ctx context.Context, in *OpenReturnRequest, opts ...grpc.CallOption) (*OpenReturnResponse, error)
+	// Get a specified return request, including its status
+	GetReturnByID(ctx context.Context, in *GetReturnByIDRequest, opts ...grpc.CallOption) (*GetReturnByIDResponse, error)
+	// Get all of the return requests that have been opened for an order
+	GetOrderReturns(ctx context.Context, in *GetOrderReturnsRequest, opts ...grpc.CallOption) (*GetOrderReturnsResponse, error)
+}
+
+type returnsAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReturnsAPIClient(cc grpc.ClientConnInterface) ReturnsAPIClient {
+	return &returnsAPIClient{cc}
+}
+
+func (c *returnsAPIClient) OpenReturn(ctx context.Context, in *OpenReturnRequest, opts ...grpc.CallOption) (*OpenReturnResponse, error) {
+	out := new(OpenReturnResponse)
+	err := c.cc.Invoke(ctx, "/mikebway.returns.ReturnsAPI/OpenReturn", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *returnsAPIClient) GetReturnByID(ctx context.Context, in *GetReturnByIDRequest, opts ...grpc.CallOption) (*GetReturnByIDResponse, error) {
+	out := new(GetReturnByIDResponse)
+	err := c.cc.Invoke(ctx, "/mikebway.returns.ReturnsAPI/GetReturnByID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *returnsAPIClient) GetOrderReturns(ctx context.Context, in *GetOrderReturnsRequest, opts ...grpc.CallOption) (*GetOrderReturnsResponse, error) {
+	out := new(GetOrderReturnsResponse)
+	err := c.cc.Invoke(ctx, "/mikebway.returns.ReturnsAPI/GetOrderReturns", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReturnsAPIServer is the server API for ReturnsAPI service.
+// All implementations must embed UnimplementedReturnsAPIServer
+// for forward compatibility
+type ReturnsAPIServer interface {
+	// Open a return request (RMA) for some or all of the delivered items of an order
+	OpenReturn(context.Context, *OpenReturnRequest) (*OpenReturnResponse, error)
+	// Get a specified return request, including its status
+	GetReturnByID(context.Context, *GetReturnByIDRequest) (*GetReturnByIDResponse, error)
+	// Get all of the return requests that have been opened for an order
+	GetOrderReturns(context.Context, *GetOrderReturnsRequest) (*GetOrderReturnsResponse, error)
+	mustEmbedUnimplementedReturnsAPIServer()
+}
+
+// UnimplementedReturnsAPIServer must be embedded to have forward compatible implementations.
+type UnimplementedReturnsAPIServer struct {
+}
+
+func (UnimplementedReturnsAPIServer) OpenReturn(context.Context, *OpenReturnRequest) (*OpenReturnResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OpenReturn not implemented")
+}
+func (UnimplementedReturnsAPIServer) GetReturnByID(context.Context, *GetReturnByIDRequest) (*GetReturnByIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReturnByID not implemented")
+}
+func (UnimplementedReturnsAPIServer) GetOrderReturns(context.Context, *GetOrderReturnsRequest) (*GetOrderReturnsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderReturns not implemented")
+}
+func (UnimplementedReturnsAPIServer) mustEmbedUnimplementedReturnsAPIServer() {}
+
+// UnsafeReturnsAPIServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReturnsAPIServer will
+// result in compilation errors.
+type UnsafeReturnsAPIServer interface {
+	mustEmbedUnimplementedReturnsAPIServer()
+}
+
+func RegisterReturnsAPIServer(s grpc.ServiceRegistrar, srv ReturnsAPIServer) {
+	s.RegisterService(&ReturnsAPI_ServiceDesc, srv)
+}
+
+func _ReturnsAPI_OpenReturn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenReturnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReturnsAPIServer).OpenReturn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mikebway.returns.ReturnsAPI/OpenReturn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReturnsAPIServer).OpenReturn(ctx, req.(*OpenReturnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReturnsAPI_GetReturnByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReturnByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReturnsAPIServer).GetReturnByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mikebway.returns.ReturnsAPI/GetReturnByID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReturnsAPIServer).GetReturnByID(ctx, req.(*GetReturnByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReturnsAPI_GetOrderReturns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderReturnsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReturnsAPIServer).GetOrderReturns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mikebway.returns.ReturnsAPI/GetOrderReturns",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReturnsAPIServer).GetOrderReturns(ctx, req.(*GetOrderReturnsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ReturnsAPI_ServiceDesc is the grpc.ServiceDesc for ReturnsAPI service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ReturnsAPI_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "mikebway.returns.ReturnsAPI",
+	HandlerType: (*ReturnsAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "OpenReturn",
+			Handler:    _ReturnsAPI_OpenReturn_Handler,
+		},
+		{
+			MethodName: "GetReturnByID",
+			Handler:    _ReturnsAPI_GetReturnByID_Handler,
+		},
+		{
+			MethodName: "GetOrderReturns",
+			Handler:    _ReturnsAPI_GetOrderReturns_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "mikebway/returns/returns_api.proto",
+}
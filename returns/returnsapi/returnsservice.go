@@ -0,0 +1,455 @@
+// Package returnsapi contains the gRPC Returns microservice implementation.
+package returnsapi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/google/uuid"
+	cartapi "github.com/mikebway/poc-gcp-ecomm/cart/cartapi"
+	fulfillschema "github.com/mikebway/poc-gcp-ecomm/fulfillment/schema"
+	pborder "github.com/mikebway/poc-gcp-ecomm/pb/order"
+	pbreturns "github.com/mikebway/poc-gcp-ecomm/pb/returns"
+	"github.com/mikebway/poc-gcp-ecomm/returns/schema"
+	"github.com/mikebway/poc-gcp-ecomm/types"
+	"go.uber.org/zap"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ProjectId is a variable so that unit tests can override it to ensures that test requests are not routed to
+	// the live project! See https://firebase.google.com/doos/emulator-suite/connect_firestore
+	ProjectId string
+
+	// UnitTestNewReturnsServiceError should be returned by NewReturnsService if we are running unit tests
+	// and UnitTestNewReturnsServiceError is not nil.
+	UnitTestNewReturnsServiceError error
+)
+
+// init is the static initializer used to configure our local and global static variables.
+func init() {
+
+	// Set the project ID to be used for live Firestore etc. connections
+	ProjectId = "poc-gcp-ecomm"
+}
+
+// OrderReader is the part of the order service API that the returns service needs in order to check what was
+// ordered, and whether it has been delivered, before authorizing its return. It is implemented by
+// orderapi.OrderService.
+type OrderReader interface {
+
+	// GetOrderByID returns the order with the ID given in the request, or a codes.NotFound status error if there
+	// is no such order.
+	GetOrderByID(ctx context.Context, req *pborder.GetOrderByIDRequest) (*pborder.GetOrderByIDResponse, error)
+}
+
+// TaskSaver is the part of the fulfillment service that the returns service needs in order to create the
+// fulfillment tasks that handle a return. It is implemented by fulfillapi.FulfillmentService.
+type TaskSaver interface {
+
+	// SaveTransactionalTasks stores the given fulfillment tasks within the given transaction, returning an error if
+	// any of them already exist.
+	SaveTransactionalTasks(tx *firestore.Transaction, tasks []*fulfillschema.Task) error
+}
+
+// ReturnsService is a structure class with methods that implements the returns.ReturnsAPIServer gRPC API
+// storing the data for return requests in a Google Cloud Firestore document collection.
+type ReturnsService struct {
+	pbreturns.UnimplementedReturnsAPIServer
+
+	// FsClient is the GCP Firestore client - it is thread safe and can be reused concurrently
+	FsClient *firestore.Client
+
+	// drProxy is used to allow unit tests to intercept firestore.DocumentRef function calls
+	// and insert errors etc. into the responses.
+	drProxy cartapi.DocumentRefProxy
+
+	// dsProxy is used to allow unit tests to intercept firestore.DocumentSnapshot function calls
+	// and insert errors etc. into the responses.
+	dsProxy cartapi.DocumentSnapshotProxy
+
+	// queryProxy is used to allow unit tests to intercept firestore.Query function calls
+	// and insert errors etc. into the responses of the document iterator that the query returns.
+	queryProxy cartapi.QueryExecutionProxy
+
+	// Orders gives the returns service the means to look up the order that items are being returned from. It is
+	// set by the service main, not by NewReturnsService, so that returns service clients that only need to read
+	// or update return requests do not have to depend on the order service. OpenReturn is refused if it has not
+	// been set.
+	Orders OrderReader
+
+	// Tasks gives the returns service the means to create the fulfillment tasks that handle a return. Like Orders,
+	// it is set by the service main and OpenReturn is refused if it has not been set.
+	Tasks TaskSaver
+}
+
+// NewReturnsService is a factory method returning an instance of our returns service.
+func NewReturnsService() (*ReturnsService, error) {
+
+	// Build our service instance here with our default, direct passthrough, interception proxies
+	// for firestore.DocumentRef and firestore.DocumentSnapshot function calls
+	svc := &ReturnsService{
+		drProxy:    &cartapi.DocRefProxy{},
+		dsProxy:    &cartapi.DocSnapProxy{},
+		queryProxy: &cartapi.QueryExecProxy{},
+	}
+
+	// Obtain a firestore client and stuff that in the service instance
+	ctx := context.Background()
+	var err error
+	if UnitTestNewReturnsServiceError == nil {
+		// Set the Firestore client if we are not unit testing an error situation.
+		svc.FsClient, err = firestore.NewClient(ctx, ProjectId)
+
+	} else {
+		// We are unit testing and required to report an error
+		err = UnitTestNewReturnsServiceError
+	}
+
+	// Check that we obtained a firestore client successfully
+	if err != nil {
+		return nil, fmt.Errorf("could not obtain firestore client: %w", err)
+	}
+
+	// All done - return the populated service instance
+	return svc, nil
+}
+
+// OpenReturn opens a return request, or RMA, for the order items and quantities given in the
+// pbreturns.OpenReturnRequest, then creates the fulfillment tasks that will see the items received, inspected,
+// and refunded.
+//
+// Only items that have been delivered, i.e. whose status is pborder.OrderItemStatus_OIS_COMPLETE, can be returned,
+// and no more of an item can be returned than was ordered, counting those already covered by earlier return
+// requests that have not been cancelled. Requests that break these rules are refused with a
+// codes.FailedPrecondition status error.
+func (rs *ReturnsService) OpenReturn(ctx context.Context, req *pbreturns.OpenReturnRequest) (*pbreturns.OpenReturnResponse, error) {
+
+	// Obtain a shortcut handle on our globally configured logger then log what we are about to do
+	l := zap.L()
+	l.Info("opening return", zap.String("orderId", req.OrderId), zap.String("reason", req.ReasonCode), zap.Int("itemCount", len(req.Items)))
+
+	// TODO: Access control - only the customer who placed the order, or customer service, should be able to do this
+
+	// We need to know what is being returned, from which order, and why
+	err := validateOpenReturnRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if rs.Orders == nil || rs.Tasks == nil {
+		return nil, status.Error(codes.Unimplemented, "no order and fulfillment services are available to open returns with")
+	}
+
+	// Fetch the order that the items came from
+	orderResponse, err := rs.Orders.GetOrderByID(ctx, &pborder.GetOrderByIDRequest{OrderId: req.OrderId})
+	if err != nil {
+		l.Error("failed to retrieve order to return items from", zap.String("orderId", req.OrderId), zap.Error(err))
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to retrieve order: order ID=%s: %v", req.OrderId, err)
+	}
+	order := orderResponse.Order
+
+	// Build the return request from the order items, checking that they can be returned
+	returnRequest := &schema.ReturnRequest{
+		Id:           uuid.NewString(),
+		OrderId:      req.OrderId,
+		CreationTime: time.Now(),
+		ReasonCode:   req.ReasonCode,
+		Status:       schema.RsOpen,
+	}
+	ordered := make(map[string]int32)
+	for _, reqItem := range req.Items {
+		orderItem := findOrderItem(order, reqItem.OrderItemId)
+		if orderItem == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "order item not found: order ID=%s, item ID=%s", req.OrderId, reqItem.OrderItemId)
+		}
+		if orderItem.Status != pborder.OrderItemStatus_OIS_COMPLETE {
+			return nil, status.Errorf(codes.FailedPrecondition, "order item has not been delivered: order ID=%s, item ID=%s, status=%s",
+				req.OrderId, reqItem.OrderItemId, orderItem.Status)
+		}
+		ordered[orderItem.Id] = orderItem.Quantity
+		refundAmount, err := itemRefundAmount(order, orderItem, reqItem.Quantity)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to work out refund amount: order ID=%s, item ID=%s: %v", req.OrderId, reqItem.OrderItemId, err)
+		}
+		returnRequest.Items = append(returnRequest.Items, &schema.ReturnItem{
+			OrderItemId:  orderItem.Id,
+			ProductCode:  orderItem.ProductCode,
+			Quantity:     reqItem.Quantity,
+			UnitPrice:    types.MoneyFromPB(orderItem.UnitPrice),
+			RefundAmount: refundAmount,
+		})
+	}
+
+	// Record the return request and create the tasks to handle it, in a transaction with the check that we are not
+	// returning more than was ordered so that two requests opened at the same time cannot both have the same items,
+	// and so that there is never a return request without its tasks
+	ref := rs.FsClient.Doc(returnRequest.StoreRefPath())
+	err = rs.FsClient.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+
+		// Add up what has already been returned
+		returned, err := rs.transactionalReturnedQuantities(tx, req.OrderId)
+		if err != nil {
+			return err
+		}
+
+		// There must be enough left of each item
+		for _, item := range returnRequest.Items {
+			if returned[item.OrderItemId]+item.Quantity > ordered[item.OrderItemId] {
+				return status.Errorf(codes.FailedPrecondition, "return quantity exceeds the quantity ordered less that already returned: "+
+					"order ID=%s, item ID=%s, ordered=%d, returned=%d, requested=%d",
+					req.OrderId, item.OrderItemId, ordered[item.OrderItemId], returned[item.OrderItemId], item.Quantity)
+			}
+		}
+
+		// All good
+		err = rs.drProxy.TransactionalCreate(ref, tx, returnRequest)
+		if err != nil {
+			return err
+		}
+		return rs.Tasks.SaveTransactionalTasks(tx, returnRequest.Tasks())
+	})
+	if err != nil {
+		l.Error("failed to record return request", zap.String("orderId", req.OrderId), zap.Error(err))
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to record return request: order ID=%s: %v", req.OrderId, err)
+	}
+
+	// All done
+	l.Info("return opened", zap.String("returnId", returnRequest.Id), zap.String("orderId", req.OrderId))
+	return &pbreturns.OpenReturnResponse{ReturnRequest: returnRequest.AsPBReturnRequest()}, nil
+}
+
+// validateOpenReturnRequest returns a codes.InvalidArgument status error if the given request is missing anything
+// that we need, or asks for the same order item more than once.
+func validateOpenReturnRequest(req *pbreturns.OpenReturnRequest) error {
+	if req.OrderId == "" {
+		return status.Error(codes.InvalidArgument, "order ID must be specified")
+	}
+	if req.ReasonCode == "" {
+		return status.Error(codes.InvalidArgument, "return reason code must be specified")
+	}
+	if len(req.Items) == 0 {
+		return status.Error(codes.InvalidArgument, "at least one order item must be returned")
+	}
+	seen := make(map[string]bool)
+	for _, item := range req.Items {
+		if item.OrderItemId == "" {
+			return status.Error(codes.InvalidArgument, "order item ID must be specified")
+		}
+		if item.Quantity < 1 {
+			return status.Errorf(codes.InvalidArgument, "return quantity must be at least one: item ID=%s, quantity=%d", item.OrderItemId, item.Quantity)
+		}
+		if seen[item.OrderItemId] {
+			return status.Errorf(codes.InvalidArgument, "order item may only be listed once: item ID=%s", item.OrderItemId)
+		}
+		seen[item.OrderItemId] = true
+	}
+	return nil
+}
+
+// findOrderItem returns the item of the given order that has the given ID, or nil if there is no such item.
+func findOrderItem(order *pborder.Order, orderItemId string) *pborder.OrderItem {
+	for _, item := range order.OrderItems {
+		if item.Id == orderItemId {
+			return item
+		}
+	}
+	return nil
+}
+
+// itemRefundAmount returns what the customer paid for the given quantity of the given item of the given order, and
+// so is to be refunded if they are returned. That is the price of the items prorated against the order subtotal
+// as a share of the order total, less its delivery cost, so that the discounts taken off the order and the tax
+// added to it are shared out across its items in proportion to their price. The delivery cost is not refunded.
+//
+// Nil is returned for items that were not priced, and the undiscounted price for orders that have no recorded
+// subtotal and total to share out.
+func itemRefundAmount(order *pborder.Order, orderItem *pborder.OrderItem, quantity int32) (*types.Money, error) {
+	if orderItem.UnitPrice == nil {
+		return nil, nil
+	}
+	price := types.MoneyFromPB(orderItem.UnitPrice).Multiply(quantity)
+	if order.Subtotal == nil || order.Total == nil {
+		return price, nil
+	}
+	paid := types.MoneyFromPB(order.Total)
+	if order.DeliveryOption != nil && order.DeliveryOption.Cost != nil {
+		var err error
+		paid, err = paid.Subtract(types.MoneyFromPB(order.DeliveryOption.Cost))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return paid.Prorate(price, types.MoneyFromPB(order.Subtotal))
+}
+
+// transactionalReturnedQuantities adds up, by order item ID, the quantities already being returned by the return
+// requests for the order with the given ID, within the given transaction.
+func (rs *ReturnsService) transactionalReturnedQuantities(tx *firestore.Transaction, orderId string) (map[string]int32, error) {
+	returned := make(map[string]int32)
+	query := rs.FsClient.Collection(schema.ReturnCollection).Where("orderId", "==", orderId)
+	docs := tx.Documents(query)
+	defer docs.Stop()
+	for {
+		returnRequest := &schema.ReturnRequest{}
+		snap, err := docs.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err == nil {
+			err = rs.dsProxy.DataTo(snap, returnRequest)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve return requests for order: order ID=%s: %w", orderId, err)
+		}
+		returnRequest.ReturnedQuantities(returned)
+	}
+	return returned, nil
+}
+
+// GetReturnByID retrieves the return request matching the UUID ID specified in the pbreturns.GetReturnByIDRequest.
+func (rs *ReturnsService) GetReturnByID(ctx context.Context, req *pbreturns.GetReturnByIDRequest) (*pbreturns.GetReturnByIDResponse, error) {
+
+	// TODO: Access control
+
+	// Obtain a shortcut handle on our globally configured logger and log some context information
+	l := zap.L()
+	l.Info("retrieving return request", zap.String("returnId", req.ReturnId))
+	if req.ReturnId == "" {
+		return nil, status.Error(codes.InvalidArgument, "return ID must be specified")
+	}
+
+	// Ask the firestore client for the specified return request
+	returnRequest := &schema.ReturnRequest{Id: req.ReturnId}
+	ref := rs.FsClient.Doc(returnRequest.StoreRefPath())
+	snap, err := rs.drProxy.Get(ref, ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Errorf(codes.NotFound, "return request not found: return ID=%s", req.ReturnId)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to retrieve return request snapshot with ID %s: %v", req.ReturnId, err)
+	}
+
+	// Unmarshall the snapshot into our internal structure form
+	err = rs.dsProxy.DataTo(snap, returnRequest)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to unmarshal return request snapshot with ID %s: %v", req.ReturnId, err)
+	}
+
+	// Wrap the return request in the response structure and we are done
+	l.Info("return request retrieved successfully", zap.String("returnId", req.ReturnId), zap.String("path", ref.Path))
+	return &pbreturns.GetReturnByIDResponse{ReturnRequest: returnRequest.AsPBReturnRequest()}, nil
+}
+
+// GetOrderReturns retrieves all of the return requests opened for the order specified in the
+// pbreturns.GetOrderReturnsRequest, oldest first. An order has only a handful of return requests at most, so
+// they are not paged.
+func (rs *ReturnsService) GetOrderReturns(ctx context.Context, req *pbreturns.GetOrderReturnsRequest) (*pbreturns.GetOrderReturnsResponse, error) {
+
+	// TODO: Access control
+
+	// Obtain a shortcut handle on our globally configured logger and log some context information
+	l := zap.L()
+	l.Info("retrieving order return requests", zap.String("orderId", req.OrderId))
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order ID must be specified")
+	}
+
+	// Run the query, closing the iterator when we are done with it regardless of whether we are successful or not
+	query := rs.FsClient.Collection(schema.ReturnCollection).Where("orderId", "==", req.OrderId)
+	docs := rs.queryProxy.Documents(ctx, query)
+	defer docs.Stop()
+	var returnRequests []*schema.ReturnRequest
+	for {
+		returnRequest := &schema.ReturnRequest{}
+		err := docs.Next(returnRequest)
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to retrieve return requests for order: order ID=%s: %v", req.OrderId, err)
+		}
+		returnRequests = append(returnRequests, returnRequest)
+	}
+
+	// Sort them here rather than in the query, saving ourselves a composite index
+	sort.Slice(returnRequests, func(i, j int) bool {
+		return returnRequests[i].CreationTime.Before(returnRequests[j].CreationTime)
+	})
+	pbReturnRequests := make([]*pbreturns.ReturnRequest, len(returnRequests))
+	for i, returnRequest := range returnRequests {
+		pbReturnRequests[i] = returnRequest.AsPBReturnRequest()
+	}
+
+	// And we are all done
+	l.Info("order return requests retrieved successfully", zap.String("orderId", req.OrderId), zap.Int("count", len(returnRequests)))
+	return &pbreturns.GetOrderReturnsResponse{ReturnRequests: pbReturnRequests}, nil
+}
+
+// UpdateReturnStatus sets the status of the return request with the given ID, as rolled up by the task trigger from
+// fulfillment tasks read at the given time, and returns the status that the return request is left with. This is for
+// internal domain use only and so does not accept or return protobuf structures.
+//
+// The task trigger can be invoked concurrently for different tasks of the same return request, and the invocations
+// can finish in any order, so a rollup of tasks read before those of the rollup that the return request already has
+// is ignored; the status already recorded is returned instead.
+func (rs *ReturnsService) UpdateReturnStatus(ctx context.Context, returnId string, returnStatus schema.ReturnStatus, asOf time.Time) (schema.ReturnStatus, error) {
+
+	// Obtain a shortcut handle on our globally configured logger and log some context information
+	l := zap.L()
+	l.Info("updating return status", zap.String("returnId", returnId), zap.Int32("status", int32(returnStatus)), zap.Time("asOf", asOf))
+
+	// Do the read and the write in the one transaction
+	recordedStatus := returnStatus
+	var stale bool
+	ref := rs.FsClient.Doc((&schema.ReturnRequest{Id: returnId}).StoreRefPath())
+	err := rs.FsClient.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+
+		// Load the return request as it stands, into a fresh structure each time in case the transaction is retried
+		returnRequest := &schema.ReturnRequest{Id: returnId}
+		snap, err := rs.drProxy.TransactionalGet(ref, tx)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve return request snapshot with ID %s: %w", returnId, err)
+		}
+		err = rs.dsProxy.DataTo(snap, returnRequest)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal return request snapshot with ID %s: %w", returnId, err)
+		}
+
+		// Leave the return request alone if it already has the rollup of a more recent set of tasks than ours
+		stale = asOf.Before(returnRequest.StatusAsOf)
+		if stale {
+			recordedStatus = returnRequest.Status
+			return nil
+		}
+		recordedStatus = returnStatus
+		return rs.drProxy.TransactionalUpdate(ref, tx, []firestore.Update{
+			{Path: "status", Value: returnStatus},
+			{Path: "statusAsOf", Value: asOf},
+		})
+	})
+	if err != nil {
+		err = fmt.Errorf("failed updating return request status in Firestore: return ID=%s: %w", returnId, err)
+		l.Error(err.Error(), zap.String("returnId", returnId))
+		return schema.RsUnspecified, err
+	}
+
+	// All done
+	if stale {
+		l.Info("return status not updated, a more recent rollup has been recorded", zap.String("returnId", returnId),
+			zap.Int32("status", int32(recordedStatus)))
+		return recordedStatus, nil
+	}
+	l.Info("return status updated", zap.String("returnId", returnId), zap.Int32("status", int32(recordedStatus)))
+	return recordedStatus, nil
+}
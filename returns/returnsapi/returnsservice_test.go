@@ -0,0 +1,266 @@
+package returnsapi
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/google/uuid"
+	fulfillschema "github.com/mikebway/poc-gcp-ecomm/fulfillment/schema"
+	pborder "github.com/mikebway/poc-gcp-ecomm/pb/order"
+	pbreturns "github.com/mikebway/poc-gcp-ecomm/pb/returns"
+	"github.com/mikebway/poc-gcp-ecomm/returns/schema"
+	"github.com/mikebway/poc-gcp-ecomm/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// EnvFirestoreEmulator defines the environment variable name that is used to convey that the Firestore emulator
+	// is running, should be used, and how to connect to it
+	EnvFirestoreEmulator = "FIRESTORE_EMULATOR_HOST"
+
+	// FirestoreEmulatorHost defines the server name and port (in TCP6 terms) of the Firestore emulator
+	FirestoreEmulatorHost = "[::1]:8219"
+
+	// unitTestErrorMessage is used as the error description for error that are deliberately forced to
+	// test error handling.
+	unitTestErrorMessage = "unit test of error handling"
+)
+
+// UTOrderReader is a stand-in for the order service that serves up whatever orders a unit test gives it.
+type UTOrderReader struct {
+	orders map[string]*pborder.Order
+}
+
+// GetOrderByID returns the order with the requested ID, or a codes.NotFound status error if there is no such order.
+func (r *UTOrderReader) GetOrderByID(ctx context.Context, req *pborder.GetOrderByIDRequest) (*pborder.GetOrderByIDResponse, error) {
+	order, ok := r.orders[req.OrderId]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "order not found: order ID=%s", req.OrderId)
+	}
+	return &pborder.GetOrderByIDResponse{Order: order}, nil
+}
+
+// UTTaskSaver is a stand-in for the fulfillment service that remembers the tasks it is asked to save, or fails to
+// save them if a unit test tells it to.
+type UTTaskSaver struct {
+	tasks []*fulfillschema.Task
+	err   error
+}
+
+// SaveTransactionalTasks remembers the given tasks, or returns the error that the unit test configured.
+func (s *UTTaskSaver) SaveTransactionalTasks(tx *firestore.Transaction, tasks []*fulfillschema.Task) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.tasks = append(s.tasks, tasks...)
+	return nil
+}
+
+// TestMain, if defined (it's optional), allows setup code to be run before and after the suite of unit tests
+// for this package.
+func TestMain(m *testing.M) {
+
+	// Ensure that our Firestore requests do not get routed to the live project by mistake
+	ProjectId = "demo-" + ProjectId
+
+	// Configure the environment variable that informs the Firestore client that it should connect to the
+	// emulator and how to reach it.
+	_ = os.Setenv(EnvFirestoreEmulator, FirestoreEmulatorHost)
+
+	// Run all the unit tests
+	m.Run()
+}
+
+// commonTestSetup returns a returns service backed by the Firestore emulator, with stand-in order and fulfillment
+// services, and an order of its own with one delivered item of three gold yoyos and one item still being made. The
+// order had $5.00 taken off by a discount, $2.60 of tax added, and $5.00 of delivery cost.
+func commonTestSetup(t *testing.T) (*require.Assertions, context.Context, *ReturnsService, *pborder.Order, *UTTaskSaver) {
+	req := require.New(t)
+	service, err := NewReturnsService()
+	req.Nil(err, "failed to create returns service: %v", err)
+
+	order := &pborder.Order{
+		Id: uuid.NewString(),
+		OrderItems: []*pborder.OrderItem{
+			{
+				Id:          uuid.NewString(),
+				ProductCode: "gold_yoyo",
+				Quantity:    3,
+				UnitPrice:   types.NewMoney("USD", 12, 500000000).AsPBMoney(),
+				Status:      pborder.OrderItemStatus_OIS_COMPLETE,
+			},
+			{Id: uuid.NewString(), ProductCode: "plastic_yoyo", Quantity: 1, Status: pborder.OrderItemStatus_OIS_IN_PROGRESS},
+		},
+		Subtotal:       types.NewMoney("USD", 37, 500000000).AsPBMoney(),
+		Total:          types.NewMoney("USD", 40, 100000000).AsPBMoney(),
+		DeliveryOption: &pborder.DeliveryOption{Code: "standard", Cost: types.NewMoney("USD", 5, 0).AsPBMoney()},
+	}
+	tasks := &UTTaskSaver{}
+	service.Orders = &UTOrderReader{orders: map[string]*pborder.Order{order.Id: order}}
+	service.Tasks = tasks
+	return req, context.Background(), service, order, tasks
+}
+
+// openReturn asks the service to return the given quantity of the given item of the given order.
+func openReturn(ctx context.Context, service *ReturnsService, order *pborder.Order, itemIndex int, quantity int32) (*pbreturns.OpenReturnResponse, error) {
+	return service.OpenReturn(ctx, &pbreturns.OpenReturnRequest{
+		OrderId:    order.Id,
+		ReasonCode: "unwanted",
+		Items:      []*pbreturns.ReturnItemRequest{{OrderItemId: order.OrderItems[itemIndex].Id, Quantity: quantity}},
+	})
+}
+
+// TestOpenReturn confirms that a return request is recorded, and tasks created to handle it, for a delivered item
+// and that no more of an item can be returned than was ordered.
+func TestOpenReturn(t *testing.T) {
+
+	// Do the common setup that most of our tests require
+	req, ctx, service, order, tasks := commonTestSetup(t)
+
+	// Send back two of the three gold yoyos
+	response, err := openReturn(ctx, service, order, 0, 2)
+	req.Nil(err, "should not have seen an error opening a return: %v", err)
+	first := response.ReturnRequest
+	req.NotEmpty(first.Id, "return request should have been given an ID")
+	req.Equal(order.Id, first.OrderId, "return request order ID did not match")
+	req.Equal(pbreturns.ReturnStatus_RS_OPEN, first.Status, "return request should have been open")
+	req.NotNil(first.CreationTime, "return request should have had a creation time")
+	req.Equal(1, len(first.Items), "return request should have had the one item")
+	req.Equal(order.OrderItems[0].Id, first.Items[0].OrderItemId, "return item ID did not match")
+	req.Equal("gold_yoyo", first.Items[0].ProductCode, "return item product code did not match")
+	req.Equal(int32(2), first.Items[0].Quantity, "return item quantity did not match")
+	req.Equal(int64(12), first.Items[0].UnitPrice.Units, "return item unit price did not match")
+	req.Equal("USD 23.40", types.MoneyFromPB(first.Items[0].RefundAmount).String(), "return item refund amount did not match")
+
+	// With tasks to see them back
+	req.Equal(3, len(tasks.tasks), "should have saved receive, inspect, and refund tasks")
+	for _, task := range tasks.tasks {
+		req.Equal(first.Id, task.ReturnId, "task return ID did not match")
+		req.Equal(order.OrderItems[0].Id, task.OrderItemId, "task order item ID did not match")
+	}
+	req.Equal(schema.TaskCodeReceiveReturn, tasks.tasks[0].TaskCode, "first task should have been to receive the return")
+
+	// The return request can be read back again
+	byId, err := service.GetReturnByID(ctx, &pbreturns.GetReturnByIDRequest{ReturnId: first.Id})
+	req.Nil(err, "should not have seen an error retrieving the return request: %v", err)
+	req.Equal(first.Items[0].Quantity, byId.ReturnRequest.Items[0].Quantity, "retrieved return request quantity did not match")
+	req.Equal(pbreturns.ReturnStatus_RS_OPEN, byId.ReturnRequest.Status, "retrieved return request should have been open")
+
+	// There is only one gold yoyo left to send back
+	_, err = openReturn(ctx, service, order, 0, 2)
+	req.Equal(codes.FailedPrecondition, status.Code(err), "return of more than was ordered should have been refused: %v", err)
+	response, err = openReturn(ctx, service, order, 0, 1)
+	req.Nil(err, "should not have seen an error returning the last yoyo: %v", err)
+	second := response.ReturnRequest
+
+	// Both returns are listed for the order, oldest first
+	byOrder, err := service.GetOrderReturns(ctx, &pbreturns.GetOrderReturnsRequest{OrderId: order.Id})
+	req.Nil(err, "should not have seen an error listing the order's return requests: %v", err)
+	req.Equal(2, len(byOrder.ReturnRequests), "order should have had two return requests")
+	req.Equal(first.Id, byOrder.ReturnRequests[0].Id, "first return request should have been listed first")
+	req.Equal(second.Id, byOrder.ReturnRequests[1].Id, "second return request should have been listed second")
+
+	// Once the first return is cancelled, its yoyos can be returned again
+	cancelledAsOf := time.Now()
+	returnStatus, err := service.UpdateReturnStatus(ctx, first.Id, schema.RsCancelled, cancelledAsOf)
+	req.Nil(err, "should not have seen an error updating the return status: %v", err)
+	req.Equal(schema.RsCancelled, returnStatus, "return status should have been updated")
+
+	// A rollup of tasks read before those of the cancellation is stale and is ignored
+	returnStatus, err = service.UpdateReturnStatus(ctx, first.Id, schema.RsReceived, cancelledAsOf.Add(-time.Second))
+	req.Nil(err, "should not have seen an error updating the return status: %v", err)
+	req.Equal(schema.RsCancelled, returnStatus, "stale rollup should not have replaced the recorded status")
+	byId, err = service.GetReturnByID(ctx, &pbreturns.GetReturnByIDRequest{ReturnId: first.Id})
+	req.Nil(err, "should not have seen an error retrieving the return request: %v", err)
+	req.Equal(pbreturns.ReturnStatus_RS_CANCELLED, byId.ReturnRequest.Status, "return request should have been cancelled")
+	_, err = openReturn(ctx, service, order, 0, 2)
+	req.Nil(err, "should not have seen an error returning the yoyos again: %v", err)
+}
+
+// TestOpenReturnRefused confirms that return requests that cannot be met are rejected with suitable status codes.
+func TestOpenReturnRefused(t *testing.T) {
+
+	// Do the common setup that most of our tests require
+	req, ctx, service, order, tasks := commonTestSetup(t)
+
+	// We must be told what is being returned, from where, and why
+	invalid := []*pbreturns.OpenReturnRequest{
+		{ReasonCode: "unwanted", Items: []*pbreturns.ReturnItemRequest{{OrderItemId: order.OrderItems[0].Id, Quantity: 1}}},
+		{OrderId: order.Id, Items: []*pbreturns.ReturnItemRequest{{OrderItemId: order.OrderItems[0].Id, Quantity: 1}}},
+		{OrderId: order.Id, ReasonCode: "unwanted"},
+		{OrderId: order.Id, ReasonCode: "unwanted", Items: []*pbreturns.ReturnItemRequest{{Quantity: 1}}},
+		{OrderId: order.Id, ReasonCode: "unwanted", Items: []*pbreturns.ReturnItemRequest{{OrderItemId: order.OrderItems[0].Id}}},
+		{OrderId: order.Id, ReasonCode: "unwanted", Items: []*pbreturns.ReturnItemRequest{
+			{OrderItemId: order.OrderItems[0].Id, Quantity: 1}, {OrderItemId: order.OrderItems[0].Id, Quantity: 1}}},
+		{OrderId: order.Id, ReasonCode: "unwanted", Items: []*pbreturns.ReturnItemRequest{{OrderItemId: uuid.NewString(), Quantity: 1}}},
+	}
+	for i, invalidReq := range invalid {
+		_, err := service.OpenReturn(ctx, invalidReq)
+		req.Equal(codes.InvalidArgument, status.Code(err), "invalid request %d should have been rejected: %v", i, err)
+	}
+
+	// Items that have not been delivered cannot be returned
+	_, err := openReturn(ctx, service, order, 1, 1)
+	req.Equal(codes.FailedPrecondition, status.Code(err), "return of an undelivered item should have been refused: %v", err)
+
+	// Nor can items from orders that do not exist
+	_, err = openReturn(ctx, service, &pborder.Order{Id: uuid.NewString(), OrderItems: order.OrderItems}, 0, 1)
+	req.Equal(codes.NotFound, status.Code(err), "return from a missing order should have been refused: %v", err)
+
+	// Or be returned at all without the order and fulfillment services
+	service.Tasks = nil
+	_, err = openReturn(ctx, service, order, 0, 1)
+	req.Equal(codes.Unimplemented, status.Code(err), "return without a fulfillment service should have been refused: %v", err)
+
+	// None of which should have left anything behind
+	req.Empty(tasks.tasks, "no tasks should have been saved")
+	byOrder, err := service.GetOrderReturns(ctx, &pbreturns.GetOrderReturnsRequest{OrderId: order.Id})
+	req.Nil(err, "should not have seen an error listing the order's return requests: %v", err)
+	req.Empty(byOrder.ReturnRequests, "no return requests should have been recorded")
+}
+
+// TestOpenReturnTaskFailure confirms that a return request is not left behind if its tasks cannot be saved.
+func TestOpenReturnTaskFailure(t *testing.T) {
+
+	// Do the common setup that most of our tests require
+	req, ctx, service, order, tasks := commonTestSetup(t)
+
+	// Have the task save fail
+	tasks.err = errors.New(unitTestErrorMessage)
+	_, err := openReturn(ctx, service, order, 0, 1)
+	req.Equal(codes.Internal, status.Code(err), "return should have failed: %v", err)
+	req.Contains(err.Error(), unitTestErrorMessage, "did not see the specific error that we expected")
+
+	// And the return request should not have been recorded without them
+	byOrder, err := service.GetOrderReturns(ctx, &pbreturns.GetOrderReturnsRequest{OrderId: order.Id})
+	req.Nil(err, "should not have seen an error listing the order's return requests: %v", err)
+	req.Empty(byOrder.ReturnRequests, "the return request should not have been recorded")
+
+	// So the items can still be returned once the tasks can be saved
+	tasks.err = nil
+	_, err = openReturn(ctx, service, order, 0, 3)
+	req.Nil(err, "return of the whole quantity should have succeeded: %v", err)
+}
+
+// TestGetReturnFailures confirms that requests for return requests that cannot be met are rejected with suitable
+// status codes.
+func TestGetReturnFailures(t *testing.T) {
+
+	// Do the common setup that most of our tests require
+	req, ctx, service, _, _ := commonTestSetup(t)
+
+	_, err := service.GetReturnByID(ctx, &pbreturns.GetReturnByIDRequest{})
+	req.Equal(codes.InvalidArgument, status.Code(err), "request without a return ID should have been rejected: %v", err)
+	_, err = service.GetReturnByID(ctx, &pbreturns.GetReturnByIDRequest{ReturnId: uuid.NewString()})
+	req.Equal(codes.NotFound, status.Code(err), "request for a missing return should have been rejected: %v", err)
+	_, err = service.GetOrderReturns(ctx, &pbreturns.GetOrderReturnsRequest{})
+	req.Equal(codes.InvalidArgument, status.Code(err), "request without an order ID should have been rejected: %v", err)
+	_, err = service.UpdateReturnStatus(ctx, uuid.NewString(), schema.RsReceived, time.Now())
+	req.NotNil(err, "update of a missing return should have failed")
+}
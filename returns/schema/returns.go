@@ -0,0 +1,233 @@
+// Package schema defines return request document structures as they might be stored in a Google Firestore
+// or represented in JSON
+package schema
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	fulfillschema "github.com/mikebway/poc-gcp-ecomm/fulfillment/schema"
+	pbreturns "github.com/mikebway/poc-gcp-ecomm/pb/returns"
+	"github.com/mikebway/poc-gcp-ecomm/types"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// ReturnCollection names the firestore collection under which all of our return request documents are stored
+	ReturnCollection = "returns"
+
+	// TaskCodeReceiveReturn is the task code of the fulfillment task that waits for a returned item to arrive back
+	// with us, e.g. after sending the customer a return shipping label
+	TaskCodeReceiveReturn = "receive_return"
+
+	// TaskCodeInspect is the task code of the fulfillment task that checks the condition of a returned item once it
+	// has been received. Canceling the refund task of an item that has been inspected rejects the return.
+	TaskCodeInspect = "inspect"
+
+	// TaskCodeRefund is the task code of the fulfillment task that refunds the customer for a returned item once it
+	// has passed inspection
+	TaskCodeRefund = "refund"
+
+	// ReasonAwaitingReturn is the reason code given to receive_return tasks while the customer has yet to send the
+	// item back
+	ReasonAwaitingReturn = "awaiting_return"
+
+	// ReasonWaitForReceipt is the reason code given to inspect tasks until the item has been received
+	ReasonWaitForReceipt = "wait_for_receipt"
+
+	// ReasonWaitForInspection is the reason code given to refund tasks until the item has been inspected
+	ReasonWaitForInspection = "wait_for_inspection"
+
+	// ParamReturnQuantity names the task parameter that carries the number of the order item being returned
+	ParamReturnQuantity = "return_quantity"
+
+	// ParamReturnReason names the task parameter that carries the reason code that the customer gave for the return
+	ParamReturnReason = "return_reason"
+
+	// ParamRefundAmount names the task parameter of refund tasks that carries the amount to be refunded; see
+	// ReturnItem.RefundDue
+	ParamRefundAmount = "refund_amount"
+)
+
+// ReturnRequest records a customer's wish to send back some or all of the delivered items of an order, otherwise
+// known as an RMA (return merchandise authorization). Each return request has its own fulfillment tasks, to
+// receive, inspect, and refund the returned items, and a status rolled up from the progress of those tasks.
+type ReturnRequest struct {
+	// Id is a UUID ID in hexadecimal string form - a unique ID for this return request, doubling as the RMA number
+	Id string `firestore:"id" json:"id"`
+
+	// OrderId is the UUID ID of the order that the returned items were purchased in
+	OrderId string `firestore:"orderId" json:"orderId"`
+
+	// CreationTime is the time at which the return request was opened
+	CreationTime time.Time `firestore:"creationTime" json:"creationTime"`
+
+	// ReasonCode identifies why the customer is returning the items, e.g. "damaged" or "unwanted"
+	ReasonCode string `firestore:"reasonCode" json:"reasonCode"`
+
+	// Items is the list of one to many order items, and how many of each, that are being returned
+	Items []*ReturnItem `firestore:"items" json:"items"`
+
+	// Status is the progress of the return, rolled up from the status of its fulfillment tasks
+	Status ReturnStatus `firestore:"status" json:"status"`
+
+	// StatusAsOf is the time at which the fulfillment tasks that Status was rolled up from were read. A rollup of
+	// tasks read before this time is stale and is not recorded; see returnsapi.ReturnsService.UpdateReturnStatus.
+	StatusAsOf time.Time `firestore:"statusAsOf,omitempty" json:"-"`
+}
+
+// ReturnItem is a single order item, or some part of its quantity, that is being returned
+type ReturnItem struct {
+	// OrderItemId is the UUID ID of the order item being returned
+	OrderItemId string `firestore:"orderItemId" json:"orderItemId"`
+
+	// ProductCode is the product code of the order item being returned
+	ProductCode string `firestore:"productCode" json:"productCode"`
+
+	// Quantity is the number of the order item's quantity that is being returned
+	Quantity int32 `firestore:"quantity" json:"quantity"`
+
+	// UnitPrice is the price of a single item, before any discounts, as it was when the item was ordered
+	UnitPrice *types.Money `firestore:"unitPrice" json:"unitPrice"`
+
+	// RefundAmount (Optional) is what the customer is to be refunded for the returned items, i.e. what they paid for
+	// them: their share of the order total, less its delivery cost, as worked out when the return was opened. Their
+	// share of any discounts is taken off and their share of the tax is given back. It is not set for items that were
+	// not priced.
+	RefundAmount *types.Money `firestore:"refundAmount,omitempty" json:"refundAmount,omitempty"`
+}
+
+// ReturnStatus is an integer enumeration of the possible states of a return request, as rolled up from the status
+// of its fulfillment tasks
+type ReturnStatus int32
+
+const (
+	// RsUnspecified should not be seen - indicates that the status has not been set
+	RsUnspecified ReturnStatus = 0
+
+	// RsOpen signals that the return has been authorized but the items have yet to be received
+	RsOpen ReturnStatus = 1
+
+	// RsReceived signals that all the returned items have been received but not all have been inspected
+	RsReceived ReturnStatus = 2
+
+	// RsInspected signals that all the returned items have been inspected and are awaiting refund
+	RsInspected ReturnStatus = 3
+
+	// RsRefunded signals that the customer has been refunded for the returned items
+	RsRefunded ReturnStatus = 4
+
+	// RsRejected signals that the returned items failed inspection and no refund is to be given
+	RsRejected ReturnStatus = 5
+
+	// RsCancelled signals that the return was cancelled before it was completed, e.g. the items were never sent back
+	RsCancelled ReturnStatus = 6
+)
+
+// StoreRefPath returns the string representation of the document reference path for this ReturnRequest.
+func (r *ReturnRequest) StoreRefPath() string {
+	return ReturnCollection + "/" + r.Id
+}
+
+// ReturnedQuantities adds up, by order item ID, the quantities of the order items being returned by this request.
+// Nothing is counted for a request that has been cancelled; its items were never sent back so are still eligible
+// for return.
+func (r *ReturnRequest) ReturnedQuantities(quantities map[string]int32) {
+	if r.Status == RsCancelled {
+		return
+	}
+	for _, item := range r.Items {
+		quantities[item.OrderItemId] += item.Quantity
+	}
+}
+
+// Tasks returns the fulfillment tasks that handle this return: for each item, a receive_return task waiting on the
+// customer to send it back, followed by inspect and refund tasks waiting on the task before them. The tasks are given
+// new IDs and are related to both the order item and the return request.
+func (r *ReturnRequest) Tasks() []*fulfillschema.Task {
+	var tasks []*fulfillschema.Task
+	for _, item := range r.Items {
+
+		// Every task for the item needs to know how many are coming back and why
+		params := []*fulfillschema.Parameter{
+			{Name: ParamReturnQuantity, Value: strconv.Itoa(int(item.Quantity))},
+			{Name: ParamReturnReason, Value: r.ReasonCode},
+		}
+
+		// And the refund needs to know how much to give back, if we know what was paid
+		refundParams := params
+		if refund := item.RefundDue(); refund != nil {
+			refundParams = append(append([]*fulfillschema.Parameter(nil), params...),
+				&fulfillschema.Parameter{Name: ParamRefundAmount, Value: refund.String()})
+		}
+
+		tasks = append(tasks,
+			r.itemTask(item, TaskCodeReceiveReturn, fulfillschema.WAITING_CUSTOMER, ReasonAwaitingReturn, params),
+			r.itemTask(item, TaskCodeInspect, fulfillschema.WAITING_TASK, ReasonWaitForReceipt, params),
+			r.itemTask(item, TaskCodeRefund, fulfillschema.WAITING_TASK, ReasonWaitForInspection, refundParams))
+	}
+	return tasks
+}
+
+// RefundDue returns what the customer is to be refunded for this returned item: the RefundAmount worked out when the
+// return was opened or, for return requests opened before that was recorded, the unit price multiplied by the
+// quantity returned. Nil is returned if the item was not priced.
+func (item *ReturnItem) RefundDue() *types.Money {
+	if item.RefundAmount != nil {
+		return item.RefundAmount
+	}
+	if item.UnitPrice != nil {
+		return item.UnitPrice.Multiply(item.Quantity)
+	}
+	return nil
+}
+
+// itemTask returns a single new fulfillment task for the given item of this return request.
+func (r *ReturnRequest) itemTask(item *ReturnItem, taskCode string, status fulfillschema.TaskStatus, reasonCode string, params []*fulfillschema.Parameter) *fulfillschema.Task {
+	return &fulfillschema.Task{
+		Id:          uuid.NewString(),
+		OrderId:     r.OrderId,
+		OrderItemId: item.OrderItemId,
+		ProductCode: item.ProductCode,
+		TaskCode:    taskCode,
+		Status:      status,
+		ReasonCode:  reasonCode,
+		Parameters:  params,
+		ReturnId:    r.Id,
+	}
+}
+
+// AsPBReturnRequest returns the protocol buffer representation of this return request.
+func (r *ReturnRequest) AsPBReturnRequest() *pbreturns.ReturnRequest {
+
+	// The creation time should be set, but we will play it safe just the same
+	var pbCreationTime *timestamppb.Timestamp
+	if !r.CreationTime.IsZero() {
+		pbCreationTime = timestamppb.New(r.CreationTime)
+	}
+
+	pbItems := make([]*pbreturns.ReturnItem, len(r.Items))
+	for i, item := range r.Items {
+		pbItems[i] = item.AsPBReturnItem()
+	}
+	return &pbreturns.ReturnRequest{
+		Id:           r.Id,
+		OrderId:      r.OrderId,
+		CreationTime: pbCreationTime,
+		ReasonCode:   r.ReasonCode,
+		Items:        pbItems,
+		Status:       pbreturns.ReturnStatus(r.Status),
+	}
+}
+
+// AsPBReturnItem returns the protocol buffer representation of this return item.
+func (item *ReturnItem) AsPBReturnItem() *pbreturns.ReturnItem {
+	return &pbreturns.ReturnItem{
+		OrderItemId:  item.OrderItemId,
+		ProductCode:  item.ProductCode,
+		Quantity:     item.Quantity,
+		UnitPrice:    item.UnitPrice.AsPBMoney(),
+		RefundAmount: item.RefundAmount.AsPBMoney(),
+	}
+}
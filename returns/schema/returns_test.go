@@ -0,0 +1,147 @@
+package schema
+
+import (
+	"testing"
+	"time"
+
+	fulfillschema "github.com/mikebway/poc-gcp-ecomm/fulfillment/schema"
+	pbreturns "github.com/mikebway/poc-gcp-ecomm/pb/returns"
+	"github.com/mikebway/poc-gcp-ecomm/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// UUID string values that we can use as return request, order, and order item IDs in our tests
+	returnId     = "7d0a5b7e-3c55-4f0e-9f3b-0b8a4c1d2e61"
+	orderId      = "a8b9d3c6-1f7e-4f4a-8d52-6c0e2f9a1b33"
+	orderItemId1 = "54f34cb9-fea6-4786-a475-cebd95d93742"
+	orderItemId2 = "3e1f2a4b-9c8d-4e7f-a6b5-1d2c3b4a5f60"
+
+	// The product codes of the items being returned
+	productCode1 = "gold_yoyo"
+	productCode2 = "plastic_yoyo"
+)
+
+// buildMockReturnRequest returns a return request for two of the first order item, bought with a discount, and one of
+// the second, the second having been ordered before we knew what it cost.
+func buildMockReturnRequest() *ReturnRequest {
+	return &ReturnRequest{
+		Id:           returnId,
+		OrderId:      orderId,
+		CreationTime: time.Date(2022, 10, 11, 10, 23, 19, 0, time.UTC),
+		ReasonCode:   "unwanted",
+		Status:       RsOpen,
+		Items: []*ReturnItem{
+			{OrderItemId: orderItemId1, ProductCode: productCode1, Quantity: 2, UnitPrice: types.NewMoney("USD", 12, 500000000),
+				RefundAmount: types.NewMoney("USD", 22, 500000000)},
+			{OrderItemId: orderItemId2, ProductCode: productCode2, Quantity: 1},
+		},
+	}
+}
+
+// TestStoreRefPath checks out how well ReturnRequest.StoreRefPath does its job.
+func TestStoreRefPath(t *testing.T) {
+	req := require.New(t)
+	req.Equal("returns/7d0a5b7e-3c55-4f0e-9f3b-0b8a4c1d2e61", buildMockReturnRequest().StoreRefPath(), "return request path did not match expected value")
+}
+
+// TestAsPBReturnRequest confirms that a return request and its items are faithfully converted to their protocol
+// buffer form.
+func TestAsPBReturnRequest(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	returnRequest := buildMockReturnRequest()
+	pbReturnRequest := returnRequest.AsPBReturnRequest()
+	req.Equal(returnId, pbReturnRequest.Id, "PB return ID did not match")
+	req.Equal(orderId, pbReturnRequest.OrderId, "PB order ID did not match")
+	req.Equal(returnRequest.CreationTime, pbReturnRequest.CreationTime.AsTime(), "PB creation time did not match")
+	req.Equal("unwanted", pbReturnRequest.ReasonCode, "PB reason code did not match")
+	req.Equal(pbreturns.ReturnStatus_RS_OPEN, pbReturnRequest.Status, "PB status did not match")
+	req.Equal(2, len(pbReturnRequest.Items), "PB item count did not match")
+	req.Equal(orderItemId1, pbReturnRequest.Items[0].OrderItemId, "PB order item ID did not match")
+	req.Equal(productCode1, pbReturnRequest.Items[0].ProductCode, "PB product code did not match")
+	req.Equal(int32(2), pbReturnRequest.Items[0].Quantity, "PB quantity did not match")
+	req.Equal(int64(12), pbReturnRequest.Items[0].UnitPrice.Units, "PB unit price did not match")
+	req.Equal(int64(22), pbReturnRequest.Items[0].RefundAmount.Units, "PB refund amount did not match")
+	req.Nil(pbReturnRequest.Items[1].UnitPrice, "PB unit price should not have been set for the unpriced item")
+	req.Nil(pbReturnRequest.Items[1].RefundAmount, "PB refund amount should not have been set for the unpriced item")
+
+	// Requests without creation times do not get one on the way through
+	req.Nil((&ReturnRequest{}).AsPBReturnRequest().CreationTime, "PB creation time should not have been set")
+}
+
+// TestReturnTasks confirms that each returned item is given receive, inspect, and refund tasks, related to the
+// return request and carrying what the tasks need to know.
+func TestReturnTasks(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	tasks := buildMockReturnRequest().Tasks()
+	req.Equal(6, len(tasks), "should have had three tasks for each of the two items")
+	expected := []struct {
+		itemId   string
+		taskCode string
+		status   fulfillschema.TaskStatus
+		reason   string
+	}{
+		{orderItemId1, TaskCodeReceiveReturn, fulfillschema.WAITING_CUSTOMER, ReasonAwaitingReturn},
+		{orderItemId1, TaskCodeInspect, fulfillschema.WAITING_TASK, ReasonWaitForReceipt},
+		{orderItemId1, TaskCodeRefund, fulfillschema.WAITING_TASK, ReasonWaitForInspection},
+		{orderItemId2, TaskCodeReceiveReturn, fulfillschema.WAITING_CUSTOMER, ReasonAwaitingReturn},
+		{orderItemId2, TaskCodeInspect, fulfillschema.WAITING_TASK, ReasonWaitForReceipt},
+		{orderItemId2, TaskCodeRefund, fulfillschema.WAITING_TASK, ReasonWaitForInspection},
+	}
+	ids := make(map[string]bool)
+	for i, task := range tasks {
+		req.NotEmpty(task.Id, "task %d should have been given an ID", i)
+		ids[task.Id] = true
+		req.Equal(returnId, task.ReturnId, "task %d return ID did not match", i)
+		req.Equal(orderId, task.OrderId, "task %d order ID did not match", i)
+		req.Equal(expected[i].itemId, task.OrderItemId, "task %d order item ID did not match", i)
+		req.Equal(expected[i].taskCode, task.TaskCode, "task %d task code did not match", i)
+		req.Equal(expected[i].status, task.Status, "task %d status did not match", i)
+		req.Equal(expected[i].reason, task.ReasonCode, "task %d reason code did not match", i)
+		req.Equal(ParamReturnQuantity, task.Parameters[0].Name, "task %d first parameter should have been the quantity", i)
+		req.Equal(ParamReturnReason, task.Parameters[1].Name, "task %d second parameter should have been the reason", i)
+	}
+	req.Equal(6, len(ids), "every task should have had its own ID")
+	req.Equal("2", tasks[0].Parameters[0].Value, "returned quantity did not match")
+	req.Equal("unwanted", tasks[0].Parameters[1].Value, "return reason did not match")
+
+	// Only the refund of the priced item knows how much to give back
+	req.Equal(3, len(tasks[2].Parameters), "priced refund task should have had a refund amount")
+	req.Equal(ParamRefundAmount, tasks[2].Parameters[2].Name, "refund amount parameter name did not match")
+	req.Equal("USD 22.50", tasks[2].Parameters[2].Value, "refund amount did not match")
+	req.Equal(2, len(tasks[1].Parameters), "inspect task should not have had a refund amount")
+	req.Equal(2, len(tasks[5].Parameters), "unpriced refund task should not have had a refund amount")
+}
+
+// TestRefundDue confirms that what is due for a returned item is the refund amount worked out when the return was
+// opened, falling back to the undiscounted price for items returned before that was recorded.
+func TestRefundDue(t *testing.T) {
+	req := require.New(t)
+	items := buildMockReturnRequest().Items
+	req.Equal("USD 22.50", items[0].RefundDue().String(), "refund amount should have been due")
+	items[0].RefundAmount = nil
+	req.Equal("USD 25.00", items[0].RefundDue().String(), "undiscounted price should have been due")
+	req.Nil(items[1].RefundDue(), "nothing should have been due for the unpriced item")
+}
+
+// TestReturnedQuantities confirms that the quantities being returned are added up by order item, ignoring
+// return requests that have been cancelled.
+func TestReturnedQuantities(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	quantities := make(map[string]int32)
+	buildMockReturnRequest().ReturnedQuantities(quantities)
+	other := &ReturnRequest{Status: RsRefunded, Items: []*ReturnItem{{OrderItemId: orderItemId1, Quantity: 1}}}
+	other.ReturnedQuantities(quantities)
+	cancelled := &ReturnRequest{Status: RsCancelled, Items: []*ReturnItem{{OrderItemId: orderItemId2, Quantity: 5}}}
+	cancelled.ReturnedQuantities(quantities)
+	req.Equal(map[string]int32{orderItemId1: 3, orderItemId2: 1}, quantities, "returned quantities did not match")
+}
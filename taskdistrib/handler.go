@@ -80,6 +80,11 @@ func init() {
 	addTaskMapping("plastic_yoyo", "upsell_to_gold", schema.WAITING_CS, "task-py-up")
 	addTaskMapping("", "sf_case", schema.WAITING_CS, "task-sf")
 	addTaskMapping("", "ship", schema.WAITING_SERVICE, "task-ship")
+
+	// The tasks that handle the return of an order item, see the returns service, are the same whatever the product
+	addTaskMapping("", "receive_return", schema.WAITING_CUSTOMER, "task-return-label")
+	addTaskMapping("", "inspect", schema.WAITING_SERVICE, "task-inspect")
+	addTaskMapping("", "refund", schema.WAITING_SERVICE, "task-refund")
 }
 
 // TaskDistributor is the Cloud Function entry point. The payload of the Pub/Sub push request is a task
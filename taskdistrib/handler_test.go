@@ -13,6 +13,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/mikebway/poc-gcp-ecomm/fulfillment/schema"
+	pb "github.com/mikebway/poc-gcp-ecomm/pb/fulfillment"
 	"github.com/mikebway/poc-gcp-ecomm/testutil"
 	"github.com/mikebway/poc-gcp-ecomm/types"
 	"github.com/stretchr/testify/require"
@@ -184,6 +185,37 @@ func TestStatusOnlyMatch(t *testing.T) {
 	req.Contains(logged, "handled", "should have seen the expected completion message in the logs")
 }
 
+// TestReturnTaskMatch confirms that the tasks that handle the return of an order item are matched to their
+// fulfillment functions whatever the product being returned.
+func TestReturnTaskMatch(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// We want to see the URLs that would really be invoked, not those that other tests have overridden them with
+	unitTestOverrideUrl = ""
+
+	// Each case gives a return task code and status, and the function that it should be routed to
+	cases := []struct {
+		taskCode string
+		status   pb.TaskStatus
+		funcName string
+	}{
+		{"receive_return", schema.WAITING_CUSTOMER, "task-return-label"},
+		{"inspect", schema.WAITING_SERVICE, "task-inspect"},
+		{"refund", schema.WAITING_SERVICE, "task-refund"},
+		{"refund", schema.WAITING_TASK, ""},
+	}
+	for _, c := range cases {
+		task := &pb.Task{ProductCode: "this-will-not-match", TaskCode: c.taskCode, Status: c.status}
+		expected := ""
+		if c.funcName != "" {
+			expected = urlProtocolPrefix + c.funcName + funcDomainSuffix
+		}
+		req.Equal(expected, functionURL(funcDomainSuffix, task), "function URL did not match for %s task in status %v", c.taskCode, c.status)
+	}
+}
+
 // TestNoMatch confirms that a failure to match a task to a fulfillment function by any criteria will result
 // in a NO-OP success.
 func TestNoMatch(t *testing.T) {
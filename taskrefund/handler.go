@@ -0,0 +1,195 @@
+// Package taskrefund implements a Google Cloud Function that receives the refund tasks of return requests from the
+// Task Distributor as CloudEvents. The handler has the payment provider give back the price of the returned items,
+// then completes the task so that the return request can be rolled up to RS_REFUNDED.
+package taskrefund
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	"github.com/GoogleCloudPlatform/functions-framework-go/functions"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/golang/protobuf/proto"
+	"github.com/mikebway/poc-gcp-ecomm/fulfillment/fulfillapi"
+	"github.com/mikebway/poc-gcp-ecomm/payments"
+	pb "github.com/mikebway/poc-gcp-ecomm/pb/fulfillment"
+	returnsschema "github.com/mikebway/poc-gcp-ecomm/returns/schema"
+	"github.com/mikebway/poc-gcp-ecomm/types"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// reasonRefunded is the reason code given to refund tasks that have been completed
+	reasonRefunded = "refunded"
+
+	// reasonRefundFailed is the reason code given to refund tasks that are handed over to customer service because
+	// the refund cannot be given, e.g. because the payment for the order was never captured
+	reasonRefundFailed = "refund_failed"
+)
+
+var (
+	// lazyFulfillmentService is the lazy-loaded fulfillment service implementation that we use to update tasks
+	lazyFulfillmentService *fulfillapi.FulfillmentService
+
+	// paymentProvider is the payment gateway that we give refunds through
+	paymentProvider payments.PaymentProvider
+)
+
+// init is the static initializer used to configure our local and global state.
+func init() {
+
+	// Initialize our Zap logger
+	serviceLogger, _ := zap.NewProduction()
+	zap.ReplaceGlobals(serviceLogger)
+
+	// We only have a pretend payment gateway for now
+	paymentProvider = payments.NewFakeProvider()
+
+	// Inform the Cloud Function framework which Go function to invoke when an event is received via HTTPS POST
+	functions.CloudEvent("RefundTask", refundTask)
+}
+
+// refundTask consumes a CloudEvent message containing an e-commerce fulfillment task description. Returning an
+// error has the Task Distributor report a failure to Pub/Sub, which will push the task to us again later.
+func refundTask(ctx context.Context, e cloudevents.Event) error {
+
+	// Flush the logs before exiting each invocation of this Cloud Function
+	//goland:noinspection GoUnhandledErrorResult
+	defer zap.L().Sync()
+
+	// Log that we have been invoked
+	zap.L().Info("refundTask event", zap.String("eventId", e.ID()), zap.String("eventType", e.Type()))
+
+	// Unpack the task data from the event
+	task, err := unmarshalTask(e.Data())
+	if err != nil {
+		return fmt.Errorf("unmarshal task failure: %v", err)
+	}
+
+	// Have our big brother sibling do all the real work
+	err = doRefundTask(ctx, task)
+	if err != nil {
+		zap.L().Error("failed to refund task", zap.String("taskId", task.Id), zap.Error(err))
+	}
+	return err
+}
+
+// doRefundTask does all the heavy lifting for refundTask. It is implemented as a separate function to isolate the
+// task processing from the transport interface.
+//
+// The task ID serves as the refund ID, so a task that is pushed to us again, e.g. because we failed to update it
+// after the refund was given, is not refunded twice. Refunds that can never be given, because the return request
+// or the payment cannot be found, or the payment has not been captured or does not have enough left in it, are
+// passed on to customer service to sort out rather than failing over and over again.
+func doRefundTask(ctx context.Context, task *pb.Task) error {
+
+	// Obtain our logger once for multiple uses in this function
+	logger := zap.L()
+
+	// Only the refund tasks of return requests that are ready to go are any concern of ours
+	if task.TaskCode != returnsschema.TaskCodeRefund || task.ReturnId == "" || task.Status != pb.TaskStatus_WAITING_SERVICE {
+		logger.Info("not a refund task waiting for service, ignored", zap.String("taskId", task.Id),
+			zap.String("task", task.TaskCode), zap.String("status", task.Status.String()))
+		return nil
+	}
+
+	// Lazy load the fulfillment service that we will use to update the task
+	svc, err := getFulfillmentService()
+	if err != nil {
+		return err
+	}
+
+	// Work out how much is due from what was paid for the returned items, as recorded on the return request
+	amount, err := refundAmount(ctx, svc.FsClient, task)
+	if err == nil && amount != nil && !amount.IsZero() {
+
+		// And give it back
+		pmts := payments.NewPayments(svc.FsClient, paymentProvider)
+		_, err = pmts.Refund(ctx, task.OrderId, task.Id, amount)
+		if err == nil {
+			logger.Info("refunded returned items", zap.String("taskId", task.Id), zap.String("orderId", task.OrderId),
+				zap.String("returnId", task.ReturnId), zap.String("amount", amount.String()))
+		}
+	}
+
+	// Hand the refunds that we will never be able to give over to customer service, and try again later for those
+	// that we might
+	if err != nil {
+		if errors.Is(err, payments.ErrNotCaptured) || errors.Is(err, payments.ErrRefundExceedsPayment) || status.Code(err) == codes.NotFound {
+			logger.Warn("refund cannot be given, passing task to customer service", zap.String("taskId", task.Id), zap.Error(err))
+			return updateTaskStatus(ctx, svc, task, pb.TaskStatus_WAITING_CS, reasonRefundFailed)
+		}
+		return err
+	}
+
+	// All done
+	return updateTaskStatus(ctx, svc, task, pb.TaskStatus_COMPLETED, reasonRefunded)
+}
+
+// refundAmount returns what was paid for the returned items that the given refund task is for, as recorded on the
+// task's return request; see returnsschema.ReturnItem.RefundDue. Nil is returned for items that were not priced,
+// e.g. those returned from orders placed before prices were recorded, which have nothing to refund. A codes.NotFound
+// status error is returned if the return request, or the item on it, cannot be found.
+func refundAmount(ctx context.Context, client *firestore.Client, task *pb.Task) (*types.Money, error) {
+
+	// Fetch the return request
+	returnRequest := &returnsschema.ReturnRequest{Id: task.ReturnId}
+	snap, err := client.Doc(returnRequest.StoreRefPath()).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, status.Errorf(codes.NotFound, "return request not found: return ID=%s", task.ReturnId)
+	}
+	if err == nil {
+		err = snap.DataTo(returnRequest)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve return request: return ID=%s: %w", task.ReturnId, err)
+	}
+
+	// Find the item that the task is for
+	for _, item := range returnRequest.Items {
+		if item.OrderItemId == task.OrderItemId {
+			return item.RefundDue(), nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "return request item not found: return ID=%s, item ID=%s", task.ReturnId, task.OrderItemId)
+}
+
+// updateTaskStatus sets the status and reason code of the given task.
+func updateTaskStatus(ctx context.Context, svc *fulfillapi.FulfillmentService, task *pb.Task, taskStatus pb.TaskStatus, reasonCode string) error {
+	_, err := svc.UpdateTaskStatus(ctx, &pb.UpdateTaskStatusRequest{TaskId: task.Id, Status: taskStatus, ReasonCode: reasonCode})
+	if err != nil {
+		return err
+	}
+	zap.L().Info("refund task updated", zap.String("taskId", task.Id), zap.String("status", taskStatus.String()), zap.String("reason", reasonCode))
+	return nil
+}
+
+// getFulfillmentService lazy loads the fulfillment service that we use to update tasks
+func getFulfillmentService() (*fulfillapi.FulfillmentService, error) {
+
+	// if we already have the service in hand, return it fast
+	if lazyFulfillmentService != nil {
+		return lazyFulfillmentService, nil
+	}
+
+	// Try to load the service and cache it for posterity
+	var err error
+	lazyFulfillmentService, err = fulfillapi.NewFulfillmentService()
+	return lazyFulfillmentService, err
+}
+
+// unmarshalTask unpacks the provided binary protobuf message into a task structure.
+func unmarshalTask(message []byte) (*pb.Task, error) {
+
+	// Unmarshal the protobuf message bytes if we can
+	task := &pb.Task{}
+	err := proto.Unmarshal(message, task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal task protobuf message: %w", err)
+	}
+	return task, nil
+}
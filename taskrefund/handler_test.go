@@ -0,0 +1,242 @@
+package taskrefund
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/uuid"
+	"github.com/mikebway/poc-gcp-ecomm/fulfillment/fulfillapi"
+	"github.com/mikebway/poc-gcp-ecomm/fulfillment/schema"
+	"github.com/mikebway/poc-gcp-ecomm/payments"
+	pb "github.com/mikebway/poc-gcp-ecomm/pb/fulfillment"
+	returnsschema "github.com/mikebway/poc-gcp-ecomm/returns/schema"
+	"github.com/mikebway/poc-gcp-ecomm/testutil"
+	"github.com/mikebway/poc-gcp-ecomm/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// EnvFirestoreEmulator defines the environment variable name that is used to convey that the Firestore emulator
+	// is running, should be used, and how to connect to it
+	EnvFirestoreEmulator = "FIRESTORE_EMULATOR_HOST"
+
+	// FirestoreEmulatorHost defines the server name and port (in TCP6 terms) of the Firestore emulator
+	FirestoreEmulatorHost = "[::1]:8219"
+
+	// eventId defines the ID of our mock event
+	eventId = "0d6e2a4c-7b0f-4bd4-9d51-1f3c0f7d2c55"
+
+	// eventSource defines the source of our mock event as being from a unit test
+	eventSource = "https://unitest.com/taskrefund"
+
+	// eventType defines the source of our mock event as being from a unit test
+	eventType = "test"
+)
+
+// TestMain, if defined (it's optional), allows setup code to be run before and after the suite of unit tests
+// for this package.
+func TestMain(m *testing.M) {
+
+	// Ensure that our Firestore requests do not get routed to the live project by mistake
+	fulfillapi.ProjectId = "demo-" + fulfillapi.ProjectId
+
+	// Configure the environment variable that informs the Firestore client that it should connect to the
+	// emulator and how to reach it.
+	_ = os.Setenv(EnvFirestoreEmulator, FirestoreEmulatorHost)
+
+	// Run all the unit tests
+	m.Run()
+}
+
+// TestRefundTaskHappyPath confirms that the price of the returned items is refunded, once only, and the refund task
+// completed.
+func TestRefundTaskHappyPath(t *testing.T) {
+
+	// Do the common setup that we share with the other tests
+	req, ctx, svc, task := commonTestSetup(t, payments.PsCaptured, payments.FakeAuthorizationPrefix)
+
+	// Have the task handled
+	var err error
+	logged := testutil.CaptureLogging(func() {
+		err = refundTask(ctx, *buildEvent(task))
+	})
+	req.Nil(err, "should have been successful: %v", err)
+	req.Contains(logged, "refunded returned items", "should have seen the expected refund message in the logs")
+
+	// What was paid for the two yo-yos returned, after their share of a discount and with their share of the tax,
+	// should have been refunded ...
+	pmts := payments.NewPayments(svc.FsClient, nil)
+	payment, err := pmts.GetPayment(ctx, task.OrderId)
+	req.Nil(err, "failed to retrieve payment: %v", err)
+	req.Equal("USD 23.40", payment.RefundedAmount.String(), "refunded amount did not match")
+	req.Equal([]string{task.Id}, payment.RefundIds, "refund should have been identified by the task ID")
+
+	// ... and the task completed
+	response, err := svc.GetTaskByID(ctx, &pb.GetTaskByIDRequest{TaskId: task.Id})
+	req.Nil(err, "failed to retrieve task: %v", err)
+	req.Equal(pb.TaskStatus_COMPLETED, response.Task.Status, "task should have been completed")
+	req.Equal(reasonRefunded, response.Task.ReasonCode, "task reason code did not match")
+
+	// Having the same task pushed to us again should not give any more back
+	err = doRefundTask(ctx, task)
+	req.Nil(err, "repeated task should not have failed: %v", err)
+	payment, err = pmts.GetPayment(ctx, task.OrderId)
+	req.Nil(err, "failed to retrieve payment: %v", err)
+	req.Equal("USD 23.40", payment.RefundedAmount.String(), "refund should not have been given again")
+}
+
+// TestRefundTaskNotCaptured confirms that a refund that can never be given is handed over to customer service
+// without an error that would have the task pushed to us again.
+func TestRefundTaskNotCaptured(t *testing.T) {
+
+	// Do the common setup with a payment that was never captured
+	req, ctx, svc, task := commonTestSetup(t, payments.PsAuthorized, payments.FakeAuthorizationPrefix)
+
+	// Have the task handled
+	var err error
+	logged := testutil.CaptureLogging(func() {
+		err = doRefundTask(ctx, task)
+	})
+	req.Nil(err, "should not have seen an error: %v", err)
+	req.Contains(logged, "refund cannot be given, passing task to customer service", "should have seen the expected message in the logs")
+
+	// The task should be waiting on customer service
+	response, err := svc.GetTaskByID(ctx, &pb.GetTaskByIDRequest{TaskId: task.Id})
+	req.Nil(err, "failed to retrieve task: %v", err)
+	req.Equal(pb.TaskStatus_WAITING_CS, response.Task.Status, "task should have been passed to customer service")
+	req.Equal(reasonRefundFailed, response.Task.ReasonCode, "task reason code did not match")
+}
+
+// TestRefundTaskProviderFailure confirms that a refund that the payment provider fails to give is reported as an
+// error, so that the task is pushed to us again later, and that the task is left alone.
+func TestRefundTaskProviderFailure(t *testing.T) {
+
+	// Do the common setup, with an authorization that the fake provider does not recognize
+	req, ctx, svc, task := commonTestSetup(t, payments.PsCaptured, "not_a_fake_auth_")
+
+	// Have the task handled
+	err := doRefundTask(ctx, task)
+	req.NotNil(err, "should have seen an error")
+	req.Contains(err.Error(), "unknown payment authorization", "should have seen the expected provider error")
+
+	// Nothing should have changed
+	response, err := svc.GetTaskByID(ctx, &pb.GetTaskByIDRequest{TaskId: task.Id})
+	req.Nil(err, "failed to retrieve task: %v", err)
+	req.Equal(pb.TaskStatus_WAITING_SERVICE, response.Task.Status, "task should have been left waiting for service")
+}
+
+// TestRefundTaskIgnored confirms that tasks other than the refund tasks of return requests are passed over.
+func TestRefundTaskIgnored(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Offer up a task that is not ours to handle
+	task := (&schema.Task{Id: uuid.NewString(), OrderId: uuid.NewString(), TaskCode: "ship", Status: schema.WAITING_SERVICE}).AsPBTask()
+	var err error
+	logged := testutil.CaptureLogging(func() {
+		err = refundTask(context.Background(), *buildEvent(task))
+	})
+	req.Nil(err, "should not have seen an error: %v", err)
+	req.Contains(logged, "not a refund task waiting for service, ignored", "should have seen the expected message in the logs")
+}
+
+// TestRefundTaskSadPath exercises the main event handler function with bad data that should result in an error.
+func TestRefundTaskSadPath(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Assemble a mock event then mess with the data so that it won't unmarshal as a task
+	event := buildEvent(&pb.Task{})
+	_ = event.SetData(cloudevents.Base64, []byte("Ceci n’est pas une pipe"))
+
+	// Confirm that we were told about it
+	err := refundTask(context.Background(), *event)
+	req.NotNil(err, "should have seen an error")
+	req.Contains(err.Error(), "failed to unmarshal task protobuf message:", "should have seen the expected error cause")
+}
+
+// commonTestSetup helps us to be a little DRY (Don't Repeat Yourself) in this file. It records a payment with the
+// given status, and an authorization ID formed from the given prefix, for a brand new order, along with a return
+// request for two of its gold yo-yos, bought with a discount, and the refund task of that return, waiting for
+// service. The refund task is returned in its protocol buffer form, as it would be pushed to us, and everything is
+// cleaned up when the test is done.
+func commonTestSetup(t *testing.T, paymentStatus payments.PaymentStatus, authorizationPrefix string) (*require.Assertions, context.Context, *fulfillapi.FulfillmentService, *pb.Task) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Obtain a clean instance of the fulfillment service
+	lazyFulfillmentService = nil
+	ctx := context.Background()
+	svc, err := getFulfillmentService()
+	req.Nil(err, "did not expect an error obtaining a new FulfillmentService: %v", err)
+
+	// Record the payment
+	orderId := uuid.NewString()
+	payment := &payments.Payment{
+		OrderId:         orderId,
+		Status:          paymentStatus,
+		Amount:          types.NewMoney("USD", 50, 0),
+		AuthorizationId: authorizationPrefix + orderId,
+	}
+	paymentRef := svc.FsClient.Doc(payment.StoreRefPath())
+	_, err = paymentRef.Set(ctx, payment)
+	req.Nil(err, "failed to store payment: %v", err)
+
+	// And the return request
+	returnRequest := &returnsschema.ReturnRequest{
+		Id:         uuid.NewString(),
+		OrderId:    orderId,
+		ReasonCode: "unwanted",
+		Status:     returnsschema.RsInspected,
+		Items: []*returnsschema.ReturnItem{
+			{OrderItemId: uuid.NewString(), ProductCode: "gold_yoyo", Quantity: 2, UnitPrice: types.NewMoney("USD", 12, 500_000_000),
+				RefundAmount: types.NewMoney("USD", 23, 400_000_000)},
+		},
+	}
+	returnRef := svc.FsClient.Doc(returnRequest.StoreRefPath())
+	_, err = returnRef.Set(ctx, returnRequest)
+	req.Nil(err, "failed to store return request: %v", err)
+
+	// And its refund task, ready to go
+	var task *schema.Task
+	for _, returnTask := range returnRequest.Tasks() {
+		if returnTask.TaskCode == returnsschema.TaskCodeRefund {
+			task = returnTask
+		}
+	}
+	task.Status = schema.WAITING_SERVICE
+	task.ReasonCode = ""
+	err = svc.SaveTasks(ctx, []*schema.Task{task})
+	req.Nil(err, "failed to store task: %v", err)
+
+	// Tidy up after ourselves
+	t.Cleanup(func() {
+		_, _ = paymentRef.Delete(ctx)
+		_, _ = returnRef.Delete(ctx)
+		_, _ = svc.FsClient.Doc(task.StoreRefPath()).Delete(ctx)
+	})
+	return req, ctx, svc, task.AsPBTask()
+}
+
+// buildEvent returns a populated CloudEvent containing the given task encoded as base64.
+func buildEvent(task *pb.Task) *cloudevents.Event {
+
+	// Marshal the task to its binary message state
+	pbBytes, _ := proto.Marshal(task)
+
+	// Create a CloudEvents structure and populate that with our data
+	e := cloudevents.NewEvent()
+	e.SetID(eventId)
+	e.SetSource(eventSource)
+	e.SetType(eventType)
+	_ = e.SetData(cloudevents.Base64, pbBytes)
+
+	// All done
+	return &e
+}
@@ -0,0 +1,161 @@
+package tasktrigger
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	orderschema "github.com/mikebway/poc-gcp-ecomm/order/schema"
+	pbfulfillment "github.com/mikebway/poc-gcp-ecomm/pb/fulfillment"
+	returnsschema "github.com/mikebway/poc-gcp-ecomm/returns/schema"
+)
+
+// rollupOrderStatus works out the status of each item of the given order from the fulfillment tasks recorded for
+// it, then has the order service store those on the order document along with the status of the order as a whole.
+// The rolled up order status is returned.
+//
+// All the tasks of the order are considered, not just the one that changed, so that the rollup is correct however
+// many times the trigger is invoked for the order's tasks. Invocations for different tasks of the same order can
+// run concurrently though, and finish in any order, so the time at which the tasks were read is recorded along with
+// the rollup; the order service will not let the rollup of an earlier read replace that of a later one. Tasks that
+// handle the return of an order item are left out; they have no bearing on how far the item got towards
+// fulfillment.
+func rollupOrderStatus(ctx context.Context, orderId string) (orderschema.OrderStatus, error) {
+
+	// Gather up all the tasks for the order, noting the time beforehand; the tasks that we get back are at least as
+	// up-to-date as they were then
+	asOf := time.Now()
+	tasks, err := fulfillClient.GetOrderTasks(ctx, orderId)
+	if err != nil {
+		return orderschema.OsUnspecified, fmt.Errorf("unable to retrieve order tasks from firestore: %w", err)
+	}
+
+	// Sort them by the order item that they belong to
+	itemTasks := make(map[string][]*pbfulfillment.Task)
+	for _, task := range tasks {
+		if task.ReturnId != "" {
+			continue
+		}
+		itemTasks[task.OrderItemId] = append(itemTasks[task.OrderItemId], task)
+	}
+
+	// Work out the status of each of those items and have the order service roll them up into an order status
+	itemStatuses := make(map[string]orderschema.OrderItemStatus, len(itemTasks))
+	for itemId, tasks := range itemTasks {
+		itemStatuses[itemId] = itemStatusFromTasks(tasks)
+	}
+	return orderClient.UpdateOrderStatus(ctx, orderId, itemStatuses, asOf)
+}
+
+// itemStatusFromTasks returns the status of an order item given the fulfillment tasks recorded for it:
+//
+//   - cancelled if every task has been canceled
+//   - complete if every task has been either completed or canceled
+//   - pending if no work has started yet, i.e. there are no tasks or they are all waiting for payment
+//   - in progress otherwise
+func itemStatusFromTasks(tasks []*pbfulfillment.Task) orderschema.OrderItemStatus {
+
+	// Count the tasks in each of the states that we care about
+	var completed, canceled, waitingPayment int
+	for _, task := range tasks {
+		switch task.Status {
+		case pbfulfillment.TaskStatus_COMPLETED:
+			completed++
+		case pbfulfillment.TaskStatus_CANCELED:
+			canceled++
+		case pbfulfillment.TaskStatus_WAITING_PAYMENT:
+			waitingPayment++
+		}
+	}
+
+	// Work from the most finished states down to the least
+	taskCount := len(tasks)
+	switch {
+	case taskCount == 0:
+		return orderschema.OisPending
+	case canceled == taskCount:
+		return orderschema.OisCancelled
+	case completed+canceled == taskCount:
+		return orderschema.OisComplete
+	case waitingPayment+canceled == taskCount:
+		return orderschema.OisPending
+	default:
+		return orderschema.OisInProgress
+	}
+}
+
+// rollupReturnStatus works out the status of the return request with the given ID, for an order with the given ID,
+// from the fulfillment tasks recorded for it, then has the returns service store that on the return request
+// document. The return status that was recorded is returned.
+//
+// As with rollupOrderStatus, the time at which the tasks were read is recorded along with the rollup, so that the
+// rollup of an earlier read by a concurrent invocation for another of the return's tasks cannot replace that of a
+// later one.
+func rollupReturnStatus(ctx context.Context, orderId string, returnId string) (returnsschema.ReturnStatus, error) {
+
+	// Gather up all the tasks for the order, noting the time beforehand, keeping only those for the return
+	asOf := time.Now()
+	tasks, err := fulfillClient.GetOrderTasks(ctx, orderId)
+	if err != nil {
+		return returnsschema.RsUnspecified, fmt.Errorf("unable to retrieve order tasks from firestore: %w", err)
+	}
+	var returnTasks []*pbfulfillment.Task
+	for _, task := range tasks {
+		if task.ReturnId == returnId {
+			returnTasks = append(returnTasks, task)
+		}
+	}
+
+	// Work out the status and have the returns service record it
+	return returnsClient.UpdateReturnStatus(ctx, returnId, returnStatusFromTasks(returnTasks), asOf)
+}
+
+// returnStatusFromTasks returns the status of a return request given the receive, inspect, and refund tasks
+// recorded for its items:
+//
+//   - cancelled if every task has been canceled
+//   - refunded if every refund task has been completed or canceled, and at least one completed
+//   - rejected if every inspect task has been completed and every refund task canceled
+//   - inspected if every inspect task has been completed
+//   - received if every receive task has been completed
+//   - open otherwise
+func returnStatusFromTasks(tasks []*pbfulfillment.Task) returnsschema.ReturnStatus {
+
+	// Count the tasks of each kind, and how many of those have been completed or canceled
+	total := make(map[string]int)
+	completed := make(map[string]int)
+	canceled := make(map[string]int)
+	canceledCount := 0
+	for _, task := range tasks {
+		total[task.TaskCode]++
+		switch task.Status {
+		case pbfulfillment.TaskStatus_COMPLETED:
+			completed[task.TaskCode]++
+		case pbfulfillment.TaskStatus_CANCELED:
+			canceled[task.TaskCode]++
+			canceledCount++
+		}
+	}
+	allCompleted := func(taskCode string) bool {
+		return total[taskCode] > 0 && completed[taskCode] == total[taskCode]
+	}
+
+	// Work from the most finished states down to the least
+	refund := returnsschema.TaskCodeRefund
+	switch {
+	case len(tasks) == 0:
+		return returnsschema.RsOpen
+	case canceledCount == len(tasks):
+		return returnsschema.RsCancelled
+	case completed[refund] > 0 && completed[refund]+canceled[refund] == total[refund]:
+		return returnsschema.RsRefunded
+	case allCompleted(returnsschema.TaskCodeInspect) && total[refund] > 0 && canceled[refund] == total[refund]:
+		return returnsschema.RsRejected
+	case allCompleted(returnsschema.TaskCodeInspect):
+		return returnsschema.RsInspected
+	case allCompleted(returnsschema.TaskCodeReceiveReturn):
+		return returnsschema.RsReceived
+	default:
+		return returnsschema.RsOpen
+	}
+}
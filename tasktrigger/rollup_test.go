@@ -0,0 +1,84 @@
+package tasktrigger
+
+import (
+	"testing"
+
+	orderschema "github.com/mikebway/poc-gcp-ecomm/order/schema"
+	pbfulfillment "github.com/mikebway/poc-gcp-ecomm/pb/fulfillment"
+	returnsschema "github.com/mikebway/poc-gcp-ecomm/returns/schema"
+	"github.com/stretchr/testify/require"
+)
+
+// TestItemStatusFromTasks confirms that the status of an order item is rolled up from the status of its tasks as we
+// would expect.
+func TestItemStatusFromTasks(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Each case gives the status of the tasks for an item and the item status that should follow from them
+	cases := []struct {
+		taskStatuses []pbfulfillment.TaskStatus
+		expected     orderschema.OrderItemStatus
+	}{
+		{nil, orderschema.OisPending},
+		{[]pbfulfillment.TaskStatus{pbfulfillment.TaskStatus_WAITING_PAYMENT}, orderschema.OisPending},
+		{[]pbfulfillment.TaskStatus{pbfulfillment.TaskStatus_WAITING_PAYMENT, pbfulfillment.TaskStatus_CANCELED}, orderschema.OisPending},
+		{[]pbfulfillment.TaskStatus{pbfulfillment.TaskStatus_WAITING_CUSTOMER}, orderschema.OisInProgress},
+		{[]pbfulfillment.TaskStatus{pbfulfillment.TaskStatus_COMPLETED, pbfulfillment.TaskStatus_WAITING_TASK}, orderschema.OisInProgress},
+		{[]pbfulfillment.TaskStatus{pbfulfillment.TaskStatus_PAUSED}, orderschema.OisInProgress},
+		{[]pbfulfillment.TaskStatus{pbfulfillment.TaskStatus_COMPLETED, pbfulfillment.TaskStatus_COMPLETED}, orderschema.OisComplete},
+		{[]pbfulfillment.TaskStatus{pbfulfillment.TaskStatus_COMPLETED, pbfulfillment.TaskStatus_CANCELED}, orderschema.OisComplete},
+		{[]pbfulfillment.TaskStatus{pbfulfillment.TaskStatus_CANCELED, pbfulfillment.TaskStatus_CANCELED}, orderschema.OisCancelled},
+	}
+	for _, c := range cases {
+		var tasks []*pbfulfillment.Task
+		for _, taskStatus := range c.taskStatuses {
+			tasks = append(tasks, &pbfulfillment.Task{Status: taskStatus})
+		}
+		req.Equal(c.expected, itemStatusFromTasks(tasks), "unexpected item status for task statuses %v", c.taskStatuses)
+	}
+}
+
+// TestReturnStatusFromTasks confirms that the status of a return request is rolled up from the status of its receive,
+// inspect, and refund tasks as we would expect.
+func TestReturnStatusFromTasks(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Shorthand for the statuses of the receive, inspect, and refund tasks of a single returned item
+	const (
+		waiting   = pbfulfillment.TaskStatus_WAITING_TASK
+		completed = pbfulfillment.TaskStatus_COMPLETED
+		canceled  = pbfulfillment.TaskStatus_CANCELED
+	)
+
+	// Each case gives the receive, inspect, and refund task statuses of each returned item and the return status
+	// that should follow from them
+	cases := []struct {
+		itemTaskStatuses [][3]pbfulfillment.TaskStatus
+		expected         returnsschema.ReturnStatus
+	}{
+		{nil, returnsschema.RsOpen},
+		{[][3]pbfulfillment.TaskStatus{{pbfulfillment.TaskStatus_WAITING_CUSTOMER, waiting, waiting}}, returnsschema.RsOpen},
+		{[][3]pbfulfillment.TaskStatus{{completed, waiting, waiting}}, returnsschema.RsReceived},
+		{[][3]pbfulfillment.TaskStatus{{completed, waiting, waiting}, {pbfulfillment.TaskStatus_WAITING_CUSTOMER, waiting, waiting}}, returnsschema.RsOpen},
+		{[][3]pbfulfillment.TaskStatus{{completed, completed, waiting}}, returnsschema.RsInspected},
+		{[][3]pbfulfillment.TaskStatus{{completed, completed, waiting}, {completed, waiting, waiting}}, returnsschema.RsReceived},
+		{[][3]pbfulfillment.TaskStatus{{completed, completed, completed}}, returnsschema.RsRefunded},
+		{[][3]pbfulfillment.TaskStatus{{completed, completed, completed}, {completed, completed, canceled}}, returnsschema.RsRefunded},
+		{[][3]pbfulfillment.TaskStatus{{completed, completed, canceled}}, returnsschema.RsRejected},
+		{[][3]pbfulfillment.TaskStatus{{canceled, canceled, canceled}}, returnsschema.RsCancelled},
+	}
+	taskCodes := []string{returnsschema.TaskCodeReceiveReturn, returnsschema.TaskCodeInspect, returnsschema.TaskCodeRefund}
+	for _, c := range cases {
+		var tasks []*pbfulfillment.Task
+		for _, taskStatuses := range c.itemTaskStatuses {
+			for i, taskStatus := range taskStatuses {
+				tasks = append(tasks, &pbfulfillment.Task{TaskCode: taskCodes[i], Status: taskStatus})
+			}
+		}
+		req.Equal(c.expected, returnStatusFromTasks(tasks), "unexpected return status for task statuses %v", c.itemTaskStatuses)
+	}
+}
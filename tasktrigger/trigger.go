@@ -1,4 +1,4 @@
-// Package tasktrigger handles Firestore trigger invocations when order documents are updated.
+// Package tasktrigger handles Firestore trigger invocations when task documents are updated.
 package tasktrigger
 
 import (
@@ -9,7 +9,11 @@ import (
 	"cloud.google.com/go/pubsub"
 	"github.com/golang/protobuf/proto"
 	"github.com/mikebway/poc-gcp-ecomm/fulfillment/fulfillapi"
+	"github.com/mikebway/poc-gcp-ecomm/order/orderapi"
+	orderschema "github.com/mikebway/poc-gcp-ecomm/order/schema"
 	pbfulfillment "github.com/mikebway/poc-gcp-ecomm/pb/fulfillment"
+	"github.com/mikebway/poc-gcp-ecomm/returns/returnsapi"
+	returnsschema "github.com/mikebway/poc-gcp-ecomm/returns/schema"
 	"go.uber.org/zap"
 )
 
@@ -25,6 +29,14 @@ var (
 	// can substitute an alternative instance here to force errors.
 	fulfillClient FulfillmentServiceClient
 
+	// orderClient is lazy-loaded and allows us to record the rolled up status of orders in Firestore. Unit tests
+	// can substitute an alternative instance here to force errors.
+	orderClient OrderServiceClient
+
+	// returnsClient is lazy-loaded and allows us to record the rolled up status of return requests in Firestore.
+	// Unit tests can substitute an alternative instance here to force errors.
+	returnsClient ReturnsServiceClient
+
 	// pubSubClient is an instance of a wrapper interface for our Pub/Sub client that allows us to inject errors
 	// when unit testing
 	pubSubClient PubSubClient
@@ -65,6 +77,19 @@ type IntegerValue struct {
 // and unit test error generation.
 type FulfillmentServiceClient interface {
 	GetTask(ctx context.Context, taskId string) (*pbfulfillment.Task, error)
+	GetOrderTasks(ctx context.Context, orderId string) ([]*pbfulfillment.Task, error)
+}
+
+// OrderServiceClient is a wrapper for the order service that supports lazy loading of the service and unit test
+// error generation.
+type OrderServiceClient interface {
+	UpdateOrderStatus(ctx context.Context, orderId string, itemStatuses map[string]orderschema.OrderItemStatus, asOf time.Time) (orderschema.OrderStatus, error)
+}
+
+// ReturnsServiceClient is a wrapper for the returns service that supports lazy loading of the service and unit
+// test error generation.
+type ReturnsServiceClient interface {
+	UpdateReturnStatus(ctx context.Context, returnId string, returnStatus returnsschema.ReturnStatus, asOf time.Time) (returnsschema.ReturnStatus, error)
 }
 
 // PubSubClient is a wrapper for our Google client that supports lazy loading of the client and unit test
@@ -84,8 +109,10 @@ func init() {
 	serviceLogger, _ := zap.NewProduction()
 	zap.ReplaceGlobals(serviceLogger)
 
-	// Instantiate our two clients
+	// Instantiate our four clients
 	fulfillClient = &FulfillmentServiceClientImpl{}
+	orderClient = &OrderServiceClientImpl{}
+	returnsClient = &ReturnsServiceClientImpl{}
 	pubSubClient = &PubSubClientImpl{}
 }
 
@@ -134,13 +161,20 @@ func doCartTrigger(ctx context.Context, e FirestoreEvent) error {
 	logger.Info("processing task", zap.String("taskId", taskId))
 
 	// Retrieve the full task from Firestore
-	order, err := fulfillClient.GetTask(ctx, taskId)
+	task, err := fulfillClient.GetTask(ctx, taskId)
 	if err != nil {
 		return fmt.Errorf("unable to retrieve task from firestore: %s - %w", taskId, err)
 	}
 
+	// Roll the status of the task's return request or order up before publishing the task, so that a publish
+	// failure and the retry that follows it do not publish the task twice; rolling up a second time does no harm
+	err = rollupTaskStatus(ctx, task)
+	if err != nil {
+		return err
+	}
+
 	// Publish the task to our target topic
-	err = pubSubClient.Publish(ctx, order)
+	err = pubSubClient.Publish(ctx, task)
 	if err != nil {
 		return fmt.Errorf("pubsub publish failed: %s - %w", taskId, err)
 	}
@@ -150,6 +184,35 @@ func doCartTrigger(ctx context.Context, e FirestoreEvent) error {
 	return nil
 }
 
+// rollupTaskStatus rolls up the status of the return request or order that the given task belongs to from all of
+// its tasks, the change to the task having possibly moved it along.
+func rollupTaskStatus(ctx context.Context, task *pbfulfillment.Task) error {
+
+	// We need to log multiple times so just get the logger and be done with that
+	logger := zap.L()
+
+	// Return tasks move their return request along rather than the order, roll its status up from all of the
+	// return's tasks
+	if task.ReturnId != "" {
+		returnStatus, err := rollupReturnStatus(ctx, task.OrderId, task.ReturnId)
+		if err != nil {
+			return fmt.Errorf("return status rollup failed: %s - %w", task.ReturnId, err)
+		}
+		logger.Info("rolled up return status", zap.String("taskId", task.Id), zap.String("returnId", task.ReturnId),
+			zap.Int32("returnStatus", int32(returnStatus)))
+		return nil
+	}
+
+	// Otherwise, roll the status of the order up from all of the order's tasks
+	orderStatus, err := rollupOrderStatus(ctx, task.OrderId)
+	if err != nil {
+		return fmt.Errorf("order status rollup failed: %s - %w", task.OrderId, err)
+	}
+	logger.Info("rolled up order status", zap.String("taskId", task.Id), zap.String("orderId", task.OrderId),
+		zap.Int32("orderStatus", int32(orderStatus)))
+	return nil
+}
+
 // FulfillmentServiceClientImpl is the default implementation of the FulfillmentServiceClient interface.
 // error generation.
 type FulfillmentServiceClientImpl struct {
@@ -177,7 +240,32 @@ func (c *FulfillmentServiceClientImpl) GetTask(ctx context.Context, taskId strin
 	return svcResponse.Task, nil
 }
 
-// getClient lazy-loads our underlying orderapi.OrderService.
+// GetOrderTasks loads all the tasks for the given order from Firestore, however many pages of results that takes.
+func (c *FulfillmentServiceClientImpl) GetOrderTasks(ctx context.Context, orderId string) ([]*pbfulfillment.Task, error) {
+
+	// Lazy-load the underlying fulfillment service that we wrap
+	err := c.lazyLoad()
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep asking for pages of tasks until there are no more
+	var tasks []*pbfulfillment.Task
+	request := &pbfulfillment.GetTasksRequest{OrderId: orderId, PageSize: 100}
+	for {
+		svcResponse, err := c.fulfillmentService.GetTasks(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, svcResponse.Tasks...)
+		if len(svcResponse.NextPageToken) == 0 {
+			return tasks, nil
+		}
+		request.PageToken = svcResponse.NextPageToken
+	}
+}
+
+// lazyLoad lazy-loads our underlying fulfillapi.FulfillmentService.
 func (c *FulfillmentServiceClientImpl) lazyLoad() error {
 
 	// In the normal case, we return quickly because the service has been cached before
@@ -193,6 +281,80 @@ func (c *FulfillmentServiceClientImpl) lazyLoad() error {
 	return err
 }
 
+// OrderServiceClientImpl is the default implementation of the OrderServiceClient interface.
+type OrderServiceClientImpl struct {
+	OrderServiceClient
+
+	orderService *orderapi.OrderService
+}
+
+// UpdateOrderStatus records the given order item statuses, worked out from tasks read at the given time, on the
+// order with the given ID and returns the order status rolled up from them.
+func (c *OrderServiceClientImpl) UpdateOrderStatus(ctx context.Context, orderId string, itemStatuses map[string]orderschema.OrderItemStatus, asOf time.Time) (orderschema.OrderStatus, error) {
+
+	// Lazy-load the underlying order service that we wrap
+	err := c.lazyLoad()
+	if err != nil {
+		return orderschema.OsUnspecified, err
+	}
+
+	// Have the order service do the rest
+	return c.orderService.UpdateOrderStatus(ctx, orderId, itemStatuses, asOf)
+}
+
+// lazyLoad lazy-loads our underlying orderapi.OrderService.
+func (c *OrderServiceClientImpl) lazyLoad() error {
+
+	// In the normal case, we return quickly because the service has been cached before
+	if c.orderService != nil {
+		return nil
+	}
+
+	// Establish our order service
+	var err error
+	c.orderService, err = orderapi.NewOrderService()
+
+	// Happy or not, we are done
+	return err
+}
+
+// ReturnsServiceClientImpl is the default implementation of the ReturnsServiceClient interface.
+type ReturnsServiceClientImpl struct {
+	ReturnsServiceClient
+
+	returnsService *returnsapi.ReturnsService
+}
+
+// UpdateReturnStatus records the given status, worked out from tasks read at the given time, on the return request
+// with the given ID and returns the status that the return request is left with.
+func (c *ReturnsServiceClientImpl) UpdateReturnStatus(ctx context.Context, returnId string, returnStatus returnsschema.ReturnStatus, asOf time.Time) (returnsschema.ReturnStatus, error) {
+
+	// Lazy-load the underlying returns service that we wrap
+	err := c.lazyLoad()
+	if err != nil {
+		return returnsschema.RsUnspecified, err
+	}
+
+	// Have the returns service do the rest
+	return c.returnsService.UpdateReturnStatus(ctx, returnId, returnStatus, asOf)
+}
+
+// lazyLoad lazy-loads our underlying returnsapi.ReturnsService.
+func (c *ReturnsServiceClientImpl) lazyLoad() error {
+
+	// In the normal case, we return quickly because the service has been cached before
+	if c.returnsService != nil {
+		return nil
+	}
+
+	// Establish our returns service
+	var err error
+	c.returnsService, err = returnsapi.NewReturnsService()
+
+	// Happy or not, we are done
+	return err
+}
+
 // PubSubClientImpl is the default implementation of the PubSubClient interface.
 type PubSubClientImpl struct {
 	PubSubClient
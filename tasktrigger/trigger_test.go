@@ -11,7 +11,13 @@ import (
 	"github.com/google/uuid"
 	"github.com/mikebway/poc-gcp-ecomm/fulfillment/fulfillapi"
 	"github.com/mikebway/poc-gcp-ecomm/fulfillment/schema"
+	"github.com/mikebway/poc-gcp-ecomm/order/orderapi"
+	orderschema "github.com/mikebway/poc-gcp-ecomm/order/schema"
 	pbfulfillment "github.com/mikebway/poc-gcp-ecomm/pb/fulfillment"
+	pborder "github.com/mikebway/poc-gcp-ecomm/pb/order"
+	pbreturns "github.com/mikebway/poc-gcp-ecomm/pb/returns"
+	"github.com/mikebway/poc-gcp-ecomm/returns/returnsapi"
+	returnsschema "github.com/mikebway/poc-gcp-ecomm/returns/schema"
 	"github.com/mikebway/poc-gcp-ecomm/testutil"
 	"github.com/mikebway/poc-gcp-ecomm/types"
 	"github.com/stretchr/testify/require"
@@ -72,6 +78,14 @@ var (
 	// fulfillmentService allows unit tests to write populated tasks to Firestore
 	fulfillmentService *fulfillapi.FulfillmentService
 
+	// orderService allows unit tests to write the orders that the tasks belong to to Firestore, and to read back
+	// their rolled up status
+	orderService *orderapi.OrderService
+
+	// returnsService allows unit tests to write the return requests that return tasks belong to to Firestore, and
+	// to read back their rolled up status
+	returnsService *returnsapi.ReturnsService
+
 	// The time our mock task was submitted
 	taskSubmissionTime time.Time
 
@@ -89,6 +103,8 @@ func TestMain(m *testing.M) {
 
 	// Ensure that our Firestore and Pub/Sub requests do not get routed to the live project by mistake
 	fulfillapi.ProjectId = "demo-" + fulfillapi.ProjectId
+	orderapi.ProjectId = "demo-" + orderapi.ProjectId
+	returnsapi.ProjectId = "demo-" + returnsapi.ProjectId
 	TopicProjectId = "demo-" + TopicProjectId
 
 	// Configure the environment variable that informs the Firestore client that it should connect to the
@@ -105,6 +121,14 @@ func TestMain(m *testing.M) {
 	if err != nil {
 		zap.L().Panic("unable to instantiate fulfillment service / firestore client", zap.Error(err))
 	}
+	orderService, err = orderapi.NewOrderService()
+	if err != nil {
+		zap.L().Panic("unable to instantiate order service / firestore client", zap.Error(err))
+	}
+	returnsService, err = returnsapi.NewReturnsService()
+	if err != nil {
+		zap.L().Panic("unable to instantiate returns service / firestore client", zap.Error(err))
+	}
 
 	// Create our Pub/Sub topic if it does not already exist
 	err = createPubSubTopic()
@@ -116,7 +140,8 @@ func TestMain(m *testing.M) {
 	tempTime, _ := types.TimestampFromRFC3339Nano(timeString)
 	taskSubmissionTime = tempTime.GetTime()
 
-	// Build and store a task that we can use as a target in our tests
+	// Build and store a task that we can use as a target in our tests, and the order that it belongs to
+	storeMockOrder(OrderID, ItemId)
 	storedTaskId = storeMockTask()
 
 	// Run all the unit tests
@@ -171,6 +196,7 @@ func TestHandlerHappyPath(t *testing.T) {
 	req.Nil(err, "no error was expected: %v", err)
 	req.Contains(logged, "published task", "did not see happy path log message")
 	req.Contains(logged, storedTaskId, "did not see task ID in log message")
+	req.Contains(logged, "rolled up order status", "did not see order status rollup log message")
 
 	// Repeat a second time (would never happen for the same task in real life) in task
 	// to exercise the already loaded paths of the task service and pubsub client lazy loaders.
@@ -238,6 +264,118 @@ func TestPublishError(t *testing.T) {
 	req.Contains(logged, storedTaskId, "did not see task ID in log message")
 }
 
+// TestOrderStatusRollup confirms that the status of the order that a task belongs to, and of each of its items, is
+// rolled up from all the tasks of the order when the trigger sees any one of them change.
+func TestOrderStatusRollup(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Store an order of our own with two items, the first of which has been made but the second of which is waiting
+	// for the payment to be captured
+	ctx := context.Background()
+	orderId := uuid.NewString()
+	itemId1 := uuid.NewString()
+	itemId2 := uuid.NewString()
+	storeMockOrder(orderId, itemId1, itemId2)
+	made := buildMockTask()
+	made.OrderId, made.OrderItemId, made.Status = orderId, itemId1, schema.COMPLETED
+	held := buildMockTask()
+	held.OrderId, held.OrderItemId = orderId, itemId2
+	err := fulfillmentService.SaveTasks(ctx, []*schema.Task{made, held.HeldForPayment()})
+	req.Nil(err, "failed saving mock tasks: %v", err)
+
+	// Trigger on the completed task
+	err = TaskTrigger(ctx, *mockFirestoreEvent(made.Id))
+	req.Nil(err, "no error was expected: %v", err)
+
+	// The order should now be partially fulfilled, the second item not having been started
+	response, err := orderService.GetOrderByID(ctx, &pborder.GetOrderByIDRequest{OrderId: orderId})
+	req.Nil(err, "failed retrieving the order: %v", err)
+	req.Equal(pborder.OrderStatus_OS_PARTIALLY_FULFILLED, response.Order.Status, "order status did not match")
+	req.Equal(pborder.OrderItemStatus_OIS_COMPLETE, response.Order.OrderItems[0].Status, "first item status did not match")
+	req.Equal(pborder.OrderItemStatus_OIS_PENDING, response.Order.OrderItems[1].Status, "second item status did not match")
+}
+
+// TestReturnStatusRollup confirms that a change to a return task rolls up the status of its return request, leaving
+// the status of the order alone.
+func TestReturnStatusRollup(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Store an order of our own with an item that has been shipped
+	ctx := context.Background()
+	orderId := uuid.NewString()
+	itemId := uuid.NewString()
+	storeMockOrder(orderId, itemId)
+	shipped := buildMockTask()
+	shipped.OrderId, shipped.OrderItemId, shipped.TaskCode, shipped.Status = orderId, itemId, "ship", schema.COMPLETED
+	err := fulfillmentService.SaveTasks(ctx, []*schema.Task{shipped})
+	req.Nil(err, "failed saving mock task: %v", err)
+
+	// And a return request for the item, which has come back but has yet to be inspected
+	returnRequest := &returnsschema.ReturnRequest{
+		Id:         uuid.NewString(),
+		OrderId:    orderId,
+		ReasonCode: "unwanted",
+		Status:     returnsschema.RsOpen,
+		Items:      []*returnsschema.ReturnItem{{OrderItemId: itemId, ProductCode: ProductCode, Quantity: 1}},
+	}
+	_, err = returnsService.FsClient.Doc(returnRequest.StoreRefPath()).Set(ctx, returnRequest)
+	req.Nil(err, "failed saving mock return request: %v", err)
+	returnTasks := returnRequest.Tasks()
+	returnTasks[0].Status = schema.COMPLETED
+	err = fulfillmentService.SaveTasks(ctx, returnTasks)
+	req.Nil(err, "failed saving mock return tasks: %v", err)
+
+	// Trigger on the shipped task, then the received one
+	err = TaskTrigger(ctx, *mockFirestoreEvent(shipped.Id))
+	req.Nil(err, "no error was expected: %v", err)
+	var logged string
+	logged = testutil.CaptureLogging(func() {
+		err = TaskTrigger(ctx, *mockFirestoreEvent(returnTasks[0].Id))
+	})
+	req.Nil(err, "no error was expected: %v", err)
+	req.Contains(logged, "rolled up return status", "did not see return rollup log message")
+
+	// The return should have been received
+	response, err := returnsService.GetReturnByID(ctx, &pbreturns.GetReturnByIDRequest{ReturnId: returnRequest.Id})
+	req.Nil(err, "failed retrieving the return request: %v", err)
+	req.Equal(pbreturns.ReturnStatus_RS_RECEIVED, response.ReturnRequest.Status, "return status did not match")
+
+	// While the order was complete and should have stayed that way, the outstanding return tasks notwithstanding
+	orderResponse, err := orderService.GetOrderByID(ctx, &pborder.GetOrderByIDRequest{OrderId: orderId})
+	req.Nil(err, "failed retrieving the order: %v", err)
+	req.Equal(pborder.OrderStatus_OS_COMPLETE, orderResponse.Order.Status, "order status did not match")
+	req.Equal(pborder.OrderItemStatus_OIS_COMPLETE, orderResponse.Order.OrderItems[0].Status, "item status did not match")
+}
+
+// TestOrderNotExist looks at what happens when a task belongs to an order that cannot be found, so that its status
+// cannot be rolled up.
+func TestOrderNotExist(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Store a task for an order that does not exist
+	ctx := context.Background()
+	task := buildMockTask()
+	task.OrderId = uuid.NewString()
+	err := fulfillmentService.SaveTasks(ctx, []*schema.Task{task})
+	req.Nil(err, "failed saving mock task: %v", err)
+
+	// The trigger fails before the task is published, leaving that to the retry
+	var logged string
+	logged = testutil.CaptureLogging(func() {
+		err = TaskTrigger(ctx, *mockFirestoreEvent(task.Id))
+	})
+	req.NotNil(err, "an error was expected")
+	req.NotContains(logged, "published task", "task should not have been published")
+	req.Contains(logged, "order status rollup failed", "did not see rollup failure log message")
+	req.Contains(logged, task.OrderId, "did not see order ID in log message")
+}
+
 // mockFirestoreEvent constructs a FirestoreEvent populated with known values that we can check in out unit tests.
 func mockFirestoreEvent(taskId string) *FirestoreEvent {
 	return &FirestoreEvent{
@@ -275,6 +413,38 @@ func buildMockTriggerTask(taskId string) *FirestoreTask {
 	}
 }
 
+// storeMockOrder stores an order with the given ID and items in the Firestore emulator, replacing any left over from
+// prior test runs, so that the status of the order can be rolled up when we invoke the trigger.
+//
+// This will panic if the order cannot be saved.
+func storeMockOrder(orderId string, itemIds ...string) {
+
+	// Build the order, with its items waiting for work to start
+	order := &orderschema.Order{
+		Id:             orderId,
+		SubmissionTime: taskSubmissionTime,
+		Status:         orderschema.OsSubmitted,
+	}
+	for _, itemId := range itemIds {
+		order.OrderItems = append(order.OrderItems, &orderschema.OrderItem{
+			Id:          itemId,
+			ProductCode: ProductCode,
+			Quantity:    1,
+			Status:      orderschema.OisPending,
+		})
+	}
+
+	// Clear away any debris, then write the order to the Firestore database
+	ctx := context.Background()
+	_, err := orderService.FsClient.Doc(order.StoreRefPath()).Delete(ctx)
+	if err == nil {
+		err = orderService.SaveOrder(ctx, order)
+	}
+	if err != nil {
+		zap.L().Panic("failed saving mock order to firestore", zap.Error(err))
+	}
+}
+
 // storeMockTask stores a task in the Firestore emulator so that it can be retrieved when
 // we invoke the trigger (i.e. the heart of this Cloud Function) in our tests.
 //
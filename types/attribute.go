@@ -0,0 +1,89 @@
+package types
+
+import (
+	"sort"
+
+	pb "github.com/mikebway/poc-gcp-ecomm/pb/types"
+)
+
+// Attribute is a named string value describing how an item is to be customized, e.g. the text to be engraved on
+// a gold yoyo. Attributes are chosen by the shopper when the item is added to their cart and are carried through
+// the order to the fulfillment tasks for the item.
+type Attribute struct {
+	// Name is the name of the attribute, e.g. "engraving"
+	Name string `firestore:"name" json:"name"`
+
+	// Value is the value of the attribute, e.g. "Happy Birthday Rupert!"
+	Value string `firestore:"value" json:"value"`
+}
+
+// AttributeFromPB is a factory method that generates an Attribute structure from its Protocol Buffer equivalent.
+//
+// If nil is passed in then nil will be returned.
+func AttributeFromPB(pbAttribute *pb.Attribute) *Attribute {
+	if pbAttribute == nil {
+		return nil
+	}
+	return &Attribute{
+		Name:  pbAttribute.Name,
+		Value: pbAttribute.Value,
+	}
+}
+
+// AsPBAttribute returns the protocol buffer representation of this Attribute.
+func (a *Attribute) AsPBAttribute() *pb.Attribute {
+	return &pb.Attribute{
+		Name:  a.Name,
+		Value: a.Value,
+	}
+}
+
+// AttributesFromPB converts a slice of protocol buffer attributes to their Attribute equivalents, skipping any nil
+// entries. If there are no attributes, nil is returned.
+func AttributesFromPB(pbAttributes []*pb.Attribute) []*Attribute {
+	var attributes []*Attribute
+	for _, pbAttribute := range pbAttributes {
+		if pbAttribute != nil {
+			attributes = append(attributes, AttributeFromPB(pbAttribute))
+		}
+	}
+	return attributes
+}
+
+// AttributesAsPB converts a slice of attributes to their protocol buffer equivalents. If there are no attributes,
+// nil is returned.
+func AttributesAsPB(attributes []*Attribute) []*pb.Attribute {
+	var pbAttributes []*pb.Attribute
+	for _, attribute := range attributes {
+		pbAttributes = append(pbAttributes, attribute.AsPBAttribute())
+	}
+	return pbAttributes
+}
+
+// SameAttributes returns true if the two slices hold the same attributes, regardless of the order in which they
+// are listed.
+func SameAttributes(a []*Attribute, b []*Attribute) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA, sortedB := sortedAttributes(a), sortedAttributes(b)
+	for i := range sortedA {
+		if *sortedA[i] != *sortedB[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// sortedAttributes returns a copy of the given attributes slice sorted by name and then by value.
+func sortedAttributes(attributes []*Attribute) []*Attribute {
+	sorted := make([]*Attribute, len(attributes))
+	copy(sorted, attributes)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Name != sorted[j].Name {
+			return sorted[i].Name < sorted[j].Name
+		}
+		return sorted[i].Value < sorted[j].Value
+	})
+	return sorted
+}
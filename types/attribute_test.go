@@ -0,0 +1,54 @@
+package types
+
+import (
+	"testing"
+
+	pbtypes "github.com/mikebway/poc-gcp-ecomm/pb/types"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPBAttributeConversion tests both Attribute conversion from and to the protocol buffer equivalent.
+func TestPBAttributeConversion(t *testing.T) {
+
+	// Build a protocol buffer attribute slice as our starting point, with a nil entry thrown in for good measure
+	source := []*pbtypes.Attribute{
+		{Name: "engraving", Value: "Happy Birthday Rupert!"},
+		nil,
+		{Name: "font", Value: "copperplate"},
+	}
+
+	// Convert that to our Attribute type, losing the nil along the way
+	req := require.New(t)
+	attributes := AttributesFromPB(source)
+	req.Equal(2, len(attributes), "nil attribute should have been skipped")
+	req.Equal("engraving", attributes[0].Name, "wrong name")
+	req.Equal("Happy Birthday Rupert!", attributes[0].Value, "wrong value")
+
+	// Convert it back again and compare with the source
+	pbReturn := AttributesAsPB(attributes)
+	req.Equal([]*pbtypes.Attribute{source[0], source[2]}, pbReturn, "converted back to protobuf did not match original")
+
+	// Nothing converts to nothing
+	req.Nil(AttributeFromPB(nil), "nil attribute should have converted to nil")
+	req.Nil(AttributesFromPB(nil), "nil attribute slice should have converted to nil")
+	req.Nil(AttributesAsPB(nil), "nil attribute slice should have converted to nil")
+}
+
+// TestSameAttributes confirms that attributes are compared without regard to the order in which they are listed.
+func TestSameAttributes(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	engraving := &Attribute{Name: "engraving", Value: "Rupert"}
+	font := &Attribute{Name: "font", Value: "copperplate"}
+	req.True(SameAttributes(nil, []*Attribute{}), "no attributes should match no attributes")
+	req.True(SameAttributes([]*Attribute{engraving, font}, []*Attribute{font, {Name: "engraving", Value: "Rupert"}}), "attributes in a different order should match")
+	req.False(SameAttributes([]*Attribute{engraving}, []*Attribute{engraving, font}), "different numbers of attributes should not match")
+	req.False(SameAttributes([]*Attribute{engraving}, []*Attribute{{Name: "engraving", Value: "Hermione"}}), "different values should not match")
+
+	// The original order is left alone
+	attributes := []*Attribute{font, engraving}
+	SameAttributes(attributes, []*Attribute{engraving, font})
+	req.Equal(font, attributes[0], "attributes should not have been reordered")
+}
@@ -0,0 +1,16 @@
+package types
+
+import "strings"
+
+const (
+	// MaxDocumentIdLength is the longest, in bytes, that Firestore allows the ID of a document to be
+	MaxDocumentIdLength = 1500
+)
+
+// IsValidDocumentId returns true if the given string, e.g. a product code or a client supplied request ID, can be
+// used as the ID of a Firestore document and is no longer than the given number of bytes. IDs must not be empty,
+// contain a slash, or be one of the names that Firestore reserves for itself.
+func IsValidDocumentId(id string, maxLength int) bool {
+	return id != "" && len(id) <= maxLength && len(id) <= MaxDocumentIdLength && !strings.Contains(id, "/") &&
+		id != "." && id != ".." && !strings.HasPrefix(id, "__")
+}
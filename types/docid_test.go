@@ -0,0 +1,21 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestIsValidDocumentId confirms that strings that could not be used as Firestore document IDs, or that are longer
+// than we will allow, are refused.
+func TestIsValidDocumentId(t *testing.T) {
+	req := require.New(t)
+	for _, id := range []string{"gold_yoyo", "SAVE10", "a.b", "x__", strings.Repeat("y", 64)} {
+		req.True(IsValidDocumentId(id, 64), "%q should have been a valid document ID", id)
+	}
+	for _, id := range []string{"", "gold/yoyo", ".", "..", "__yoyo__", strings.Repeat("y", 65)} {
+		req.False(IsValidDocumentId(id, 64), "%q should not have been a valid document ID", id)
+	}
+	req.False(IsValidDocumentId(strings.Repeat("y", MaxDocumentIdLength+1), MaxDocumentIdLength+10), "Firestore's own limit should have been applied")
+}
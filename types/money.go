@@ -19,9 +19,25 @@
 package types
 
 import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
 	pbmoney "google.golang.org/genproto/googleapis/type/money"
 )
 
+const (
+	// nanosPerUnit is the number of nanos in a single whole unit of currency
+	nanosPerUnit = 1_000_000_000
+)
+
+var (
+	// ErrCurrencyMismatch is returned, wrapped with some detail, when an attempt is made to combine or compare
+	// money values that are expressed in different currencies.
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+)
+
 // Money is a representation of a currency value that can be directly mapped to the Google APIs
 // protocol buffer representation of money.
 //
@@ -65,10 +81,224 @@ func MoneyFromPB(pbm *pbmoney.Money) *Money {
 }
 
 // AsPBMoney returns the protocol buffer representation of this Money.
+//
+// Calling this on a nil Money returns nil, mirroring MoneyFromPB, so that optional prices can be
+// converted without the caller having to check for nil first.
 func (m *Money) AsPBMoney() *pbmoney.Money {
+	if m == nil {
+		return nil
+	}
 	return &pbmoney.Money{
 		CurrencyCode: m.CurrencyCode,
 		Units:        m.Units,
 		Nanos:        m.Nanos,
 	}
 }
+
+// NewMoney is a factory method that returns a normalized Money structure for the given currency, units, and nanos.
+func NewMoney(currencyCode string, units int64, nanos int32) *Money {
+	return (&Money{CurrencyCode: currencyCode, Units: units, Nanos: nanos}).Normalize()
+}
+
+// SumMoney returns the total of the given money values, all of which must be of the same currency. An error is
+// returned if no values are given, or an error wrapping ErrCurrencyMismatch if any of them is nil or is of a
+// different currency to the first.
+func SumMoney(amounts ...*Money) (*Money, error) {
+
+	// We need at least one value to know what currency we are dealing with
+	if len(amounts) == 0 || amounts[0] == nil {
+		return nil, errors.New("cannot sum money without a first value")
+	}
+
+	// Add them all up
+	total := amounts[0].Normalize()
+	for _, amount := range amounts[1:] {
+		var err error
+		total, err = total.Add(amount)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return total, nil
+}
+
+// Normalize returns a copy of this Money with any whole units held in the nanos carried over into the units, and
+// with the signs of the units and nanos made consistent, e.g. 1 unit and -250,000,000 nanos becomes 0 units and
+// 750,000,000 nanos.
+func (m *Money) Normalize() *Money {
+
+	// Carry any whole units out of the nanos
+	units := m.Units + int64(m.Nanos/nanosPerUnit)
+	nanos := m.Nanos % nanosPerUnit
+
+	// Make sure that the signs agree
+	if units > 0 && nanos < 0 {
+		units--
+		nanos += nanosPerUnit
+	} else if units < 0 && nanos > 0 {
+		units++
+		nanos -= nanosPerUnit
+	}
+	return &Money{CurrencyCode: m.CurrencyCode, Units: units, Nanos: nanos}
+}
+
+// IsZero returns true if this Money has no value, regardless of its currency.
+func (m *Money) IsZero() bool {
+	n := m.Normalize()
+	return n.Units == 0 && n.Nanos == 0
+}
+
+// Add returns the sum of this Money and another. An error wrapping ErrCurrencyMismatch is returned if the two
+// values are not of the same currency.
+func (m *Money) Add(other *Money) (*Money, error) {
+	if err := m.checkCurrency(other); err != nil {
+		return nil, err
+	}
+	return (&Money{CurrencyCode: m.CurrencyCode, Units: m.Units + other.Units, Nanos: m.Nanos + other.Nanos}).Normalize(), nil
+}
+
+// Subtract returns the difference between this Money and another. An error wrapping ErrCurrencyMismatch is returned
+// if the two values are not of the same currency.
+func (m *Money) Subtract(other *Money) (*Money, error) {
+	if err := m.checkCurrency(other); err != nil {
+		return nil, err
+	}
+	return (&Money{CurrencyCode: m.CurrencyCode, Units: m.Units - other.Units, Nanos: m.Nanos - other.Nanos}).Normalize(), nil
+}
+
+// Multiply returns the value of this Money multiplied by the given quantity, e.g. to obtain the price of a
+// number of items from their unit price.
+func (m *Money) Multiply(quantity int32) *Money {
+
+	// Multiplying the nanos cannot overflow an int64, the carry from them is added to the multiplied units
+	n := m.Normalize()
+	nanos := int64(n.Nanos) * int64(quantity)
+	return (&Money{
+		CurrencyCode: n.CurrencyCode,
+		Units:        n.Units*int64(quantity) + nanos/nanosPerUnit,
+		Nanos:        int32(nanos % nanosPerUnit),
+	}).Normalize()
+}
+
+// Percent returns the given percentage of this Money, e.g. to obtain the value of a percentage discount. Any
+// fraction of a nano is dropped, i.e. the result is rounded toward zero.
+func (m *Money) Percent(percent int32) *Money {
+	return m.BasisPoints(percent * 100)
+}
+
+// BasisPoints returns the given number of hundredths of a percent of this Money, e.g. 825 basis points for a tax
+// rate of 8.25%. Any fraction of a nano is dropped, i.e. the result is rounded toward zero.
+func (m *Money) BasisPoints(points int32) *Money {
+
+	// Work out the whole units first, carrying what is left over from them into the nanos
+	n := m.Normalize()
+	units := n.Units * int64(points)
+	nanos := (units%10_000)*nanosPerUnit/10_000 + int64(n.Nanos)*int64(points)/10_000
+	return (&Money{
+		CurrencyCode: n.CurrencyCode,
+		Units:        units/10_000 + nanos/nanosPerUnit,
+		Nanos:        int32(nanos % nanosPerUnit),
+	}).Normalize()
+}
+
+// Prorate returns the share of this Money that part is of whole, e.g. the share of a payment that is due back for
+// some of the items that it paid for, where part is the price of those items and whole is the price of all of them.
+// The share is rounded toward zero to whole cents, so that the shares of a number of parts never add up to more than
+// this Money. An error wrapping ErrCurrencyMismatch is returned if part and whole are not of the same currency, and
+// an error is also returned if whole is zero.
+func (m *Money) Prorate(part, whole *Money) (*Money, error) {
+	if err := whole.checkCurrency(part); err != nil {
+		return nil, err
+	}
+	if whole.IsZero() {
+		return nil, errors.New("cannot prorate money against a zero whole")
+	}
+
+	// Work in nanos, with big integers so that multiplying them cannot overflow
+	const nanosPerCent = nanosPerUnit / 100
+	cents := new(big.Int).Mul(m.asNanos(), part.asNanos())
+	cents.Quo(cents, whole.asNanos())
+	cents.Quo(cents, big.NewInt(nanosPerCent))
+	units, remainder := new(big.Int).QuoRem(cents, big.NewInt(100), new(big.Int))
+	return (&Money{CurrencyCode: m.CurrencyCode, Units: units.Int64(), Nanos: int32(remainder.Int64()) * nanosPerCent}).Normalize(), nil
+}
+
+// RoundToCents returns this Money rounded to two decimal places, with halves rounded away from zero.
+func (m *Money) RoundToCents() *Money {
+	const nanosPerCent = nanosPerUnit / 100
+	n := m.Normalize()
+	cents := n.Nanos / nanosPerCent
+	remainder := n.Nanos % nanosPerCent
+	if remainder >= nanosPerCent/2 {
+		cents++
+	} else if remainder <= -nanosPerCent/2 {
+		cents--
+	}
+	return (&Money{CurrencyCode: n.CurrencyCode, Units: n.Units, Nanos: cents * nanosPerCent}).Normalize()
+}
+
+// Compare returns -1, 0, or +1 depending on whether this Money is less than, equal to, or greater than another.
+// An error wrapping ErrCurrencyMismatch is returned if the two values are not of the same currency.
+func (m *Money) Compare(other *Money) (int, error) {
+	if err := m.checkCurrency(other); err != nil {
+		return 0, err
+	}
+
+	// Once normalized, the units and nanos of both values have consistent signs so can be compared in turn
+	a, b := m.Normalize(), other.Normalize()
+	switch {
+	case a.Units < b.Units:
+		return -1, nil
+	case a.Units > b.Units:
+		return 1, nil
+	case a.Nanos < b.Nanos:
+		return -1, nil
+	case a.Nanos > b.Nanos:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// String returns a human readable representation of this Money, e.g. "USD 21499.99" or "USD -1.75", with at least
+// two decimal places and as many more as are needed to express the nanos exactly.
+func (m *Money) String() string {
+
+	// Nil money is not very interesting
+	if m == nil {
+		return "<nil>"
+	}
+
+	// Work with the absolute value and then put any minus sign back in front
+	n := m.Normalize()
+	sign := ""
+	units, nanos := n.Units, n.Nanos
+	if units < 0 || nanos < 0 {
+		sign = "-"
+		units, nanos = -units, -nanos
+	}
+
+	// Trim the fraction down to the significant digits, but no fewer than two of them
+	fraction := strings.TrimRight(fmt.Sprintf("%09d", nanos), "0")
+	for len(fraction) < 2 {
+		fraction += "0"
+	}
+	return fmt.Sprintf("%s %s%d.%s", n.CurrencyCode, sign, units, fraction)
+}
+
+// asNanos returns the value of this Money as a number of nanos.
+func (m *Money) asNanos() *big.Int {
+	nanos := new(big.Int).Mul(big.NewInt(m.Units), big.NewInt(nanosPerUnit))
+	return nanos.Add(nanos, big.NewInt(int64(m.Nanos)))
+}
+
+// checkCurrency returns an error wrapping ErrCurrencyMismatch if this Money and another are not of the same
+// currency, or if the other is nil.
+func (m *Money) checkCurrency(other *Money) error {
+	if other == nil {
+		return fmt.Errorf("%w: %s and nil", ErrCurrencyMismatch, m.CurrencyCode)
+	}
+	if m.CurrencyCode != other.CurrencyCode {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.CurrencyCode, other.CurrencyCode)
+	}
+	return nil
+}
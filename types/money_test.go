@@ -41,6 +41,14 @@ func TestMilPBMoneyConversion(t *testing.T) {
 	require.Nil(t, money, "expected nil in return for nil")
 }
 
+// TestNilMoneyAsPB tests what happens if AsPBMoney is called on a nil Money
+func TestNilMoneyAsPB(t *testing.T) {
+
+	// Ask for the protobuf equivalent of a nil money
+	var money *Money
+	require.Nil(t, money.AsPBMoney(), "expected nil in return for nil")
+}
+
 // buildMockMoney returns a pbtypes.Money structure populated with the constant
 // attributes defined at the head of this file to be used to create new shopping carts in our tests.
 func buildMockMoney() *pbmoney.Money {
@@ -50,3 +58,162 @@ func buildMockMoney() *pbmoney.Money {
 		Nanos:        priceNanos,
 	}
 }
+
+// TestMoneyNormalize examines the carrying of whole units out of the nanos and the reconciliation of signs.
+func TestMoneyNormalize(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Each case is a units / nanos pair and the normalized units / nanos we expect back
+	cases := []struct {
+		units, wantUnits int64
+		nanos, wantNanos int32
+	}{
+		{1, 2, 1_250_000_000, 250_000_000},
+		{1, 0, -250_000_000, 750_000_000},
+		{-1, 0, 250_000_000, -750_000_000},
+		{0, 0, -250_000_000, -250_000_000},
+		{-2, -3, -1_500_000_000, -500_000_000},
+		{0, 0, 0, 0},
+	}
+	for _, c := range cases {
+		n := (&Money{CurrencyCode: priceCurrency, Units: c.units, Nanos: c.nanos}).Normalize()
+		req.Equal(priceCurrency, n.CurrencyCode, "normalize should not change the currency")
+		req.Equal(c.wantUnits, n.Units, "wrong units normalizing %d / %d", c.units, c.nanos)
+		req.Equal(c.wantNanos, n.Nanos, "wrong nanos normalizing %d / %d", c.units, c.nanos)
+	}
+	req.True(NewMoney(priceCurrency, 1, -1_000_000_000).IsZero(), "one unit less a billion nanos should be zero")
+	req.False(NewMoney(priceCurrency, 0, 1).IsZero(), "one nano should not be zero")
+}
+
+// TestMoneyArithmetic examines addition and multiplication, including currency mismatches.
+func TestMoneyArithmetic(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Add two amounts that carry nanos over into the units
+	price := MoneyFromPB(buildMockMoney())
+	sum, err := price.Add(price)
+	req.Nil(err, "should not have seen an error adding money of the same currency: %v", err)
+	req.Equal(NewMoney(priceCurrency, 3_303, 880_000_000), sum, "sum was incorrect")
+
+	// Add a negative amount to cross zero
+	sum, err = NewMoney(priceCurrency, 1, 250_000_000).Add(NewMoney(priceCurrency, -2, 0))
+	req.Nil(err, "should not have seen an error adding a negative amount: %v", err)
+	req.Equal(NewMoney(priceCurrency, 0, -750_000_000), sum, "sum crossing zero was incorrect")
+
+	// Try to add different currencies
+	_, err = price.Add(NewMoney("GBP", 1, 0))
+	req.ErrorIs(err, ErrCurrencyMismatch, "should have seen a currency mismatch adding GBP to USD")
+	_, err = price.Add(nil)
+	req.ErrorIs(err, ErrCurrencyMismatch, "should have seen a currency mismatch adding nil to USD")
+
+	// Subtract amounts, including crossing zero, and different currencies
+	difference, err := price.Subtract(NewMoney(priceCurrency, 1_000, 950_000_000))
+	req.Nil(err, "should not have seen an error subtracting money of the same currency: %v", err)
+	req.Equal(NewMoney(priceCurrency, 650, 990_000_000), difference, "difference was incorrect")
+	difference, err = NewMoney(priceCurrency, 1, 250_000_000).Subtract(NewMoney(priceCurrency, 2, 0))
+	req.Nil(err, "should not have seen an error subtracting a larger amount: %v", err)
+	req.Equal(NewMoney(priceCurrency, 0, -750_000_000), difference, "difference crossing zero was incorrect")
+	_, err = price.Subtract(NewMoney("GBP", 1, 0))
+	req.ErrorIs(err, ErrCurrencyMismatch, "should have seen a currency mismatch subtracting GBP from USD")
+
+	// Take percentages, the first of which carries some of the units into the nanos
+	req.Equal(NewMoney(priceCurrency, 247, 791_000_000), price.Percent(15), "15%% was incorrect")
+	req.Equal(price, price.Percent(100), "100%% was incorrect")
+	req.Equal(NewMoney(priceCurrency, 0, 0), price.Percent(0), "0%% was incorrect")
+	req.Equal(NewMoney(priceCurrency, 0, -330_000_000), NewMoney(priceCurrency, -1, 0).Percent(33), "negative percentage was incorrect")
+	req.Equal(NewMoney(priceCurrency, 1, 970_100_000), NewMoney(priceCurrency, 1, 990_000_000).Percent(99), "percentage carrying into the units was incorrect")
+
+	// Take fractions of a percent, then round them to cents
+	req.Equal(NewMoney(priceCurrency, 0, 906_675_000), NewMoney(priceCurrency, 10, 990_000_000).BasisPoints(825), "8.25%% was incorrect")
+	req.Equal(NewMoney(priceCurrency, 0, 910_000_000), NewMoney(priceCurrency, 0, 906_675_000).RoundToCents(), "rounding up to cents was incorrect")
+	req.Equal(NewMoney(priceCurrency, 1, 0), NewMoney(priceCurrency, 0, 995_000_000).RoundToCents(), "rounding a half cent into the units was incorrect")
+	req.Equal(NewMoney(priceCurrency, -2, -340_000_000), NewMoney(priceCurrency, -2, -344_999_999).RoundToCents(), "rounding a negative amount was incorrect")
+
+	// Take shares of an amount, rounded down to cents
+	share, err := NewMoney(priceCurrency, 100, 0).Prorate(NewMoney(priceCurrency, 1, 0), NewMoney(priceCurrency, 3, 0))
+	req.Nil(err, "should not have seen an error prorating: %v", err)
+	req.Equal(NewMoney(priceCurrency, 33, 330_000_000), share, "a third share was incorrect")
+	share, err = price.Prorate(NewMoney(priceCurrency, 25, 0), NewMoney(priceCurrency, 25, 0))
+	req.Nil(err, "should not have seen an error prorating the whole: %v", err)
+	req.Equal(price, share, "the whole share was incorrect")
+	share, err = NewMoney(priceCurrency, 54, 120_000_000).Prorate(NewMoney(priceCurrency, 30, 0), NewMoney(priceCurrency, 50, 0))
+	req.Nil(err, "should not have seen an error prorating: %v", err)
+	req.Equal(NewMoney(priceCurrency, 32, 470_000_000), share, "a three fifths share was incorrect")
+	_, err = price.Prorate(NewMoney("GBP", 1, 0), NewMoney(priceCurrency, 2, 0))
+	req.ErrorIs(err, ErrCurrencyMismatch, "should have seen a currency mismatch prorating GBP against USD")
+	_, err = price.Prorate(NewMoney(priceCurrency, 1, 0), NewMoney(priceCurrency, 0, 0))
+	req.NotNil(err, "should have seen an error prorating against zero")
+
+	// Multiply up by a quantity
+	req.Equal(NewMoney(priceCurrency, 4_955, 820_000_000), price.Multiply(3), "product was incorrect")
+	req.Equal(NewMoney(priceCurrency, 0, 0), price.Multiply(0), "product with zero was incorrect")
+	req.Equal(NewMoney(priceCurrency, -3, -500_000_000), NewMoney(priceCurrency, 1, 750_000_000).Multiply(-2), "negative product was incorrect")
+}
+
+// TestSumMoney examines the totalling of a list of money values.
+func TestSumMoney(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// A happy sum
+	total, err := SumMoney(NewMoney(priceCurrency, 1, 600_000_000), NewMoney(priceCurrency, 2, 500_000_000), NewMoney(priceCurrency, 0, 10))
+	req.Nil(err, "should not have seen an error summing money: %v", err)
+	req.Equal(NewMoney(priceCurrency, 4, 100_000_010), total, "total was incorrect")
+
+	// Some unhappy sums
+	_, err = SumMoney()
+	req.NotNil(err, "should have seen an error summing nothing")
+	_, err = SumMoney(nil, NewMoney(priceCurrency, 1, 0))
+	req.NotNil(err, "should have seen an error summing a nil first value")
+	_, err = SumMoney(NewMoney(priceCurrency, 1, 0), nil)
+	req.ErrorIs(err, ErrCurrencyMismatch, "should have seen a currency mismatch summing a nil second value")
+	_, err = SumMoney(NewMoney(priceCurrency, 1, 0), NewMoney("GBP", 1, 0))
+	req.ErrorIs(err, ErrCurrencyMismatch, "should have seen a currency mismatch summing USD and GBP")
+}
+
+// TestMoneyCompare examines the comparison of money values.
+func TestMoneyCompare(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+
+	// Each case is a pair of amounts and the comparison result that we expect
+	cases := []struct {
+		a, b *Money
+		want int
+	}{
+		{NewMoney(priceCurrency, 1, 500_000_000), NewMoney(priceCurrency, 2, 0), -1},
+		{NewMoney(priceCurrency, 2, 0), NewMoney(priceCurrency, 1, 500_000_000), 1},
+		{NewMoney(priceCurrency, 0, -500_000_000), NewMoney(priceCurrency, 0, 0), -1},
+		{NewMoney(priceCurrency, -1, -500_000_000), NewMoney(priceCurrency, -1, 0), -1},
+		{&Money{CurrencyCode: priceCurrency, Units: 1, Nanos: -500_000_000}, NewMoney(priceCurrency, 0, 500_000_000), 0},
+	}
+	for _, c := range cases {
+		got, err := c.a.Compare(c.b)
+		req.Nil(err, "should not have seen an error comparing %s and %s: %v", c.a, c.b, err)
+		req.Equal(c.want, got, "wrong result comparing %s and %s", c.a, c.b)
+	}
+
+	// Comparing different currencies is not allowed
+	_, err := NewMoney(priceCurrency, 1, 0).Compare(NewMoney("GBP", 1, 0))
+	req.ErrorIs(err, ErrCurrencyMismatch, "should have seen a currency mismatch comparing GBP to USD")
+}
+
+// TestMoneyString examines the formatting of money values.
+func TestMoneyString(t *testing.T) {
+
+	// Avoid having to pass t in to every assertion
+	req := require.New(t)
+	req.Equal("USD 1651.94", MoneyFromPB(buildMockMoney()).String(), "wrong format for mock price")
+	req.Equal("USD 3.00", NewMoney(priceCurrency, 3, 0).String(), "wrong format for whole units")
+	req.Equal("USD -1.75", NewMoney(priceCurrency, -1, -750_000_000).String(), "wrong format for negative amount")
+	req.Equal("USD -0.50", NewMoney(priceCurrency, 0, -500_000_000).String(), "wrong format for negative nanos only")
+	req.Equal("JPY 0.000000001", NewMoney("JPY", 0, 1).String(), "wrong format for a single nano")
+	var nilMoney *Money
+	req.Equal("<nil>", nilMoney.String(), "wrong format for nil")
+}